@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +19,43 @@ var (
 	DBName    string
 	AppPort   string
 	ENV       string
+
+	// 附件存放設定，未設定時使用本機目錄
+	StorageDriver   string
+	StorageLocalDir string
+	S3Endpoint      string
+	S3Region        string
+	S3Bucket        string
+	S3AccessKey     string
+	S3SecretKey     string
+
+	AttachmentMaxSize      int64
+	AttachmentAllowedTypes []string
+	AttachmentURLSecret    string
+
+	// 即時更新在多個 instance 之間廣播的方式，單一 instance 使用 memory 即可
+	RealtimePubSub string
+	RedisAddr      string
+	RedisPassword  string
+
+	// 寄送 email 的 SMTP 伺服器，未設定 SMTP_HOST 時不寄送
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	// AppBaseURL 信件中連結（如取消訂閱）的網址開頭
+	AppBaseURL string
+	// 摘要信寄出的時間：每日摘要在每天的這個小時，每週摘要在 EmailDigestWeekday 的這個小時
+	EmailDigestHour    int
+	EmailDigestWeekday time.Weekday
+
+	// 到期提醒：到期前 ReminderBefore 各提醒一次、到期時提醒一次，逾期後每 ReminderOverdueRepeat 提醒一次（0 不重複）；
+	// 逾期超過 ReminderEscalateAfter 時通知 ReminderEscalateTo（owner 為清單建立者、admins 為所有 Admin），0 不通知
+	ReminderBefore        []time.Duration
+	ReminderOverdueRepeat time.Duration
+	ReminderEscalateAfter time.Duration
+	ReminderEscalateTo    string
 )
 
 // LoadEnv 載入指定的 env 檔案，並設定全局變數
@@ -39,6 +79,73 @@ func loadEnvVars() {
 	DBName = mustGetenv("DB_NAME")
 	AppPort = mustGetenv("APP_PORT")
 	ENV = mustGetenv("GO_ENV")
+
+	StorageDriver = getenv("STORAGE_DRIVER", "local")
+	StorageLocalDir = getenv("STORAGE_LOCAL_DIR", "storage")
+	S3Endpoint = os.Getenv("S3_ENDPOINT")
+	S3Region = getenv("S3_REGION", "us-east-1")
+	S3Bucket = os.Getenv("S3_BUCKET")
+	S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	S3SecretKey = os.Getenv("S3_SECRET_KEY")
+
+	maxSize, err := strconv.ParseInt(getenv("ATTACHMENT_MAX_SIZE", "10485760"), 10, 64)
+	if err != nil || maxSize <= 0 {
+		panic("Environment variable ATTACHMENT_MAX_SIZE must be a positive integer")
+	}
+	AttachmentMaxSize = maxSize
+	AttachmentAllowedTypes = strings.Split(getenv("ATTACHMENT_ALLOWED_TYPES",
+		"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain,application/zip"), ",")
+	// 下載連結簽章預設沿用 JWT_SECRET
+	AttachmentURLSecret = getenv("ATTACHMENT_URL_SECRET", JWTSecret)
+
+	RealtimePubSub = getenv("REALTIME_PUBSUB", "memory")
+	RedisAddr = getenv("REDIS_ADDR", "localhost:6379")
+	RedisPassword = os.Getenv("REDIS_PASSWORD")
+
+	SMTPHost = os.Getenv("SMTP_HOST")
+	SMTPPort = getenv("SMTP_PORT", "1025")
+	SMTPUsername = os.Getenv("SMTP_USERNAME")
+	SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	SMTPFrom = getenv("SMTP_FROM", "Todolist <no-reply@localhost>")
+	AppBaseURL = strings.TrimRight(getenv("APP_BASE_URL", "http://localhost:"+AppPort), "/")
+
+	digestHour, err := strconv.Atoi(getenv("EMAIL_DIGEST_HOUR", "8"))
+	if err != nil || digestHour < 0 || digestHour > 23 {
+		panic("Environment variable EMAIL_DIGEST_HOUR must be between 0 and 23")
+	}
+	EmailDigestHour = digestHour
+	digestWeekday, err := strconv.Atoi(getenv("EMAIL_DIGEST_WEEKDAY", "1"))
+	if err != nil || digestWeekday < 0 || digestWeekday > 6 {
+		panic("Environment variable EMAIL_DIGEST_WEEKDAY must be between 0 (Sunday) and 6")
+	}
+	EmailDigestWeekday = time.Weekday(digestWeekday)
+
+	ReminderBefore = nil
+	for _, value := range strings.Split(getenv("REMINDER_BEFORE", "24h"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		before, err := time.ParseDuration(value)
+		if err != nil || before <= 0 {
+			panic("Environment variable REMINDER_BEFORE must be a comma-separated list of positive durations")
+		}
+		ReminderBefore = append(ReminderBefore, before)
+	}
+	ReminderOverdueRepeat = mustDuration("REMINDER_OVERDUE_REPEAT", "24h")
+	ReminderEscalateAfter = mustDuration("REMINDER_ESCALATE_AFTER", "0")
+	ReminderEscalateTo = getenv("REMINDER_ESCALATE_TO", "owner")
+	if ReminderEscalateTo != "owner" && ReminderEscalateTo != "admins" {
+		panic("Environment variable REMINDER_ESCALATE_TO must be owner or admins")
+	}
+}
+
+// mustDuration 讀取不小於 0 的時間長度，格式錯誤時 panic
+func mustDuration(key, fallback string) time.Duration {
+	value, err := time.ParseDuration(getenv(key, fallback))
+	if err != nil || value < 0 {
+		panic("Environment variable " + key + " must be a non-negative duration such as 24h")
+	}
+	return value
 }
 
 func mustGetenv(key string) string {
@@ -49,6 +156,14 @@ func mustGetenv(key string) string {
 	return val
 }
 
+// getenv 讀取選填的設定，未設定時回傳預設值
+func getenv(key, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return fallback
+}
+
 func IsLocal() bool {
 	return ENV != "production"
 }
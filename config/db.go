@@ -25,7 +25,10 @@ func ConnectDatabase() {
 	var err error
 
 	for i := 0; i < 10; i++ {
-		DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
+			// 將 duplicate key 等錯誤轉成 gorm.ErrDuplicatedKey，方便上層判斷
+			TranslateError: true,
+		})
 		if err == nil {
 			log.Println("Connected to database:", dbName)
 			return
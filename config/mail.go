@@ -0,0 +1,24 @@
+package config
+
+import (
+	"log"
+	"todolist/pkg/mail"
+)
+
+// Mailer 未設定 SMTP_HOST 時為 nil，不寄送 email
+var Mailer mail.Sender
+
+// ConnectMail 依 SMTP_* 設定寄送 email 的方式
+func ConnectMail() {
+	if SMTPHost == "" {
+		log.Println("Email: disabled")
+		return
+	}
+	Mailer = mail.NewSMTP(mail.SMTPConfig{
+		Host:     SMTPHost,
+		Port:     SMTPPort,
+		Username: SMTPUsername,
+		Password: SMTPPassword,
+	})
+	log.Println("Email: smtp", SMTPHost+":"+SMTPPort)
+}
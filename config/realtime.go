@@ -0,0 +1,40 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"todolist/pkg/realtime"
+)
+
+// realtimeChannel、realtimeRetention 廣播使用的 channel 與每個 instance 保留供重連接續的事件數
+const (
+	realtimeChannel   = "todolist:events"
+	realtimeRetention = 1000
+)
+
+var Realtime *realtime.Hub
+
+// ConnectRealtime 依 REALTIME_PUBSUB 建立即時更新的 Hub 並開始接收事件：memory 只在行程內廣播，redis 可跨 instance
+func ConnectRealtime() {
+	var pubsub realtime.PubSub
+	switch RealtimePubSub {
+	case "memory":
+		pubsub = realtime.NewMemory()
+		log.Println("Realtime pubsub: memory")
+
+	case "redis":
+		redis := realtime.NewRedis(RedisAddr, RedisPassword)
+		redis.OnError = func(err error) {
+			log.Println("Redis 訂閱中斷，稍後重連:", err)
+		}
+		pubsub = redis
+		log.Println("Realtime pubsub: redis", RedisAddr)
+
+	default:
+		log.Fatal(fmt.Sprintf("不支援的 REALTIME_PUBSUB: %s", RealtimePubSub))
+	}
+
+	Realtime = realtime.NewHub(pubsub, realtimeChannel, realtimeRetention)
+	go Realtime.Run(context.Background())
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"todolist/pkg/storage"
+)
+
+var Storage storage.Storage
+
+// ConnectStorage 依 STORAGE_DRIVER 建立附件存放位置：local 為本機目錄，s3 為 S3 相容服務（如 MinIO）
+func ConnectStorage() {
+	switch StorageDriver {
+	case "local":
+		s, err := storage.NewLocalStorage(StorageLocalDir)
+		if err != nil {
+			log.Fatal("無法建立附件目錄:", err)
+		}
+		Storage = s
+		log.Println("Attachment storage: local", StorageLocalDir)
+
+	case "s3":
+		s, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:  S3Endpoint,
+			Region:    S3Region,
+			Bucket:    S3Bucket,
+			AccessKey: S3AccessKey,
+			SecretKey: S3SecretKey,
+		})
+		if err != nil {
+			log.Fatal("S3 設定錯誤:", err)
+		}
+		if err := s.EnsureBucket(context.Background()); err != nil {
+			log.Fatal("無法建立 S3 bucket:", err)
+		}
+		Storage = s
+		log.Println("Attachment storage: s3", S3Endpoint, S3Bucket)
+
+	default:
+		log.Fatal(fmt.Sprintf("不支援的 STORAGE_DRIVER: %s", StorageDriver))
+	}
+}
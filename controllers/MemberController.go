@@ -113,7 +113,8 @@ func (con MenberController) Edit(c *gin.Context) {
 	}
 
 	repo := repositories.NewAuthRepository()
-	service := services.NewMemberService(c.Request.Context(), repo)
+	service := services.NewMemberService(c.Request.Context(), repo).
+		WithEvents(newTodoOutboxService(c))
 
 	result, err := service.Edit(config.DB, id, input.RoleID)
 
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/pkg/events"
+	"todolist/pkg/realtime"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+// realtimeHeartbeat 沒有事件時送出心跳的間隔，避免代理伺服器因閒置中斷連線
+const realtimeHeartbeat = 25 * time.Second
+
+type RealtimeController struct{}
+
+// realtimeMessage WebSocket 的訊息：type 為 event、heartbeat 或 reset
+type realtimeMessage struct {
+	Type  string        `json:"type"`
+	Event *events.Event `json:"event,omitempty"`
+}
+
+// subscribe 依查詢參數訂閱，失敗時已回傳錯誤；Last-Event-ID header 優先於 last_event_id 參數
+func (ctl *RealtimeController) subscribe(c *gin.Context) (*realtime.Subscription, []events.Event, bool, bool) {
+	var query dto.RealtimeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return nil, nil, false, false
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.LastEventID
+	}
+
+	service := services.NewRealtimeService(c.Request.Context(), repositories.NewAuthRepository(), config.Realtime)
+	sub, replay, resumed, err := service.Subscribe(config.DB, query.ListIDs, lastEventID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		response.Error(c, status, err.Error())
+		return nil, nil, false, false
+	}
+	return sub, replay, resumed, true
+}
+
+// Ticket Realtime
+// @Summary 取得即時更新連線用的一次性票證
+// @Description EventSource 與 WebSocket 無法設定 Authorization header，改將票證放在 ticket 查詢參數連線；JWT 不可放在網址中。
+// @Description 票證只能使用一次，30 秒內未使用即失效，每次連線（包含重新連線）前都需要重新取得
+// @Tags Realtime
+// @Produce json
+// @Success 200 {object} models.TodoRealtimeTickets "成功回傳票證"
+// @Security BearerAuth
+// @Router /api/realtime/ticket [post]
+func (ctl *RealtimeController) Ticket(c *gin.Context) {
+	service := services.NewRealtimeTicketService(c.Request.Context(), repositories.NewTodoRealtimeTicketRepository())
+	result, err := service.Issue(config.DB)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrNotLoggedIn) {
+			status = http.StatusUnauthorized
+		}
+		response.Error(c, status, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Stream Realtime
+// @Summary 以 Server-Sent Events 接收即時更新
+// @Description 事件的 id 為事件 ID、event 為事件類型（如 detail.updated、comment.created）、data 為事件 JSON；每 25 秒送出 ": heartbeat" 註解。
+// @Description 重新連線時帶 Last-Event-ID header（EventSource 會自動帶上）或 last_event_id 參數即可補上斷線期間的事件；事件已不在保留範圍時先送出 event: reset，用戶端需重新取得資料
+// @Tags Realtime
+// @Produce text/event-stream
+// @Param query query dto.RealtimeQuery false "訂閱條件"
+// @Param ticket query string false "POST /api/realtime/ticket 取得的一次性票證，無法設定 Authorization header 時使用"
+// @Param Last-Event-ID header string false "最後收到的事件 ID"
+// @Success 200 {string} string "事件串流"
+// @Security BearerAuth
+// @Router /api/realtime/events [get]
+func (ctl *RealtimeController) Stream(c *gin.Context) {
+	sub, replay, resumed, ok := ctl.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if !resumed {
+		fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n")
+	}
+	for _, e := range replay {
+		if err := writeSSE(c, e); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			// 來不及接收而被中斷，用戶端會帶最後的事件 ID 重新連線
+			if !ok {
+				return
+			}
+			if err := writeSSE(c, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeSSE(c *gin.Context, e events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// WebSocket Realtime
+// @Summary 以 WebSocket 接收即時更新
+// @Description 伺服器送出 JSON 訊息：{"type":"event","event":{...}}、每 25 秒一次的 {"type":"heartbeat"}，以及無法從 last_event_id 接續時的 {"type":"reset"}（用戶端需重新取得資料）。
+// @Description 重新連線時帶 last_event_id 參數即可補上斷線期間的事件；用戶端送來的訊息會被忽略
+// @Tags Realtime
+// @Param query query dto.RealtimeQuery false "訂閱條件"
+// @Param ticket query string false "POST /api/realtime/ticket 取得的一次性票證，瀏覽器無法設定 Authorization header 時使用"
+// @Success 101 {string} string "切換為 WebSocket"
+// @Security BearerAuth
+// @Router /api/realtime/ws [get]
+func (ctl *RealtimeController) WebSocket(c *gin.Context) {
+	sub, replay, resumed, ok := ctl.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		// 以票證或 token 驗證而非 cookie，不需要限制 Origin；未帶 Origin 的非瀏覽器用戶端也能連線
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			streamWebSocket(ws, sub, replay, resumed)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func streamWebSocket(ws *websocket.Conn, sub *realtime.Subscription, replay []events.Event, resumed bool) {
+	// 讀取失敗表示用戶端已斷線
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	if !resumed {
+		if websocket.JSON.Send(ws, realtimeMessage{Type: "reset"}) != nil {
+			return
+		}
+	}
+	for i := range replay {
+		if websocket.JSON.Send(ws, realtimeMessage{Type: "event", Event: &replay[i]}) != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		var message realtimeMessage
+		select {
+		case <-closed:
+			return
+		case e, ok := <-sub.C:
+			// 來不及接收而被中斷，用戶端會帶最後的事件 ID 重新連線
+			if !ok {
+				return
+			}
+			message = realtimeMessage{Type: "event", Event: &e}
+		case <-heartbeat.C:
+			message = realtimeMessage{Type: "heartbeat"}
+		}
+		if websocket.JSON.Send(ws, message) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct{}
+
+func newReportService(c *gin.Context) *services.ReportService {
+	return services.NewReportService(c.Request.Context(), repositories.NewReportRepository())
+}
+
+// reportFilter 將查詢參數轉為篩選條件，失敗時已回傳錯誤
+func reportFilter(c *gin.Context, query *dto.ReportQuery) (models.ReportFilter, bool) {
+	filter, err := services.NewReportFilter(query.ListID, query.TypeID, query.From, query.To)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return filter, false
+	}
+	return filter, true
+}
+
+func itoa(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+// Throughput Report
+// @Summary 每日／每週新增與完成的任務數
+// @Description 依任務建立時間與完成時間計算，週以週一為起點；期間內沒有資料的區間補 0。format=csv 時回傳 CSV
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param query query dto.ThroughputQuery false "篩選條件"
+// @Success 200 {array} models.ThroughputRow "成功回傳報表"
+// @Security BearerAuth
+// @Router /api/reports/throughput [get]
+func (ctl *ReportController) Throughput(c *gin.Context) {
+	var query dto.ThroughputQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+	filter, ok := reportFilter(c, &query.ReportQuery)
+	if !ok {
+		return
+	}
+	if query.Interval == "" {
+		query.Interval = models.ReportIntervalDay
+	}
+
+	result, err := newReportService(c).Throughput(config.DB, query.Interval, filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if query.Format == "csv" {
+		records := make([][]string, len(result))
+		for i, row := range result {
+			records[i] = []string{row.Period, itoa(row.Created), itoa(row.Completed)}
+		}
+		response.CSV(c, "throughput.csv", []string{"period", "created", "completed"}, records)
+		return
+	}
+	response.Success(c, result)
+}
+
+// CycleTime Report
+// @Summary 完成任務的 lead time 與 cycle time 百分位數
+// @Description 以期間內完成的任務計算（秒）；lead time 為建立到完成，cycle time 為第一次進行中到完成。format=csv 時回傳 CSV
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param query query dto.ReportQuery false "篩選條件"
+// @Success 200 {object} models.CycleTimeReport "成功回傳報表"
+// @Security BearerAuth
+// @Router /api/reports/cycle-time [get]
+func (ctl *ReportController) CycleTime(c *gin.Context) {
+	var query dto.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+	filter, ok := reportFilter(c, &query)
+	if !ok {
+		return
+	}
+
+	result, err := newReportService(c).CycleTime(config.DB, filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if query.Format == "csv" {
+		record := func(metric string, count int64, p models.Percentiles) []string {
+			return []string{metric, itoa(count), itoa(p.P50), itoa(p.P75), itoa(p.P90), itoa(p.P95)}
+		}
+		response.CSV(c, "cycle-time.csv", []string{"metric", "count", "p50", "p75", "p90", "p95"}, [][]string{
+			record("lead_time", result.Completed, result.LeadTime),
+			record("cycle_time", result.CycleTimeCount, result.CycleTime),
+		})
+		return
+	}
+	response.Success(c, result)
+}
+
+// Workload Report
+// @Summary 每位使用者尚未完成的任務數
+// @Description 依 to_do_task_assignments 計算，期間以任務建立時間篩選。format=csv 時回傳 CSV
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param query query dto.ReportQuery false "篩選條件"
+// @Success 200 {array} models.WorkloadRow "成功回傳報表"
+// @Security BearerAuth
+// @Router /api/reports/workload [get]
+func (ctl *ReportController) Workload(c *gin.Context) {
+	var query dto.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+	filter, ok := reportFilter(c, &query)
+	if !ok {
+		return
+	}
+
+	result, err := newReportService(c).Workload(config.DB, filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if query.Format == "csv" {
+		records := make([][]string, len(result))
+		for i, row := range result {
+			records[i] = []string{strconv.Itoa(row.UserID), row.Account, itoa(row.Todo), itoa(row.InProgress), itoa(row.Open)}
+		}
+		response.CSV(c, "workload.csv", []string{"user_id", "account", "todo", "in_progress", "open"}, records)
+		return
+	}
+	response.Success(c, result)
+}
+
+// Types Report
+// @Summary 依 TodoTypes 分類的任務數
+// @Description created、completed 依建立與完成時間落在期間內計算，open 為期間內建立且尚未完成。format=csv 時回傳 CSV
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param query query dto.ReportQuery false "篩選條件"
+// @Success 200 {array} models.TypeBreakdownRow "成功回傳報表"
+// @Security BearerAuth
+// @Router /api/reports/types [get]
+func (ctl *ReportController) Types(c *gin.Context) {
+	var query dto.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+	filter, ok := reportFilter(c, &query)
+	if !ok {
+		return
+	}
+
+	result, err := newReportService(c).TypeBreakdown(config.DB, filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if query.Format == "csv" {
+		records := make([][]string, len(result))
+		for i, row := range result {
+			records[i] = []string{strconv.Itoa(row.TypeID), row.Name, itoa(row.Created), itoa(row.Completed), itoa(row.Open)}
+		}
+		response.CSV(c, "types.csv", []string{"type_id", "name", "created", "completed", "open"}, records)
+		return
+	}
+	response.Success(c, result)
+}
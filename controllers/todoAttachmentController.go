@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead multipart 邊界與其他欄位預留的大小
+const multipartOverhead = 1 << 20
+
+type TodoAttachmentController struct{}
+
+func newTodoAttachmentService(c *gin.Context) *services.TodoAttachmentService {
+	return services.NewTodoAttachmentService(
+		c.Request.Context(),
+		repositories.NewTodoAttachmentRepository(),
+		repositories.NewAuthRepository(),
+		config.Storage,
+	)
+}
+
+// attachmentErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func attachmentErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrAttachmentTypeNotAllowed):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, services.ErrAttachmentForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return fallback
+	}
+}
+
+// upload 讀取 multipart 的 file 欄位並上傳；請求大小超過上限時不會整包讀進來
+func (ctl *TodoAttachmentController) upload(c *gin.Context, target services.AttachmentTarget) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.AttachmentMaxSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, services.ErrAttachmentTooLarge.Error())
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "請以 file 欄位上傳檔案")
+		return
+	}
+	if header.Size > config.AttachmentMaxSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, services.ErrAttachmentTooLarge.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無法讀取上傳的檔案")
+		return
+	}
+	defer file.Close()
+
+	result, err := newTodoAttachmentService(c).Upload(config.DB, target, header.Filename, file)
+	if err != nil {
+		response.Error(c, attachmentErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// serve 以下載方式回傳附件內容
+func (ctl *TodoAttachmentController) serve(c *gin.Context, service *services.TodoAttachmentService, id int) {
+	item, reader, err := service.Open(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, item.Size, item.ContentType, reader, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": item.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
+// UploadToDetail TodoAttachment
+// @Summary 上傳附件到 TodoListDetails
+// @Description 以 multipart/form-data 的 file 欄位上傳，大小與類型有上限，相同內容只會保存一份
+// @Tags TodoAttachment
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param file formData file true "附件"
+// @Success 200 {object} models.TodoAttachments "成功回傳附件"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/attachments [post]
+func (ctl *TodoAttachmentController) UploadToDetail(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	ctl.upload(c, services.AttachmentTarget{DetailID: detailID})
+}
+
+// UploadToComment TodoAttachment
+// @Summary 上傳附件到留言
+// @Description 以 multipart/form-data 的 file 欄位上傳，大小與類型有上限，相同內容只會保存一份
+// @Tags TodoAttachment
+// @Accept multipart/form-data
+// @Produce json
+// @Param comment_id path int true "留言 ID"
+// @Param file formData file true "附件"
+// @Success 200 {object} models.TodoAttachments "成功回傳附件"
+// @Security BearerAuth
+// @Router /api/todo/list/details/comments/{comment_id}/attachments [post]
+func (ctl *TodoAttachmentController) UploadToComment(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	ctl.upload(c, services.AttachmentTarget{CommentID: commentID})
+}
+
+// IndexByDetail TodoAttachment
+// @Summary 取得 TodoListDetails 的附件
+// @Tags TodoAttachment
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Success 200 {array} models.TodoAttachments "成功回傳附件"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/attachments [get]
+func (ctl *TodoAttachmentController) IndexByDetail(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	ctl.index(c, services.AttachmentTarget{DetailID: detailID})
+}
+
+// IndexByComment TodoAttachment
+// @Summary 取得留言的附件
+// @Tags TodoAttachment
+// @Accept json
+// @Produce json
+// @Param comment_id path int true "留言 ID"
+// @Success 200 {array} models.TodoAttachments "成功回傳附件"
+// @Security BearerAuth
+// @Router /api/todo/list/details/comments/{comment_id}/attachments [get]
+func (ctl *TodoAttachmentController) IndexByComment(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	ctl.index(c, services.AttachmentTarget{CommentID: commentID})
+}
+
+func (ctl *TodoAttachmentController) index(c *gin.Context, target services.AttachmentTarget) {
+	result, err := newTodoAttachmentService(c).Index(config.DB, target)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Download TodoAttachment
+// @Summary 下載附件
+// @Tags TodoAttachment
+// @Produce application/octet-stream
+// @Param attachment_id path int true "附件 ID"
+// @Success 200 {file} file "附件內容"
+// @Security BearerAuth
+// @Router /api/todo/list/details/attachments/{attachment_id}/download [get]
+func (ctl *TodoAttachmentController) Download(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	ctl.serve(c, newTodoAttachmentService(c), id)
+}
+
+// SignedURL TodoAttachment
+// @Summary 取得附件的簽章下載連結
+// @Description 連結不需登入即可下載，過期後失效
+// @Tags TodoAttachment
+// @Accept json
+// @Produce json
+// @Param attachment_id path int true "附件 ID"
+// @Success 200 {object} dto.TodoAttachmentURLResponse "成功回傳下載連結"
+// @Security BearerAuth
+// @Router /api/todo/list/details/attachments/{attachment_id}/url [get]
+func (ctl *TodoAttachmentController) SignedURL(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	url, expiresAt, err := newTodoAttachmentService(c).SignedURL(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.Success(c, dto.TodoAttachmentURLResponse{URL: url, ExpiresAt: expiresAt})
+}
+
+// SignedDownload TodoAttachment
+// @Summary 以簽章連結下載附件
+// @Description 不需登入，驗證簽章與期限後回傳附件內容
+// @Tags TodoAttachment
+// @Produce application/octet-stream
+// @Param id path int true "附件 ID"
+// @Param query query dto.TodoAttachmentSignedQuery true "簽章"
+// @Success 200 {file} file "附件內容"
+// @Router /api/attachments/{id}/download [get]
+func (ctl *TodoAttachmentController) SignedDownload(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoAttachmentSignedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusForbidden, services.ErrInvalidDownloadURL.Error())
+		return
+	}
+
+	service := newTodoAttachmentService(c)
+	if err := service.VerifySignature(id, query.Expires, query.Signature); err != nil {
+		response.Error(c, http.StatusForbidden, err.Error())
+		return
+	}
+
+	ctl.serve(c, service, id)
+}
+
+// Delete TodoAttachment
+// @Summary 刪除附件
+// @Description 只有上傳者或 Admin 可以刪除，內容在沒有其他附件引用後由排程清除
+// @Tags TodoAttachment
+// @Accept json
+// @Produce json
+// @Param attachment_id path int true "附件 ID"
+// @Success 200 {object} models.TodoAttachments "成功回傳被刪除的附件"
+// @Security BearerAuth
+// @Router /api/todo/list/details/attachments/{attachment_id} [delete]
+func (ctl *TodoAttachmentController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoAttachmentService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, attachmentErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoAutomationController struct{}
+
+func newTodoAutomationService(c *gin.Context) *services.TodoAutomationService {
+	return services.NewTodoAutomationService(c.Request.Context(), repositories.NewTodoAutomationRepository(), repositories.NewAuthRepository())
+}
+
+// automationErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func automationErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAutomationForbidden), errors.Is(err, services.ErrAutomationGlobalForbidden),
+		errors.Is(err, services.ErrAutomationWebhookForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrInvalidAutomationAction):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+// automationInput 轉為規則的條件與動作
+func automationInput(conditions dto.TodoAutomationConditionsRequest, actions []dto.TodoAutomationActionRequest) (models.AutomationConditions, []models.AutomationAction) {
+	result := make([]models.AutomationAction, len(actions))
+	for i, a := range actions {
+		result[i] = models.AutomationAction{
+			Type:       a.Type,
+			UserID:     a.UserID,
+			Status:     a.Status,
+			Body:       a.Body,
+			TodoListID: a.TodoListID,
+			WebhookID:  a.WebhookID,
+		}
+	}
+	return models.AutomationConditions{
+		TypeIDs:     conditions.TypeIDs,
+		LabelIDs:    conditions.LabelIDs,
+		AssigneeIDs: conditions.AssigneeIDs,
+	}, result
+}
+
+// Create TodoAutomation
+// @Summary 新增自動化規則
+// @Description 觸發的資料 commit 後，項目符合所有條件時以建立者的身分依序執行動作；動作全部成功或全部復原。trigger 為 detail.created、detail.status_changed、detail.assigned 或 detail.due_passed（到期後仍未完成，24 小時內補執行）。動作引起的變更可以再觸發規則，最多連鎖 3 層
+// @Description call_webhook 只能使用不限 TodoList 或與規則同一個 TodoList 的 webhook，其他 TodoList 的 webhook 只有 Admin 可以使用
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoAutomationRequest true "自動化規則"
+// @Success 200 {object} models.TodoAutomationRules "成功回傳規則"
+// @Security BearerAuth
+// @Router /api/automations [post]
+func (ctl *TodoAutomationController) Create(c *gin.Context) {
+	var input dto.TodoAutomationRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	conditions, actions := automationInput(input.Conditions, input.Actions)
+	result, err := newTodoAutomationService(c).Create(config.DB, input.TodoListID, input.Name, input.Trigger, conditions, actions)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoAutomation
+// @Summary 取得自動化規則列表
+// @Description 指定 to_do_list_id 時只回傳該 TodoList 的規則
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoAutomationQuery false "篩選條件"
+// @Success 200 {array} models.TodoAutomationRules "成功回傳規則"
+// @Security BearerAuth
+// @Router /api/automations [get]
+func (ctl *TodoAutomationController) Index(c *gin.Context) {
+	var query dto.TodoAutomationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	result, err := newTodoAutomationService(c).Index(config.DB, query.TodoListID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Show TodoAutomation
+// @Summary 取得自動化規則
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param id path int true "規則 ID"
+// @Success 200 {object} models.TodoAutomationRules "成功回傳規則"
+// @Security BearerAuth
+// @Router /api/automations/{id} [get]
+func (ctl *TodoAutomationController) Show(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoAutomationService(c).Show(config.DB, id)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Edit TodoAutomation
+// @Summary 修改自動化規則
+// @Description 只有建立者或 Admin 可以修改；不能更換 TodoList，停用期間的觸發不會補執行
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param id path int true "規則 ID"
+// @Param input body dto.TodoAutomationUpdateRequest true "自動化規則"
+// @Success 200 {object} models.TodoAutomationRules "成功回傳規則"
+// @Security BearerAuth
+// @Router /api/automations/{id} [put]
+func (ctl *TodoAutomationController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoAutomationUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	conditions, actions := automationInput(input.Conditions, input.Actions)
+	result, err := newTodoAutomationService(c).Edit(config.DB, id, input.Name, input.Trigger, conditions, actions, *input.Active)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoAutomation
+// @Summary 刪除自動化規則
+// @Description 只有建立者或 Admin 可以刪除
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param id path int true "規則 ID"
+// @Success 200 {object} models.TodoAutomationRules "成功回傳被刪除的規則"
+// @Security BearerAuth
+// @Router /api/automations/{id} [delete]
+func (ctl *TodoAutomationController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoAutomationService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Test TodoAutomation
+// @Summary 試跑自動化規則
+// @Description 以項目目前的資料檢查規則的條件，回傳每個條件的結果與符合時會執行的動作；不會執行動作也不會留下執行紀錄，停用的規則也可以試跑
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param id path int true "規則 ID"
+// @Param input body dto.TodoAutomationTestRequest true "試跑的項目"
+// @Success 200 {object} models.AutomationTestResult "成功回傳試跑結果"
+// @Security BearerAuth
+// @Router /api/automations/{id}/test [post]
+func (ctl *TodoAutomationController) Test(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoAutomationTestRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	result, err := newTodoAutomationService(c).Test(config.DB, id, input.TodoListDetailID)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Executions TodoAutomation
+// @Summary 取得自動化規則的執行紀錄
+// @Description 新的在前；status 為 succeeded、failed（所有動作已復原）或 skipped（超過連鎖層數上限）
+// @Tags TodoAutomation
+// @Accept json
+// @Produce json
+// @Param id path int true "規則 ID"
+// @Param query query dto.TodoAutomationExecutionsQuery false "分頁"
+// @Success 200 {array} models.TodoAutomationExecutions "成功回傳執行紀錄"
+// @Security BearerAuth
+// @Router /api/automations/{id}/executions [get]
+func (ctl *TodoAutomationController) Executions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoAutomationExecutionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoAutomationService(c).Executions(config.DB, id, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, automationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoBoardController struct{}
+
+// Show TodoBoard
+// @Summary 取得 TodoList 看板
+// @Description 依欄位分組回傳卡片，每個欄位包含總數與依位置排序的分頁資料；尚未設定欄位時依狀態產生預設欄位
+// @Tags TodoBoard
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param query query dto.TodoBoardQuery false "每個欄位的分頁"
+// @Success 200 {object} models.Board "成功回傳看板"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/board [get]
+func (ctl *TodoBoardController) Show(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoBoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	service := services.NewTodoBoardService(c.Request.Context(), repositories.NewTodoBoardRepository())
+	result, err := service.Show(config.DB, listID, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Configure TodoBoard
+// @Summary 設定 TodoList 看板
+// @Description 以新的欄位（對應狀態與 WIP 上限）與狀態轉換規則取代原本設定，每個狀態都必須有一個欄位
+// @Tags TodoBoard
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param input body dto.TodoBoardConfigRequest true "看板設定"
+// @Success 200 {object} models.Board "成功回傳看板（不含卡片）"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/board [put]
+func (ctl *TodoBoardController) Configure(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoBoardConfigRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	columns := make([]services.BoardColumnInput, len(input.Columns))
+	for i, col := range input.Columns {
+		columns[i] = services.BoardColumnInput{Name: col.Name, Status: col.Status, WIPLimit: col.WIPLimit}
+	}
+	transitions := make([]services.BoardTransitionInput, len(input.Transitions))
+	for i, t := range input.Transitions {
+		transitions[i] = services.BoardTransitionInput{From: t.From, To: t.To}
+	}
+
+	service := services.NewTodoBoardService(c.Request.Context(), repositories.NewTodoBoardRepository())
+	result, err := service.Configure(config.DB, listID, columns, transitions)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
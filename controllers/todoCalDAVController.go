@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"todolist/config"
+	"todolist/pkg/caldav"
+	"todolist/pkg/ical"
+	"todolist/repositories"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// caldavMaxBody PROPFIND、REPORT 與 PUT 的內容上限
+const caldavMaxBody = 1 << 20
+
+// caldavAllow 支援的方法
+const caldavAllow = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+
+// TodoCalDAVController CalDAV 的端點；WebDAV 的方法無法以 swagger 描述，請以 CalDAV 用戶端連線到 /caldav/
+type TodoCalDAVController struct{}
+
+func newTodoCalDAVService(c *gin.Context) *services.TodoCalDAVService {
+	ctx := c.Request.Context()
+	return services.NewTodoCalDAVService(
+		ctx,
+		repositories.NewTodoCalendarRepository(),
+		repositories.NewAuthRepository(),
+		newStatusAwareDetailsService(c).
+			WithChecklist(services.NewTodoChecklistService(ctx, repositories.NewTodoChecklistRepository())),
+	)
+}
+
+// calDAVErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func calDAVErrorStatus(err error, fallback int) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrCalDAVNotResource):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, services.ErrCalDAVPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, services.ErrCalDAVUnsupportedComponent), errors.Is(err, services.ErrCalDAVReportTarget), errors.Is(err, caldav.ErrUnsupportedReport):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCalDAVInvalidUID), errors.Is(err, ical.ErrInvalidCalendar), errors.Is(err, caldav.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return fallback
+	}
+}
+
+// calDAVError CalDAV 用戶端不讀 JSON，錯誤以純文字回傳
+func calDAVError(c *gin.Context, err error, fallback int) {
+	c.String(calDAVErrorStatus(err, fallback), err.Error())
+}
+
+// writeMultistatus 先寫到記憶體，發生錯誤時才能回傳錯誤訊息
+func writeMultistatus(c *gin.Context, ms *caldav.Multistatus) {
+	var buf bytes.Buffer
+	if err := ms.Encode(&buf); err != nil {
+		calDAVError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.Data(caldav.StatusMultiStatus, "application/xml; charset=utf-8", buf.Bytes())
+}
+
+// WellKnown 日曆程式以 /.well-known/caldav 尋找 CalDAV 的位置（RFC 6764）
+func (ctl *TodoCalDAVController) WellKnown(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, services.CalDAVRoot)
+}
+
+// Options 不需驗證，讓用戶端判斷是否支援 CalDAV
+func (ctl *TodoCalDAVController) Options(c *gin.Context) {
+	c.Header("DAV", "1, calendar-access")
+	c.Header("Allow", caldavAllow)
+	c.Status(http.StatusOK)
+}
+
+// Propfind 取得屬性，Depth 為 0 時只回傳路徑本身，其他值（包含 infinity）回傳下一層
+func (ctl *TodoCalDAVController) Propfind(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, caldavMaxBody)
+	req, err := caldav.ParsePropfind(c.Request.Body)
+	if err != nil {
+		calDAVError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	depth := 1
+	if c.GetHeader("Depth") == "0" {
+		depth = 0
+	}
+
+	ms, err := newTodoCalDAVService(c).Propfind(config.DB, c.Param("path"), depth, req)
+	if err != nil {
+		calDAVError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeMultistatus(c, ms)
+}
+
+// Report calendar-query 與 calendar-multiget
+func (ctl *TodoCalDAVController) Report(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, caldavMaxBody)
+	req, err := caldav.ParseReport(c.Request.Body)
+	if err != nil {
+		calDAVError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ms, err := newTodoCalDAVService(c).Report(config.DB, c.Param("path"), req)
+	if err != nil {
+		calDAVError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeMultistatus(c, ms)
+}
+
+// Get 取得項目的 iCalendar，HEAD 只回傳標頭
+func (ctl *TodoCalDAVController) Get(c *gin.Context) {
+	object, err := newTodoCalDAVService(c).Get(config.DB, c.Param("path"))
+	if err != nil {
+		calDAVError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("ETag", object.ETag)
+	c.Header("Last-Modified", object.LastModified.UTC().Format(http.TimeFormat))
+	if c.Request.Method == http.MethodHead {
+		c.Header("Content-Type", "text/calendar; charset=utf-8")
+		c.Status(http.StatusOK)
+		return
+	}
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", object.Data)
+}
+
+// Put 新增或修改項目，支援 If-Match 與 If-None-Match: *，成功時回傳新的 ETag
+func (ctl *TodoCalDAVController) Put(c *gin.Context) {
+	if mediaType := c.ContentType(); mediaType != "" && !strings.EqualFold(mediaType, "text/calendar") {
+		c.String(http.StatusUnsupportedMediaType, "Content-Type 必須為 text/calendar")
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, caldavMaxBody)
+
+	object, created, err := newTodoCalDAVService(c).Put(
+		config.DB,
+		c.Param("path"),
+		c.GetHeader("If-Match"),
+		c.GetHeader("If-None-Match"),
+		c.Request.Body,
+	)
+	if err != nil {
+		// 其餘為看板規則、前置任務等無法套用的修改
+		calDAVError(c, err, http.StatusConflict)
+		return
+	}
+
+	c.Header("ETag", object.ETag)
+	if created {
+		c.Header("Location", object.Href)
+		c.Status(http.StatusCreated)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Delete 刪除項目，支援 If-Match
+func (ctl *TodoCalDAVController) Delete(c *gin.Context) {
+	err := newTodoCalDAVService(c).Delete(config.DB, c.Param("path"), c.GetHeader("If-Match"))
+	if err != nil {
+		calDAVError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
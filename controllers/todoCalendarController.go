@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/pkg/ical"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoCalendarController struct{}
+
+func newTodoCalendarService(c *gin.Context) *services.TodoCalendarService {
+	return services.NewTodoCalendarService(
+		c.Request.Context(),
+		repositories.NewTodoCalendarRepository(),
+		repositories.NewAuthRepository(),
+		newMarkdownAwareDetailsService(c),
+	)
+}
+
+// calendarErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func calendarErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrFeedTokenForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrCalDAVTokenList):
+		return http.StatusBadRequest
+	case errors.Is(err, ical.ErrInvalidCalendar), errors.Is(err, services.ErrCalendarEmpty), errors.Is(err, services.ErrImportTooManyRows):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+// notModified 依 If-None-Match（優先）或 If-Modified-Since 判斷日曆程式手上的版本是否仍是最新的
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP 日期只精確到秒
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// CreateToken TodoCalendar
+// @Summary 建立 iCalendar 訂閱或 CalDAV 憑證
+// @Description 日曆程式無法帶 JWT，訂閱以網址中的憑證驗證，CalDAV 以帳號與憑證做 HTTP Basic 驗證；憑證與訂閱網址只會在建立時回傳一次。
+// @Description 不給 to_do_list_id 時訂閱指派給自己的項目，CalDAV 憑證不可指定 to_do_list_id
+// @Tags TodoCalendar
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoFeedTokenRequest true "訂閱範圍"
+// @Success 200 {object} models.TodoFeedTokens "成功回傳憑證與訂閱網址"
+// @Security BearerAuth
+// @Router /api/todo/feed-tokens [post]
+func (ctl *TodoCalendarController) CreateToken(c *gin.Context) {
+	var input dto.TodoFeedTokenRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoCalendarService(c).CreateToken(config.DB, input.TodoListID, input.Name, input.Scope)
+	if err != nil {
+		response.Error(c, calendarErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// IndexTokens TodoCalendar
+// @Summary 取得自己的 iCalendar 訂閱與 CalDAV 憑證
+// @Description 不含原始憑證，包含已撤銷的
+// @Tags TodoCalendar
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.TodoFeedTokens "成功回傳憑證"
+// @Security BearerAuth
+// @Router /api/todo/feed-tokens [get]
+func (ctl *TodoCalendarController) IndexTokens(c *gin.Context) {
+	result, err := newTodoCalendarService(c).IndexTokens(config.DB)
+	if err != nil {
+		response.Error(c, calendarErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RevokeToken TodoCalendar
+// @Summary 撤銷 iCalendar 訂閱或 CalDAV 憑證
+// @Description 撤銷後使用該憑證的訂閱與同步立即失效，只有建立者或 Admin 可以撤銷
+// @Tags TodoCalendar
+// @Accept json
+// @Produce json
+// @Param id path int true "憑證 ID"
+// @Success 200 {object} models.TodoFeedTokens "成功回傳撤銷的憑證"
+// @Security BearerAuth
+// @Router /api/todo/feed-tokens/{id} [delete]
+func (ctl *TodoCalendarController) RevokeToken(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoCalendarService(c).RevokeToken(config.DB, id)
+	if err != nil {
+		response.Error(c, calendarErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Feed TodoCalendar
+// @Summary iCalendar 訂閱
+// @Description 不需登入，以訂閱憑證驗證；輸出有到期時間的項目。支援 ETag / Last-Modified，內容沒有變動時回傳 304
+// @Tags TodoCalendar
+// @Produce text/calendar
+// @Param token path string true "訂閱憑證，結尾為 .ics"
+// @Param query query dto.TodoFeedQuery false "元件類型"
+// @Success 200 {file} file "iCalendar"
+// @Router /api/feeds/{token} [get]
+func (ctl *TodoCalendarController) Feed(c *gin.Context) {
+	var query dto.TodoFeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, services.ErrInvalidFeedComponent.Error())
+		return
+	}
+	if query.Component == "" {
+		query.Component = services.FeedComponentTodo
+	}
+
+	service := newTodoCalendarService(c)
+	feed, err := service.Feed(config.DB, strings.TrimSuffix(c.Param("token"), ".ics"))
+	if err != nil {
+		response.Error(c, calendarErrorStatus(err, http.StatusInternalServerError), "訂閱不存在或已撤銷")
+		return
+	}
+
+	etag := service.ETag(feed, query.Component)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", feed.LastModified.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "private, no-cache")
+	if notModified(c.Request, etag, feed.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	// 先寫到記憶體，發生錯誤時才能回傳錯誤訊息
+	var buf bytes.Buffer
+	if err := service.WriteFeed(config.DB, feed, query.Component, &buf); err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+// Import TodoCalendar
+// @Summary 匯入 .ics 到 TodoList
+// @Description 以 multipart/form-data 的 file 欄位上傳，VTODO 與 VEVENT 各建立為一個項目（DUE 或 DTSTART 為到期時間），
+// @Description 已匯入過相同 UID 的項目與已取消的項目略過，同一個檔案可以重複匯入
+// @Tags TodoCalendar
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param file formData file true ".ics 檔案"
+// @Success 200 {object} models.CalendarImportResult "成功回傳建立的項目 ID 與略過數"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/ical [post]
+func (ctl *TodoCalendarController) Import(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "檔案不可超過 10MB")
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "請以 file 欄位上傳檔案")
+		return
+	}
+	if header.Size > importMaxSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, "檔案不可超過 10MB")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無法讀取上傳的檔案")
+		return
+	}
+	defer file.Close()
+
+	result, err := newTodoCalendarService(c).Import(config.DB, listID, file)
+	if err != nil {
+		response.Error(c, calendarErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
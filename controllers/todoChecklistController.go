@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoChecklistController struct{}
+
+// Create TodoChecklistItem
+// @Summary 新增 checklist 項目
+// @Description 在指定的 TodoListDetails 底下新增一個 checklist 項目，排在最後
+// @Tags TodoChecklist
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoChecklistItemCreateRequest true "建立 checklist 項目所需資料"
+// @Success 200 {object} models.TodoChecklistItems "建立成功回傳的項目"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/items [post]
+func (ctl *TodoChecklistController) Create(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoChecklistItemCreateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	repo := repositories.NewTodoChecklistRepository()
+	service := services.NewTodoChecklistService(c.Request.Context(), repo)
+	result, err := service.Create(config.DB, detailID, input.Name)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Edit TodoChecklistItem
+// @Summary 修改 checklist 項目
+// @Description 修改 checklist 項目名稱與完成狀態；由說明 - [ ] 產生的項目會一併回寫說明
+// @Tags TodoChecklist
+// @Accept json
+// @Produce json
+// @Param item_id path int true "Checklist 項目 ID"
+// @Param input body dto.TodoChecklistItemUpdateRequest true "要更新的項目資料"
+// @Success 200 {object} models.TodoChecklistItems "成功回傳更新後的項目"
+// @Security BearerAuth
+// @Router /api/todo/list/details/items/{item_id} [put]
+func (ctl *TodoChecklistController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoChecklistItemUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoChecklistRepository()
+	service := services.NewTodoChecklistService(c.Request.Context(), repo)
+	result, err := service.Edit(config.DB, id, input.Name, *input.IsDone)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoChecklistItem
+// @Summary 刪除 checklist 項目
+// @Description 根據 ID 刪除 checklist 項目；由說明 - [ ] 產生的項目會一併從說明中移除
+// @Tags TodoChecklist
+// @Accept json
+// @Produce json
+// @Param item_id path int true "Checklist 項目 ID"
+// @Success 200 {object} models.TodoChecklistItems "成功回傳被刪除的項目"
+// @Security BearerAuth
+// @Router /api/todo/list/details/items/{item_id} [delete]
+func (ctl *TodoChecklistController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	repo := repositories.NewTodoChecklistRepository()
+	service := services.NewTodoChecklistService(c.Request.Context(), repo)
+	result, err := service.Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Reorder TodoChecklistItem
+// @Summary 重新排序 checklist 項目
+// @Description 依傳入的 ID 順序重新排列 TodoListDetails 底下的所有項目
+// @Tags TodoChecklist
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoChecklistReorderRequest true "完整的項目 ID 順序"
+// @Success 200 {array} models.TodoChecklistItems "成功回傳排序後的項目"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/items/order [put]
+func (ctl *TodoChecklistController) Reorder(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoChecklistReorderRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoChecklistRepository()
+	service := services.NewTodoChecklistService(c.Request.Context(), repo)
+	result, err := service.Reorder(config.DB, detailID, input.ItemIDs)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
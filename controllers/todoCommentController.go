@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoCommentController struct{}
+
+func newTodoCommentService(c *gin.Context) *services.TodoCommentService {
+	return services.NewTodoCommentService(
+		c.Request.Context(),
+		repositories.NewTodoCommentRepository(),
+		repositories.NewAuthRepository(),
+	).WithEvents(newTodoOutboxService(c)).
+		WithWatchers(newTodoWatcherService(c))
+}
+
+// commentErrorStatus 權限不足回 403，其餘依呼叫端指定
+func commentErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrCommentForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return fallback
+	}
+}
+
+// Create TodoComment
+// @Summary 新增留言
+// @Description 在 TodoListDetails 上留言或回覆（parent_id），內容中的 @account 會解析為提及的使用者
+// @Tags TodoComment
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoCommentCreateRequest true "留言內容"
+// @Success 200 {object} models.TodoComments "成功回傳留言"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/comments [post]
+func (ctl *TodoCommentController) Create(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoCommentCreateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoCommentService(c).Create(config.DB, detailID, input.ParentID, input.Body)
+	if err != nil {
+		response.Error(c, commentErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoComment
+// @Summary 取得留言
+// @Description 分頁取得 TodoListDetails 的討論串（依時間先後），每串包含所有回覆
+// @Tags TodoComment
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param page query int false "頁碼（預設 1）"
+// @Param page_size query int false "每頁筆數（預設 20）"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/comments [get]
+func (ctl *TodoCommentController) Index(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoCommentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoCommentService(c).Index(config.DB, detailID, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Edit TodoComment
+// @Summary 修改留言
+// @Description 只有作者或 Admin 可以修改，修改前的內容會保存在修改紀錄
+// @Tags TodoComment
+// @Accept json
+// @Produce json
+// @Param comment_id path int true "留言 ID"
+// @Param input body dto.TodoCommentUpdateRequest true "留言內容"
+// @Success 200 {object} models.TodoComments "成功回傳留言"
+// @Security BearerAuth
+// @Router /api/todo/list/details/comments/{comment_id} [put]
+func (ctl *TodoCommentController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoCommentUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoCommentService(c).Edit(config.DB, id, input.Body)
+	if err != nil {
+		response.Error(c, commentErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoComment
+// @Summary 刪除留言
+// @Description 只有作者或 Admin 可以刪除（軟刪除）
+// @Tags TodoComment
+// @Accept json
+// @Produce json
+// @Param comment_id path int true "留言 ID"
+// @Success 200 {object} models.TodoComments "成功回傳被刪除的留言"
+// @Security BearerAuth
+// @Router /api/todo/list/details/comments/{comment_id} [delete]
+func (ctl *TodoCommentController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoCommentService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, commentErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Revisions TodoComment
+// @Summary 取得留言修改紀錄
+// @Description 依時間先後回傳每次修改前的內容
+// @Tags TodoComment
+// @Accept json
+// @Produce json
+// @Param comment_id path int true "留言 ID"
+// @Success 200 {array} models.TodoCommentRevisions "成功回傳修改紀錄"
+// @Security BearerAuth
+// @Router /api/todo/list/details/comments/{comment_id}/revisions [get]
+func (ctl *TodoCommentController) Revisions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoCommentService(c).Revisions(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
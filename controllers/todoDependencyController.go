@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoDependencyController struct{}
+
+func newTodoDependencyService(c *gin.Context) *services.TodoDependencyService {
+	return services.NewTodoDependencyService(
+		c.Request.Context(),
+		repositories.NewTodoDependencyRepository(),
+		repositories.NewTodoListDetailsRepository(),
+	)
+}
+
+// AddBlocker TodoListDetails
+// @Summary 新增前置任務
+// @Description 設定 blocker_id 擋住指定的 TodoListDetails（可跨 TodoList），會形成循環時回傳錯誤與循環路徑
+// @Tags TodoDependency
+// @Accept json
+// @Produce json
+// @Param id path int true "被擋住的 TodoListDetails ID"
+// @Param input body dto.TodoListDetailsBlockerRequest true "前置任務 ID"
+// @Success 200 {object} models.TodoDetailDependency "成功回傳相依關係"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/blockers [post]
+func (ctl *TodoDependencyController) AddBlocker(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListDetailsBlockerRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoDependencyService(c).AddBlocker(config.DB, id, input.BlockerID)
+	if errors.Is(err, services.ErrDependencyBusy) {
+		response.Error(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RemoveBlocker TodoListDetails
+// @Summary 移除前置任務
+// @Description 移除 blocker_id 對指定 TodoListDetails 的阻擋關係
+// @Tags TodoDependency
+// @Accept json
+// @Produce json
+// @Param id path int true "被擋住的 TodoListDetails ID"
+// @Param blocker_id path int true "前置任務 ID"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/blockers/{blocker_id} [delete]
+func (ctl *TodoDependencyController) RemoveBlocker(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	blockerID, err := strconv.Atoi(c.Param("blocker_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 blocker ID")
+		return
+	}
+
+	if err := newTodoDependencyService(c).RemoveBlocker(config.DB, id, blockerID); err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Graph TodoList
+// @Summary 取得 TodoList 相依圖
+// @Description 回傳 TodoList 的相依圖，format=json（預設）或 format=dot（Graphviz）
+// @Tags TodoDependency
+// @Accept json
+// @Produce json,text/vnd.graphviz
+// @Param id path int true "TodoList ID"
+// @Param format query string false "輸出格式：json 或 dot"
+// @Success 200 {object} models.DependencyGraph "成功回傳相依圖"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/graph [get]
+func (ctl *TodoDependencyController) Graph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	graph, err := newTodoDependencyService(c).Graph(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if c.Query("format") == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+		return
+	}
+
+	response.Success(c, graph)
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/templates"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoEmailController struct{}
+
+func newTodoEmailService(c *gin.Context) *services.TodoEmailService {
+	return services.NewTodoEmailService(c.Request.Context(), repositories.NewTodoEmailRepository())
+}
+
+// emailErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func emailErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return fallback
+	}
+}
+
+// Settings TodoEmail
+// @Summary 取得目前使用者的 email 設定
+// @Description 尚未設定過時 email 為空，不會寄送任何信件；unsubscribed_at 有值表示已從信件中取消訂閱
+// @Tags TodoEmail
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TodoEmailSettings "成功回傳設定"
+// @Security BearerAuth
+// @Router /api/email/settings [get]
+func (ctl *TodoEmailController) Settings(c *gin.Context) {
+	settings, err := newTodoEmailService(c).Settings(config.DB)
+	if err != nil {
+		response.Error(c, emailErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, settings)
+}
+
+// SaveSettings TodoEmail
+// @Summary 更新目前使用者的 email 設定
+// @Description notify_assigned 為被指派時寄信，notify_due 為即將到期、逾期與逾期多日的提醒寄信；digest 為 off、daily 或 weekly 的未完成任務摘要。儲存後會重新訂閱
+// @Tags TodoEmail
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoEmailSettingsRequest true "email 設定"
+// @Success 200 {object} models.TodoEmailSettings "成功回傳設定"
+// @Security BearerAuth
+// @Router /api/email/settings [put]
+func (ctl *TodoEmailController) SaveSettings(c *gin.Context) {
+	var input dto.TodoEmailSettingsRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	settings, err := newTodoEmailService(c).SaveSettings(config.DB, input.Email, input.Locale, *input.NotifyAssigned, *input.NotifyDue, input.Digest)
+	if err != nil {
+		response.Error(c, emailErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, settings)
+}
+
+// unsubscribePage 回傳取消訂閱的網頁，done 為 false 時顯示確認按鈕
+func unsubscribePage(c *gin.Context, settings *models.TodoEmailSettings, token string, done bool) {
+	page, err := templates.RenderPage(settings.Locale, "unsubscribe", gin.H{
+		"Email":  settings.Email,
+		"Done":   done,
+		"Action": "?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// UnsubscribePage TodoEmail
+// @Summary 取消訂閱確認頁
+// @Description 信件中的取消訂閱連結，不需要登入；只顯示確認按鈕，避免信箱的連結掃描誤觸取消訂閱
+// @Tags TodoEmail
+// @Produce html
+// @Param query query dto.TodoEmailUnsubscribeQuery true "信件中的 token"
+// @Success 200 {string} string "確認頁"
+// @Router /api/email/unsubscribe [get]
+func (ctl *TodoEmailController) UnsubscribePage(c *gin.Context) {
+	var query dto.TodoEmailUnsubscribeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.String(http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	settings, err := newTodoEmailService(c).FindByToken(config.DB, query.Token)
+	if err != nil {
+		c.String(emailErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	unsubscribePage(c, settings, query.Token, settings.UnsubscribedAt != nil)
+}
+
+// Unsubscribe TodoEmail
+// @Summary 取消訂閱所有 email
+// @Description 不需要登入；同時支援 RFC 8058 一鍵取消訂閱（郵件軟體以 POST 送出 List-Unsubscribe=One-Click），重複取消不會出錯
+// @Tags TodoEmail
+// @Produce html
+// @Param query query dto.TodoEmailUnsubscribeQuery true "信件中的 token"
+// @Success 200 {string} string "完成頁"
+// @Router /api/email/unsubscribe [post]
+func (ctl *TodoEmailController) Unsubscribe(c *gin.Context) {
+	var query dto.TodoEmailUnsubscribeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.String(http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	settings, err := newTodoEmailService(c).Unsubscribe(config.DB, query.Token)
+	if err != nil {
+		c.String(emailErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	unsubscribePage(c, settings, query.Token, true)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TodoExportController struct{}
+
+func newTodoExportService(c *gin.Context) *services.TodoExportService {
+	return services.NewTodoExportService(
+		c.Request.Context(),
+		repositories.NewTodoExportJobRepository(),
+		repositories.NewTodoListRepository(),
+		repositories.NewAuthRepository(),
+		config.Storage,
+	)
+}
+
+// exportErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func exportErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrExportForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrExportNotReady):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return fallback
+	}
+}
+
+// stream 邊讀邊寫出匯出內容；開始傳送後發生錯誤只能中斷連線並記錄
+func (ctl *TodoExportController) stream(c *gin.Context, filter models.ExportFilter, format, filename string) {
+	if format == "" {
+		format = models.ExportFormatCSV
+	}
+
+	c.Header("Content-Type", services.ExportContentType(format))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename + "." + format}))
+
+	err := newTodoExportService(c).Write(config.DB, filter, format, c.Writer)
+	if err == nil {
+		return
+	}
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		response.Error(c, exportErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	utils.Logger.Error("匯出中斷", zap.Error(err))
+	c.Abort()
+}
+
+// ExportList TodoExport
+// @Summary 匯出單一 TodoList
+// @Description 以 CSV（每個項目一列）或 JSON（與 TodoList Show 相同的結構，含 Type、Details 與負責人）下載
+// @Tags TodoExport
+// @Produce text/csv
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param query query dto.TodoExportListQuery false "格式（預設 csv）"
+// @Success 200 {file} file "匯出內容"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/export [get]
+func (ctl *TodoExportController) ExportList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoExportListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, services.ErrInvalidExportFormat.Error())
+		return
+	}
+
+	ctl.stream(c, models.ExportFilter{ListID: id}, query.Format, "todo-list-"+strconv.Itoa(id))
+}
+
+// Export TodoExport
+// @Summary 匯出多個 TodoList
+// @Description 依篩選條件匯出，不給條件時匯出整個工作區；內容邊讀邊傳送，資料量大時建議改用背景匯出
+// @Tags TodoExport
+// @Produce text/csv
+// @Produce json
+// @Param query query dto.TodoExportQuery false "格式（預設 csv）與篩選條件"
+// @Success 200 {file} file "匯出內容"
+// @Security BearerAuth
+// @Router /api/todo/list/export [get]
+func (ctl *TodoExportController) Export(c *gin.Context) {
+	var query dto.TodoExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	filter := models.ExportFilter{TypeID: query.TypeID, Keyword: query.Keyword, LabelIDs: query.LabelIDs}
+	ctl.stream(c, filter, query.Format, "todo-lists")
+}
+
+// CreateJob TodoExport
+// @Summary 建立背景匯出工作
+// @Description 由排程在背景匯出，完成後查詢工作即可取得下載連結；不給篩選條件時匯出整個工作區
+// @Tags TodoExport
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoExportJobRequest true "格式與篩選條件"
+// @Success 200 {object} models.TodoExportJobs "成功回傳匯出工作"
+// @Security BearerAuth
+// @Router /api/todo/export-jobs [post]
+func (ctl *TodoExportController) CreateJob(c *gin.Context) {
+	var input dto.TodoExportJobRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	filter := models.ExportFilter{ListID: input.ListID, TypeID: input.TypeID, Keyword: input.Keyword, LabelIDs: input.LabelIDs}
+	result, err := newTodoExportService(c).CreateJob(config.DB, filter, input.Format)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ShowJob TodoExport
+// @Summary 取得背景匯出工作
+// @Description 只有建立者或 Admin 可以查看，status 為 done 時 download_url 為下載連結
+// @Tags TodoExport
+// @Accept json
+// @Produce json
+// @Param id path int true "匯出工作 ID"
+// @Success 200 {object} models.TodoExportJobs "成功回傳匯出工作"
+// @Security BearerAuth
+// @Router /api/todo/export-jobs/{id} [get]
+func (ctl *TodoExportController) ShowJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoExportService(c).ShowJob(config.DB, id)
+	if err != nil {
+		response.Error(c, exportErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DownloadJob TodoExport
+// @Summary 下載背景匯出的檔案
+// @Description 只有建立者或 Admin 可以下載，工作尚未完成時回傳 409
+// @Tags TodoExport
+// @Produce application/octet-stream
+// @Param id path int true "匯出工作 ID"
+// @Success 200 {file} file "匯出內容"
+// @Security BearerAuth
+// @Router /api/todo/export-jobs/{id}/download [get]
+func (ctl *TodoExportController) DownloadJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	job, reader, err := newTodoExportService(c).OpenJob(config.DB, id)
+	if err != nil {
+		response.Error(c, exportErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, job.FileSize, services.ExportContentType(job.Format), reader, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": job.FileName()}),
+	})
+}
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/pkg/importer"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// importMaxSize 匯入檔案的大小上限
+const importMaxSize = 10 << 20
+
+type TodoImportController struct{}
+
+func newTodoImportService(c *gin.Context) *services.TodoImportService {
+	ctx := c.Request.Context()
+	return services.NewTodoImportService(
+		ctx,
+		repositories.NewTodoImportRepository(),
+		repositories.NewAuthRepository(),
+		services.NewTodoTypeService(ctx, repositories.NewTodoTypeRepository()),
+		services.NewTodoListService(ctx, repositories.NewTodoListRepository()).
+			WithEvents(newTodoOutboxService(c)).
+			WithWatchers(newTodoWatcherService(c)),
+		newMarkdownAwareDetailsService(c),
+	)
+}
+
+// importErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func importErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrImportForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return fallback
+	}
+}
+
+// Create TodoImport
+// @Summary 上傳並驗證匯入檔案（dry run）
+// @Description 支援自訂欄位對應的 CSV（source=csv，mapping 為 JSON，可對應 list、type、name、detail、status、assignees，list 與 name 必填）
+// @Description 與 Trello 看板匯出的 JSON（source=trello）。只會逐列驗證並保存結果，不建立資料；找不到的帳號與已存在的同名 TodoList 列為警告
+// @Tags TodoImport
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "匯入檔案"
+// @Param source formData string true "來源：csv 或 trello"
+// @Param mapping formData string false "CSV 欄位對應（JSON）"
+// @Success 200 {object} models.TodoImports "成功回傳驗證結果"
+// @Security BearerAuth
+// @Router /api/todo/imports [post]
+func (ctl *TodoImportController) Create(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxSize+multipartOverhead)
+
+	var form dto.TodoImportForm
+	if err := c.ShouldBind(&form); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "檔案不可超過 10MB")
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "source 必須為 csv 或 trello")
+		return
+	}
+
+	var mapping importer.Mapping
+	if form.Mapping != "" {
+		if err := json.Unmarshal([]byte(form.Mapping), &mapping); err != nil {
+			response.Error(c, http.StatusBadRequest, "mapping 必須為 JSON 物件")
+			return
+		}
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "請以 file 欄位上傳檔案")
+		return
+	}
+	if header.Size > importMaxSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, "檔案不可超過 10MB")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無法讀取上傳的檔案")
+		return
+	}
+	defer file.Close()
+
+	result, err := newTodoImportService(c).Validate(config.DB, form.Source, header.Filename, file, mapping)
+	if err != nil {
+		response.Error(c, importErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Show TodoImport
+// @Summary 取得匯入
+// @Description 只有建立者或 Admin 可以查看
+// @Tags TodoImport
+// @Accept json
+// @Produce json
+// @Param id path int true "匯入 ID"
+// @Success 200 {object} models.TodoImports "成功回傳匯入"
+// @Security BearerAuth
+// @Router /api/todo/imports/{id} [get]
+func (ctl *TodoImportController) Show(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoImportService(c).Show(config.DB, id)
+	if err != nil {
+		response.Error(c, importErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Rows TodoImport
+// @Summary 取得匯入的逐列驗證報告
+// @Description 每一列的錯誤與警告，valid 的列會在確認時建立，建立後帶有 to_do_list_detail_id
+// @Tags TodoImport
+// @Accept json
+// @Produce json
+// @Param id path int true "匯入 ID"
+// @Param query query dto.TodoImportRowsQuery false "篩選與分頁"
+// @Success 200 {array} models.TodoImportRows "成功回傳匯入列"
+// @Security BearerAuth
+// @Router /api/todo/imports/{id}/rows [get]
+func (ctl *TodoImportController) Rows(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoImportRowsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoImportService(c).Rows(config.DB, id, query.Problems, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, importErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Commit TodoImport
+// @Summary 確認匯入
+// @Description 建立驗證通過的列，每個 TodoList 一個交易；中途失敗時已建立的 TodoList 保留，再次確認會從尚未建立的部分繼續
+// @Tags TodoImport
+// @Accept json
+// @Produce json
+// @Param id path int true "匯入 ID"
+// @Success 200 {object} models.TodoImports "成功回傳匯入"
+// @Security BearerAuth
+// @Router /api/todo/imports/{id}/commit [post]
+func (ctl *TodoImportController) Commit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoImportService(c).Commit(config.DB, id)
+	if err != nil {
+		response.Error(c, importErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoLabelController struct{}
+
+// Create TodoLabel
+// @Summary 新增 TodoLabel
+// @Description 建立一個新的 TodoLabel 項目
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoLabelCreateRequest true "建立 TodoLabel 所需資料"
+// @Success 200 {object} models.TodoLabels "建立成功回傳的 TodoLabel 資料"
+// @Security BearerAuth
+// @Router /api/todo/label [post]
+func (ctl *TodoLabelController) Create(c *gin.Context) {
+	var input dto.TodoLabelCreateRequest
+
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Create(config.DB, input.Name, input.Color)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// @Summary 取得 TodoLabel 列表
+// @Description 查詢 TodoLabel 清單，支援關鍵字與排序
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Param page query int false "頁碼（預設 1）"
+// @Param page_size query int false "每頁筆數（預設 10）"
+// @Param keyword query string false "關鍵字搜尋"
+// @Param order query string false "排序欄位與方式，如 created_at desc"
+// @Security BearerAuth
+// @Router /api/todo/label [get]
+func (ctl *TodoLabelController) Index(c *gin.Context) {
+	var query dto.TodoLabelQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 10
+	}
+
+	orders := utils.ParseOrders(query.Order, utils.AllowedOrders, "created_at desc")
+
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Index(config.DB, query.Keyword, query.Page, query.PageSize, orders)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Usage TodoLabel
+// @Summary 取得 TodoLabel 使用次數
+// @Description 統計每個 TodoLabel 被 TodoList 與 TodoListDetails 使用的次數
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.TodoLabelUsage "成功回傳使用次數"
+// @Security BearerAuth
+// @Router /api/todo/label/usage [get]
+func (ctl *TodoLabelController) Usage(c *gin.Context) {
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Usage(config.DB)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Show TodoLabel
+// @Summary 取得單一 TodoLabel
+// @Description 根據 ID 取得 TodoLabel 詳細資料
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoLabel ID"
+// @Success 200 {object} models.TodoLabels "成功回傳 TodoLabel"
+// @Security BearerAuth
+// @Router /api/todo/label/{id} [get]
+func (ctl *TodoLabelController) Show(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Show(config.DB, id)
+
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Edit TodoLabel
+// @Summary 修改 TodoLabel
+// @Description 根據 ID 修改 TodoLabel 名稱與顏色
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoLabel ID"
+// @Param input body dto.TodoLabelUpdateRequest true "要更新的 TodoLabel 資料"
+// @Success 200 {object} models.TodoLabels "成功回傳更新後的 TodoLabel"
+// @Security BearerAuth
+// @Router /api/todo/label/{id} [put]
+func (ctl *TodoLabelController) Edit(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input dto.TodoLabelUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Edit(config.DB, id, input.Name, input.Color)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Delete TodoLabel
+// @Summary 刪除 TodoLabel
+// @Description 根據 ID 刪除指定的 TodoLabel，並解除與任務的關聯（不刪除任務）
+// @Tags TodoLabels
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoLabel ID"
+// @Success 200 {object} models.TodoLabels "成功回傳被刪除的 TodoLabel"
+// @Security BearerAuth
+// @Router /api/todo/label/{id} [delete]
+func (ctl *TodoLabelController) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	repo := repositories.NewTodoLabelRepository()
+	service := services.NewTodoLabelService(c.Request.Context(), repo)
+	result, err := service.Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
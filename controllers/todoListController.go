@@ -15,6 +15,11 @@ import (
 
 type TodoListController struct{}
 
+// newTodoOutboxService TodoList、TodoListDetails 與成員的寫入都在交易內透過它寫入領域事件
+func newTodoOutboxService(c *gin.Context) *services.TodoOutboxService {
+	return services.NewTodoOutboxService(c.Request.Context(), repositories.NewTodoOutboxRepository())
+}
+
 // Create TodoList
 // @Summary 新增 TodoList
 // @Description 建議一個新的 todoList 項目
@@ -33,7 +38,9 @@ func (ctl *TodoListController) Create(c *gin.Context) {
 	}
 
 	repo := repositories.NewTodoListRepository()
-	service := services.NewTodoListService(c.Request.Context(), repo)
+	service := services.NewTodoListService(c.Request.Context(), repo).
+		WithEvents(newTodoOutboxService(c)).
+		WithWatchers(newTodoWatcherService(c))
 	result, err := service.Create(config.DB, input.Name, input.TypeID) // 這裡多傳入 type_id
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
@@ -51,6 +58,7 @@ func (ctl *TodoListController) Create(c *gin.Context) {
 // @Param page_size query int false "每頁筆數（預設 10）"
 // @Param keyword query string false "關鍵字搜尋"
 // @Param order query string false "排序欄位與方式，如 created_at desc"
+// @Param label_ids query []int false "標籤 ID（可重複帶入，符合任一即可）"
 // @Security BearerAuth
 // @Router /api/todo/list [get]
 func (ctl *TodoListController) Index(c *gin.Context) {
@@ -75,7 +83,7 @@ func (ctl *TodoListController) Index(c *gin.Context) {
 	repo := repositories.NewTodoListRepository()
 	service := services.NewTodoListService(c.Request.Context(), repo)
 
-	result, err := service.Index(config.DB, query.Keyword, query.Page, query.PageSize, orders)
+	result, err := service.Index(config.DB, query.Keyword, query.LabelIDs, query.Page, query.PageSize, orders)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
@@ -140,7 +148,8 @@ func (ctl *TodoListController) Edit(c *gin.Context) {
 	}
 
 	repo := repositories.NewTodoListRepository()
-	service := services.NewTodoListService(c.Request.Context(), repo)
+	service := services.NewTodoListService(c.Request.Context(), repo).
+		WithEvents(newTodoOutboxService(c))
 	result, err := service.Edit(config.DB, id, input.Name, input.TypeID)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
@@ -169,7 +178,8 @@ func (ctl *TodoListController) Delete(c *gin.Context) {
 	}
 
 	repo := repositories.NewTodoListRepository()
-	service := services.NewTodoListService(c.Request.Context(), repo)
+	service := services.NewTodoListService(c.Request.Context(), repo).
+		WithEvents(newTodoOutboxService(c))
 	result, err := service.Delete(config.DB, id)
 
 	if err != nil {
@@ -179,3 +189,123 @@ func (ctl *TodoListController) Delete(c *gin.Context) {
 	response.Success(c, result)
 
 }
+
+// AttachLabels TodoList
+// @Summary 為 TodoList 加上標籤
+// @Description 將一或多個 TodoLabel 加到指定的 TodoList
+// @Tags TodoList
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param input body dto.TodoLabelAttachRequest true "要加上的標籤 ID"
+// @Success 200 {object} models.TodoList "成功回傳含標籤的 TodoList"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/labels [post]
+func (ctl *TodoListController) AttachLabels(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoLabelAttachRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoListRepository()
+	service := services.NewTodoListService(c.Request.Context(), repo)
+	result, err := service.AttachLabels(config.DB, id, input.LabelIDs)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// DetachLabel TodoList
+// @Summary 移除 TodoList 的標籤
+// @Description 從指定的 TodoList 移除一個 TodoLabel
+// @Tags TodoList
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param label_id path int true "TodoLabel ID"
+// @Success 200 {object} models.TodoList "成功回傳含標籤的 TodoList"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/labels/{label_id} [delete]
+func (ctl *TodoListController) DetachLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 Label ID")
+		return
+	}
+
+	repo := repositories.NewTodoListRepository()
+	service := services.NewTodoListService(c.Request.Context(), repo)
+	result, err := service.DetachLabel(config.DB, id, labelID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Move TodoList
+// @Summary 調整 TodoList 順序
+// @Description 移到 after_id 之後、before_id 之前（可只給其中一個，都不給則移到最後），只會更新被移動的 TodoList
+// @Tags TodoList
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param input body dto.TodoListMoveRequest true "移動位置"
+// @Success 200 {object} models.TodoList "成功回傳移動後的 TodoList"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/move [put]
+func (ctl *TodoListController) Move(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListMoveRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoListRepository()
+	service := services.NewTodoListService(c.Request.Context(), repo)
+	result, err := service.Move(config.DB, id, input.AfterID, input.BeforeID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Rebalance TodoList
+// @Summary 重新分配 TodoList 位置鍵
+// @Description 位置鍵過長時重新平均分配，順序不變
+// @Tags TodoList
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Router /api/todo/list/rebalance [post]
+func (ctl *TodoListController) Rebalance(c *gin.Context) {
+	repo := repositories.NewTodoListRepository()
+	service := services.NewTodoListService(c.Request.Context(), repo)
+	if err := service.Rebalance(config.DB); err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"todolist/config"
@@ -11,13 +12,36 @@ import (
 	"todolist/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type TodoListDetailsController struct{}
 
+// newDetailsService 會寫入的操作需要在交易內寫入領域事件，建立者與負責人在同一個交易內自動關注
+func newDetailsService(c *gin.Context) *services.TodoListDetailsService {
+	return services.NewTodoListDetailsService(c.Request.Context(), repositories.NewTodoListDetailsRepository()).
+		WithEvents(newTodoOutboxService(c)).
+		WithWatchers(newTodoWatcherService(c))
+}
+
+// newStatusAwareDetailsService 變更狀態時需要套用看板規則與週期性任務
+func newStatusAwareDetailsService(c *gin.Context) *services.TodoListDetailsService {
+	ctx := c.Request.Context()
+	return newDetailsService(c).
+		WithRecurrence(newTodoRecurrenceService(c)).
+		WithBoard(services.NewTodoBoardService(ctx, repositories.NewTodoBoardRepository()))
+}
+
+// newMarkdownAwareDetailsService 新增、修改說明時需要同步其中的 - [ ] 待辦
+func newMarkdownAwareDetailsService(c *gin.Context) *services.TodoListDetailsService {
+	ctx := c.Request.Context()
+	return newDetailsService(c).
+		WithChecklist(services.NewTodoChecklistService(ctx, repositories.NewTodoChecklistRepository()))
+}
+
 // Create TodoListDetails
 // @summary 新增 todoListDetails
-// @Description 建立一個新的 todoListDetails 項目
+// @Description 建立一個新的 todoListDetails 項目，detail 為 Markdown，回傳時附上過濾後的 detail_html，其中的 - [ ] 待辦會建立為 checklist 項目
 // @Tags TodoListDetails
 // @Accept json
 // @Produce json
@@ -32,9 +56,36 @@ func (ctl *TodoListDetailsController) Create(c *gin.Context) {
 		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
 	}
 
+	service := newMarkdownAwareDetailsService(c)
+	result, err := service.Create(config.DB, input.TodoListID, input.Name, input.Detail, input.IDs)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Show TodoListDetails
+// @Summary 取得單一 TodoListDetails
+// @Description 根據 ID 取得 TodoListDetails，包含負責人、標籤、checklist 項目與完成進度
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Success 200 {object} models.TodoListDetails "成功回傳 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id} [get]
+func (ctl *TodoListDetailsController) Show(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
 	repo := repositories.NewTodoListDetailsRepository()
 	service := services.NewTodoListDetailsService(c.Request.Context(), repo)
-	result, err := service.Create(config.DB, input.TodoListID, input.Name, input.Detail, input.IDs)
+	result, err := service.Show(config.DB, id)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
@@ -45,7 +96,7 @@ func (ctl *TodoListDetailsController) Create(c *gin.Context) {
 
 // Edit TodoListDetails
 // @Summary 修改 TodoListDetails
-// @Description 根據 ID 修改 TodoListDetails 名稱
+// @Description 根據 ID 修改 TodoListDetails 名稱與說明（Markdown），說明中的 - [ ] 待辦會同步到 checklist 項目
 // @Tags TodoListDetails
 // @Accept json
 // @Produce json
@@ -67,9 +118,74 @@ func (ctl *TodoListDetailsController) Edit(c *gin.Context) {
 		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
 	}
 
+	service := newMarkdownAwareDetailsService(c)
+	result, err := service.Edit(config.DB, id, input.Name, input.Detail)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ChangeStatus TodoListDetails
+// @Summary 變更 TodoListDetails 狀態
+// @Description 變更狀態（todo / in_progress / done），仍有未完成的前置任務時不能改為 done
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoListDetailsStatusRequest true "新的狀態"
+// @Success 200 {object} models.TodoListDetails "成功回傳更新後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/status [put]
+func (ctl *TodoListDetailsController) ChangeStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListDetailsStatusRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newStatusAwareDetailsService(c).ChangeStatus(config.DB, id, input.Status)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetEstimate TodoListDetails
+// @Summary 設定 TodoListDetails 預估工時
+// @Description 設定原始與剩餘預估工時（分鐘），不給或給 null 表示清空；工時彙總會與實際工時一併列出
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoListDetailsEstimateRequest true "預估工時"
+// @Success 200 {object} models.TodoListDetails "成功回傳更新後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/estimate [put]
+func (ctl *TodoListDetailsController) SetEstimate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListDetailsEstimateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
 	repo := repositories.NewTodoListDetailsRepository()
 	service := services.NewTodoListDetailsService(c.Request.Context(), repo)
-	result, err := service.Edit(config.DB, id, input.Name, input.Detail)
+	result, err := service.SetEstimate(config.DB, id, input.OriginalEstimateMinutes, input.RemainingEstimateMinutes)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
@@ -78,6 +194,74 @@ func (ctl *TodoListDetailsController) Edit(c *gin.Context) {
 	response.Success(c, result)
 }
 
+// SetDue TodoListDetails
+// @Summary 設定 TodoListDetails 到期時間
+// @Description 不給或給 null 表示清空；有到期時間的項目會出現在 iCalendar 訂閱中
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoListDetailsDueRequest true "到期時間"
+// @Success 200 {object} models.TodoListDetails "成功回傳更新後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/due [put]
+func (ctl *TodoListDetailsController) SetDue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListDetailsDueRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newDetailsService(c).SetDue(config.DB, id, input.DueAt)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetAssignees TodoListDetails
+// @Summary 設定 TodoListDetails 負責人
+// @Description 以 user_ids 取代原本的負責人；新增與移除的負責人會收到通知
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoListDetailsAssigneesRequest true "負責人"
+// @Success 200 {object} models.TodoListDetails "成功回傳更新後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/assignees [put]
+func (ctl *TodoListDetailsController) SetAssignees(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoListDetailsAssigneesRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newDetailsService(c).SetAssignees(config.DB, id, input.IDs)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		response.Error(c, status, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
 func (ctl *TodoListDetailsController) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
@@ -86,15 +270,171 @@ func (ctl *TodoListDetailsController) Delete(c *gin.Context) {
 		return
 	}
 
+	result, err := newDetailsService(c).Delete(config.DB, id)
+
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AttachLabels TodoListDetails
+// @Summary 為 TodoListDetails 加上標籤
+// @Description 將一或多個 TodoLabel 加到指定的 TodoListDetails
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoLabelAttachRequest true "要加上的標籤 ID"
+// @Success 200 {object} models.TodoListDetails "成功回傳含標籤的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/labels [post]
+func (ctl *TodoListDetailsController) AttachLabels(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoLabelAttachRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	repo := repositories.NewTodoListDetailsRepository()
+	service := services.NewTodoListDetailsService(c.Request.Context(), repo)
+	result, err := service.AttachLabels(config.DB, id, input.LabelIDs)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DetachLabel TodoListDetails
+// @Summary 移除 TodoListDetails 的標籤
+// @Description 從指定的 TodoListDetails 移除一個 TodoLabel
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param label_id path int true "TodoLabel ID"
+// @Success 200 {object} models.TodoListDetails "成功回傳含標籤的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/labels/{label_id} [delete]
+func (ctl *TodoListDetailsController) DetachLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 Label ID")
+		return
+	}
+
 	repo := repositories.NewTodoListDetailsRepository()
 	service := services.NewTodoListDetailsService(c.Request.Context(), repo)
+	result, err := service.DetachLabel(config.DB, id, labelID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Move TodoListDetails
+// @Summary 調整 TodoListDetails 順序
+// @Description 移到 after_id 之後、before_id 之前，可同時移到另一個 TodoList（to_do_list_id），只會更新被移動的項目
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoListDetailsMoveRequest true "移動位置"
+// @Success 200 {object} models.TodoListDetails "成功回傳移動後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/move [put]
+func (ctl *TodoListDetailsController) Move(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
 
-	result, err := service.Delete(config.DB, id)
+	var input dto.TodoListDetailsMoveRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
 
+	// 移到其他 TodoList 時需要檢查目標看板的 WIP 上限
+	service := newDetailsService(c).
+		WithBoard(services.NewTodoBoardService(c.Request.Context(), repositories.NewTodoBoardRepository()))
+	result, err := service.Move(config.DB, id, input.TodoListID, input.AfterID, input.BeforeID)
 	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// Rebalance TodoListDetails
+// @Summary 重新分配 TodoList 底下項目的位置鍵
+// @Description 位置鍵過長時重新平均分配，順序不變
+// @Tags TodoListDetails
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/details/rebalance [post]
+func (ctl *TodoListDetailsController) Rebalance(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	repo := repositories.NewTodoListDetailsRepository()
+	service := services.NewTodoListDetailsService(c.Request.Context(), repo)
+	if err := service.Rebalance(config.DB, listID); err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	response.Success(c, nil)
+}
 
+// MoveCard TodoListDetails
+// @Summary 在看板上移動卡片
+// @Description 移到 status 對應的欄位並排在 after_id 之後、before_id 之前；狀態轉換規則、WIP 上限與前置任務在同一個交易內檢查
+// @Tags TodoBoard
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoBoardMoveRequest true "目標欄位與位置"
+// @Success 200 {object} models.TodoListDetails "成功回傳移動後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/board/move [put]
+func (ctl *TodoListDetailsController) MoveCard(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoBoardMoveRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	result, err := newStatusAwareDetailsService(c).MoveCard(config.DB, id, input.Status, input.AfterID, input.BeforeID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	response.Success(c, result)
 }
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoMilestoneController struct{}
+
+func newTodoMilestoneService(c *gin.Context) *services.TodoMilestoneService {
+	return services.NewTodoMilestoneService(c.Request.Context(), repositories.NewTodoMilestoneRepository())
+}
+
+// milestoneErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func milestoneErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrMilestoneClosed):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
+
+// Create TodoMilestone
+// @Summary 新增里程碑
+// @Description 建立里程碑（sprint），日期為伺服器時區的當地日期
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoMilestoneRequest true "里程碑"
+// @Success 200 {object} models.TodoMilestones "成功回傳里程碑"
+// @Security BearerAuth
+// @Router /api/todo/milestone [post]
+func (ctl *TodoMilestoneController) Create(c *gin.Context) {
+	var input dto.TodoMilestoneRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoMilestoneService(c).Create(config.DB, input.Name, input.StartDate, input.EndDate)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoMilestone
+// @Summary 取得里程碑列表
+// @Description 依開始日期由新到舊分頁列出，可依是否已關閉篩選
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoMilestoneQuery false "分頁與篩選條件"
+// @Security BearerAuth
+// @Router /api/todo/milestone [get]
+func (ctl *TodoMilestoneController) Index(c *gin.Context) {
+	var query dto.TodoMilestoneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoMilestoneService(c).Index(config.DB, query.Closed, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Show TodoMilestone
+// @Summary 取得單一里程碑
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "里程碑 ID"
+// @Success 200 {object} models.TodoMilestones "成功回傳里程碑"
+// @Security BearerAuth
+// @Router /api/todo/milestone/{id} [get]
+func (ctl *TodoMilestoneController) Show(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoMilestoneService(c).Show(config.DB, id)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Edit TodoMilestone
+// @Summary 修改里程碑
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "里程碑 ID"
+// @Param input body dto.TodoMilestoneRequest true "里程碑"
+// @Success 200 {object} models.TodoMilestones "成功回傳里程碑"
+// @Security BearerAuth
+// @Router /api/todo/milestone/{id} [put]
+func (ctl *TodoMilestoneController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoMilestoneRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoMilestoneService(c).Edit(config.DB, id, input.Name, input.StartDate, input.EndDate)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoMilestone
+// @Summary 刪除里程碑
+// @Description 軟刪除里程碑，TodoList 與 TodoListDetails 不再屬於該里程碑
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "里程碑 ID"
+// @Success 200 {object} models.TodoMilestones "成功回傳被刪除的里程碑"
+// @Security BearerAuth
+// @Router /api/todo/milestone/{id} [delete]
+func (ctl *TodoMilestoneController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoMilestoneService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Chart TodoMilestone
+// @Summary 取得里程碑的燃盡與累積流量
+// @Description 依狀態變更紀錄重建里程碑期間每天結束時的剩餘量（項目數或預估分鐘數）與各狀態的項目數，
+// @Description 只算到今天；關閉時移到下一個里程碑的項目仍列在原里程碑中
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "里程碑 ID"
+// @Param query query dto.TodoMilestoneChartQuery false "計算方式"
+// @Success 200 {object} models.MilestoneChart "成功回傳圖表資料"
+// @Security BearerAuth
+// @Router /api/todo/milestone/{id}/chart [get]
+func (ctl *TodoMilestoneController) Chart(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoMilestoneChartQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, services.ErrInvalidMilestoneMetric.Error())
+		return
+	}
+
+	result, err := newTodoMilestoneService(c).Chart(config.DB, id, query.Metric)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Close TodoMilestone
+// @Summary 關閉里程碑
+// @Description 關閉後不能再加入項目；有給 next_milestone_id 時，尚未完成的項目會一次移到該里程碑
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "里程碑 ID"
+// @Param input body dto.TodoMilestoneCloseRequest false "下一個里程碑"
+// @Success 200 {object} models.MilestoneCloseResult "成功回傳關閉結果"
+// @Security BearerAuth
+// @Router /api/todo/milestone/{id}/close [post]
+func (ctl *TodoMilestoneController) Close(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoMilestoneCloseRequest
+	if c.Request.ContentLength > 0 && !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoMilestoneService(c).Close(config.DB, id, input.NextMilestoneID)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AssignList TodoMilestone
+// @Summary 設定 TodoList 的里程碑
+// @Description 底下沒有另外指定里程碑的項目沿用；不給或給 null 表示移除
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param input body dto.TodoMilestoneAssignRequest true "里程碑"
+// @Success 200 {object} models.TodoList "成功回傳更新後的 TodoList"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/milestone [put]
+func (ctl *TodoMilestoneController) AssignList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoMilestoneAssignRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoMilestoneService(c).AssignList(config.DB, id, input.MilestoneID)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AssignDetail TodoMilestone
+// @Summary 設定 TodoListDetails 的里程碑
+// @Description 不給或給 null 表示改為沿用所屬 TodoList 的里程碑
+// @Tags TodoMilestone
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoMilestoneAssignRequest true "里程碑"
+// @Success 200 {object} models.TodoListDetails "成功回傳更新後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/milestone [put]
+func (ctl *TodoMilestoneController) AssignDetail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoMilestoneAssignRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoMilestoneService(c).AssignDetail(config.DB, id, input.MilestoneID)
+	if err != nil {
+		response.Error(c, milestoneErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
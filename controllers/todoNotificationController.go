@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoNotificationController struct{}
+
+func newTodoNotificationService(c *gin.Context) *services.TodoNotificationService {
+	return services.NewTodoNotificationService(c.Request.Context(), repositories.NewTodoNotificationRepository(), newTodoWatcherService(c))
+}
+
+// notificationErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func notificationErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrUnknownNotificationType):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+// Index TodoNotification
+// @Summary 取得目前使用者的通知
+// @Description 新的在前；type 為 assigned、unassigned、mentioned、due_soon、overdue、comment、role_changed 或 escalated，read_at 為 null 表示未讀
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoNotificationQuery false "篩選條件"
+// @Success 200 {array} models.TodoNotifications "成功回傳通知"
+// @Security BearerAuth
+// @Router /api/notifications [get]
+func (ctl *TodoNotificationController) Index(c *gin.Context) {
+	var query dto.TodoNotificationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoNotificationService(c).Index(config.DB, query.Unread, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// UnreadCount TodoNotification
+// @Summary 取得未讀通知數
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.TodoNotificationCountResponse "成功回傳未讀數"
+// @Security BearerAuth
+// @Router /api/notifications/unread-count [get]
+func (ctl *TodoNotificationController) UnreadCount(c *gin.Context) {
+	count, err := newTodoNotificationService(c).UnreadCount(config.DB)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, dto.TodoNotificationCountResponse{Unread: count})
+}
+
+// MarkRead TodoNotification
+// @Summary 將通知標為已讀
+// @Description 已讀或不屬於目前使用者的通知略過，updated 為實際標記的筆數
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoNotificationReadRequest true "通知 ID"
+// @Success 200 {object} dto.TodoNotificationReadResponse "成功回傳標記筆數與剩餘未讀數"
+// @Security BearerAuth
+// @Router /api/notifications/read [put]
+func (ctl *TodoNotificationController) MarkRead(c *gin.Context) {
+	var input dto.TodoNotificationReadRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	ctl.markRead(c, input.IDs)
+}
+
+// MarkAllRead TodoNotification
+// @Summary 將所有通知標為已讀
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.TodoNotificationReadResponse "成功回傳標記筆數與剩餘未讀數"
+// @Security BearerAuth
+// @Router /api/notifications/read-all [put]
+func (ctl *TodoNotificationController) MarkAllRead(c *gin.Context) {
+	ctl.markRead(c, nil)
+}
+
+func (ctl *TodoNotificationController) markRead(c *gin.Context, ids []int) {
+	service := newTodoNotificationService(c)
+	updated, err := service.MarkRead(config.DB, ids)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	unread, err := service.UnreadCount(config.DB)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, dto.TodoNotificationReadResponse{Updated: updated, Unread: unread})
+}
+
+// Preferences TodoNotification
+// @Summary 取得通知設定
+// @Description 每一類通知是否接收，預設全部接收
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]bool "成功回傳通知設定"
+// @Security BearerAuth
+// @Router /api/notifications/preferences [get]
+func (ctl *TodoNotificationController) Preferences(c *gin.Context) {
+	result, err := newTodoNotificationService(c).Preferences(config.DB)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetPreferences TodoNotification
+// @Summary 修改通知設定
+// @Description 只更新有指定的類型，關閉後不再產生該類通知，已產生的不受影響
+// @Tags TodoNotification
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoNotificationPreferencesRequest true "通知設定"
+// @Success 200 {object} map[string]bool "成功回傳更新後的通知設定"
+// @Security BearerAuth
+// @Router /api/notifications/preferences [put]
+func (ctl *TodoNotificationController) SetPreferences(c *gin.Context) {
+	var input dto.TodoNotificationPreferencesRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoNotificationService(c).SetPreferences(config.DB, input.Preferences)
+	if err != nil {
+		response.Error(c, notificationErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
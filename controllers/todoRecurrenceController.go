@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoRecurrenceController struct{}
+
+func newTodoRecurrenceService(c *gin.Context) *services.TodoRecurrenceService {
+	ctx := c.Request.Context()
+	return services.NewTodoRecurrenceService(ctx, repositories.NewTodoRecurrenceRepository()).
+		WithChecklist(services.NewTodoChecklistService(ctx, repositories.NewTodoChecklistRepository()))
+}
+
+// Create TodoRecurrence
+// @Summary 新增週期性任務
+// @Description 以 RRULE（FREQ=DAILY/WEEKLY/MONTHLY、INTERVAL、BYDAY、BYMONTHDAY、UNTIL、COUNT）建立週期規則，dtstart 為 timezone 時區的當地時間，建立後立即產生第一次任務
+// @Tags TodoRecurrence
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param input body dto.TodoRecurrenceRequest true "週期規則"
+// @Success 200 {object} models.TodoRecurrences "成功回傳週期規則"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/recurrences [post]
+func (ctl *TodoRecurrenceController) Create(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoRecurrenceRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoRecurrenceService(c).Create(config.DB, listID, input.Name, input.Detail, input.RRule, input.Timezone, input.DtStart)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoRecurrence
+// @Summary 取得 TodoList 的週期性任務
+// @Tags TodoRecurrence
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Success 200 {array} models.TodoRecurrences "成功回傳週期規則"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/recurrences [get]
+func (ctl *TodoRecurrenceController) Index(c *gin.Context) {
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoRecurrenceService(c).Index(config.DB, listID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Show TodoRecurrence
+// @Summary 取得單一週期性任務
+// @Tags TodoRecurrence
+// @Accept json
+// @Produce json
+// @Param recurrence_id path int true "週期規則 ID"
+// @Success 200 {object} models.TodoRecurrences "成功回傳週期規則"
+// @Security BearerAuth
+// @Router /api/todo/list/recurrences/{recurrence_id} [get]
+func (ctl *TodoRecurrenceController) Show(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoRecurrenceService(c).Show(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Edit TodoRecurrence
+// @Summary 修改週期性任務
+// @Description 已產生的任務不受影響，下一次發生時間從最後一次產生之後重新計算
+// @Tags TodoRecurrence
+// @Accept json
+// @Produce json
+// @Param recurrence_id path int true "週期規則 ID"
+// @Param input body dto.TodoRecurrenceRequest true "週期規則"
+// @Success 200 {object} models.TodoRecurrences "成功回傳週期規則"
+// @Security BearerAuth
+// @Router /api/todo/list/recurrences/{recurrence_id} [put]
+func (ctl *TodoRecurrenceController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoRecurrenceRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoRecurrenceService(c).Edit(config.DB, id, input.Name, input.Detail, input.RRule, input.Timezone, input.DtStart)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoRecurrence
+// @Summary 刪除週期性任務
+// @Description 停止產生新任務，已產生的任務保留
+// @Tags TodoRecurrence
+// @Accept json
+// @Produce json
+// @Param recurrence_id path int true "週期規則 ID"
+// @Success 200 {object} models.TodoRecurrences "成功回傳被刪除的週期規則"
+// @Security BearerAuth
+// @Router /api/todo/list/recurrences/{recurrence_id} [delete]
+func (ctl *TodoRecurrenceController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoRecurrenceService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoReminderController struct{}
+
+func newTodoReminderService(c *gin.Context) *services.TodoReminderService {
+	return services.NewTodoReminderService(c.Request.Context(), repositories.NewTodoReminderRepository(), newTodoNotificationService(c))
+}
+
+// reminderErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func reminderErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return fallback
+	}
+}
+
+// Upcoming TodoReminder
+// @Summary 取得即將送出的提醒
+// @Description 依提醒時間排序，重複提醒的只列出下一次；rule 為 before_<時間>（到期前）、at_due（到期時）、overdue（逾期後重複）或 escalation（通知清單建立者或 Admin），user_ids 為會收到提醒的使用者
+// @Tags TodoReminder
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoReminderUpcomingQuery false "時間範圍"
+// @Success 200 {array} models.ReminderUpcoming "成功回傳即將送出的提醒"
+// @Security BearerAuth
+// @Router /api/reminders/upcoming [get]
+func (ctl *TodoReminderController) Upcoming(c *gin.Context) {
+	var query dto.TodoReminderUpcomingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Hours == 0 {
+		query.Hours = 24
+	}
+
+	upcoming, err := newTodoReminderService(c).Upcoming(config.DB, time.Duration(query.Hours)*time.Hour)
+	if err != nil {
+		response.Error(c, reminderErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, upcoming)
+}
+
+// Runs TodoReminder
+// @Summary 取得提醒排程的執行紀錄
+// @Description 新的在前，保留 7 天；status 為 running、succeeded 或 failed，sent 為該次送出的提醒數，instance 為執行的 instance
+// @Tags TodoReminder
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoReminderRunsQuery false "分頁"
+// @Success 200 {array} models.TodoReminderRuns "成功回傳執行紀錄"
+// @Security BearerAuth
+// @Router /api/reminders/runs [get]
+func (ctl *TodoReminderController) Runs(c *gin.Context) {
+	var query dto.TodoReminderRunsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoReminderService(c).Runs(config.DB, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, reminderErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Run TodoReminder
+// @Summary 取得一次執行與該次送出的提醒
+// @Description logs 為該次送出的提醒，occurrence 為重複提醒的第幾次；使用者關閉了該類通知時仍會記錄，但不會收到
+// @Tags TodoReminder
+// @Accept json
+// @Produce json
+// @Param id path int true "執行紀錄 ID"
+// @Success 200 {object} models.TodoReminderRuns "成功回傳執行紀錄"
+// @Security BearerAuth
+// @Router /api/reminders/runs/{id} [get]
+func (ctl *TodoReminderController) Run(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	run, err := newTodoReminderService(c).Run(config.DB, id)
+	if err != nil {
+		response.Error(c, reminderErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, run)
+}
@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TodoTimeEntryController struct{}
+
+func newTodoTimeEntryService(c *gin.Context) *services.TodoTimeEntryService {
+	return services.NewTodoTimeEntryService(
+		c.Request.Context(),
+		repositories.NewTodoTimeEntryRepository(),
+		repositories.NewAuthRepository(),
+	)
+}
+
+// timeEntryErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func timeEntryErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrTimerRunning):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrNoRunningTimer):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrTimeEntryForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return fallback
+	}
+}
+
+// Start TodoTimeEntry
+// @Summary 開始計時
+// @Description 為目前使用者在 TodoListDetails 上開始計時，每人同時只能有一個計時中的計時器
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoTimerStartRequest false "備註"
+// @Success 200 {object} models.TodoTimeEntries "成功回傳計時中的紀錄"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/timer/start [post]
+func (ctl *TodoTimeEntryController) Start(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoTimerStartRequest
+	if c.Request.ContentLength > 0 && !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoTimeEntryService(c).Start(config.DB, detailID, input.Note)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Stop TodoTimeEntry
+// @Summary 停止計時
+// @Description 停止目前使用者計時中的計時器並計算工時
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TodoTimeEntries "成功回傳已結束的紀錄"
+// @Security BearerAuth
+// @Router /api/todo/timer/stop [post]
+func (ctl *TodoTimeEntryController) Stop(c *gin.Context) {
+	result, err := newTodoTimeEntryService(c).Stop(config.DB)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Current TodoTimeEntry
+// @Summary 取得計時中的計時器
+// @Description 沒有計時中的計時器時 data 為 null
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TodoTimeEntries "成功回傳計時中的紀錄"
+// @Security BearerAuth
+// @Router /api/todo/timer [get]
+func (ctl *TodoTimeEntryController) Current(c *gin.Context) {
+	result, err := newTodoTimeEntryService(c).Current(config.DB)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Create TodoTimeEntry
+// @Summary 手動新增工時
+// @Description 為目前使用者新增已完成的工時，時間為伺服器時區的當地時間
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param input body dto.TodoTimeEntryCreateRequest true "工時"
+// @Success 200 {object} models.TodoTimeEntries "成功回傳工時"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/time-entries [post]
+func (ctl *TodoTimeEntryController) Create(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoTimeEntryCreateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoTimeEntryService(c).Create(config.DB, detailID, input.StartedAt, input.EndedAt, input.Note)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoTimeEntry
+// @Summary 取得 TodoListDetails 的工時
+// @Description 依開始時間由新到舊分頁列出，包含計時中的紀錄
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param page query int false "頁碼（預設 1）"
+// @Param page_size query int false "每頁筆數（預設 20）"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/time-entries [get]
+func (ctl *TodoTimeEntryController) Index(c *gin.Context) {
+	detailID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoTimeEntryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoTimeEntryService(c).IndexByDetail(config.DB, detailID, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Edit TodoTimeEntry
+// @Summary 修改工時
+// @Description 只有紀錄本人或 Admin 可以修改；計時中的紀錄可以不給 ended_at，給了則一併停止
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param entry_id path int true "工時 ID"
+// @Param input body dto.TodoTimeEntryUpdateRequest true "工時"
+// @Success 200 {object} models.TodoTimeEntries "成功回傳工時"
+// @Security BearerAuth
+// @Router /api/todo/list/details/time-entries/{entry_id} [put]
+func (ctl *TodoTimeEntryController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("entry_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoTimeEntryUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoTimeEntryService(c).Edit(config.DB, id, input.StartedAt, input.EndedAt, input.Note)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoTimeEntry
+// @Summary 刪除工時
+// @Description 只有紀錄本人或 Admin 可以刪除，刪除計時中的紀錄等同取消計時
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param entry_id path int true "工時 ID"
+// @Success 200 {object} models.TodoTimeEntries "成功回傳被刪除的工時"
+// @Security BearerAuth
+// @Router /api/todo/list/details/time-entries/{entry_id} [delete]
+func (ctl *TodoTimeEntryController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("entry_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoTimeEntryService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, timeEntryErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Summary TodoTimeEntry
+// @Summary 工時彙總
+// @Description 依使用者（user）、日期（day）、TodoListDetails（detail）、TodoList（list）或類型（type）彙總已結束的工時，
+// @Description 依 detail / list / type 分組時附上預估工時的合計以便與實際工時比較
+// @Tags TodoTimeEntry
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoTimeSummaryQuery true "分組與篩選條件"
+// @Success 200 {array} models.TimeSummary "成功回傳彙總"
+// @Security BearerAuth
+// @Router /api/todo/time-entries/summary [get]
+func (ctl *TodoTimeEntryController) Summary(c *gin.Context) {
+	var query dto.TodoTimeSummaryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, services.ErrInvalidTimeGrouping.Error())
+		return
+	}
+
+	result, err := newTodoTimeEntryService(c).Summary(config.DB, query.GroupBy, query.UserID, query.ListID, query.TypeID, query.From, query.To)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
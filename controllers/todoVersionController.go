@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoVersionController struct{}
+
+func newTodoVersionService(c *gin.Context) *services.TodoVersionService {
+	return services.NewTodoVersionService(c.Request.Context(), repositories.NewTodoVersionRepository())
+}
+
+// versionErrorStatus 找不到資料或版本時回 404，其餘依呼叫端指定
+func versionErrorStatus(err error, fallback int) int {
+	if errors.Is(err, services.ErrVersionNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return fallback
+}
+
+// ListVersions TodoVersion
+// @Summary 取得 TodoList 的版本
+// @Description 由新到舊列出每個版本的快照，每次修改都會產生新的版本
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param page query int false "頁碼（預設 1）"
+// @Param page_size query int false "每頁筆數（預設 20）"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/versions [get]
+func (ctl *TodoVersionController) ListVersions(c *gin.Context) {
+	ctl.index(c, models.VersionEntityList)
+}
+
+// ListDiff TodoVersion
+// @Summary 比較 TodoList 的兩個版本
+// @Description 逐欄列出兩個版本不同的欄位
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param query query dto.TodoVersionDiffQuery true "比較的版本"
+// @Success 200 {array} models.VersionChange "成功回傳差異"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/versions/diff [get]
+func (ctl *TodoVersionController) ListDiff(c *gin.Context) {
+	ctl.diff(c, models.VersionEntityList)
+}
+
+// ListRevert TodoVersion
+// @Summary 還原 TodoList 到指定版本
+// @Description 以該版本的內容重新修改，檢查與一般修改相同，並產生新的版本
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Param version path int true "版本"
+// @Success 200 {object} models.TodoList "成功回傳還原後的 TodoList"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/versions/{version}/revert [post]
+func (ctl *TodoVersionController) ListRevert(c *gin.Context) {
+	id, version, ok := versionParams(c)
+	if !ok {
+		return
+	}
+
+	service := services.NewTodoListService(c.Request.Context(), repositories.NewTodoListRepository()).
+		WithVersions(newTodoVersionService(c)).
+		WithEvents(newTodoOutboxService(c))
+	result, err := service.Revert(config.DB, id, version)
+	if err != nil {
+		response.Error(c, versionErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DetailVersions TodoVersion
+// @Summary 取得 TodoListDetails 的版本
+// @Description 由新到舊列出每個版本的快照，每次修改都會產生新的版本
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param page query int false "頁碼（預設 1）"
+// @Param page_size query int false "每頁筆數（預設 20）"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/versions [get]
+func (ctl *TodoVersionController) DetailVersions(c *gin.Context) {
+	ctl.index(c, models.VersionEntityDetail)
+}
+
+// DetailDiff TodoVersion
+// @Summary 比較 TodoListDetails 的兩個版本
+// @Description 逐欄列出兩個版本不同的欄位
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param query query dto.TodoVersionDiffQuery true "比較的版本"
+// @Success 200 {array} models.VersionChange "成功回傳差異"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/versions/diff [get]
+func (ctl *TodoVersionController) DetailDiff(c *gin.Context) {
+	ctl.diff(c, models.VersionEntityDetail)
+}
+
+// DetailRevert TodoVersion
+// @Summary 還原 TodoListDetails 到指定版本
+// @Description 還原名稱、說明與狀態，狀態的檢查與一般變更狀態相同，並產生新的版本
+// @Tags TodoVersion
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Param version path int true "版本"
+// @Success 200 {object} models.TodoListDetails "成功回傳還原後的 TodoListDetails"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/versions/{version}/revert [post]
+func (ctl *TodoVersionController) DetailRevert(c *gin.Context) {
+	id, version, ok := versionParams(c)
+	if !ok {
+		return
+	}
+
+	// 還原會同時改到說明與狀態，需要兩者的後續處理
+	service := newStatusAwareDetailsService(c).
+		WithChecklist(services.NewTodoChecklistService(c.Request.Context(), repositories.NewTodoChecklistRepository())).
+		WithVersions(newTodoVersionService(c))
+	result, err := service.Revert(config.DB, id, version)
+	if err != nil {
+		response.Error(c, versionErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+func (ctl *TodoVersionController) index(c *gin.Context, entityType string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoVersionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoVersionService(c).Index(config.DB, entityType, id, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+func (ctl *TodoVersionController) diff(c *gin.Context, entityType string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoVersionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "請指定要比較的版本 from 與 to")
+		return
+	}
+
+	result, err := newTodoVersionService(c).Diff(config.DB, entityType, id, query.From, query.To)
+	if err != nil {
+		response.Error(c, versionErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+func versionParams(c *gin.Context) (id int, version int, ok bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return 0, 0, false
+	}
+	version, err = strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		response.Error(c, http.StatusBadRequest, "無效的版本")
+		return 0, 0, false
+	}
+	return id, version, true
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/models"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoWatcherController struct{}
+
+func newTodoWatcherService(c *gin.Context) *services.TodoWatcherService {
+	return services.NewTodoWatcherService(c.Request.Context(), repositories.NewTodoWatcherRepository())
+}
+
+// watcherErrorStatus 依錯誤類型決定狀態碼
+func watcherErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondWatchers 以路徑中的 id 呼叫 action 並回傳關注者
+func respondWatchers(c *gin.Context, action func(s *services.TodoWatcherService, db *gorm.DB, id int) ([]models.User, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := action(newTodoWatcherService(c), config.DB, id)
+	if err != nil {
+		response.Error(c, watcherErrorStatus(err), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// WatchList TodoWatcher
+// @Summary 關注 TodoList
+// @Description 目前的使用者關注 TodoList，會收到底下所有項目的更新；已經關注時不變
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/watch [post]
+func (ctl *TodoWatcherController) WatchList(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).WatchList)
+}
+
+// UnwatchList TodoWatcher
+// @Summary 取消關注 TodoList
+// @Description 底下個別關注的項目不受影響
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/watch [delete]
+func (ctl *TodoWatcherController) UnwatchList(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).UnwatchList)
+}
+
+// ListWatchers TodoWatcher
+// @Summary 取得 TodoList 的關注者
+// @Description 依關注時間排序；TodoList 的建立者會自動關注
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoList ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/{id}/watchers [get]
+func (ctl *TodoWatcherController) ListWatchers(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).ListWatchers)
+}
+
+// WatchDetail TodoWatcher
+// @Summary 關注 TodoListDetails
+// @Description 目前的使用者關注項目，不需要是負責人也會收到新留言等更新；已經關注時不變
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/watch [post]
+func (ctl *TodoWatcherController) WatchDetail(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).WatchDetail)
+}
+
+// UnwatchDetail TodoWatcher
+// @Summary 取消關注 TodoListDetails
+// @Description 之後再被指派或在項目留言時會重新關注；仍關注所屬 TodoList 時會繼續收到更新
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/watch [delete]
+func (ctl *TodoWatcherController) UnwatchDetail(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).UnwatchDetail)
+}
+
+// DetailWatchers TodoWatcher
+// @Summary 取得 TodoListDetails 的關注者
+// @Description 依關注時間排序，不含只關注所屬 TodoList 的使用者；建立者、負責人與留言者會自動關注
+// @Tags TodoWatcher
+// @Accept json
+// @Produce json
+// @Param id path int true "TodoListDetails ID"
+// @Success 200 {array} models.User "成功回傳關注者"
+// @Security BearerAuth
+// @Router /api/todo/list/details/{id}/watchers [get]
+func (ctl *TodoWatcherController) DetailWatchers(c *gin.Context) {
+	respondWatchers(c, (*services.TodoWatcherService).DetailWatchers)
+}
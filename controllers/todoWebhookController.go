@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todolist/config"
+	"todolist/dto"
+	"todolist/repositories"
+	"todolist/response"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TodoWebhookController struct{}
+
+func newTodoWebhookService(c *gin.Context) *services.TodoWebhookService {
+	return services.NewTodoWebhookService(c.Request.Context(), repositories.NewTodoWebhookRepository())
+}
+
+// webhookErrorStatus 依錯誤類型決定狀態碼，其餘依呼叫端指定
+func webhookErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidWebhookURL):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+// Create TodoWebhook
+// @Summary 新增 webhook
+// @Description 訂閱事件，事件發生的交易 commit 後以 POST 送出 JSON；X-Todolist-Signature 為 t=<unix 秒>,v1=<HMAC-SHA256(signing_secret, "<t>.<body>") 的 hex>。signing_secret 只在建立時回傳一次
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param input body dto.TodoWebhookRequest true "webhook"
+// @Success 200 {object} models.TodoWebhooks "成功回傳 webhook 與 signing_secret"
+// @Security BearerAuth
+// @Router /api/webhooks [post]
+func (ctl *TodoWebhookController) Create(c *gin.Context) {
+	var input dto.TodoWebhookRequest
+	if !utils.BindAndValidate(c, &input) {
+		return // 綁定或驗證失敗，已經回傳錯誤了，直接結束
+	}
+
+	result, err := newTodoWebhookService(c).Create(config.DB, input.TodoListID, input.URL, input.Events, input.Description)
+	if err != nil {
+		response.Error(c, webhookErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Index TodoWebhook
+// @Summary 取得 webhook 列表
+// @Description 指定 to_do_list_id 時只回傳該 TodoList 的 webhook
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param query query dto.TodoWebhookQuery false "篩選條件"
+// @Success 200 {array} models.TodoWebhooks "成功回傳 webhook"
+// @Security BearerAuth
+// @Router /api/webhooks [get]
+func (ctl *TodoWebhookController) Index(c *gin.Context) {
+	var query dto.TodoWebhookQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	result, err := newTodoWebhookService(c).Index(config.DB, query.TodoListID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Edit TodoWebhook
+// @Summary 修改 webhook
+// @Description 修改網址、事件、說明與是否啟用；停用期間發生的事件不會補送，尚未送出的紀錄會標記為 dead
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param id path int true "webhook ID"
+// @Param input body dto.TodoWebhookUpdateRequest true "webhook"
+// @Success 200 {object} models.TodoWebhooks "成功回傳 webhook"
+// @Security BearerAuth
+// @Router /api/webhooks/{id} [put]
+func (ctl *TodoWebhookController) Edit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var input dto.TodoWebhookUpdateRequest
+	if !utils.BindAndValidate(c, &input) {
+		return
+	}
+
+	result, err := newTodoWebhookService(c).Edit(config.DB, id, input.URL, input.Events, input.Description, *input.Active)
+	if err != nil {
+		response.Error(c, webhookErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Delete TodoWebhook
+// @Summary 刪除 webhook
+// @Description 刪除後尚未送出的紀錄不再送出
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param id path int true "webhook ID"
+// @Success 200 {object} models.TodoWebhooks "成功回傳被刪除的 webhook"
+// @Security BearerAuth
+// @Router /api/webhooks/{id} [delete]
+func (ctl *TodoWebhookController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoWebhookService(c).Delete(config.DB, id)
+	if err != nil {
+		response.Error(c, webhookErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Deliveries TodoWebhook
+// @Summary 取得 webhook 的送出紀錄
+// @Description 新的在前；status 為 pending、delivering、succeeded 或 dead（重試次數用完）
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param id path int true "webhook ID"
+// @Param query query dto.TodoWebhookDeliveriesQuery false "分頁"
+// @Success 200 {array} models.TodoWebhookDeliveries "成功回傳送出紀錄"
+// @Security BearerAuth
+// @Router /api/webhooks/{id}/deliveries [get]
+func (ctl *TodoWebhookController) Deliveries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	var query dto.TodoWebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的查詢參數")
+		return
+	}
+
+	// 補預設值
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 20
+	}
+
+	result, err := newTodoWebhookService(c).Deliveries(config.DB, id, query.Page, query.PageSize)
+	if err != nil {
+		response.Error(c, webhookErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.SuccessWithPagination(c, result)
+}
+
+// Redeliver TodoWebhook
+// @Summary 重送
+// @Description 以相同的內容與事件 ID 建立新的送出紀錄，由背景工作送出；原本的紀錄保留
+// @Tags TodoWebhook
+// @Accept json
+// @Produce json
+// @Param id path int true "送出紀錄 ID"
+// @Success 200 {object} models.TodoWebhookDeliveries "成功回傳新的送出紀錄"
+// @Security BearerAuth
+// @Router /api/webhook-deliveries/{id}/redeliver [post]
+func (ctl *TodoWebhookController) Redeliver(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "無效的 ID")
+		return
+	}
+
+	result, err := newTodoWebhookService(c).Redeliver(config.DB, id)
+	if err != nil {
+		response.Error(c, webhookErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	response.Success(c, result)
+}
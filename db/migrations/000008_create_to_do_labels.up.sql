@@ -0,0 +1,11 @@
+CREATE TABLE to_do_labels (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_list_labels;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE to_do_list_labels (
+    to_do_list_id INT NOT NULL,
+    label_id INT NOT NULL,
+    PRIMARY KEY (to_do_list_id, label_id),
+    FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES to_do_labels(id) ON DELETE CASCADE
+);
\ No newline at end of file
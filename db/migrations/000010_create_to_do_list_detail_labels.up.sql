@@ -0,0 +1,7 @@
+CREATE TABLE to_do_list_detail_labels (
+    to_do_list_detail_id INT NOT NULL,
+    label_id INT NOT NULL,
+    PRIMARY KEY (to_do_list_detail_id, label_id),
+    FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES to_do_labels(id) ON DELETE CASCADE
+);
\ No newline at end of file
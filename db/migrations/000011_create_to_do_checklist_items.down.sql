@@ -0,0 +1 @@
+DROP TABLE to_do_checklist_items;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE to_do_checklist_items (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_detail_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_done TINYINT(1) NOT NULL DEFAULT 0,
+    sort_order INT NOT NULL DEFAULT 0,
+    done_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_checklist_items_detail (to_do_list_detail_id, sort_order),
+    CONSTRAINT fk_checklist_items_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE
+);
\ No newline at end of file
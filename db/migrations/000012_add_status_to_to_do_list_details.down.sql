@@ -0,0 +1 @@
+ALTER TABLE to_do_list_details DROP COLUMN completed_at, DROP COLUMN status;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE to_do_list_details
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo' AFTER detail,
+    ADD COLUMN completed_at DATETIME DEFAULT NULL AFTER status;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_detail_dependencies;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE to_do_detail_dependencies (
+    blocker_id INT NOT NULL,
+    blocked_id INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    INDEX idx_detail_dependencies_blocked (blocked_id),
+    FOREIGN KEY (blocker_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_recurrences;
\ No newline at end of file
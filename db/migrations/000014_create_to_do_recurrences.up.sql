@@ -0,0 +1,20 @@
+CREATE TABLE to_do_recurrences (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    detail VARCHAR(255) NOT NULL DEFAULT '',
+    rrule VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    dtstart DATETIME NOT NULL,
+    next_at DATETIME DEFAULT NULL,
+    last_occurrence_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_recurrences_next_at (next_at),
+    CONSTRAINT fk_recurrences_list FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE to_do_list_details
+    DROP FOREIGN KEY fk_list_details_recurrence,
+    DROP INDEX uk_list_details_occurrence,
+    DROP COLUMN occurrence_at,
+    DROP COLUMN recurrence_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE to_do_list_details
+    ADD COLUMN recurrence_id INT DEFAULT NULL AFTER completed_at,
+    ADD COLUMN occurrence_at DATETIME DEFAULT NULL AFTER recurrence_id,
+    ADD UNIQUE KEY uk_list_details_occurrence (recurrence_id, occurrence_at),
+    ADD CONSTRAINT fk_list_details_recurrence FOREIGN KEY (recurrence_id) REFERENCES to_do_recurrences(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE to_do_list_details
+    DROP INDEX uk_list_details_position,
+    DROP COLUMN position;
+
+ALTER TABLE to_do_list
+    DROP INDEX uk_list_position,
+    DROP COLUMN position;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE to_do_list
+    ADD COLUMN position VARCHAR(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL AFTER name,
+    ADD UNIQUE KEY uk_list_position (position);
+
+ALTER TABLE to_do_list_details
+    ADD COLUMN position VARCHAR(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL AFTER status,
+    ADD UNIQUE KEY uk_list_details_position (to_do_list_id, position);
\ No newline at end of file
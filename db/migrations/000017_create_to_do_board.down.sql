@@ -0,0 +1,2 @@
+DROP TABLE to_do_board_transitions;
+DROP TABLE to_do_board_columns;
\ No newline at end of file
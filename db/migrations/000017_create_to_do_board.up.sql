@@ -0,0 +1,26 @@
+CREATE TABLE to_do_board_columns (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    wip_limit INT DEFAULT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_board_columns_list (to_do_list_id, sort_order),
+    CONSTRAINT fk_board_columns_list FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_board_transitions (
+    to_do_list_id INT NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (to_do_list_id, from_status, to_status),
+    CONSTRAINT fk_board_transitions_list FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE to_do_comment_mentions;
+DROP TABLE to_do_comment_revisions;
+DROP TABLE to_do_comments;
\ No newline at end of file
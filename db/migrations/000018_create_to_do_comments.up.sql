@@ -0,0 +1,38 @@
+CREATE TABLE to_do_comments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_detail_id INT NOT NULL,
+    parent_id INT DEFAULT NULL,
+    body TEXT NOT NULL,
+    edited_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_comments_detail (to_do_list_detail_id, parent_id, created_at),
+    INDEX idx_comments_parent (parent_id),
+    CONSTRAINT fk_comments_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_parent FOREIGN KEY (parent_id) REFERENCES to_do_comments(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_comment_revisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    comment_id INT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INT NULL,
+
+    INDEX idx_comment_revisions_comment (comment_id, id),
+    CONSTRAINT fk_comment_revisions_comment FOREIGN KEY (comment_id) REFERENCES to_do_comments(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_comment_mentions (
+    comment_id INT NOT NULL,
+    user_id INT NOT NULL,
+    PRIMARY KEY (comment_id, user_id),
+    INDEX idx_comment_mentions_user (user_id),
+    CONSTRAINT fk_comment_mentions_comment FOREIGN KEY (comment_id) REFERENCES to_do_comments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_comment_mentions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE to_do_attachments;
+DROP TABLE to_do_blobs;
\ No newline at end of file
@@ -0,0 +1,34 @@
+CREATE TABLE to_do_blobs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    sha256 CHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_blobs_sha256 (sha256)
+);
+
+CREATE TABLE to_do_attachments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    blob_id INT NOT NULL,
+    to_do_list_detail_id INT DEFAULT NULL,
+    comment_id INT DEFAULT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_attachments_detail (to_do_list_detail_id),
+    INDEX idx_attachments_comment (comment_id),
+    INDEX idx_attachments_blob (blob_id, deleted_at),
+    CONSTRAINT chk_attachments_target CHECK ((to_do_list_detail_id IS NULL) <> (comment_id IS NULL)),
+    CONSTRAINT fk_attachments_blob FOREIGN KEY (blob_id) REFERENCES to_do_blobs(id),
+    CONSTRAINT fk_attachments_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    CONSTRAINT fk_attachments_comment FOREIGN KEY (comment_id) REFERENCES to_do_comments(id) ON DELETE CASCADE
+);
\ No newline at end of file
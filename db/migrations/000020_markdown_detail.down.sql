@@ -0,0 +1,9 @@
+ALTER TABLE to_do_checklist_items
+    DROP INDEX idx_checklist_items_markdown,
+    DROP COLUMN markdown_index;
+
+ALTER TABLE to_do_recurrences
+    MODIFY COLUMN detail VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE to_do_list_details
+    MODIFY COLUMN detail VARCHAR(255) NOT NULL;
\ No newline at end of file
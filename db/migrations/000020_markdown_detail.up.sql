@@ -0,0 +1,9 @@
+ALTER TABLE to_do_list_details
+    MODIFY COLUMN detail MEDIUMTEXT NOT NULL;
+
+ALTER TABLE to_do_recurrences
+    MODIFY COLUMN detail MEDIUMTEXT NOT NULL;
+
+ALTER TABLE to_do_checklist_items
+    ADD COLUMN markdown_index INT DEFAULT NULL AFTER sort_order,
+    ADD INDEX idx_checklist_items_markdown (to_do_list_detail_id, markdown_index);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_versions;
\ No newline at end of file
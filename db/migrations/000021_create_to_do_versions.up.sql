@@ -0,0 +1,12 @@
+CREATE TABLE to_do_versions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id INT NOT NULL,
+    version INT NOT NULL,
+    snapshot JSON NOT NULL,
+    reverted_from INT DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by INT NULL,
+
+    UNIQUE KEY uk_versions_entity_version (entity_type, entity_id, version)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE to_do_list_details
+    DROP COLUMN remaining_estimate_minutes,
+    DROP COLUMN original_estimate_minutes;
+
+DROP TABLE to_do_time_entries;
\ No newline at end of file
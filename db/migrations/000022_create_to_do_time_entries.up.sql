@@ -0,0 +1,26 @@
+CREATE TABLE to_do_time_entries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_detail_id INT NOT NULL,
+    user_id INT NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME DEFAULT NULL,
+    duration_seconds INT DEFAULT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+    running_user_id INT AS (IF(ended_at IS NULL AND deleted_at IS NULL, user_id, NULL)) STORED,
+
+    UNIQUE KEY uk_time_entries_running_user (running_user_id),
+    INDEX idx_time_entries_detail (to_do_list_detail_id, started_at),
+    INDEX idx_time_entries_user (user_id, started_at),
+    CONSTRAINT fk_time_entries_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    CONSTRAINT fk_time_entries_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE to_do_list_details
+    ADD COLUMN original_estimate_minutes INT DEFAULT NULL AFTER completed_at,
+    ADD COLUMN remaining_estimate_minutes INT DEFAULT NULL AFTER original_estimate_minutes;
\ No newline at end of file
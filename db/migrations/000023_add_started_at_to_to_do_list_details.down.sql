@@ -0,0 +1,4 @@
+ALTER TABLE to_do_list_details
+    DROP INDEX idx_details_completed_at,
+    DROP INDEX idx_details_created_at,
+    DROP COLUMN started_at;
\ No newline at end of file
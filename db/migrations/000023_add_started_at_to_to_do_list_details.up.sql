@@ -0,0 +1,6 @@
+ALTER TABLE to_do_list_details
+    ADD COLUMN started_at DATETIME DEFAULT NULL AFTER status,
+    ADD INDEX idx_details_created_at (created_at),
+    ADD INDEX idx_details_completed_at (completed_at);
+
+UPDATE to_do_list_details SET started_at = updated_at WHERE status = 'in_progress';
\ No newline at end of file
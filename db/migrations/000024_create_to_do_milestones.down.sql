@@ -0,0 +1,13 @@
+DROP TABLE to_do_status_changes;
+
+DROP TABLE to_do_milestone_rollovers;
+
+ALTER TABLE to_do_list_details
+    DROP FOREIGN KEY fk_details_milestone,
+    DROP COLUMN milestone_id;
+
+ALTER TABLE to_do_list
+    DROP FOREIGN KEY fk_list_milestone,
+    DROP COLUMN milestone_id;
+
+DROP TABLE to_do_milestones;
\ No newline at end of file
@@ -0,0 +1,54 @@
+CREATE TABLE to_do_milestones (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    closed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_milestones_start_date (start_date)
+);
+
+ALTER TABLE to_do_list
+    ADD COLUMN milestone_id INT DEFAULT NULL AFTER type_id,
+    ADD CONSTRAINT fk_list_milestone FOREIGN KEY (milestone_id) REFERENCES to_do_milestones(id) ON DELETE SET NULL;
+
+ALTER TABLE to_do_list_details
+    ADD COLUMN milestone_id INT DEFAULT NULL AFTER to_do_list_id,
+    ADD CONSTRAINT fk_details_milestone FOREIGN KEY (milestone_id) REFERENCES to_do_milestones(id) ON DELETE SET NULL;
+
+CREATE TABLE to_do_milestone_rollovers (
+    milestone_id INT NOT NULL,
+    to_do_list_detail_id INT NOT NULL,
+    next_milestone_id INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (milestone_id, to_do_list_detail_id),
+    INDEX idx_rollovers_detail (to_do_list_detail_id),
+    CONSTRAINT fk_rollovers_milestone FOREIGN KEY (milestone_id) REFERENCES to_do_milestones(id) ON DELETE CASCADE,
+    CONSTRAINT fk_rollovers_next_milestone FOREIGN KEY (next_milestone_id) REFERENCES to_do_milestones(id) ON DELETE CASCADE,
+    CONSTRAINT fk_rollovers_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_status_changes (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_detail_id INT NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_at DATETIME NOT NULL,
+    changed_by INT NULL,
+
+    INDEX idx_status_changes_detail (to_do_list_detail_id, changed_at),
+    CONSTRAINT fk_status_changes_detail FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE
+);
+
+INSERT INTO to_do_status_changes (to_do_list_detail_id, from_status, to_status, changed_at)
+SELECT id, 'todo', 'in_progress', started_at FROM to_do_list_details WHERE started_at IS NOT NULL;
+
+INSERT INTO to_do_status_changes (to_do_list_detail_id, from_status, to_status, changed_at)
+SELECT id, IF(started_at IS NULL, 'todo', 'in_progress'), 'done', completed_at FROM to_do_list_details WHERE status = 'done' AND completed_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_export_jobs;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE to_do_export_jobs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    format VARCHAR(10) NOT NULL,
+    filter JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    storage_key VARCHAR(255) NOT NULL DEFAULT '',
+    file_size BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    started_at DATETIME DEFAULT NULL,
+    finished_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_export_jobs_status (status, id)
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE to_do_import_rows;
+DROP TABLE to_do_imports;
\ No newline at end of file
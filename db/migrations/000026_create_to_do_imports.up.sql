@@ -0,0 +1,40 @@
+CREATE TABLE to_do_imports (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'validated',
+    total_rows INT NOT NULL DEFAULT 0,
+    valid_rows INT NOT NULL DEFAULT 0,
+    error_rows INT NOT NULL DEFAULT 0,
+    warning_rows INT NOT NULL DEFAULT 0,
+    imported_rows INT NOT NULL DEFAULT 0,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    committed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null
+);
+
+CREATE TABLE to_do_import_rows (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    import_id INT NOT NULL,
+    row_no INT NOT NULL,
+    list_name VARCHAR(255) NOT NULL DEFAULT '',
+    type_name VARCHAR(255) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    detail MEDIUMTEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    assignees JSON NOT NULL,
+    errors JSON NOT NULL,
+    warnings JSON NOT NULL,
+    valid BOOLEAN NOT NULL DEFAULT FALSE,
+    to_do_list_detail_id INT NULL,
+
+    INDEX idx_import_rows_list (import_id, list_name),
+    UNIQUE KEY uk_import_rows_row (import_id, row_no),
+    FOREIGN KEY (import_id) REFERENCES to_do_imports(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE SET NULL
+);
\ No newline at end of file
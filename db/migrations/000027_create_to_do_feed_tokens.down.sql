@@ -0,0 +1,7 @@
+DROP TABLE to_do_feed_tokens;
+
+ALTER TABLE to_do_list_details
+    DROP INDEX idx_details_ical_uid,
+    DROP INDEX idx_details_due_at,
+    DROP COLUMN ical_uid,
+    DROP COLUMN due_at;
\ No newline at end of file
@@ -0,0 +1,26 @@
+ALTER TABLE to_do_list_details
+    ADD COLUMN due_at DATETIME DEFAULT NULL AFTER completed_at,
+    ADD COLUMN ical_uid VARCHAR(255) DEFAULT NULL AFTER occurrence_at,
+    ADD INDEX idx_details_due_at (due_at),
+    ADD INDEX idx_details_ical_uid (to_do_list_id, ical_uid);
+
+CREATE TABLE to_do_feed_tokens (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    to_do_list_id INT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    token_hash CHAR(64) NOT NULL,
+    last_used_at DATETIME DEFAULT NULL,
+    revoked_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    UNIQUE KEY uk_feed_tokens_hash (token_hash),
+    INDEX idx_feed_tokens_user (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
\ No newline at end of file
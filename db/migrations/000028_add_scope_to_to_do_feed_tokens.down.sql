@@ -0,0 +1,2 @@
+ALTER TABLE to_do_feed_tokens
+    DROP COLUMN scope;
\ No newline at end of file
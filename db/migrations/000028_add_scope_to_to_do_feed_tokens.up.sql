@@ -0,0 +1,2 @@
+ALTER TABLE to_do_feed_tokens
+    ADD COLUMN scope VARCHAR(20) NOT NULL DEFAULT 'feed' AFTER to_do_list_id;
\ No newline at end of file
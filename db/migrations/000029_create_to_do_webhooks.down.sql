@@ -0,0 +1,2 @@
+DROP TABLE to_do_webhook_deliveries;
+DROP TABLE to_do_webhooks;
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE TABLE to_do_webhooks (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_id INT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    events JSON NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT null,
+
+    INDEX idx_webhooks_list (to_do_list_id),
+    FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_webhook_deliveries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id INT NOT NULL,
+    event_id CHAR(32) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    payload MEDIUMTEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_attempt_at DATETIME DEFAULT NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    response_body VARCHAR(1000) NOT NULL DEFAULT '',
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    delivered_at DATETIME DEFAULT NULL,
+    redelivery_of INT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_webhook (webhook_id, id),
+    FOREIGN KEY (webhook_id) REFERENCES to_do_webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (redelivery_of) REFERENCES to_do_webhook_deliveries(id) ON DELETE SET NULL
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE to_do_outbox_events;
+
+ALTER TABLE to_do_webhook_deliveries
+    DROP INDEX idx_webhook_deliveries_event;
\ No newline at end of file
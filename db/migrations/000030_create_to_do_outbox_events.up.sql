@@ -0,0 +1,24 @@
+CREATE TABLE to_do_outbox_events (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    event_id CHAR(32) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    to_do_list_id INT NULL,
+    actor_id INT NULL,
+    payload MEDIUMTEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_attempt_at DATETIME DEFAULT NULL,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    dispatched_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_outbox_events_event_id (event_id),
+    INDEX idx_outbox_events_due (status, next_attempt_at),
+    INDEX idx_outbox_events_dispatched (dispatched_at)
+);
+
+ALTER TABLE to_do_webhook_deliveries
+    ADD INDEX idx_webhook_deliveries_event (event_id);
\ No newline at end of file
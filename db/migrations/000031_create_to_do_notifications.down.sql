@@ -0,0 +1,3 @@
+DROP TABLE to_do_notification_preferences;
+
+DROP TABLE to_do_notifications;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE to_do_email_messages;
+
+DROP TABLE to_do_email_settings;
\ No newline at end of file
@@ -0,0 +1,43 @@
+CREATE TABLE to_do_email_settings (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    locale VARCHAR(10) NOT NULL DEFAULT 'zh-TW',
+    notify_assigned BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_due BOOLEAN NOT NULL DEFAULT TRUE,
+    digest VARCHAR(10) NOT NULL DEFAULT 'off',
+    unsubscribe_token CHAR(32) NOT NULL,
+    unsubscribed_at DATETIME DEFAULT NULL,
+    last_digest_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_email_settings_user (user_id),
+    UNIQUE KEY uk_email_settings_token (unsubscribe_token),
+    INDEX idx_email_settings_digest (digest, last_digest_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_email_messages (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NULL,
+    kind VARCHAR(30) NOT NULL,
+    to_address VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    text_body MEDIUMTEXT NOT NULL,
+    html_body MEDIUMTEXT NOT NULL,
+    unsubscribe_url VARCHAR(500) NOT NULL DEFAULT '',
+    dedupe_key VARCHAR(191) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_attempt_at DATETIME DEFAULT NULL,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    sent_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_email_messages_dedupe (dedupe_key),
+    INDEX idx_email_messages_due (status, next_attempt_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE to_do_reminder_logs;
+
+DROP TABLE to_do_reminder_runs;
+
+DROP TABLE to_do_scheduler_leases;
\ No newline at end of file
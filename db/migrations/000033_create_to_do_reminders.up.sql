@@ -0,0 +1,34 @@
+CREATE TABLE to_do_scheduler_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    owner VARCHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE to_do_reminder_runs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    instance VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    sent INT NOT NULL DEFAULT 0,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME DEFAULT NULL,
+
+    INDEX idx_reminder_runs_started (started_at)
+);
+
+CREATE TABLE to_do_reminder_logs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    run_id INT NULL,
+    rule VARCHAR(30) NOT NULL,
+    to_do_list_detail_id INT NOT NULL,
+    user_id INT NOT NULL,
+    due_at DATETIME NOT NULL,
+    occurrence INT NOT NULL DEFAULT 0,
+    sent_at DATETIME NOT NULL,
+
+    UNIQUE KEY uk_reminder_logs (to_do_list_detail_id, rule, due_at, occurrence, user_id),
+    INDEX idx_reminder_logs_run (run_id),
+    FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
\ No newline at end of file
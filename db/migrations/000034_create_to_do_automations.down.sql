@@ -0,0 +1,6 @@
+ALTER TABLE to_do_outbox_events
+    DROP COLUMN depth;
+
+DROP TABLE to_do_automation_executions;
+
+DROP TABLE to_do_automation_rules;
\ No newline at end of file
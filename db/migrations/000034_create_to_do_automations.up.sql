@@ -0,0 +1,40 @@
+CREATE TABLE to_do_automation_rules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    to_do_list_id INT NULL,
+    name VARCHAR(255) NOT NULL,
+    `trigger` VARCHAR(50) NOT NULL,
+    conditions JSON NOT NULL,
+    actions JSON NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    created_by INT NULL,
+    updated_by INT NULL,
+    deleted_by INT NULL,
+
+    INDEX idx_automation_rules_trigger (`trigger`, active),
+    INDEX idx_automation_rules_list (to_do_list_id),
+    FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_automation_executions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    rule_id INT NOT NULL,
+    trigger_key VARCHAR(64) NOT NULL,
+    `trigger` VARCHAR(50) NOT NULL,
+    to_do_list_detail_id INT NULL,
+    depth INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    results JSON NOT NULL,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_automation_executions (rule_id, trigger_key),
+    INDEX idx_automation_executions_rule (rule_id, id),
+    FOREIGN KEY (rule_id) REFERENCES to_do_automation_rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE SET NULL
+);
+
+ALTER TABLE to_do_outbox_events
+    ADD COLUMN depth INT NOT NULL DEFAULT 0;
\ No newline at end of file
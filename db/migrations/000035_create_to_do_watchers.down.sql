@@ -0,0 +1,3 @@
+DROP TABLE to_do_list_detail_watchers;
+
+DROP TABLE to_do_list_watchers;
\ No newline at end of file
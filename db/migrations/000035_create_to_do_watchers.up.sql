@@ -0,0 +1,39 @@
+CREATE TABLE to_do_list_watchers (
+    to_do_list_id INT NOT NULL,
+    user_id INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (to_do_list_id, user_id),
+    INDEX idx_list_watchers_user (user_id),
+    FOREIGN KEY (to_do_list_id) REFERENCES to_do_list(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE to_do_list_detail_watchers (
+    to_do_list_detail_id INT NOT NULL,
+    user_id INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (to_do_list_detail_id, user_id),
+    INDEX idx_detail_watchers_user (user_id),
+    FOREIGN KEY (to_do_list_detail_id) REFERENCES to_do_list_details(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT IGNORE INTO to_do_list_watchers (to_do_list_id, user_id)
+SELECT l.id, l.created_by FROM to_do_list AS l
+JOIN users AS u ON u.id = l.created_by
+WHERE l.deleted_at IS NULL;
+
+INSERT IGNORE INTO to_do_list_detail_watchers (to_do_list_detail_id, user_id)
+SELECT d.id, d.created_by FROM to_do_list_details AS d
+JOIN users AS u ON u.id = d.created_by
+WHERE d.deleted_at IS NULL;
+
+INSERT IGNORE INTO to_do_list_detail_watchers (to_do_list_detail_id, user_id)
+SELECT to_do_list_detail_id, user_id FROM to_do_task_assignments;
+
+INSERT IGNORE INTO to_do_list_detail_watchers (to_do_list_detail_id, user_id)
+SELECT DISTINCT c.to_do_list_detail_id, c.created_by FROM to_do_comments AS c
+JOIN users AS u ON u.id = c.created_by
+WHERE c.deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE to_do_realtime_tickets;
\ No newline at end of file
@@ -15,61 +15,55 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/member": {
+        "/api/attachments/{id}/download": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "查詢 Member 清單，支援關鍵字與排序",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "不需登入，驗證簽章與期限後回傳附件內容",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Member"
+                    "TodoAttachment"
                 ],
-                "summary": "取得 Member 列表",
+                "summary": "以簽章連結下載附件",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "頁碼（預設 1）",
-                        "name": "page",
-                        "in": "query"
+                        "description": "附件 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "每頁筆數（預設 10）",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "關鍵字搜尋",
-                        "name": "keyword",
-                        "in": "query"
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "排序欄位與方式，如 created_at desc",
-                        "name": "order",
-                        "in": "query"
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "附件內容",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
             }
         },
-        "/api/member/{id}": {
+        "/api/automations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 取得 Member 詳細資料",
+                "description": "指定 to_do_list_id 時只回傳該 TodoList 的規則",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,34 +71,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "TodoAutomation"
                 ],
-                "summary": "取得單一 Member",
+                "summary": "取得自動化規則列表",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Member ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": 2,
+                        "name": "to_do_list_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳 User",
+                        "description": "成功回傳規則",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoAutomationRules"
+                            }
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 修改 Member 權限",
+                "description": "觸發的資料 commit 後，項目符合所有條件時以建立者的身分依序執行動作；動作全部成功或全部復原。trigger 為 detail.created、detail.status_changed、detail.assigned 或 detail.due_passed（到期後仍未完成，24 小時內補執行）。動作引起的變更可以再觸發規則，最多連鎖 3 層\ncall_webhook 只能使用不限 TodoList 或與規則同一個 TodoList 的 webhook，其他 TodoList 的 webhook 只有 Admin 可以使用",
                 "consumes": [
                     "application/json"
                 ],
@@ -112,45 +109,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "TodoAutomation"
                 ],
-                "summary": "修改 Member",
+                "summary": "新增自動化規則",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Member ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "要更新的 Member 資料",
+                        "description": "自動化規則",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.MenberUpdate"
+                            "$ref": "#/definitions/dto.TodoAutomationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳更新後的 User",
+                        "description": "成功回傳規則",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.TodoAutomationRules"
                         }
                     }
                 }
             }
         },
-        "/api/todo/details": {
-            "post": {
+        "/api/automations/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "建立一個新的 todoListDetails 項目",
                 "consumes": [
                     "application/json"
                 ],
@@ -158,27 +147,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoListDetails"
+                    "TodoAutomation"
+                ],
+                "summary": "取得自動化規則",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "規則 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "新增 todoListDetails",
                 "responses": {
                     "200": {
-                        "description": "建立成功回傳的 TodoListDetails 資料",
+                        "description": "成功回傳規則",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoListDetails"
+                            "$ref": "#/definitions/models.TodoAutomationRules"
                         }
                     }
                 }
-            }
-        },
-        "/api/todo/details/{id}": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 修改 TodoListDetails 名稱",
+                "description": "只有建立者或 Admin 可以修改；不能更換 TodoList，停用期間的觸發不會補執行",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,45 +182,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoListDetails"
+                    "TodoAutomation"
                 ],
-                "summary": "修改 TodoListDetails",
+                "summary": "修改自動化規則",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "TodoListDetails ID",
+                        "description": "規則 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "要更新的 TodoListDetails 資料",
+                        "description": "自動化規則",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TodoTypeUpdateRequest"
+                            "$ref": "#/definitions/dto.TodoAutomationUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳更新後的 TodoType",
+                        "description": "成功回傳規則",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoListDetails"
+                            "$ref": "#/definitions/models.TodoAutomationRules"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有建立者或 Admin 可以刪除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAutomation"
+                ],
+                "summary": "刪除自動化規則",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "規則 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的規則",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoAutomationRules"
                         }
                     }
                 }
             }
         },
-        "/api/todo/list": {
+        "/api/automations/{id}/executions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "查詢 TodoList 清單，支援關鍵字與排序",
+                "description": "新的在前；status 為 succeeded、failed（所有動作已復原）或 skipped（超過連鎖層數上限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,44 +263,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoList"
+                    "TodoAutomation"
                 ],
-                "summary": "取得 TodoList 列表",
+                "summary": "取得自動化規則的執行紀錄",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "頁碼（預設 1）",
-                        "name": "page",
-                        "in": "query"
+                        "description": "規則 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "每頁筆數（預設 10）",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "關鍵字搜尋",
-                        "name": "keyword",
+                        "example": 1,
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "排序欄位與方式，如 created_at desc",
-                        "name": "order",
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
-                "responses": {}
-            },
+                "responses": {
+                    "200": {
+                        "description": "成功回傳執行紀錄",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoAutomationExecutions"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/automations/{id}/test": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "建議一個新的 todoList 項目",
+                "description": "以項目目前的資料檢查規則的條件，回傳每個條件的結果與符合時會執行的動作；不會執行動作也不會留下執行紀錄，停用的規則也可以試跑",
                 "consumes": [
                     "application/json"
                 ],
@@ -277,38 +318,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoList"
+                    "TodoAutomation"
                 ],
-                "summary": "新增 TodoList",
+                "summary": "試跑自動化規則",
                 "parameters": [
                     {
-                        "description": "建立 TodoList 所需資料",
+                        "type": "integer",
+                        "description": "規則 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "試跑的項目",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TodoListCreateRequest"
+                            "$ref": "#/definitions/dto.TodoAutomationTestRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "建立成功回傳的 TodoList 資料",
+                        "description": "成功回傳試跑結果",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoList"
+                            "$ref": "#/definitions/models.AutomationTestResult"
                         }
                     }
                 }
             }
         },
-        "/api/todo/list/{id}": {
+        "/api/email/settings": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 取得 TodoList 詳細資料",
+                "description": "尚未設定過時 email 為空，不會寄送任何信件；unsubscribed_at 有值表示已從信件中取消訂閱",
                 "consumes": [
                     "application/json"
                 ],
@@ -316,23 +364,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoList"
-                ],
-                "summary": "取得單一 TodoList",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "TodoList ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "TodoEmail"
                 ],
+                "summary": "取得目前使用者的 email 設定",
                 "responses": {
                     "200": {
-                        "description": "成功回傳 TodoList",
+                        "description": "成功回傳設定",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoList"
+                            "$ref": "#/definitions/models.TodoEmailSettings"
                         }
                     }
                 }
@@ -343,7 +382,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 修改 TodoList 名稱",
+                "description": "notify_assigned 為被指派時寄信，notify_due 為即將到期、逾期與逾期多日的提醒寄信；digest 為 off、daily 或 weekly 的未完成任務摘要。儲存後會重新訂閱",
                 "consumes": [
                     "application/json"
                 ],
@@ -351,100 +390,154 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoList"
+                    "TodoEmail"
                 ],
-                "summary": "修改 TodoList",
+                "summary": "更新目前使用者的 email 設定",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "TodoList ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "要更新的 TodoList 資料",
+                        "description": "email 設定",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TodeListUpdateRequest"
+                            "$ref": "#/definitions/dto.TodoEmailSettingsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳更新後的 TodoList",
+                        "description": "成功回傳設定",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoList"
+                            "$ref": "#/definitions/models.TodoEmailSettings"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
+            }
+        },
+        "/api/email/unsubscribe": {
+            "get": {
+                "description": "信件中的取消訂閱連結，不需要登入；只顯示確認按鈕，避免信箱的連結掃描誤觸取消訂閱",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "TodoEmail"
+                ],
+                "summary": "取消訂閱確認頁",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "example": "0123456789abcdef0123456789abcdef",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
                     }
                 ],
-                "description": "根據 ID 刪除指定的 TodoList",
-                "consumes": [
-                    "application/json"
-                ],
+                "responses": {
+                    "200": {
+                        "description": "確認頁",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "不需要登入；同時支援 RFC 8058 一鍵取消訂閱（郵件軟體以 POST 送出 List-Unsubscribe=One-Click），重複取消不會出錯",
                 "produces": [
-                    "application/json"
+                    "text/html"
                 ],
                 "tags": [
-                    "TodoList"
+                    "TodoEmail"
                 ],
-                "summary": "刪除 TodoList",
+                "summary": "取消訂閱所有 email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "TodoList ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "example": "0123456789abcdef0123456789abcdef",
+                        "name": "token",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳被刪除的 TodoList",
+                        "description": "完成頁",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoList"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/todo/type": {
+        "/api/feeds/{token}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "查詢 TodoType 清單，支援關鍵字與排序",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "不需登入，以訂閱憑證驗證；輸出有到期時間的項目。支援 ETag / Last-Modified，內容沒有變動時回傳 304",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "TodoTypes"
+                    "TodoCalendar"
                 ],
-                "summary": "取得 TodoType 列表",
+                "summary": "iCalendar 訂閱",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "頁碼（預設 1）",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "訂閱憑證，結尾為 .ics",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "每頁筆數（預設 10）",
+                        "enum": [
+                            "vtodo",
+                            "vevent"
+                        ],
+                        "type": "string",
+                        "example": "vtodo",
+                        "description": "Component 預設為 vtodo；部分日曆程式（例如 Google 日曆）不顯示 VTODO，可改用 vevent",
+                        "name": "component",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/member": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查詢 Member 清單，支援關鍵字與排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "取得 Member 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 10）",
                         "name": "page_size",
                         "in": "query"
                     },
@@ -462,14 +555,51 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {}
+            }
+        },
+        "/api/member/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 取得 Member 詳細資料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "取得單一 Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 User",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "建立一個新的 TodoType 項目",
+                "description": "根據 ID 修改 Member 權限",
                 "consumes": [
                     "application/json"
                 ],
@@ -477,38 +607,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoTypes"
+                    "Member"
                 ],
-                "summary": "新增 TodoType",
+                "summary": "修改 Member",
                 "parameters": [
                     {
-                        "description": "建立 TodoType 所需資料",
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的 Member 資料",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TodoTypeCreateRequest"
+                            "$ref": "#/definitions/dto.MenberUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "建立成功回傳的 TodoType 資料",
+                        "description": "成功回傳更新後的 User",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoTypes"
+                            "$ref": "#/definitions/models.User"
                         }
                     }
                 }
             }
         },
-        "/api/todo/type/{id}": {
+        "/api/notifications": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 取得 TodoType 詳細資料",
+                "description": "新的在前；type 為 assigned、unassigned、mentioned、due_soon、overdue、comment、role_changed 或 escalated，read_at 為 null 表示未讀",
                 "consumes": [
                     "application/json"
                 ],
@@ -516,23 +653,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoTypes"
+                    "TodoNotification"
                 ],
-                "summary": "取得單一 TodoType",
+                "summary": "取得目前使用者的通知",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "TodoType ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "name": "unread",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳 TodoType",
+                        "description": "成功回傳通知",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoTypes"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoNotifications"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "每一類通知是否接收，預設全部接收",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoNotification"
+                ],
+                "summary": "取得通知設定",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳通知設定",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     }
                 }
@@ -543,7 +728,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 修改 TodoType 名稱",
+                "description": "只更新有指定的類型，關閉後不再產生該類通知，已產生的不受影響",
                 "consumes": [
                     "application/json"
                 ],
@@ -551,43 +736,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoTypes"
+                    "TodoNotification"
                 ],
-                "summary": "修改 TodoType",
+                "summary": "修改通知設定",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "TodoType ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "通知設定",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoNotificationPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的通知設定",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read": {
+            "put": {
+                "security": [
                     {
-                        "description": "要更新的 TodoType 資料",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "已讀或不屬於目前使用者的通知略過，updated 為實際標記的筆數",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoNotification"
+                ],
+                "summary": "將通知標為已讀",
+                "parameters": [
+                    {
+                        "description": "通知 ID",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TodoTypeUpdateRequest"
+                            "$ref": "#/definitions/dto.TodoNotificationReadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳更新後的 TodoType",
+                        "description": "成功回傳標記筆數與剩餘未讀數",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoTypes"
+                            "$ref": "#/definitions/dto.TodoNotificationReadResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/notifications/read-all": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根據 ID 刪除指定的 TodoType",
                 "consumes": [
                     "application/json"
                 ],
@@ -595,114 +816,7387 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TodoTypes"
+                    "TodoNotification"
                 ],
-                "summary": "刪除 TodoType",
+                "summary": "將所有通知標為已讀",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳標記筆數與剩餘未讀數",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoNotificationReadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoNotification"
+                ],
+                "summary": "取得未讀通知數",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳未讀數",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoNotificationCountResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/realtime/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "事件的 id 為事件 ID、event 為事件類型（如 detail.updated、comment.created）、data 為事件 JSON；每 25 秒送出 \": heartbeat\" 註解。\n重新連線時帶 Last-Event-ID header（EventSource 會自動帶上）或 last_event_id 參數即可補上斷線期間的事件；事件已不在保留範圍時先送出 event: reset，用戶端需重新取得資料",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "以 Server-Sent Events 接收即時更新",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "TodoType ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "example": "9f86d081884c7d659a2feaa0c55ad015",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2
+                        ],
+                        "description": "未指定時訂閱整個工作區",
+                        "name": "list_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "POST /api/realtime/ticket 取得的一次性票證，無法設定 Authorization header 時使用",
+                        "name": "ticket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最後收到的事件 ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功回傳被刪除的 TodoType",
+                        "description": "事件串流",
                         "schema": {
-                            "$ref": "#/definitions/models.TodoTypes"
+                            "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.MenberUpdate": {
+        },
+        "/api/realtime/ticket": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "EventSource 與 WebSocket 無法設定 Authorization header，改將票證放在 ticket 查詢參數連線；JWT 不可放在網址中。\n票證只能使用一次，30 秒內未使用即失效，每次連線（包含重新連線）前都需要重新取得",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "取得即時更新連線用的一次性票證",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳票證",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoRealtimeTickets"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/realtime/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "伺服器送出 JSON 訊息：{\"type\":\"event\",\"event\":{...}}、每 25 秒一次的 {\"type\":\"heartbeat\"}，以及無法從 last_event_id 接續時的 {\"type\":\"reset\"}（用戶端需重新取得資料）。\n重新連線時帶 last_event_id 參數即可補上斷線期間的事件；用戶端送來的訊息會被忽略",
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "以 WebSocket 接收即時更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "9f86d081884c7d659a2feaa0c55ad015",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2
+                        ],
+                        "description": "未指定時訂閱整個工作區",
+                        "name": "list_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "POST /api/realtime/ticket 取得的一次性票證，瀏覽器無法設定 Authorization header 時使用",
+                        "name": "ticket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "切換為 WebSocket",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminders/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新的在前，保留 7 天；status 為 running、succeeded 或 failed，sent 為該次送出的提醒數，instance 為執行的 instance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoReminder"
+                ],
+                "summary": "取得提醒排程的執行紀錄",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳執行紀錄",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoReminderRuns"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminders/runs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "logs 為該次送出的提醒，occurrence 為重複提醒的第幾次；使用者關閉了該類通知時仍會記錄，但不會收到",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoReminder"
+                ],
+                "summary": "取得一次執行與該次送出的提醒",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "執行紀錄 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳執行紀錄",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoReminderRuns"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminders/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依提醒時間排序，重複提醒的只列出下一次；rule 為 before_\u003c時間\u003e（到期前）、at_due（到期時）、overdue（逾期後重複）或 escalation（通知清單建立者或 Admin），user_ids 為會收到提醒的使用者",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoReminder"
+                ],
+                "summary": "取得即將送出的提醒",
+                "parameters": [
+                    {
+                        "maximum": 168,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 24,
+                        "description": "Hours 列出接下來幾小時內的提醒，預設 24",
+                        "name": "hours",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳即將送出的提醒",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReminderUpcoming"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/cycle-time": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以期間內完成的任務計算（秒）；lead time 為建立到完成，cycle time 為第一次進行中到完成。format=csv 時回傳 CSV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "完成任務的 lead time 與 cycle time 百分位數",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "example": "json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳報表",
+                        "schema": {
+                            "$ref": "#/definitions/models.CycleTimeReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/throughput": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依任務建立時間與完成時間計算，週以週一為起點；期間內沒有資料的區間補 0。format=csv 時回傳 CSV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "每日／每週新增與完成的任務數",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "example": "json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "example": "week",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳報表",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ThroughputRow"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "created、completed 依建立與完成時間落在期間內計算，open 為期間內建立且尚未完成。format=csv 時回傳 CSV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "依 TodoTypes 分類的任務數",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "example": "json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳報表",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TypeBreakdownRow"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/workload": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依 to_do_task_assignments 計算，期間以任務建立時間篩選。format=csv 時回傳 CSV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "每位使用者尚未完成的任務數",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "example": "json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳報表",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WorkloadRow"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/details": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建立一個新的 todoListDetails 項目，detail 為 Markdown，回傳時附上過濾後的 detail_html，其中的 - [ ] 待辦會建立為 checklist 項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "新增 todoListDetails",
+                "responses": {
+                    "200": {
+                        "description": "建立成功回傳的 TodoListDetails 資料",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/details/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 修改 TodoListDetails 名稱與說明（Markdown），說明中的 - [ ] 待辦會同步到 checklist 項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "修改 TodoListDetails",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的 TodoListDetails 資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTypeUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoType",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/export-jobs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "由排程在背景匯出，完成後查詢工作即可取得下載連結；不給篩選條件時匯出整個工作區",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoExport"
+                ],
+                "summary": "建立背景匯出工作",
+                "parameters": [
+                    {
+                        "description": "格式與篩選條件",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoExportJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳匯出工作",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoExportJobs"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/export-jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有建立者或 Admin 可以查看，status 為 done 時 download_url 為下載連結",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoExport"
+                ],
+                "summary": "取得背景匯出工作",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "匯出工作 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳匯出工作",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoExportJobs"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/export-jobs/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有建立者或 Admin 可以下載，工作尚未完成時回傳 409",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "TodoExport"
+                ],
+                "summary": "下載背景匯出的檔案",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "匯出工作 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "匯出內容",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/feed-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "不含原始憑證，包含已撤銷的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoCalendar"
+                ],
+                "summary": "取得自己的 iCalendar 訂閱與 CalDAV 憑證",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳憑證",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoFeedTokens"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "日曆程式無法帶 JWT，訂閱以網址中的憑證驗證，CalDAV 以帳號與憑證做 HTTP Basic 驗證；憑證與訂閱網址只會在建立時回傳一次。\n不給 to_do_list_id 時訂閱指派給自己的項目，CalDAV 憑證不可指定 to_do_list_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoCalendar"
+                ],
+                "summary": "建立 iCalendar 訂閱或 CalDAV 憑證",
+                "parameters": [
+                    {
+                        "description": "訂閱範圍",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoFeedTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳憑證與訂閱網址",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoFeedTokens"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/feed-tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤銷後使用該憑證的訂閱與同步立即失效，只有建立者或 Admin 可以撤銷",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoCalendar"
+                ],
+                "summary": "撤銷 iCalendar 訂閱或 CalDAV 憑證",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "憑證 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳撤銷的憑證",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoFeedTokens"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "支援自訂欄位對應的 CSV（source=csv，mapping 為 JSON，可對應 list、type、name、detail、status、assignees，list 與 name 必填）\n與 Trello 看板匯出的 JSON（source=trello）。只會逐列驗證並保存結果，不建立資料；找不到的帳號與已存在的同名 TodoList 列為警告",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoImport"
+                ],
+                "summary": "上傳並驗證匯入檔案（dry run）",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "匯入檔案",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "來源：csv 或 trello",
+                        "name": "source",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV 欄位對應（JSON）",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳驗證結果",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoImports"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有建立者或 Admin 可以查看",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoImport"
+                ],
+                "summary": "取得匯入",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "匯入 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳匯入",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoImports"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/imports/{id}/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建立驗證通過的列，每個 TodoList 一個交易；中途失敗時已建立的 TodoList 保留，再次確認會從尚未建立的部分繼續",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoImport"
+                ],
+                "summary": "確認匯入",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "匯入 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳匯入",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoImports"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/imports/{id}/rows": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "每一列的錯誤與警告，valid 的列會在確認時建立，建立後帶有 to_do_list_detail_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoImport"
+                ],
+                "summary": "取得匯入的逐列驗證報告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "匯入 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Problems 只列出有錯誤或警告的列",
+                        "name": "problems",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳匯入列",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoImportRows"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/label": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查詢 TodoLabel 清單，支援關鍵字與排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "取得 TodoLabel 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 10）",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "關鍵字搜尋",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序欄位與方式，如 created_at desc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建立一個新的 TodoLabel 項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "新增 TodoLabel",
+                "parameters": [
+                    {
+                        "description": "建立 TodoLabel 所需資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoLabelCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "建立成功回傳的 TodoLabel 資料",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoLabels"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/label/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "統計每個 TodoLabel 被 TodoList 與 TodoListDetails 使用的次數",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "取得 TodoLabel 使用次數",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳使用次數",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoLabelUsage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/label/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 取得 TodoLabel 詳細資料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "取得單一 TodoLabel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoLabel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 TodoLabel",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoLabels"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 修改 TodoLabel 名稱與顏色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "修改 TodoLabel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoLabel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的 TodoLabel 資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoLabelUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoLabel",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoLabels"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 刪除指定的 TodoLabel，並解除與任務的關聯（不刪除任務）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoLabels"
+                ],
+                "summary": "刪除 TodoLabel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoLabel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的 TodoLabel",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoLabels"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查詢 TodoList 清單，支援關鍵字與排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "取得 TodoList 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 10）",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "關鍵字搜尋",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序欄位與方式，如 created_at desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "標籤 ID（可重複帶入，符合任一即可）",
+                        "name": "label_ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建議一個新的 todoList 項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "新增 TodoList",
+                "parameters": [
+                    {
+                        "description": "建立 TodoList 所需資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "建立成功回傳的 TodoList 資料",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/attachments/{attachment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有上傳者或 Admin 可以刪除，內容在沒有其他附件引用後由排程清除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "刪除附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "附件 ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的附件",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoAttachments"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/attachments/{attachment_id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "下載附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "附件 ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "附件內容",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/attachments/{attachment_id}/url": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "連結不需登入即可下載，過期後失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "取得附件的簽章下載連結",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "附件 ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳下載連結",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoAttachmentURLResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/comments/{comment_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有作者或 Admin 可以修改，修改前的內容會保存在修改紀錄",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoComment"
+                ],
+                "summary": "修改留言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "留言 ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "留言內容",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoCommentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳留言",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoComments"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有作者或 Admin 可以刪除（軟刪除）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoComment"
+                ],
+                "summary": "刪除留言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "留言 ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的留言",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoComments"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/comments/{comment_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "取得留言的附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "留言 ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳附件",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoAttachments"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 multipart/form-data 的 file 欄位上傳，大小與類型有上限，相同內容只會保存一份",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "上傳附件到留言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "留言 ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "附件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳附件",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoAttachments"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/comments/{comment_id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依時間先後回傳每次修改前的內容",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoComment"
+                ],
+                "summary": "取得留言修改紀錄",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "留言 ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳修改紀錄",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoCommentRevisions"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/items/{item_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改 checklist 項目名稱與完成狀態；由說明 - [ ] 產生的項目會一併回寫說明",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoChecklist"
+                ],
+                "summary": "修改 checklist 項目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checklist 項目 ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的項目資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoChecklistItemUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的項目",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoChecklistItems"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 刪除 checklist 項目；由說明 - [ ] 產生的項目會一併從說明中移除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoChecklist"
+                ],
+                "summary": "刪除 checklist 項目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checklist 項目 ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的項目",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoChecklistItems"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/time-entries/{entry_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有紀錄本人或 Admin 可以修改；計時中的紀錄可以不給 ended_at，給了則一併停止",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "修改工時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "工時 ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "工時",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTimeEntryUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳工時",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "只有紀錄本人或 Admin 可以刪除，刪除計時中的紀錄等同取消計時",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "刪除工時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "工時 ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的工時",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 取得 TodoListDetails，包含負責人、標籤、checklist 項目與完成進度",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "取得單一 TodoListDetails",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/assignees": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 user_ids 取代原本的負責人；新增與移除的負責人會收到通知",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "設定 TodoListDetails 負責人",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "負責人",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsAssigneesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "取得 TodoListDetails 的附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳附件",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoAttachments"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 multipart/form-data 的 file 欄位上傳，大小與類型有上限，相同內容只會保存一份",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoAttachment"
+                ],
+                "summary": "上傳附件到 TodoListDetails",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "附件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳附件",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoAttachments"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/blockers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "設定 blocker_id 擋住指定的 TodoListDetails（可跨 TodoList），會形成循環時回傳錯誤與循環路徑",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoDependency"
+                ],
+                "summary": "新增前置任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "被擋住的 TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "前置任務 ID",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsBlockerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳相依關係",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoDetailDependency"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/blockers/{blocker_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移除 blocker_id 對指定 TodoListDetails 的阻擋關係",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoDependency"
+                ],
+                "summary": "移除前置任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "被擋住的 TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "前置任務 ID",
+                        "name": "blocker_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/todo/list/details/{id}/board/move": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移到 status 對應的欄位並排在 after_id 之後、before_id 之前；狀態轉換規則、WIP 上限與前置任務在同一個交易內檢查",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoBoard"
+                ],
+                "summary": "在看板上移動卡片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "目標欄位與位置",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoBoardMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳移動後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分頁取得 TodoListDetails 的討論串（依時間先後），每串包含所有回覆",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoComment"
+                ],
+                "summary": "取得留言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 20）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在 TodoListDetails 上留言或回覆（parent_id），內容中的 @account 會解析為提及的使用者",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoComment"
+                ],
+                "summary": "新增留言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "留言內容",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoCommentCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳留言",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoComments"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/due": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "不給或給 null 表示清空；有到期時間的項目會出現在 iCalendar 訂閱中",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "設定 TodoListDetails 到期時間",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "到期時間",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsDueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/estimate": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "設定原始與剩餘預估工時（分鐘），不給或給 null 表示清空；工時彙總會與實際工時一併列出",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "設定 TodoListDetails 預估工時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "預估工時",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsEstimateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在指定的 TodoListDetails 底下新增一個 checklist 項目，排在最後",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoChecklist"
+                ],
+                "summary": "新增 checklist 項目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "建立 checklist 項目所需資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoChecklistItemCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "建立成功回傳的項目",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoChecklistItems"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/items/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依傳入的 ID 順序重新排列 TodoListDetails 底下的所有項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoChecklist"
+                ],
+                "summary": "重新排序 checklist 項目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "完整的項目 ID 順序",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoChecklistReorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳排序後的項目",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoChecklistItems"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/labels": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "將一或多個 TodoLabel 加到指定的 TodoListDetails",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "為 TodoListDetails 加上標籤",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要加上的標籤 ID",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoLabelAttachRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳含標籤的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/labels/{label_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "從指定的 TodoListDetails 移除一個 TodoLabel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "移除 TodoListDetails 的標籤",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "TodoLabel ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳含標籤的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/milestone": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "不給或給 null 表示改為沿用所屬 TodoList 的里程碑",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "設定 TodoListDetails 的里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "里程碑",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoMilestoneAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/move": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移到 after_id 之後、before_id 之前，可同時移到另一個 TodoList（to_do_list_id），只會更新被移動的項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "調整 TodoListDetails 順序",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "移動位置",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳移動後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "變更狀態（todo / in_progress / done），仍有未完成的前置任務時不能改為 done",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "變更 TodoListDetails 狀態",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的狀態",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListDetailsStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依開始時間由新到舊分頁列出，包含計時中的紀錄",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "取得 TodoListDetails 的工時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 20）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "為目前使用者新增已完成的工時，時間為伺服器時區的當地時間",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "手動新增工時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "工時",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTimeEntryCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳工時",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/timer/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "為目前使用者在 TodoListDetails 上開始計時，每人同時只能有一個計時中的計時器",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "開始計時",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "備註",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTimerStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳計時中的紀錄",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "由新到舊列出每個版本的快照，每次修改都會產生新的版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "取得 TodoListDetails 的版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 20）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/todo/list/details/{id}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "逐欄列出兩個版本不同的欄位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "比較 TodoListDetails 的兩個版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳差異",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VersionChange"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/versions/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "還原名稱、說明與狀態，狀態的檢查與一般變更狀態相同，並產生新的版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "還原 TodoListDetails 到指定版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳還原後的 TodoListDetails",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoListDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "目前的使用者關注項目，不需要是負責人也會收到新留言等更新；已經關注時不變",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "關注 TodoListDetails",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "之後再被指派或在項目留言時會重新關注；仍關注所屬 TodoList 時會繼續收到更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "取消關注 TodoListDetails",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/details/{id}/watchers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依關注時間排序，不含只關注所屬 TodoList 的使用者；建立者、負責人與留言者會自動關注",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "取得 TodoListDetails 的關注者",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoListDetails ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依篩選條件匯出，不給條件時匯出整個工作區；內容邊讀邊傳送，資料量大時建議改用背景匯出",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoExport"
+                ],
+                "summary": "匯出多個 TodoList",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "example": "csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "任務類別",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            1
+                        ],
+                        "name": "label_ids",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "匯出內容",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/rebalance": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "位置鍵過長時重新平均分配，順序不變",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "重新分配 TodoList 位置鍵",
+                "responses": {}
+            }
+        },
+        "/api/todo/list/recurrences/{recurrence_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoRecurrence"
+                ],
+                "summary": "取得單一週期性任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "週期規則 ID",
+                        "name": "recurrence_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳週期規則",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoRecurrences"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "已產生的任務不受影響，下一次發生時間從最後一次產生之後重新計算",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoRecurrence"
+                ],
+                "summary": "修改週期性任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "週期規則 ID",
+                        "name": "recurrence_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "週期規則",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoRecurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳週期規則",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoRecurrences"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "停止產生新任務，已產生的任務保留",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoRecurrence"
+                ],
+                "summary": "刪除週期性任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "週期規則 ID",
+                        "name": "recurrence_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的週期規則",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoRecurrences"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 取得 TodoList 詳細資料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "取得單一 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 修改 TodoList 名稱",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "修改 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的 TodoList 資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodeListUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 刪除指定的 TodoList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "刪除 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/board": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依欄位分組回傳卡片，每個欄位包含總數與依位置排序的分頁資料；尚未設定欄位時依狀態產生預設欄位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoBoard"
+                ],
+                "summary": "取得 TodoList 看板",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳看板",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以新的欄位（對應狀態與 WIP 上限）與狀態轉換規則取代原本設定，每個狀態都必須有一個欄位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoBoard"
+                ],
+                "summary": "設定 TodoList 看板",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "看板設定",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoBoardConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳看板（不含卡片）",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/details/rebalance": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "位置鍵過長時重新平均分配，順序不變",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoListDetails"
+                ],
+                "summary": "重新分配 TodoList 底下項目的位置鍵",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/todo/list/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 CSV（每個項目一列）或 JSON（與 TodoList Show 相同的結構，含 Type、Details 與負責人）下載",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoExport"
+                ],
+                "summary": "匯出單一 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "example": "csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "匯出內容",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/graph": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "回傳 TodoList 的相依圖，format=json（預設）或 format=dot（Graphviz）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/vnd.graphviz"
+                ],
+                "tags": [
+                    "TodoDependency"
+                ],
+                "summary": "取得 TodoList 相依圖",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "輸出格式：json 或 dot",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳相依圖",
+                        "schema": {
+                            "$ref": "#/definitions/models.DependencyGraph"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/ical": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 multipart/form-data 的 file 欄位上傳，VTODO 與 VEVENT 各建立為一個項目（DUE 或 DTSTART 為到期時間），\n已匯入過相同 UID 的項目與已取消的項目略過，同一個檔案可以重複匯入",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoCalendar"
+                ],
+                "summary": "匯入 .ics 到 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": ".ics 檔案",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳建立的項目 ID 與略過數",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarImportResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/labels": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "將一或多個 TodoLabel 加到指定的 TodoList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "為 TodoList 加上標籤",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要加上的標籤 ID",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoLabelAttachRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳含標籤的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/labels/{label_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "從指定的 TodoList 移除一個 TodoLabel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "移除 TodoList 的標籤",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "TodoLabel ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳含標籤的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/milestone": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "底下沒有另外指定里程碑的項目沿用；不給或給 null 表示移除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "設定 TodoList 的里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "里程碑",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoMilestoneAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/move": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移到 after_id 之後、before_id 之前（可只給其中一個，都不給則移到最後），只會更新被移動的 TodoList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoList"
+                ],
+                "summary": "調整 TodoList 順序",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "移動位置",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoListMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳移動後的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/recurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoRecurrence"
+                ],
+                "summary": "取得 TodoList 的週期性任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳週期規則",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoRecurrences"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 RRULE（FREQ=DAILY/WEEKLY/MONTHLY、INTERVAL、BYDAY、BYMONTHDAY、UNTIL、COUNT）建立週期規則，dtstart 為 timezone 時區的當地時間，建立後立即產生第一次任務",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoRecurrence"
+                ],
+                "summary": "新增週期性任務",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "週期規則",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoRecurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳週期規則",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoRecurrences"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "由新到舊列出每個版本的快照，每次修改都會產生新的版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "取得 TodoList 的版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 20）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/todo/list/{id}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "逐欄列出兩個版本不同的欄位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "比較 TodoList 的兩個版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳差異",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VersionChange"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/versions/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以該版本的內容重新修改，檢查與一般修改相同，並產生新的版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoVersion"
+                ],
+                "summary": "還原 TodoList 到指定版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳還原後的 TodoList",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "目前的使用者關注 TodoList，會收到底下所有項目的更新；已經關注時不變",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "關注 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "底下個別關注的項目不受影響",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "取消關注 TodoList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/list/{id}/watchers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依關注時間排序；TodoList 的建立者會自動關注",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWatcher"
+                ],
+                "summary": "取得 TodoList 的關注者",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoList ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關注者",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/milestone": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依開始日期由新到舊分頁列出，可依是否已關閉篩選",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "取得里程碑列表",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "example": false,
+                        "name": "closed",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建立里程碑（sprint），日期為伺服器時區的當地日期",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "新增里程碑",
+                "parameters": [
+                    {
+                        "description": "里程碑",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoMilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳里程碑",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoMilestones"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/milestone/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "取得單一里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "里程碑 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳里程碑",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoMilestones"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "修改里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "里程碑 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "里程碑",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoMilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳里程碑",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoMilestones"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "軟刪除里程碑，TodoList 與 TodoListDetails 不再屬於該里程碑",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "刪除里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "里程碑 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的里程碑",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoMilestones"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/milestone/{id}/chart": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依狀態變更紀錄重建里程碑期間每天結束時的剩餘量（項目數或預估分鐘數）與各狀態的項目數，\n只算到今天；關閉時移到下一個里程碑的項目仍列在原里程碑中",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "取得里程碑的燃盡與累積流量",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "里程碑 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "count",
+                            "estimate"
+                        ],
+                        "type": "string",
+                        "example": "count",
+                        "name": "metric",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳圖表資料",
+                        "schema": {
+                            "$ref": "#/definitions/models.MilestoneChart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/milestone/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "關閉後不能再加入項目；有給 next_milestone_id 時，尚未完成的項目會一次移到該里程碑",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoMilestone"
+                ],
+                "summary": "關閉里程碑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "里程碑 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "下一個里程碑",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoMilestoneCloseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳關閉結果",
+                        "schema": {
+                            "$ref": "#/definitions/models.MilestoneCloseResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/time-entries/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "依使用者（user）、日期（day）、TodoListDetails（detail）、TodoList（list）或類型（type）彙總已結束的工時，\n依 detail / list / type 分組時附上預估工時的合計以便與實際工時比較",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "工時彙總",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "day",
+                            "detail",
+                            "list",
+                            "type"
+                        ],
+                        "type": "string",
+                        "example": "list",
+                        "name": "group_by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "type_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳彙總",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TimeSummary"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/timer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "沒有計時中的計時器時 data 為 null",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "取得計時中的計時器",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳計時中的紀錄",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/timer/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "停止目前使用者計時中的計時器並計算工時",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTimeEntry"
+                ],
+                "summary": "停止計時",
+                "responses": {
+                    "200": {
+                        "description": "成功回傳已結束的紀錄",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/type": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查詢 TodoType 清單，支援關鍵字與排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTypes"
+                ],
+                "summary": "取得 TodoType 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "頁碼（預設 1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每頁筆數（預設 10）",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "關鍵字搜尋",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序欄位與方式，如 created_at desc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "建立一個新的 TodoType 項目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTypes"
+                ],
+                "summary": "新增 TodoType",
+                "parameters": [
+                    {
+                        "description": "建立 TodoType 所需資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTypeCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "建立成功回傳的 TodoType 資料",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTypes"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/todo/type/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 取得 TodoType 詳細資料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTypes"
+                ],
+                "summary": "取得單一 TodoType",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoType ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 TodoType",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTypes"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 修改 TodoType 名稱",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTypes"
+                ],
+                "summary": "修改 TodoType",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoType ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要更新的 TodoType 資料",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoTypeUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳更新後的 TodoType",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTypes"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根據 ID 刪除指定的 TodoType",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoTypes"
+                ],
+                "summary": "刪除 TodoType",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TodoType ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的 TodoType",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoTypes"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook-deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以相同的內容與事件 ID 建立新的送出紀錄，由背景工作送出；原本的紀錄保留",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "重送",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "送出紀錄 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳新的送出紀錄",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoWebhookDeliveries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定 to_do_list_id 時只回傳該 TodoList 的 webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "取得 webhook 列表",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "name": "to_do_list_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 webhook",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoWebhooks"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "訂閱事件，事件發生的交易 commit 後以 POST 送出 JSON；X-Todolist-Signature 為 t=\u003cunix 秒\u003e,v1=\u003cHMAC-SHA256(signing_secret, \"\u003ct\u003e.\u003cbody\u003e\") 的 hex\u003e。signing_secret 只在建立時回傳一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "新增 webhook",
+                "parameters": [
+                    {
+                        "description": "webhook",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 webhook 與 signing_secret",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoWebhooks"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改網址、事件、說明與是否啟用；停用期間發生的事件不會補送，尚未送出的紀錄會標記為 dead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "修改 webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TodoWebhookUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳 webhook",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoWebhooks"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "刪除後尚未送出的紀錄不再送出",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "刪除 webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳被刪除的 webhook",
+                        "schema": {
+                            "$ref": "#/definitions/models.TodoWebhooks"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新的在前；status 為 pending、delivering、succeeded 或 dead（重試次數用完）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TodoWebhook"
+                ],
+                "summary": "取得 webhook 的送出紀錄",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功回傳送出紀錄",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TodoWebhookDeliveries"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.MenberUpdate": {
+            "type": "object",
+            "required": [
+                "role_id"
+            ],
+            "properties": {
+                "role_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TodeListUpdateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type_id"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TodoAttachmentURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/attachments/1/download?expires=1700000000\u0026signature=..."
+                }
+            }
+        },
+        "dto.TodoAutomationActionRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "已自動指派，請確認"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "assign_user",
+                        "set_status",
+                        "add_comment",
+                        "move_to_list",
+                        "call_webhook"
+                    ],
+                    "example": "assign_user"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.TodoAutomationConditionsRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                },
+                "label_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "type_ids": {
+                    "description": "TypeIDs 項目所屬 TodoList 的類型",
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                }
+            }
+        },
+        "dto.TodoAutomationRequest": {
+            "type": "object",
+            "required": [
+                "actions",
+                "name",
+                "trigger"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.TodoAutomationActionRequest"
+                    }
+                },
+                "conditions": {
+                    "$ref": "#/definitions/dto.TodoAutomationConditionsRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "新任務自動指派"
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "trigger": {
+                    "type": "string",
+                    "enum": [
+                        "detail.created",
+                        "detail.status_changed",
+                        "detail.assigned",
+                        "detail.due_passed"
+                    ],
+                    "example": "detail.created"
+                }
+            }
+        },
+        "dto.TodoAutomationTestRequest": {
+            "type": "object",
+            "required": [
+                "to_do_list_detail_id"
+            ],
+            "properties": {
+                "to_do_list_detail_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
+                }
+            }
+        },
+        "dto.TodoAutomationUpdateRequest": {
+            "type": "object",
+            "required": [
+                "actions",
+                "active",
+                "name",
+                "trigger"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.TodoAutomationActionRequest"
+                    }
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "conditions": {
+                    "$ref": "#/definitions/dto.TodoAutomationConditionsRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "新任務自動指派"
+                },
+                "trigger": {
+                    "type": "string",
+                    "enum": [
+                        "detail.created",
+                        "detail.status_changed",
+                        "detail.assigned",
+                        "detail.due_passed"
+                    ],
+                    "example": "detail.created"
+                }
+            }
+        },
+        "dto.TodoBoardColumnRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "status"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "進行中"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                },
+                "wip_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "dto.TodoBoardConfigRequest": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TodoBoardColumnRequest"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TodoBoardTransitionRequest"
+                    }
+                }
+            }
+        },
+        "dto.TodoBoardMoveRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "after_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "before_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 4
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
+        "dto.TodoBoardTransitionRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "todo"
+                },
+                "to": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
+        "dto.TodoChecklistItemCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoChecklistItemUpdateRequest": {
+            "type": "object",
+            "required": [
+                "is_done",
+                "name"
+            ],
+            "properties": {
+                "is_done": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoChecklistReorderRequest": {
+            "type": "object",
+            "required": [
+                "item_ids"
+            ],
+            "properties": {
+                "item_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.TodoCommentCreateRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "@alice 請協助確認"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 0
+                }
+            }
+        },
+        "dto.TodoCommentUpdateRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoEmailSettingsRequest": {
+            "type": "object",
+            "required": [
+                "digest",
+                "email",
+                "locale",
+                "notify_assigned",
+                "notify_due"
+            ],
+            "properties": {
+                "digest": {
+                    "type": "string",
+                    "enum": [
+                        "off",
+                        "daily",
+                        "weekly"
+                    ],
+                    "example": "daily"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "user@example.com"
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "zh-TW",
+                        "en"
+                    ],
+                    "example": "zh-TW"
+                },
+                "notify_assigned": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notify_due": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.TodoExportJobRequest": {
+            "type": "object",
+            "required": [
+                "format"
+            ],
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "json"
+                    ],
+                    "example": "csv"
+                },
+                "keyword": {
+                    "type": "string",
+                    "example": "任務類別"
+                },
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "type_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.TodoFeedTokenRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "手機行事曆"
+                },
+                "scope": {
+                    "description": "Scope 預設為 feed；caldav 用於 CalDAV 同步，可以修改資料",
+                    "type": "string",
+                    "enum": [
+                        "feed",
+                        "caldav"
+                    ],
+                    "example": "feed"
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "dto.TodoLabelAttachRequest": {
+            "type": "object",
+            "required": [
+                "label_ids"
+            ],
+            "properties": {
+                "label_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.TodoLabelCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#ff0000"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoLabelUpdateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#ff0000"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoListCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type_id"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TodoListDetailsAssigneesRequest": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "user_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "dto.TodoListDetailsBlockerRequest": {
+            "type": "object",
+            "required": [
+                "blocker_id"
+            ],
+            "properties": {
+                "blocker_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TodoListDetailsDueRequest": {
+            "type": "object",
+            "properties": {
+                "due_at": {
+                    "type": "string",
+                    "example": "2026-01-05T09:00:00+08:00"
+                }
+            }
+        },
+        "dto.TodoListDetailsEstimateRequest": {
+            "type": "object",
+            "properties": {
+                "original_estimate_minutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 120
+                },
+                "remaining_estimate_minutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                }
+            }
+        },
+        "dto.TodoListDetailsMoveRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "before_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 4
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "dto.TodoListDetailsStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "done"
+                }
+            }
+        },
+        "dto.TodoListMoveRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "before_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
+        "dto.TodoMilestoneAssignRequest": {
+            "type": "object",
+            "properties": {
+                "milestone_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.TodoMilestoneCloseRequest": {
+            "type": "object",
+            "properties": {
+                "next_milestone_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "dto.TodoMilestoneRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "name",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-01-16"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Sprint 1"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-01-05"
+                }
+            }
+        },
+        "dto.TodoNotificationCountResponse": {
+            "type": "object",
+            "properties": {
+                "unread": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.TodoNotificationPreferencesRequest": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "dto.TodoNotificationReadRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "dto.TodoNotificationReadResponse": {
+            "type": "object",
+            "properties": {
+                "unread": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.TodoRecurrenceRequest": {
+            "type": "object",
+            "required": [
+                "dtstart",
+                "name",
+                "rrule",
+                "timezone"
+            ],
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "dtstart": {
+                    "type": "string",
+                    "example": "2026-01-05T09:00:00"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Taipei"
+                }
+            }
+        },
+        "dto.TodoTimeEntryCreateRequest": {
+            "type": "object",
+            "required": [
+                "ended_at",
+                "started_at"
+            ],
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2026-01-05T10:30:00"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "串接金流"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-01-05T09:00:00"
+                }
+            }
+        },
+        "dto.TodoTimeEntryUpdateRequest": {
+            "type": "object",
+            "required": [
+                "started_at"
+            ],
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2026-01-05T10:30:00"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "串接金流"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-01-05T09:00:00"
+                }
+            }
+        },
+        "dto.TodoTimerStartRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "串接金流"
+                }
+            }
+        },
+        "dto.TodoTypeCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoTypeUpdateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TodoWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "同步到 CI"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "detail.updated"
+                    ]
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/todolist"
+                }
+            }
+        },
+        "dto.TodoWebhookUpdateRequest": {
+            "type": "object",
+            "required": [
+                "active",
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "同步到 CI"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "detail.updated"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/todolist"
+                }
+            }
+        },
+        "models.AutomationAction": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Body add_comment 的留言內容",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status set_status 改成的狀態",
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "description": "TodoListID move_to_list 移到的 TodoList，排在最後",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID assign_user 加入的負責人",
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "description": "WebhookID call_webhook 送出的 webhook，不需要訂閱觸發的事件",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AutomationCheck": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "passed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AutomationConditions": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "type_ids": {
+                    "description": "TypeIDs 項目所屬 TodoList 的類型",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.AutomationTestResult": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Actions 符合時會執行的動作說明",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AutomationCheck"
+                    }
+                },
+                "in_scope": {
+                    "description": "InScope 項目是否屬於規則的 TodoList",
+                    "type": "boolean"
+                },
+                "matched": {
+                    "type": "boolean"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Board": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BoardColumn"
+                    }
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoBoardTransitions"
+                    }
+                }
+            }
+        },
+        "models.BoardColumn": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoListDetails"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "wip_limit": {
+                    "description": "WIPLimit 欄位內最多可放幾張卡片，nil 表示不限制",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BurndownPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "ideal": {
+                    "type": "number"
+                },
+                "remaining": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CalendarImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CycleTimeReport": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "cycle_time": {
+                    "$ref": "#/definitions/models.Percentiles"
+                },
+                "cycle_time_count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "lead_time": {
+                    "$ref": "#/definitions/models.Percentiles"
+                }
+            }
+        },
+        "models.DependencyGraph": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DependencyGraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DependencyGraphNode"
+                    }
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DependencyGraphEdge": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DependencyGraphNode": {
+            "type": "object",
+            "properties": {
+                "external": {
+                    "description": "不屬於目前 TodoList 的節點",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ExportFilter": {
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "type": "string"
+                },
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FlowPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "statuses": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.MilestoneChart": {
+            "type": "object",
+            "properties": {
+                "burndown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BurndownPoint"
+                    }
+                },
+                "cumulative_flow": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FlowPoint"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "milestone_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MilestoneCloseResult": {
+            "type": "object",
+            "properties": {
+                "milestone": {
+                    "$ref": "#/definitions/models.TodoMilestones"
+                },
+                "rolled_over": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Percentiles": {
+            "type": "object",
+            "properties": {
+                "p50": {
+                    "type": "integer",
+                    "example": 86400
+                },
+                "p75": {
+                    "type": "integer",
+                    "example": 172800
+                },
+                "p90": {
+                    "type": "integer",
+                    "example": 345600
+                },
+                "p95": {
+                    "type": "integer",
+                    "example": 432000
+                }
+            }
+        },
+        "models.Progress": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ReminderUpcoming": {
+            "type": "object",
+            "properties": {
+                "due_at": {
+                    "type": "string"
+                },
+                "fire_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "寫報告"
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "before_24h"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "to_do_list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "models.ThroughputRow": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2026-01-05"
+                }
+            }
+        },
+        "models.TimeSummary": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "key": {
+                    "type": "string",
+                    "example": "3"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "官網改版"
+                },
+                "original_estimate_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "remaining_estimate_minutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "seconds": {
+                    "type": "integer",
+                    "example": 5400
+                }
+            }
+        },
+        "models.TodoAttachments": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoAutomationExecutions": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error 失敗時所有動作都會復原",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "trigger": {
+                    "type": "string"
+                },
+                "trigger_key": {
+                    "description": "TriggerKey 領域事件觸發時為事件 ID，逾期觸發時為 due:\u003c項目 ID\u003e:\u003c到期時間\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TodoAutomationRules": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AutomationAction"
+                    }
+                },
+                "active": {
+                    "type": "boolean"
+                },
+                "conditions": {
+                    "$ref": "#/definitions/models.AutomationConditions"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "trigger": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoBoardTransitions": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TodoChecklistItems": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "done_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_done": {
+                    "type": "boolean"
+                },
+                "markdown_index": {
+                    "description": "MarkdownIndex 由說明中第幾個 - [ ] 產生，手動新增的項目為 nil",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoCommentRevisions": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoComments": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Author 由 created_by 對應的使用者，另外批次查詢後填入",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "description": "Deleted 已刪除但仍有回覆的第一則，保留在討論串中，內容與提及會清空",
+                    "type": "boolean"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoComments"
+                    }
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoDetailDependency": {
+            "type": "object",
+            "properties": {
+                "blocked_id": {
+                    "type": "integer"
+                },
+                "blocker_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TodoEmailSettings": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "digest": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_digest_at": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "notify_assigned": {
+                    "type": "boolean"
+                },
+                "notify_due": {
+                    "type": "boolean"
+                },
+                "unsubscribed_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoExportJobs": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "download_url": {
+                    "description": "DownloadURL 完成後才有值，不存入資料庫",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "filter": {
+                    "$ref": "#/definitions/models.ExportFilter"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoFeedTokens": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "feed_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "description": "TodoListID 有值時為該 TodoList 的訂閱，否則為 UserID 被指派項目的訂閱；CalDAV 憑證不限定 TodoList",
+                    "type": "integer"
+                },
+                "token": {
+                    "description": "Token、FeedURL 只在建立時有值，不存入資料庫",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoImportRows": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "import_id": {
+                    "type": "integer"
+                },
+                "list_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "row_no": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "type_name": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.TodoImports": {
+            "type": "object",
+            "properties": {
+                "committed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imported_rows": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                },
+                "warning_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoLabelUsage": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "detail_count": {
+                    "type": "integer"
+                },
+                "label_id": {
+                    "type": "integer"
+                },
+                "list_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TodoLabels": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoList": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoListDetails"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoLabels"
+                    }
+                },
+                "milestone_id": {
+                    "description": "MilestoneID 所屬里程碑，底下沒有另外指定的項目沿用",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position 手動排序用的分數位置鍵（pkg/rank），刪除時清空",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Progress 彙總所有 Details 的進度，不存入資料庫",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Progress"
+                        }
+                    ]
+                },
+                "type": {
+                    "$ref": "#/definitions/models.TodoTypes"
+                },
+                "type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "watchers": {
+                    "description": "Watchers 關注的使用者，只在取得單一 TodoList 時載入",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "models.TodoListDetails": {
+            "type": "object",
+            "properties": {
+                "blocked_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoListDetails"
+                    }
+                },
+                "blocks": {
+                    "description": "Blocks 被此項目擋住的項目；BlockedBy 擋住此項目的項目",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoListDetails"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "detail": {
+                    "description": "Detail 說明原文（Markdown），DetailHTML 為轉換並過濾後可直接顯示的 HTML",
+                    "type": "string"
+                },
+                "detail_html": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "description": "DueAt 到期時間，有值的項目會出現在 iCalendar 訂閱中",
+                    "type": "string"
+                },
+                "ical_uid": {
+                    "description": "ICalUID 從 .ics 匯入時的 UID，訂閱中沿用，重複匯入同一個檔案時略過已匯入的項目",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoChecklistItems"
+                    }
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TodoLabels"
+                    }
+                },
+                "milestone_id": {
+                    "description": "MilestoneID 為 nil 時沿用所屬 TodoList 的里程碑",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurrence_at": {
+                    "type": "string"
+                },
+                "original_estimate_minutes": {
+                    "description": "預估工時（分鐘），用來與實際紀錄的工時比較",
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Position 在所屬 TodoList 內的手動排序位置鍵（pkg/rank），刪除時清空",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Progress 由 Items 計算而來，不存入資料庫",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Progress"
+                        }
+                    ]
+                },
+                "recurrence_id": {
+                    "description": "由週期性規則產生時才有值，(recurrence_id, occurrence_at) 唯一",
+                    "type": "integer"
+                },
+                "remaining_estimate_minutes": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "description": "StartedAt 第一次改為進行中的時間",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "watchers": {
+                    "description": "Watchers 關注的使用者，只在取得單一項目時載入",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "models.TodoMilestones": {
             "type": "object",
-            "required": [
-                "role_id"
-            ],
             "properties": {
-                "role_id": {
+                "closed_at": {
+                    "description": "ClosedAt 關閉後不能再加入項目",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
                     "type": "integer"
                 }
             }
         },
-        "dto.TodeListUpdateRequest": {
+        "models.TodoNotifications": {
             "type": "object",
-            "required": [
-                "name",
-                "type_id"
-            ],
             "properties": {
-                "name": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "type_id": {
+                "id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.TodoListCreateRequest": {
+        "models.TodoRealtimeTickets": {
             "type": "object",
-            "required": [
-                "name",
-                "type_id"
-            ],
             "properties": {
-                "name": {
+                "expires_at": {
                     "type": "string"
                 },
-                "type_id": {
-                    "type": "integer"
+                "ticket": {
+                    "description": "Ticket 只在建立時有值，不存入資料庫",
+                    "type": "string"
                 }
             }
         },
-        "dto.TodoTypeCreateRequest": {
+        "models.TodoRecurrences": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "dtstart": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_occurrence_at": {
+                    "description": "LastOccurrenceAt 最近一次已產生的發生時間",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "next_at": {
+                    "description": "NextAt 下一次尚未產生的發生時間，規則結束後為 nil",
+                    "type": "string"
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Taipei"
+                },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.TodoTypeUpdateRequest": {
+        "models.TodoReminderLogs": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "description": "Occurrence 重複提醒的第幾次，從 0 開始",
+                    "type": "integer"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "sent_at": {
                     "type": "string"
+                },
+                "to_do_list_detail_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Role": {
+        "models.TodoReminderRuns": {
             "type": "object",
             "properties": {
-                "description": {
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "instance": {
                     "type": "string"
                 },
-                "users": {
+                "logs": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.User"
+                        "$ref": "#/definitions/models.TodoReminderLogs"
                     }
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "models.TodoList": {
+        "models.TodoTimeEntries": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -714,22 +8208,23 @@ const docTemplate = `{
                 "deleted_by": {
                     "type": "integer"
                 },
-                "details": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TodoListDetails"
-                    }
+                "duration_seconds": {
+                    "description": "DurationSeconds 結束時才計算，計時中為 nil",
+                    "type": "integer"
+                },
+                "ended_at": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "note": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/models.TodoTypes"
+                "started_at": {
+                    "type": "string"
                 },
-                "type_id": {
+                "to_do_list_detail_id": {
                     "type": "integer"
                 },
                 "updated_at": {
@@ -737,11 +8232,17 @@ const docTemplate = `{
                 },
                 "updated_by": {
                     "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.TodoListDetails": {
+        "models.TodoTypes": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
                 "created_at": {
                     "type": "string"
@@ -752,38 +8253,80 @@ const docTemplate = `{
                 "deleted_by": {
                     "type": "integer"
                 },
-                "detail": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TodoWebhookDeliveries": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "event_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "last_attempt_at": {
                     "type": "string"
                 },
-                "to_do_list_id": {
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "redelivery_of": {
+                    "description": "RedeliveryOf 手動重送時為原本的紀錄",
                     "type": "integer"
                 },
-                "updated_at": {
+                "response_body": {
                     "type": "string"
                 },
-                "updated_by": {
+                "response_status": {
                     "type": "integer"
                 },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.User"
-                    }
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.TodoTypes": {
+        "models.TodoWebhooks": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -793,17 +8336,58 @@ const docTemplate = `{
                 "deleted_by": {
                     "type": "integer"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "signing_secret": {
+                    "description": "SigningSecret 簽章用的密鑰，只在建立時回傳",
                     "type": "string"
                 },
+                "to_do_list_id": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 },
                 "updated_by": {
                     "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TypeBreakdownRow": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "工作"
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "type_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -847,6 +8431,41 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.VersionChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "models.WorkloadRow": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "in_progress": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "todo": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,7 @@
+package dto
+
+type RealtimeQuery struct {
+	// 未指定時訂閱整個工作區
+	ListIDs     []int  `form:"list_ids" example:"2" binding:"omitempty,dive,min=1"`
+	LastEventID string `form:"last_event_id" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
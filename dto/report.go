@@ -0,0 +1,15 @@
+package dto
+
+// ReportQuery 報表共用的篩選條件；from、to 為日期（含當天），不指定 list_id、type_id 即為整個工作區
+type ReportQuery struct {
+	From   string `form:"from" example:"2026-01-01"`
+	To     string `form:"to" example:"2026-01-31"`
+	ListID int    `form:"list_id" example:"2" binding:"omitempty,min=1"`
+	TypeID int    `form:"type_id" example:"1" binding:"omitempty,min=1"`
+	Format string `form:"format" example:"json" binding:"omitempty,oneof=json csv"`
+}
+
+type ThroughputQuery struct {
+	ReportQuery
+	Interval string `form:"interval" example:"week" binding:"omitempty,oneof=day week"`
+}
@@ -3,11 +3,47 @@ package dto
 type TodoListDetailsCreateRequest struct {
 	TodoListID int    `json:"to_do_list_id" binding:"required"`
 	Name       string `json:"name" binding:"required"`
-	Detail     string `json:"detail" binding:"required"`
-	IDs        []int  `json:"user_ids" binding:"required"`
+	// Detail 為 Markdown，其中的 - [ ] 待辦會同步為 checklist 項目
+	Detail string `json:"detail" example:"## 說明\n- [ ] 撰寫測試" binding:"required"`
+	IDs    []int  `json:"user_ids" binding:"required"`
 }
 
 type TodoListDetailsUpdateRequest struct {
-	Name   string `json:"name" binding:"required"`
-	Detail string `json:"detail" binding:"required"`
+	Name string `json:"name" binding:"required"`
+	// Detail 為 Markdown，其中的 - [ ] 待辦會同步為 checklist 項目
+	Detail string `json:"detail" example:"## 說明\n- [x] 撰寫測試" binding:"required"`
+}
+
+// TodoListDetailsAssigneesRequest 以 user_ids 取代原本的負責人，給空陣列表示移除全部
+type TodoListDetailsAssigneesRequest struct {
+	IDs []int `json:"user_ids" example:"1,2" binding:"required,unique,dive,min=1"`
+}
+
+type TodoListDetailsStatusRequest struct {
+	Status string `json:"status" example:"done" binding:"required,oneof=todo in_progress done"`
+}
+
+type TodoListDetailsBlockerRequest struct {
+	BlockerID int `json:"blocker_id" binding:"required"`
+}
+
+type TodoRecurrenceRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Detail   string `json:"detail"`
+	RRule    string `json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO" binding:"required"`
+	Timezone string `json:"timezone" example:"Asia/Taipei" binding:"required"`
+	DtStart  string `json:"dtstart" example:"2026-01-05T09:00:00" binding:"required"`
+}
+
+// TodoListDetailsMoveRequest to_do_list_id 不給則留在原本的 TodoList；after_id / before_id 必須屬於目標 TodoList
+type TodoListDetailsMoveRequest struct {
+	TodoListID int `json:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+	AfterID    int `json:"after_id" example:"3" binding:"omitempty,min=1"`
+	BeforeID   int `json:"before_id" example:"4" binding:"omitempty,min=1"`
+}
+
+// TodoListDetailsEstimateRequest 不給或給 null 表示清空
+type TodoListDetailsEstimateRequest struct {
+	OriginalEstimateMinutes  *int `json:"original_estimate_minutes" example:"120" binding:"omitempty,min=0"`
+	RemainingEstimateMinutes *int `json:"remaining_estimate_minutes" example:"30" binding:"omitempty,min=0"`
 }
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type TodoAttachmentURLResponse struct {
+	URL       string    `json:"url" example:"/api/attachments/1/download?expires=1700000000&signature=..."`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type TodoAttachmentSignedQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+// TodoAutomationConditionsRequest 全部符合才執行；同一個條件內符合任一個即可，不給的條件不檢查
+type TodoAutomationConditionsRequest struct {
+	// TypeIDs 項目所屬 TodoList 的類型
+	TypeIDs     []int `json:"type_ids" example:"1" binding:"omitempty,unique,dive,min=1"`
+	LabelIDs    []int `json:"label_ids" example:"3" binding:"omitempty,unique,dive,min=1"`
+	AssigneeIDs []int `json:"assignee_ids" example:"2" binding:"omitempty,unique,dive,min=1"`
+}
+
+// TodoAutomationActionRequest 依 type 需要對應的欄位：assign_user 需要 user_id、set_status 需要 status、add_comment 需要 body、
+// move_to_list 需要 to_do_list_id、call_webhook 需要 webhook_id
+type TodoAutomationActionRequest struct {
+	Type       string `json:"type" example:"assign_user" binding:"required,oneof=assign_user set_status add_comment move_to_list call_webhook"`
+	UserID     int    `json:"user_id" example:"2" binding:"omitempty,min=1"`
+	Status     string `json:"status" example:"in_progress" binding:"omitempty,oneof=todo in_progress done"`
+	Body       string `json:"body" example:"已自動指派，請確認" binding:"max=2000"`
+	TodoListID int    `json:"to_do_list_id" example:"3" binding:"omitempty,min=1"`
+	WebhookID  int    `json:"webhook_id" example:"1" binding:"omitempty,min=1"`
+}
+
+// TodoAutomationRequest to_do_list_id 不給時處理所有 TodoList 的項目，只有 Admin 可以建立
+type TodoAutomationRequest struct {
+	TodoListID int                             `json:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+	Name       string                          `json:"name" example:"新任務自動指派" binding:"required,max=255"`
+	Trigger    string                          `json:"trigger" example:"detail.created" binding:"required,oneof=detail.created detail.status_changed detail.assigned detail.due_passed"`
+	Conditions TodoAutomationConditionsRequest `json:"conditions"`
+	Actions    []TodoAutomationActionRequest   `json:"actions" binding:"required,min=1,max=10,dive"`
+}
+
+// TodoAutomationUpdateRequest 修改時不能更換 TodoList
+type TodoAutomationUpdateRequest struct {
+	Name       string                          `json:"name" example:"新任務自動指派" binding:"required,max=255"`
+	Trigger    string                          `json:"trigger" example:"detail.created" binding:"required,oneof=detail.created detail.status_changed detail.assigned detail.due_passed"`
+	Conditions TodoAutomationConditionsRequest `json:"conditions"`
+	Actions    []TodoAutomationActionRequest   `json:"actions" binding:"required,min=1,max=10,dive"`
+	Active     *bool                           `json:"active" example:"true" binding:"required"`
+}
+
+type TodoAutomationQuery struct {
+	TodoListID int `form:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+}
+
+type TodoAutomationTestRequest struct {
+	TodoListDetailID int `json:"to_do_list_detail_id" example:"5" binding:"required,min=1"`
+}
+
+type TodoAutomationExecutionsQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+type TodoBoardQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+type TodoBoardColumnRequest struct {
+	Name     string `json:"name" example:"進行中" binding:"required"`
+	Status   string `json:"status" example:"in_progress" binding:"required,oneof=todo in_progress done"`
+	WIPLimit *int   `json:"wip_limit" example:"3" binding:"omitempty,min=1"`
+}
+
+type TodoBoardTransitionRequest struct {
+	From string `json:"from" example:"todo" binding:"required,oneof=todo in_progress done"`
+	To   string `json:"to" example:"in_progress" binding:"required,oneof=todo in_progress done"`
+}
+
+// TodoBoardConfigRequest columns 為空表示使用預設欄位；transitions 為空表示不限制狀態轉換
+type TodoBoardConfigRequest struct {
+	Columns     []TodoBoardColumnRequest     `json:"columns" binding:"dive"`
+	Transitions []TodoBoardTransitionRequest `json:"transitions" binding:"dive"`
+}
+
+type TodoBoardMoveRequest struct {
+	Status   string `json:"status" example:"in_progress" binding:"required,oneof=todo in_progress done"`
+	AfterID  int    `json:"after_id" example:"3" binding:"omitempty,min=1"`
+	BeforeID int    `json:"before_id" example:"4" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// TodoFeedTokenRequest 不給 to_do_list_id 時訂閱指派給自己的項目
+type TodoFeedTokenRequest struct {
+	Name       string `json:"name" example:"手機行事曆"`
+	TodoListID int    `json:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+	// Scope 預設為 feed；caldav 用於 CalDAV 同步，可以修改資料
+	Scope string `json:"scope" example:"feed" binding:"omitempty,oneof=feed caldav"`
+}
+
+type TodoFeedQuery struct {
+	// Component 預設為 vtodo；部分日曆程式（例如 Google 日曆）不顯示 VTODO，可改用 vevent
+	Component string `form:"component" example:"vtodo" binding:"omitempty,oneof=vtodo vevent"`
+}
+
+// TodoListDetailsDueRequest 不給或給 null 表示清空
+type TodoListDetailsDueRequest struct {
+	DueAt *time.Time `json:"due_at" example:"2026-01-05T09:00:00+08:00"`
+}
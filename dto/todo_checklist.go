@@ -0,0 +1,14 @@
+package dto
+
+type TodoChecklistItemCreateRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type TodoChecklistItemUpdateRequest struct {
+	Name   string `json:"name" binding:"required"`
+	IsDone *bool  `json:"is_done" binding:"required"`
+}
+
+type TodoChecklistReorderRequest struct {
+	ItemIDs []int `json:"item_ids" binding:"required,min=1"`
+}
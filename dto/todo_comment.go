@@ -0,0 +1,15 @@
+package dto
+
+type TodoCommentCreateRequest struct {
+	Body     string `json:"body" example:"@alice 請協助確認" binding:"required"`
+	ParentID int    `json:"parent_id" example:"0" binding:"omitempty,min=1"`
+}
+
+type TodoCommentUpdateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type TodoCommentQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type TodoEmailSettingsRequest struct {
+	Email          string `json:"email" example:"user@example.com" binding:"required,email,max=255"`
+	Locale         string `json:"locale" example:"zh-TW" binding:"required,oneof=zh-TW en"`
+	NotifyAssigned *bool  `json:"notify_assigned" example:"true" binding:"required"`
+	NotifyDue      *bool  `json:"notify_due" example:"true" binding:"required"`
+	Digest         string `json:"digest" example:"daily" binding:"required,oneof=off daily weekly"`
+}
+
+type TodoEmailUnsubscribeQuery struct {
+	Token string `form:"token" example:"0123456789abcdef0123456789abcdef" binding:"required,len=32,hexadecimal"`
+}
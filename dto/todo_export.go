@@ -0,0 +1,22 @@
+package dto
+
+// TodoExportQuery 不給任何篩選條件時匯出整個工作區
+type TodoExportQuery struct {
+	Format   string `form:"format" example:"csv" binding:"omitempty,oneof=csv json"`
+	TypeID   int    `form:"type_id" example:"1" binding:"omitempty,min=1"`
+	Keyword  string `form:"keyword" example:"任務類別"`
+	LabelIDs []int  `form:"label_ids" example:"1"`
+}
+
+type TodoExportListQuery struct {
+	Format string `form:"format" example:"csv" binding:"omitempty,oneof=csv json"`
+}
+
+// TodoExportJobRequest 不給任何篩選條件時匯出整個工作區
+type TodoExportJobRequest struct {
+	Format   string `json:"format" example:"csv" binding:"required,oneof=csv json"`
+	ListID   int    `json:"list_id" example:"2" binding:"omitempty,min=1"`
+	TypeID   int    `json:"type_id" example:"1" binding:"omitempty,min=1"`
+	Keyword  string `json:"keyword" example:"任務類別"`
+	LabelIDs []int  `json:"label_ids" example:"1"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// TodoImportForm 以 multipart/form-data 上傳，CSV 需要 mapping
+type TodoImportForm struct {
+	Source  string `form:"source" example:"csv" binding:"required,oneof=csv trello"`
+	Mapping string `form:"mapping" example:"{\"list\":\"Project\",\"name\":\"Task\",\"status\":\"State\",\"assignees\":\"Owner\"}"`
+}
+
+type TodoImportRowsQuery struct {
+	// Problems 只列出有錯誤或警告的列
+	Problems bool `form:"problems" example:"true"`
+	Page     int  `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int  `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type TodoLabelCreateRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color" example:"#ff0000" binding:"omitempty,hexcolor"`
+}
+
+type TodoLabelQuery struct {
+	Page     int    `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" example:"10" binding:"omitempty,min=1,max=100"`
+	Keyword  string `form:"keyword" example:"標籤名稱"`
+	Order    string `form:"order" example:"created_at desc"`
+}
+
+type TodoLabelUpdateRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color" example:"#ff0000" binding:"omitempty,hexcolor"`
+}
+
+type TodoLabelAttachRequest struct {
+	LabelIDs []int `json:"label_ids" binding:"required,min=1"`
+}
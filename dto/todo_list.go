@@ -10,9 +10,16 @@ type TodoListQuery struct {
 	PageSize int    `form:"page_size" example:"10" binding:"omitempty,min=1,max=100"`
 	Keyword  string `form:"keyword" example:"任務類別"`
 	Order    string `form:"order" example:"created_at desc"`
+	LabelIDs []int  `form:"label_ids" example:"1"`
 }
 
 type TodeListUpdateRequest struct {
 	Name   string `json:"name" binding:"required"`
 	TypeID int    `json:"type_id" binding:"required"`
 }
+
+// TodoListMoveRequest after_id / before_id 可只給一個，都不給則移到最後
+type TodoListMoveRequest struct {
+	AfterID  int `json:"after_id" example:"3" binding:"omitempty,min=1"`
+	BeforeID int `json:"before_id" example:"4" binding:"omitempty,min=1"`
+}
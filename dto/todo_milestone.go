@@ -0,0 +1,28 @@
+package dto
+
+// TodoMilestoneRequest 日期為伺服器時區的當地日期（含當天）
+type TodoMilestoneRequest struct {
+	Name      string `json:"name" example:"Sprint 1" binding:"required,max=255"`
+	StartDate string `json:"start_date" example:"2026-01-05" binding:"required"`
+	EndDate   string `json:"end_date" example:"2026-01-16" binding:"required"`
+}
+
+type TodoMilestoneQuery struct {
+	Page     int   `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int   `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+	Closed   *bool `form:"closed" example:"false"`
+}
+
+type TodoMilestoneChartQuery struct {
+	Metric string `form:"metric" example:"count" binding:"omitempty,oneof=count estimate"`
+}
+
+// TodoMilestoneCloseRequest 有給 next_milestone_id 時，未完成的項目會移到該里程碑
+type TodoMilestoneCloseRequest struct {
+	NextMilestoneID int `json:"next_milestone_id" example:"2" binding:"omitempty,min=1"`
+}
+
+// TodoMilestoneAssignRequest 不給或給 null 表示移除
+type TodoMilestoneAssignRequest struct {
+	MilestoneID *int `json:"milestone_id" example:"1" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type TodoNotificationQuery struct {
+	Unread   bool `form:"unread" example:"true"`
+	Page     int  `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int  `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+type TodoNotificationReadRequest struct {
+	IDs []int `json:"ids" example:"1,2" binding:"required,min=1,dive,min=1"`
+}
+
+// TodoNotificationPreferencesRequest 通知類型對應是否接收，未指定的類型維持原本的設定
+type TodoNotificationPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" binding:"required,dive,keys,oneof=assigned unassigned mentioned due_soon overdue comment role_changed escalated,endkeys"`
+}
+
+type TodoNotificationCountResponse struct {
+	Unread int64 `json:"unread" example:"3"`
+}
+
+type TodoNotificationReadResponse struct {
+	Updated int64 `json:"updated" example:"2"`
+	Unread  int64 `json:"unread" example:"1"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type TodoReminderUpcomingQuery struct {
+	// Hours 列出接下來幾小時內的提醒，預設 24
+	Hours int `form:"hours" example:"24" binding:"omitempty,min=1,max=168"`
+}
+
+type TodoReminderRunsQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+type TodoTimerStartRequest struct {
+	Note string `json:"note" example:"串接金流" binding:"max=255"`
+}
+
+// TodoTimeEntryCreateRequest 時間為伺服器時區的當地時間
+type TodoTimeEntryCreateRequest struct {
+	StartedAt string `json:"started_at" example:"2026-01-05T09:00:00" binding:"required"`
+	EndedAt   string `json:"ended_at" example:"2026-01-05T10:30:00" binding:"required"`
+	Note      string `json:"note" example:"串接金流" binding:"max=255"`
+}
+
+// TodoTimeEntryUpdateRequest 計時中的紀錄可以不給 ended_at，給了則一併停止
+type TodoTimeEntryUpdateRequest struct {
+	StartedAt string `json:"started_at" example:"2026-01-05T09:00:00" binding:"required"`
+	EndedAt   string `json:"ended_at" example:"2026-01-05T10:30:00"`
+	Note      string `json:"note" example:"串接金流" binding:"max=255"`
+}
+
+type TodoTimeEntryQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+// TodoTimeSummaryQuery from、to 為日期（含當天）
+type TodoTimeSummaryQuery struct {
+	GroupBy string `form:"group_by" example:"list" binding:"required,oneof=user day detail list type"`
+	UserID  int    `form:"user_id" example:"1" binding:"omitempty,min=1"`
+	ListID  int    `form:"list_id" example:"2" binding:"omitempty,min=1"`
+	TypeID  int    `form:"type_id" example:"1" binding:"omitempty,min=1"`
+	From    string `form:"from" example:"2026-01-01"`
+	To      string `form:"to" example:"2026-01-31"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type TodoVersionQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+type TodoVersionDiffQuery struct {
+	From int `form:"from" example:"1" binding:"required,min=1"`
+	To   int `form:"to" example:"2" binding:"required,min=1"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+// TodoWebhookRequest to_do_list_id 不給時接收所有 TodoList 的事件與成員角色變更
+type TodoWebhookRequest struct {
+	TodoListID  int      `json:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+	URL         string   `json:"url" example:"https://example.com/hooks/todolist" binding:"required,max=2048"`
+	Events      []string `json:"events" example:"detail.updated" binding:"required,min=1,dive,oneof=list.created list.updated list.deleted detail.created detail.updated detail.status_changed detail.deleted detail.assigned detail.unassigned comment.created comment.updated comment.deleted member.role_changed"`
+	Description string   `json:"description" example:"同步到 CI" binding:"max=255"`
+}
+
+// TodoWebhookUpdateRequest 修改時不能更換 TodoList
+type TodoWebhookUpdateRequest struct {
+	URL         string   `json:"url" example:"https://example.com/hooks/todolist" binding:"required,max=2048"`
+	Events      []string `json:"events" example:"detail.updated" binding:"required,min=1,dive,oneof=list.created list.updated list.deleted detail.created detail.updated detail.status_changed detail.deleted detail.assigned detail.unassigned comment.created comment.updated comment.deleted member.role_changed"`
+	Description string   `json:"description" example:"同步到 CI" binding:"max=255"`
+	Active      *bool    `json:"active" example:"true" binding:"required"`
+}
+
+type TodoWebhookQuery struct {
+	TodoListID int `form:"to_do_list_id" example:"2" binding:"omitempty,min=1"`
+}
+
+type TodoWebhookDeliveriesQuery struct {
+	Page     int `form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" example:"20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/pkg/storage"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// attachmentGCBatchSize 每次最多清除的 blob 數
+const attachmentGCBatchSize = 100
+
+// StartAttachmentGCJob 定期清除已沒有附件引用的 blob，ctx 取消時停止。
+// 每個 blob 都在各自的交易中以 FOR UPDATE 鎖定並重新確認，和上傳同時進行也不會誤刪。
+func StartAttachmentGCJob(ctx context.Context, db *gorm.DB, store storage.Storage, interval time.Duration) {
+	service := services.NewTodoAttachmentService(ctx, repositories.NewTodoAttachmentRepository(), repositories.NewAuthRepository(), store)
+
+	run := func() {
+		for {
+			removed, err := service.CollectGarbage(db, attachmentGCBatchSize)
+			if err != nil {
+				utils.Logger.Error("附件清除排程失敗", zap.Error(err))
+				return
+			}
+			if removed > 0 {
+				utils.Logger.Info("已清除未使用的附件內容", zap.Int("count", removed))
+			}
+			// 還有下一批才繼續
+			if removed < attachmentGCBatchSize {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newAutomationService 建立自動化規則服務；動作與使用者操作相同，會寫入領域事件、自動關注、檢查看板規則並產生下一次週期任務
+func newAutomationService(ctx context.Context) *services.TodoAutomationService {
+	return services.NewTodoAutomationService(ctx, repositories.NewTodoAutomationRepository(), repositories.NewAuthRepository()).
+		WithActions(func(ctx context.Context) *services.AutomationActions {
+			outbox := services.NewTodoOutboxService(ctx, repositories.NewTodoOutboxRepository())
+			watchers := services.NewTodoWatcherService(ctx, repositories.NewTodoWatcherRepository())
+			recurrence := services.NewTodoRecurrenceService(ctx, repositories.NewTodoRecurrenceRepository()).
+				WithChecklist(services.NewTodoChecklistService(ctx, repositories.NewTodoChecklistRepository()))
+			return &services.AutomationActions{
+				Details: services.NewTodoListDetailsService(ctx, repositories.NewTodoListDetailsRepository()).
+					WithEvents(outbox).
+					WithWatchers(watchers).
+					WithRecurrence(recurrence).
+					WithBoard(services.NewTodoBoardService(ctx, repositories.NewTodoBoardRepository())),
+				Comments: services.NewTodoCommentService(ctx, repositories.NewTodoCommentRepository(), repositories.NewAuthRepository()).
+					WithEvents(outbox).
+					WithWatchers(watchers),
+				Webhooks: services.NewTodoWebhookService(ctx, repositories.NewTodoWebhookRepository()),
+			}
+		})
+}
+
+// StartAutomationJob 定期為逾期的項目執行逾期觸發的規則，ctx 取消時停止；其他觸發條件由事件訂閱者處理。
+// 執行前先寫入以項目與到期時間為鍵的紀錄，多個 instance 同時執行也只會執行一次
+func StartAutomationJob(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	service := newAutomationService(ctx)
+
+	run := func() {
+		if _, err := service.RunDuePassed(db, time.Now()); err != nil {
+			utils.Logger.Error("逾期自動化規則執行失敗", zap.Error(err))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/pkg/mail"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// emailDigestInterval 檢查是否有使用者到了摘要寄送時間的間隔
+const emailDigestInterval = time.Minute
+
+// newNotificationService 建立通知服務，新留言依關注者通知；有設定 SMTP 時一併排入 email
+func newNotificationService(ctx context.Context, mailer mail.Sender) *services.TodoNotificationService {
+	service := services.NewTodoNotificationService(ctx, repositories.NewTodoNotificationRepository(),
+		services.NewTodoWatcherService(ctx, repositories.NewTodoWatcherRepository()))
+	if mailer != nil {
+		service.WithEmail(services.NewTodoEmailService(ctx, repositories.NewTodoEmailRepository()))
+	}
+	return service
+}
+
+// StartEmailJob 定期寄出到期的信件並排入摘要，ctx 取消時停止。
+// 信件與摘要都以 FOR UPDATE SKIP LOCKED 領取，多個 instance 同時執行也不會重複寄送
+func StartEmailJob(ctx context.Context, db *gorm.DB, mailer mail.Sender, interval time.Duration) {
+	service := services.NewTodoEmailService(ctx, repositories.NewTodoEmailRepository()).WithSender(mailer)
+
+	send := func() {
+		for {
+			processed, err := service.RunNext(db)
+			if err != nil {
+				utils.Logger.Error("email 寄送失敗", zap.Error(err))
+			}
+			// 沒有到期的信件才停止
+			if !processed {
+				return
+			}
+		}
+	}
+
+	digest := func() {
+		for {
+			processed, err := service.RunDigest(db, time.Now())
+			if err != nil {
+				utils.Logger.Error("email 摘要排程失敗", zap.Error(err))
+				return
+			}
+			if !processed {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		digestTicker := time.NewTicker(emailDigestInterval)
+		defer digestTicker.Stop()
+
+		digest()
+		send()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				send()
+			case <-digestTicker.C:
+				digest()
+				send()
+			}
+		}
+	}()
+}
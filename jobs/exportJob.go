@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/pkg/storage"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartExportJob 定期執行待處理的背景匯出，ctx 取消時停止。
+// 工作以 FOR UPDATE SKIP LOCKED 領取，多個 instance 同時執行也不會重複處理同一個工作。
+func StartExportJob(ctx context.Context, db *gorm.DB, store storage.Storage, interval time.Duration) {
+	service := services.NewTodoExportService(ctx, repositories.NewTodoExportJobRepository(), repositories.NewTodoListRepository(), repositories.NewAuthRepository(), store)
+
+	run := func() {
+		for {
+			processed, err := service.RunNext(db)
+			if err != nil {
+				utils.Logger.Error("匯出排程失敗", zap.Error(err))
+			}
+			// 沒有待處理的工作才停止
+			if !processed {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/pkg/events"
+	"todolist/pkg/mail"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// outboxRetention 已送出的事件保留的時間，之後刪除
+const outboxRetention = 7 * 24 * time.Hour
+
+// NewEventBus 建立行程內的事件訂閱者；訂閱者需以事件 ID 處理重複送達。mailer 為 nil 時不寄送 email 通知
+func NewEventBus(ctx context.Context, db *gorm.DB, mailer mail.Sender) *events.Bus {
+	bus := events.NewBus()
+
+	webhooks := services.NewTodoWebhookService(ctx, repositories.NewTodoWebhookRepository())
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		return webhooks.HandleEvent(db, e)
+	})
+
+	notifications := newNotificationService(ctx, mailer)
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		return notifications.HandleEvent(db, e)
+	})
+
+	automations := newAutomationService(ctx)
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		return automations.HandleEvent(db, e)
+	})
+
+	return bus
+}
+
+// watcherBroker 送出前在事件附上關注者
+type watcherBroker struct {
+	db       *gorm.DB
+	watchers *services.TodoWatcherService
+	next     events.Broker
+}
+
+func (b *watcherBroker) Publish(ctx context.Context, e events.Event) error {
+	ids, err := b.watchers.Recipients(b.db, e)
+	if err != nil {
+		return err
+	}
+	e.WatcherIDs = ids
+	return b.next.Publish(ctx, e)
+}
+
+// WithWatchers 讓 broker 收到的事件帶有關注所屬 TodoList 或項目的使用者（watcher_ids）
+func WithWatchers(ctx context.Context, db *gorm.DB, broker events.Broker) events.Broker {
+	return &watcherBroker{
+		db:       db,
+		watchers: services.NewTodoWatcherService(ctx, repositories.NewTodoWatcherRepository()),
+		next:     broker,
+	}
+}
+
+// StartOutboxRelay 定期把 commit 後的領域事件交給 broker，ctx 取消時停止；每小時刪除超過保留期限的已送出事件。
+// 事件以 FOR UPDATE SKIP LOCKED 領取，多個 instance 同時執行也不會同時送出同一個事件
+func StartOutboxRelay(ctx context.Context, db *gorm.DB, broker events.Broker, interval time.Duration) {
+	service := services.NewTodoOutboxService(ctx, repositories.NewTodoOutboxRepository()).WithBroker(broker)
+
+	run := func() {
+		for {
+			processed, err := service.RunNext(db)
+			if err != nil {
+				utils.Logger.Error("事件送出失敗", zap.Error(err))
+			}
+			// 沒有到期的事件才停止
+			if !processed {
+				return
+			}
+		}
+	}
+
+	purge := func() {
+		if _, err := service.Purge(db, time.Now().Add(-outboxRetention)); err != nil {
+			utils.Logger.Error("刪除已送出的事件失敗", zap.Error(err))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		purgeTicker := time.NewTicker(time.Hour)
+		defer purgeTicker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			case <-purgeTicker.C:
+				purge()
+			}
+		}
+	}()
+}
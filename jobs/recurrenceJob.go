@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// recurrenceBatchSize 每次最多處理的到期規則數
+const recurrenceBatchSize = 100
+
+// StartRecurrenceJob 定期為到期的週期規則產生任務，ctx 取消時停止。
+// 每條規則都在各自的交易中以 FOR UPDATE 鎖定後處理，多個 instance 同時執行也不會重複產生。
+func StartRecurrenceJob(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	service := services.NewTodoRecurrenceService(ctx, repositories.NewTodoRecurrenceRepository()).
+		WithChecklist(services.NewTodoChecklistService(ctx, repositories.NewTodoChecklistRepository()))
+
+	run := func() {
+		for {
+			processed, err := service.GenerateDue(db, time.Now(), recurrenceBatchSize)
+			if err != nil {
+				utils.Logger.Error("週期任務排程失敗", zap.Error(err))
+				return
+			}
+			if processed > 0 {
+				utils.Logger.Info("已產生週期任務", zap.Int("count", processed))
+			}
+			// 還有下一批才繼續
+			if processed < recurrenceBatchSize {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// 啟動時先補上停機期間到期的任務
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
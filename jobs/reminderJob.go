@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/pkg/mail"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartReminderJob 定期依提醒規則通知即將到期、到期與逾期的任務，ctx 取消時停止；mailer 不為 nil 時一併寄送 email。
+// 以資料庫租約確保同一時間只有一個 instance 執行，送出的提醒另外記錄，不會重複提醒
+func StartReminderJob(ctx context.Context, db *gorm.DB, mailer mail.Sender, interval time.Duration) {
+	service := services.NewTodoReminderService(ctx, repositories.NewTodoReminderRepository(), newNotificationService(ctx, mailer))
+
+	run := func() {
+		if _, err := service.RunScan(db, time.Now()); err != nil {
+			utils.Logger.Error("到期提醒排程失敗", zap.Error(err))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartWebhookJob 定期送出到期的 webhook 紀錄，ctx 取消時停止。
+// 紀錄以 FOR UPDATE SKIP LOCKED 領取，多個 instance 同時執行也不會重複送出同一筆
+func StartWebhookJob(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	service := services.NewTodoWebhookService(ctx, repositories.NewTodoWebhookRepository())
+
+	run := func() {
+		for {
+			processed, err := service.RunNext(db)
+			if err != nil {
+				utils.Logger.Error("webhook 排程失敗", zap.Error(err))
+			}
+			// 沒有到期的紀錄才停止
+			if !processed {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
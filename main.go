@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,7 +12,9 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"todolist/config"
+	"todolist/jobs"
 	"todolist/middleware"
+	"todolist/pkg/events"
 	"todolist/routes"
 	"todolist/utils"
 
@@ -30,6 +34,23 @@ func main() {
 	utils.Logger.Info("Logger 初始化成功")
 
 	config.ConnectDatabase()
+	config.ConnectStorage()
+	config.ConnectRealtime()
+	config.ConnectMail()
+
+	// 背景排程
+	jobs.StartRecurrenceJob(context.Background(), config.DB, time.Minute)
+	jobs.StartAttachmentGCJob(context.Background(), config.DB, config.Storage, time.Hour)
+	jobs.StartExportJob(context.Background(), config.DB, config.Storage, 10*time.Second)
+	// 事件附上關注者後同時交給行程內的訂閱者與即時更新的 Hub
+	broker := jobs.WithWatchers(context.Background(), config.DB, events.Fanout{jobs.NewEventBus(context.Background(), config.DB, config.Mailer), config.Realtime})
+	jobs.StartOutboxRelay(context.Background(), config.DB, broker, time.Second)
+	jobs.StartWebhookJob(context.Background(), config.DB, 5*time.Second)
+	jobs.StartReminderJob(context.Background(), config.DB, config.Mailer, time.Minute)
+	jobs.StartAutomationJob(context.Background(), config.DB, time.Minute)
+	if config.Mailer != nil {
+		jobs.StartEmailJob(context.Background(), config.DB, config.Mailer, 5*time.Second)
+	}
 
 	r := gin.Default()
 	r.Use(middleware.RecoveryMiddleware())
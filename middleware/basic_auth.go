@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"todolist/config"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CalDAVAuthMiddleware 以 HTTP Basic 驗證 CalDAV 請求，帳號為使用者帳號、密碼為 CalDAV 憑證；
+// 日曆程式無法取得 JWT，驗證失敗時回傳 WWW-Authenticate 讓它詢問帳號密碼
+func CalDAVAuthMiddleware(realm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		account, token, ok := c.Request.BasicAuth()
+		if !ok {
+			c.Header("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		service := services.NewTodoCalendarService(
+			c.Request.Context(),
+			repositories.NewTodoCalendarRepository(),
+			repositories.NewAuthRepository(),
+			nil,
+		)
+		user, err := service.AuthenticateBasic(config.DB, account, token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		utils.Logger.Info("CalDAV驗證成功",
+			zap.Int("user_id", user.ID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("client_ip", c.ClientIP()),
+		)
+
+		// 與 JWT 相同，以 float64 放入 context
+		ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, float64(user.ID))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
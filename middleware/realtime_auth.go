@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"todolist/config"
+	"todolist/repositories"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RealtimeAuthMiddleware 瀏覽器的 EventSource 與 WebSocket 無法自訂 header，改以 ticket 查詢參數帶上
+// POST /api/realtime/ticket 取得的一次性票證；沒有 ticket 時與其他 API 相同以 Authorization header 的 JWT 驗證。
+// 網址會被寫入存取紀錄，長效的 JWT 不可放在網址中
+func RealtimeAuthMiddleware() gin.HandlerFunc {
+	jwtAuth := JwtAuthMiddleware()
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			jwtAuth(c)
+			return
+		}
+
+		service := services.NewRealtimeTicketService(c.Request.Context(), repositories.NewTodoRealtimeTicketRepository())
+		userID, err := service.Redeem(config.DB, ticket)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, services.ErrInvalidRealtimeTicket) {
+				status = http.StatusUnauthorized
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		utils.Logger.Info("即時更新票證驗證成功",
+			zap.Int("user_id", userID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("client_ip", c.ClientIP()),
+		)
+
+		// 與 JWT 相同，以 float64 放入 context
+		ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, float64(userID))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -72,6 +72,21 @@ func (mr *MockTodoListRepositoryMockRecorder) FindAllWithQuery(ctx, db, page, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithQuery", reflect.TypeOf((*MockTodoListRepository)(nil).FindAllWithQuery), varargs...)
 }
 
+// FindBatch mocks base method.
+func (m *MockTodoListRepository) FindBatch(ctx context.Context, db *gorm.DB, afterID, limit int, opts *base.FindOptions) ([]*models.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBatch", ctx, db, afterID, limit, opts)
+	ret0, _ := ret[0].([]*models.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBatch indicates an expected call of FindBatch.
+func (mr *MockTodoListRepositoryMockRecorder) FindBatch(ctx, db, afterID, limit, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatch", reflect.TypeOf((*MockTodoListRepository)(nil).FindBatch), ctx, db, afterID, limit, opts)
+}
+
 // FindByID mocks base method.
 func (m *MockTodoListRepository) FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoList, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +122,80 @@ func (mr *MockTodoListRepositoryMockRecorder) IsNameExist(ctx, db, name, exclude
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNameExist", reflect.TypeOf((*MockTodoListRepository)(nil).IsNameExist), ctx, db, name, excludeID)
 }
 
+// LastPosition mocks base method.
+func (m *MockTodoListRepository) LastPosition(ctx context.Context, db *gorm.DB, excludeID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastPosition", ctx, db, excludeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastPosition indicates an expected call of LastPosition.
+func (mr *MockTodoListRepositoryMockRecorder) LastPosition(ctx, db, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastPosition", reflect.TypeOf((*MockTodoListRepository)(nil).LastPosition), ctx, db, excludeID)
+}
+
+// LockByID mocks base method.
+func (m *MockTodoListRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", ctx, db, id)
+	ret0, _ := ret[0].(*models.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockTodoListRepositoryMockRecorder) LockByID(ctx, db, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockTodoListRepository)(nil).LockByID), ctx, db, id)
+}
+
+// NextPosition mocks base method.
+func (m *MockTodoListRepository) NextPosition(ctx context.Context, db *gorm.DB, after string, excludeID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextPosition", ctx, db, after, excludeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextPosition indicates an expected call of NextPosition.
+func (mr *MockTodoListRepositoryMockRecorder) NextPosition(ctx, db, after, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextPosition", reflect.TypeOf((*MockTodoListRepository)(nil).NextPosition), ctx, db, after, excludeID)
+}
+
+// PrevPosition mocks base method.
+func (m *MockTodoListRepository) PrevPosition(ctx context.Context, db *gorm.DB, before string, excludeID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrevPosition", ctx, db, before, excludeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrevPosition indicates an expected call of PrevPosition.
+func (mr *MockTodoListRepositoryMockRecorder) PrevPosition(ctx, db, before, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrevPosition", reflect.TypeOf((*MockTodoListRepository)(nil).PrevPosition), ctx, db, before, excludeID)
+}
+
+// RebalancePositions mocks base method.
+func (m *MockTodoListRepository) RebalancePositions(ctx context.Context, db *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebalancePositions", ctx, db)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebalancePositions indicates an expected call of RebalancePositions.
+func (mr *MockTodoListRepositoryMockRecorder) RebalancePositions(ctx, db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebalancePositions", reflect.TypeOf((*MockTodoListRepository)(nil).RebalancePositions), ctx, db)
+}
+
 // SoftDelete mocks base method.
 func (m *MockTodoListRepository) SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoList) error {
 	m.ctrl.T.Helper()
@@ -48,3 +48,11 @@ func (m *OperatorModel) BeforeDelete(tx *gorm.DB) (err error) {
 	}
 	return nil
 }
+
+// ModifiedBy 最後修改者，沒有修改過時為建立者
+func (m OperatorModel) ModifiedBy() *uint {
+	if m.UpdatedBy != nil {
+		return m.UpdatedBy
+	}
+	return m.CreatedBy
+}
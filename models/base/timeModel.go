@@ -11,3 +11,8 @@ type TimeModel struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swaggerignore:"true"`
 }
+
+// ModifiedAt 最後修改時間
+func (m TimeModel) ModifiedAt() time.Time {
+	return m.UpdatedAt
+}
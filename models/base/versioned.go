@@ -0,0 +1,13 @@
+package base
+
+import "time"
+
+// Versioned 需要保留版本紀錄的模型，BaseRepository.Update 前後會各保存一次快照
+type Versioned interface {
+	// VersionEntity 回傳版本紀錄的類型與 ID
+	VersionEntity() (string, int)
+	// VersionSnapshot 回傳納入版本的欄位，key 與 json 標籤相同，排序位置等不算在內
+	VersionSnapshot() map[string]interface{}
+	ModifiedAt() time.Time
+	ModifiedBy() *uint
+}
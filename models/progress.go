@@ -0,0 +1,16 @@
+package models
+
+// Progress 完成進度，例如 3/5 代表 5 項中已完成 3 項
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// Add 累加另一個進度
+func (p *Progress) Add(other *Progress) {
+	if other == nil {
+		return
+	}
+	p.Done += other.Done
+	p.Total += other.Total
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// 報表的時間區間
+const (
+	ReportIntervalDay  = "day"
+	ReportIntervalWeek = "week"
+)
+
+// ReportFilter 報表共用的篩選條件，0 與 nil 表示不篩選；To 為不含的上限
+type ReportFilter struct {
+	ListID int
+	TypeID int
+	From   *time.Time
+	To     *time.Time
+}
+
+// PeriodCount 一個區間的筆數
+type PeriodCount struct {
+	Period string
+	Total  int64
+}
+
+// CompletedTiming 已完成任務的時間點
+type CompletedTiming struct {
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt time.Time
+}
+
+// ThroughputRow 一個區間內新增與完成的任務數；週的 Period 為該週週一
+type ThroughputRow struct {
+	Period    string `json:"period" example:"2026-01-05"`
+	Created   int64  `json:"created" example:"12"`
+	Completed int64  `json:"completed" example:"9"`
+}
+
+// Percentiles 耗時的百分位數（秒）
+type Percentiles struct {
+	P50 int64 `json:"p50" example:"86400"`
+	P75 int64 `json:"p75" example:"172800"`
+	P90 int64 `json:"p90" example:"345600"`
+	P95 int64 `json:"p95" example:"432000"`
+}
+
+// CycleTimeReport lead time 為建立到完成，cycle time 為第一次進行中到完成；沒有進行中紀錄的任務不計入 cycle time
+type CycleTimeReport struct {
+	Completed      int64       `json:"completed" example:"9"`
+	LeadTime       Percentiles `json:"lead_time"`
+	CycleTimeCount int64       `json:"cycle_time_count" example:"7"`
+	CycleTime      Percentiles `json:"cycle_time"`
+}
+
+// WorkloadRow 指派給使用者且尚未完成的任務數
+type WorkloadRow struct {
+	UserID     int    `json:"user_id" example:"1"`
+	Account    string `json:"account" example:"alice"`
+	Todo       int64  `json:"todo" example:"3"`
+	InProgress int64  `json:"in_progress" example:"2"`
+	Open       int64  `json:"open" example:"5"`
+}
+
+// TypeBreakdownRow 依 TodoTypes 分類的任務數；Created、Completed 依期間計算，Open 為目前尚未完成
+type TypeBreakdownRow struct {
+	TypeID    int    `json:"type_id" example:"1"`
+	Name      string `json:"name" example:"工作"`
+	Created   int64  `json:"created" example:"12"`
+	Completed int64  `json:"completed" example:"9"`
+	Open      int64  `json:"open" example:"4"`
+}
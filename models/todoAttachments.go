@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// TodoBlobs 附件實際內容，相同 sha256 的檔案只存一份
+type TodoBlobs struct {
+	ID          int       `gorm:"primaryKey" json:"id"`
+	SHA256      string    `gorm:"column:sha256;type:char(64);not null" json:"sha256"`
+	Size        int64     `gorm:"not null" json:"size"`
+	ContentType string    `gorm:"type:varchar(255);not null" json:"content_type"`
+	StorageKey  string    `gorm:"type:varchar(255);not null" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (TodoBlobs) TableName() string {
+	return "to_do_blobs"
+}
+
+// TodoAttachments 掛在 TodoListDetails 或留言上的附件，兩者只會有一個
+type TodoAttachments struct {
+	ID               int    `gorm:"primaryKey" json:"id"`
+	BlobID           int    `gorm:"column:blob_id;not null" json:"-"`
+	TodoListDetailID *int   `gorm:"column:to_do_list_detail_id" json:"to_do_list_detail_id"`
+	CommentID        *int   `gorm:"column:comment_id" json:"comment_id"`
+	FileName         string `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType      string `gorm:"type:varchar(255);not null" json:"content_type"`
+	Size             int64  `gorm:"not null" json:"size"`
+
+	Blob *TodoBlobs `gorm:"foreignKey:BlobID" json:"-"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoAttachments) TableName() string {
+	return "to_do_attachments"
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// 自動化規則的觸發條件；due_passed 由排程在項目逾期時觸發，其餘對應同名的領域事件
+const (
+	AutomationTriggerDetailCreated = EventDetailCreated
+	AutomationTriggerStatusChanged = EventDetailStatusChanged
+	AutomationTriggerAssigned      = EventDetailAssigned
+	AutomationTriggerDuePassed     = "detail.due_passed"
+)
+
+// 自動化規則的動作
+const (
+	AutomationActionAssignUser  = "assign_user"
+	AutomationActionSetStatus   = "set_status"
+	AutomationActionAddComment  = "add_comment"
+	AutomationActionMoveToList  = "move_to_list"
+	AutomationActionCallWebhook = "call_webhook"
+)
+
+// 執行結果
+const (
+	AutomationExecutionSucceeded = "succeeded"
+	AutomationExecutionFailed    = "failed"
+	// AutomationExecutionSkipped 超過連鎖層數上限，沒有執行動作
+	AutomationExecutionSkipped = "skipped"
+)
+
+// EventAutomationTriggered call_webhook 動作送給 webhook 的事件類型
+const EventAutomationTriggered = "automation.triggered"
+
+// AutomationConditions 觸發後還需符合的條件，全部符合才執行；同一個條件內符合任一個即可，空的條件不檢查
+type AutomationConditions struct {
+	// TypeIDs 項目所屬 TodoList 的類型
+	TypeIDs     []int `json:"type_ids,omitempty"`
+	LabelIDs    []int `json:"label_ids,omitempty"`
+	AssigneeIDs []int `json:"assignee_ids,omitempty"`
+}
+
+// AutomationAction 要執行的動作，依 Type 使用對應的欄位
+type AutomationAction struct {
+	Type string `json:"type"`
+	// UserID assign_user 加入的負責人
+	UserID int `json:"user_id,omitempty"`
+	// Status set_status 改成的狀態
+	Status string `json:"status,omitempty"`
+	// Body add_comment 的留言內容
+	Body string `json:"body,omitempty"`
+	// TodoListID move_to_list 移到的 TodoList，排在最後
+	TodoListID int `json:"to_do_list_id,omitempty"`
+	// WebhookID call_webhook 送出的 webhook，不需要訂閱觸發的事件
+	WebhookID int `json:"webhook_id,omitempty"`
+}
+
+// TodoAutomationRules 自動化規則；TodoListID 有值時只處理該 TodoList 的項目，否則處理所有 TodoList（只有 Admin 可以建立）。
+// 動作以建立者的身分執行
+type TodoAutomationRules struct {
+	ID         int                  `gorm:"primaryKey" json:"id"`
+	TodoListID *int                 `gorm:"column:to_do_list_id" json:"to_do_list_id"`
+	Name       string               `gorm:"type:varchar(255);not null" json:"name"`
+	Trigger    string               `gorm:"column:trigger;type:varchar(50);not null" json:"trigger"`
+	Conditions AutomationConditions `gorm:"type:json;serializer:json;not null" json:"conditions"`
+	Actions    []AutomationAction   `gorm:"type:json;serializer:json;not null" json:"actions"`
+	Active     bool                 `gorm:"not null;default:true" json:"active"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoAutomationRules) TableName() string {
+	return "to_do_automation_rules"
+}
+
+// TodoAutomationExecutions 規則的執行紀錄；(rule_id, trigger_key) 唯一，同一個觸發重送時不會再執行
+type TodoAutomationExecutions struct {
+	ID     int `gorm:"primaryKey" json:"id"`
+	RuleID int `gorm:"column:rule_id;not null" json:"rule_id"`
+	// TriggerKey 領域事件觸發時為事件 ID，逾期觸發時為 due:<項目 ID>:<到期時間>
+	TriggerKey       string   `gorm:"type:varchar(64);not null" json:"trigger_key"`
+	Trigger          string   `gorm:"column:trigger;type:varchar(50);not null" json:"trigger"`
+	TodoListDetailID *int     `gorm:"column:to_do_list_detail_id" json:"to_do_list_detail_id"`
+	Depth            int      `gorm:"not null;default:0" json:"depth"`
+	Status           string   `gorm:"type:varchar(20);not null" json:"status"`
+	Results          []string `gorm:"type:json;serializer:json;not null" json:"results"`
+	// Error 失敗時所有動作都會復原
+	Error     string    `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (TodoAutomationExecutions) TableName() string {
+	return "to_do_automation_executions"
+}
+
+// AutomationTarget 規則處理的項目與判斷條件需要的資料
+type AutomationTarget struct {
+	Detail *TodoListDetails
+	TypeID int
+}
+
+// AutomationCheck 試跑時單一條件的檢查結果
+type AutomationCheck struct {
+	Condition string `json:"condition"`
+	Expected  []int  `json:"expected"`
+	Actual    []int  `json:"actual"`
+	Passed    bool   `json:"passed"`
+}
+
+// AutomationTestResult 試跑的結果，不會執行任何動作
+type AutomationTestResult struct {
+	RuleID           int `json:"rule_id"`
+	TodoListDetailID int `json:"to_do_list_detail_id"`
+	// InScope 項目是否屬於規則的 TodoList
+	InScope bool              `json:"in_scope"`
+	Checks  []AutomationCheck `json:"checks"`
+	Matched bool              `json:"matched"`
+	// Actions 符合時會執行的動作說明
+	Actions []string `json:"actions"`
+}
+
+// AutomationWebhookData call_webhook 送出的內容
+type AutomationWebhookData struct {
+	RuleID           int              `json:"rule_id"`
+	RuleName         string           `json:"rule_name"`
+	Trigger          string           `json:"trigger"`
+	TriggerKey       string           `json:"trigger_key"`
+	TodoListDetailID int              `json:"to_do_list_detail_id"`
+	Detail           *TodoListDetails `json:"detail"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// DetailStatuses 看板欄位可對應的狀態
+var DetailStatuses = []string{DetailStatusTodo, DetailStatusInProgress, DetailStatusDone}
+
+// TodoBoardColumns 看板欄位，每個欄位對應一個 TodoListDetails 狀態
+type TodoBoardColumns struct {
+	ID         int    `gorm:"primaryKey" json:"id"`
+	TodoListID int    `gorm:"column:to_do_list_id;not null" json:"to_do_list_id"`
+	Name       string `gorm:"type:varchar(255);not null" json:"name"`
+	Status     string `gorm:"type:varchar(20);not null" json:"status"`
+	// WIPLimit 欄位內最多可放幾張卡片，nil 表示不限制
+	WIPLimit  *int `gorm:"column:wip_limit" json:"wip_limit"`
+	SortOrder int  `gorm:"column:sort_order;not null;default:0" json:"sort_order"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoBoardColumns) TableName() string {
+	return "to_do_board_columns"
+}
+
+// TodoBoardTransitions 允許的狀態轉換；TodoList 沒有設定任何轉換時不限制
+type TodoBoardTransitions struct {
+	TodoListID int       `gorm:"primaryKey;column:to_do_list_id;autoIncrement:false" json:"to_do_list_id"`
+	FromStatus string    `gorm:"primaryKey;column:from_status" json:"from_status"`
+	ToStatus   string    `gorm:"primaryKey;column:to_status" json:"to_status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (TodoBoardTransitions) TableName() string {
+	return "to_do_board_transitions"
+}
+
+// BoardColumn 看板上的一個欄位與分頁後的卡片
+type BoardColumn struct {
+	TodoBoardColumns
+	Count    int64             `json:"count"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+	Cards    []TodoListDetails `json:"cards"`
+}
+
+// Board 一個 TodoList 的看板
+type Board struct {
+	TodoListID  int                    `json:"to_do_list_id"`
+	Columns     []BoardColumn          `json:"columns"`
+	Transitions []TodoBoardTransitions `json:"transitions"`
+}
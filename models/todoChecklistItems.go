@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+type TodoChecklistItems struct {
+	ID               int    `gorm:"primaryKey" json:"id"`
+	TodoListDetailID int    `gorm:"column:to_do_list_detail_id;not null" json:"to_do_list_detail_id"`
+	Name             string `gorm:"type:varchar(255);not null" json:"name"`
+	IsDone           bool   `gorm:"column:is_done;not null;default:false" json:"is_done"`
+	SortOrder        int    `gorm:"column:sort_order;not null;default:0" json:"sort_order"`
+	// MarkdownIndex 由說明中第幾個 - [ ] 產生，手動新增的項目為 nil
+	MarkdownIndex *int       `gorm:"column:markdown_index" json:"markdown_index"`
+	DoneAt        *time.Time `gorm:"column:done_at" json:"done_at"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoChecklistItems) TableName() string {
+	return "to_do_checklist_items"
+}
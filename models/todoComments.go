@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// TodoComments TodoListDetails 上的留言；ParentID 為 nil 的是討論串的第一則，回覆都掛在第一則底下
+type TodoComments struct {
+	ID               int        `gorm:"primaryKey" json:"id"`
+	TodoListDetailID int        `gorm:"column:to_do_list_detail_id;not null" json:"to_do_list_detail_id"`
+	ParentID         *int       `gorm:"column:parent_id" json:"parent_id"`
+	Body             string     `gorm:"type:text;not null" json:"body"`
+	EditedAt         *time.Time `gorm:"column:edited_at" json:"edited_at"`
+	// Deleted 已刪除但仍有回覆的第一則，保留在討論串中，內容與提及會清空
+	Deleted bool `gorm:"-" json:"deleted"`
+
+	// Author 由 created_by 對應的使用者，另外批次查詢後填入
+	Author   *User          `gorm:"-" json:"author,omitempty"`
+	Mentions []User         `gorm:"many2many:to_do_comment_mentions;joinForeignKey:CommentID;joinReferences:UserID" json:"mentions"`
+	Replies  []TodoComments `gorm:"foreignKey:ParentID" json:"replies,omitempty"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoComments) TableName() string {
+	return "to_do_comments"
+}
+
+// TodoCommentRevisions 留言每次修改前的內容
+type TodoCommentRevisions struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	CommentID int       `gorm:"column:comment_id;not null" json:"comment_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy *uint     `gorm:"column:created_by" json:"created_by"`
+}
+
+func (TodoCommentRevisions) TableName() string {
+	return "to_do_comment_revisions"
+}
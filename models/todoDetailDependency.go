@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TodoDetailDependency 代表「BlockerID 擋住 BlockedID」，BlockerID 完成前 BlockedID 不能完成
+type TodoDetailDependency struct {
+	BlockerID int       `gorm:"primaryKey;column:blocker_id;autoIncrement:false" json:"blocker_id"`
+	BlockedID int       `gorm:"primaryKey;column:blocked_id;autoIncrement:false" json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (TodoDetailDependency) TableName() string {
+	return "to_do_detail_dependencies"
+}
+
+// DependencyGraphNode 相依圖上的節點
+type DependencyGraphNode struct {
+	ID         int    `json:"id"`
+	TodoListID int    `json:"to_do_list_id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	External   bool   `json:"external"` // 不屬於目前 TodoList 的節點
+}
+
+// DependencyGraphEdge 相依圖上的邊，From 擋住 To
+type DependencyGraphEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// DependencyGraph 一個 TodoList 的相依圖
+type DependencyGraph struct {
+	TodoListID int                   `json:"to_do_list_id"`
+	Nodes      []DependencyGraphNode `json:"nodes"`
+	Edges      []DependencyGraphEdge `json:"edges"`
+}
+
+// DOT 以 Graphviz DOT 格式輸出相依圖
+func (g *DependencyGraph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph todo_list_%d {\n", g.TodoListID)
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%q", fmt.Sprintf("#%d %s", n.ID, n.Name))}
+		if n.Status == DetailStatusDone {
+			attrs = append(attrs, "style=filled", `fillcolor="#d4edda"`)
+		}
+		if n.External {
+			attrs = append(attrs, "shape=box", "style=dashed")
+		}
+		fmt.Fprintf(&b, "  d%d [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  d%d -> d%d;\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
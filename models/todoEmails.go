@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// 摘要信頻率
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// 信件種類
+const (
+	EmailKindAssigned  = "assigned"
+	EmailKindDueSoon   = "due_soon"
+	EmailKindOverdue   = "overdue"
+	EmailKindEscalated = "escalated"
+	EmailKindDigest    = "digest"
+)
+
+// 寄送狀態
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSending = "sending"
+	EmailStatusSent    = "sent"
+	// EmailStatusDead 重試次數用完，不再寄送
+	EmailStatusDead = "dead"
+)
+
+// TodoEmailSettings 使用者的 email 設定；沒有紀錄或 UnsubscribedAt 有值時不寄送任何信件
+type TodoEmailSettings struct {
+	ID             int    `gorm:"primaryKey" json:"id"`
+	UserID         int    `gorm:"column:user_id;not null" json:"user_id"`
+	Email          string `gorm:"type:varchar(255);not null" json:"email"`
+	Locale         string `gorm:"type:varchar(10);not null;default:zh-TW" json:"locale"`
+	NotifyAssigned bool   `gorm:"not null;default:true" json:"notify_assigned"`
+	NotifyDue      bool   `gorm:"not null;default:true" json:"notify_due"`
+	Digest         string `gorm:"type:varchar(10);not null;default:off" json:"digest"`
+	// UnsubscribeToken 信件中取消訂閱連結使用，不需要登入
+	UnsubscribeToken string     `gorm:"type:char(32);not null" json:"-"`
+	UnsubscribedAt   *time.Time `gorm:"column:unsubscribed_at" json:"unsubscribed_at"`
+	LastDigestAt     *time.Time `gorm:"column:last_digest_at" json:"last_digest_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (TodoEmailSettings) TableName() string {
+	return "to_do_email_settings"
+}
+
+// Subscribed 是否會收到信件
+func (s *TodoEmailSettings) Subscribed() bool {
+	return s.Email != "" && s.UnsubscribedAt == nil
+}
+
+// TodoEmailMessages 待寄出的信件，建立時已套用範本；(dedupe_key) 唯一，同一則通知或同一期摘要只寄一次
+type TodoEmailMessages struct {
+	ID             int        `gorm:"primaryKey" json:"id"`
+	UserID         *int       `gorm:"column:user_id" json:"user_id"`
+	Kind           string     `gorm:"type:varchar(30);not null" json:"kind"`
+	ToAddress      string     `gorm:"type:varchar(255);not null" json:"to_address"`
+	Subject        string     `gorm:"type:varchar(255);not null" json:"subject"`
+	TextBody       string     `gorm:"type:mediumtext;not null" json:"text_body"`
+	HTMLBody       string     `gorm:"column:html_body;type:mediumtext;not null" json:"html_body"`
+	UnsubscribeURL string     `gorm:"type:varchar(500);not null;default:''" json:"unsubscribe_url"`
+	DedupeKey      string     `gorm:"type:varchar(191);not null" json:"-"`
+	Status         string     `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at"`
+	Error          string     `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	SentAt         *time.Time `gorm:"column:sent_at" json:"sent_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (TodoEmailMessages) TableName() string {
+	return "to_do_email_messages"
+}
+
+// EmailTask 信件中列出的任務
+type EmailTask struct {
+	ID       int        `gorm:"column:id"`
+	Name     string     `gorm:"column:name"`
+	ListName string     `gorm:"column:list_name"`
+	Status   string     `gorm:"column:status"`
+	DueAt    *time.Time `gorm:"column:due_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"todolist/models/base"
+)
+
+// 匯出格式
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// 匯出工作狀態
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+// ExportFilter 匯出範圍，全部為零值時匯出整個工作區
+type ExportFilter struct {
+	ListID   int    `json:"list_id,omitempty"`
+	TypeID   int    `json:"type_id,omitempty"`
+	Keyword  string `json:"keyword,omitempty"`
+	LabelIDs []int  `json:"label_ids,omitempty"`
+}
+
+// TodoExportJobs 背景匯出工作，完成後檔案存放在附件的儲存空間
+type TodoExportJobs struct {
+	ID         int          `gorm:"primaryKey" json:"id"`
+	Format     string       `gorm:"type:varchar(10);not null" json:"format"`
+	Filter     ExportFilter `gorm:"type:json;serializer:json;not null" json:"filter"`
+	Status     string       `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	StorageKey string       `gorm:"type:varchar(255);not null;default:''" json:"-"`
+	FileSize   int64        `gorm:"not null;default:0" json:"file_size"`
+	Error      string       `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	StartedAt  *time.Time   `gorm:"column:started_at" json:"started_at"`
+	FinishedAt *time.Time   `gorm:"column:finished_at" json:"finished_at"`
+
+	// DownloadURL 完成後才有值，不存入資料庫
+	DownloadURL string `gorm:"-" json:"download_url,omitempty"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoExportJobs) TableName() string {
+	return "to_do_export_jobs"
+}
+
+// FileName 下載時的檔名
+func (j *TodoExportJobs) FileName() string {
+	return fmt.Sprintf("export-%d.%s", j.ID, j.Format)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// 憑證用途
+const (
+	// TokenScopeFeed 唯讀的 iCalendar 訂閱，放在網址中
+	TokenScopeFeed = "feed"
+	// TokenScopeCalDAV CalDAV 同步，以 HTTP Basic 的密碼帶入，可以修改資料
+	TokenScopeCalDAV = "caldav"
+)
+
+// TodoFeedTokens 日曆程式用的個人憑證；日曆程式無法帶 JWT，改以網址中的憑證（訂閱）或 HTTP Basic（CalDAV）驗證。
+// 只保存憑證的 SHA-256，原始值只在建立時回傳一次
+type TodoFeedTokens struct {
+	ID     int `gorm:"primaryKey" json:"id"`
+	UserID int `gorm:"column:user_id;not null" json:"user_id"`
+	// TodoListID 有值時為該 TodoList 的訂閱，否則為 UserID 被指派項目的訂閱；CalDAV 憑證不限定 TodoList
+	TodoListID *int       `gorm:"column:to_do_list_id" json:"to_do_list_id"`
+	Scope      string     `gorm:"type:varchar(20);not null;default:feed" json:"scope"`
+	Name       string     `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	TokenHash  string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+
+	// Token、FeedURL 只在建立時有值，不存入資料庫
+	Token   string `gorm:"-" json:"token,omitempty"`
+	FeedURL string `gorm:"-" json:"feed_url,omitempty"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoFeedTokens) TableName() string {
+	return "to_do_feed_tokens"
+}
+
+// CalendarFeed 一個訂閱要輸出的範圍與版本，版本相同時內容不變
+type CalendarFeed struct {
+	Token        *TodoFeedTokens
+	Name         string
+	Count        int64
+	LastModified time.Time
+}
+
+// CalendarImportResult 匯入 .ics 的結果，Skipped 為已匯入過或已取消而略過的項目數
+type CalendarImportResult struct {
+	Created []int `json:"created"`
+	Skipped int   `json:"skipped"`
+}
+
+// CalDAVObject CalDAV 中的一個資源，內容為只包含一個 VTODO 的 iCalendar；ETag 由內容計算
+type CalDAVObject struct {
+	Href         string
+	ETag         string
+	LastModified time.Time
+	Data         []byte
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// 匯入來源
+const (
+	ImportSourceCSV    = "csv"
+	ImportSourceTrello = "trello"
+)
+
+// 匯入狀態：驗證完成（dry run）後等待確認；確認時中途失敗為 failed，可以再次確認從失敗的 TodoList 繼續
+const (
+	ImportStatusValidated = "validated"
+	ImportStatusCommitted = "committed"
+	ImportStatusFailed    = "failed"
+)
+
+// TodoImports 一次匯入，上傳時只驗證並保存每一列的結果，確認後才建立資料
+type TodoImports struct {
+	ID           int        `gorm:"primaryKey" json:"id"`
+	Source       string     `gorm:"type:varchar(20);not null" json:"source"`
+	FileName     string     `gorm:"type:varchar(255);not null;default:''" json:"file_name"`
+	Status       string     `gorm:"type:varchar(20);not null;default:validated" json:"status"`
+	TotalRows    int        `gorm:"not null;default:0" json:"total_rows"`
+	ValidRows    int        `gorm:"not null;default:0" json:"valid_rows"`
+	ErrorRows    int        `gorm:"not null;default:0" json:"error_rows"`
+	WarningRows  int        `gorm:"not null;default:0" json:"warning_rows"`
+	ImportedRows int        `gorm:"not null;default:0" json:"imported_rows"`
+	Error        string     `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	CommittedAt  *time.Time `gorm:"column:committed_at" json:"committed_at"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoImports) TableName() string {
+	return "to_do_imports"
+}
+
+// TodoImportRows 匯入的每一列與驗證結果；Valid 的列在確認時建立，建立後記錄 TodoListDetailID
+type TodoImportRows struct {
+	ID               int      `gorm:"primaryKey" json:"id"`
+	ImportID         int      `gorm:"column:import_id;not null" json:"import_id"`
+	RowNo            int      `gorm:"column:row_no;not null" json:"row_no"`
+	ListName         string   `gorm:"type:varchar(255);not null;default:''" json:"list_name"`
+	TypeName         string   `gorm:"type:varchar(255);not null;default:''" json:"type_name"`
+	Name             string   `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	Detail           string   `gorm:"type:mediumtext;not null" json:"detail"`
+	Status           string   `gorm:"type:varchar(20);not null;default:todo" json:"status"`
+	Assignees        []string `gorm:"type:json;serializer:json;not null" json:"assignees"`
+	Errors           []string `gorm:"type:json;serializer:json;not null" json:"errors"`
+	Warnings         []string `gorm:"type:json;serializer:json;not null" json:"warnings"`
+	Valid            bool     `gorm:"not null;default:false" json:"valid"`
+	TodoListDetailID *int     `gorm:"column:to_do_list_detail_id" json:"to_do_list_detail_id"`
+}
+
+func (TodoImportRows) TableName() string {
+	return "to_do_import_rows"
+}
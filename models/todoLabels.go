@@ -0,0 +1,27 @@
+package models
+
+import (
+	"todolist/models/base"
+)
+
+type TodoLabels struct {
+	ID    int    `gorm:"primary_key" json:"id"`
+	Name  string `gorm:"type:varchar(255);NOT NULL" json:"name" binding:"required"`
+	Color string `gorm:"type:varchar(7);NOT NULL;default:#808080" json:"color"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoLabels) TableName() string {
+	return "to_do_labels"
+}
+
+// TodoLabelUsage 標籤被 TodoList / TodoListDetails 使用的次數統計
+type TodoLabelUsage struct {
+	LabelID     int    `json:"label_id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	ListCount   int64  `json:"list_count"`
+	DetailCount int64  `json:"detail_count"`
+}
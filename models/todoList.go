@@ -8,9 +8,20 @@ type TodoList struct {
 	ID     int    `gorm:"primaryKey" json:"id"`
 	TypeID int    `gorm:"column:type_id;not null" json:"type_id"`
 	Name   string `gorm:"type:varchar(255);not null" json:"name"`
+	// MilestoneID 所屬里程碑，底下沒有另外指定的項目沿用
+	MilestoneID *int `gorm:"column:milestone_id" json:"milestone_id"`
+
+	// Position 手動排序用的分數位置鍵（pkg/rank），刪除時清空
+	Position *string `gorm:"type:varchar(64)" json:"position"`
 
 	Type    TodoTypes         `gorm:"foreignKey:TypeID;constraint:OnDelete:CASCADE;" json:"type"`
 	Details []TodoListDetails `gorm:"foreignKey:TodoListID;references:ID" json:"details"`
+	Labels  []TodoLabels      `gorm:"many2many:to_do_list_labels;joinForeignKey:ToDoListID;joinReferences:LabelID" json:"labels"`
+	// Watchers 關注的使用者，只在取得單一 TodoList 時載入
+	Watchers []User `gorm:"many2many:to_do_list_watchers;joinForeignKey:ToDoListID;joinReferences:UserID" json:"watchers,omitempty"`
+
+	// Progress 彙總所有 Details 的進度，不存入資料庫
+	Progress *Progress `gorm:"-" json:"progress,omitempty"`
 
 	base.TimeModel
 	base.OperatorModel
@@ -19,3 +30,26 @@ type TodoList struct {
 func (TodoList) TableName() string {
 	return "to_do_list"
 }
+
+// RollUpProgress 彙總已載入的 Details 進度
+func (l *TodoList) RollUpProgress() *Progress {
+	progress := &Progress{}
+	for i := range l.Details {
+		progress.Add(l.Details[i].RollUpProgress())
+	}
+	l.Progress = progress
+	return progress
+}
+
+// VersionEntity 實作 base.Versioned
+func (l *TodoList) VersionEntity() (string, int) {
+	return VersionEntityList, l.ID
+}
+
+// VersionSnapshot 名稱與類型
+func (l *TodoList) VersionSnapshot() map[string]interface{} {
+	return map[string]interface{}{
+		"name":    l.Name,
+		"type_id": l.TypeID,
+	}
+}
@@ -1,16 +1,60 @@
 package models
 
 import (
+	"time"
 	"todolist/models/base"
+	"todolist/pkg/markdown"
+
+	"gorm.io/gorm"
+)
+
+// TodoListDetails 狀態
+const (
+	DetailStatusTodo       = "todo"
+	DetailStatusInProgress = "in_progress"
+	DetailStatusDone       = "done"
 )
 
 type TodoListDetails struct {
-	ID         int    `gorm:"primaryKey" json:"id"`
-	TodoListID int    `gorm:"column:to_do_list_id;not null" json:"to_do_list_id"`
-	Name       string `gorm:"type:varchar(255);not null" json:"name"`
-	Detail     string `gorm:"type:varchar(255);not null" json:"detail"`
+	ID         int `gorm:"primaryKey" json:"id"`
+	TodoListID int `gorm:"column:to_do_list_id;not null" json:"to_do_list_id"`
+	// MilestoneID 為 nil 時沿用所屬 TodoList 的里程碑
+	MilestoneID *int   `gorm:"column:milestone_id" json:"milestone_id"`
+	Name        string `gorm:"type:varchar(255);not null" json:"name"`
+	// Detail 說明原文（Markdown），DetailHTML 為轉換並過濾後可直接顯示的 HTML
+	Detail     string `gorm:"type:mediumtext;not null" json:"detail"`
+	DetailHTML string `gorm:"-" json:"detail_html"`
+
+	Status string `gorm:"type:varchar(20);not null;default:todo" json:"status"`
+	// StartedAt 第一次改為進行中的時間
+	StartedAt   *time.Time `gorm:"column:started_at" json:"started_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at" json:"completed_at"`
+	// DueAt 到期時間，有值的項目會出現在 iCalendar 訂閱中
+	DueAt *time.Time `gorm:"column:due_at" json:"due_at"`
+	// 預估工時（分鐘），用來與實際紀錄的工時比較
+	OriginalEstimateMinutes  *int `gorm:"column:original_estimate_minutes" json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int `gorm:"column:remaining_estimate_minutes" json:"remaining_estimate_minutes"`
+	// Position 在所屬 TodoList 內的手動排序位置鍵（pkg/rank），刪除時清空
+	Position *string `gorm:"type:varchar(64)" json:"position"`
+
+	// 由週期性規則產生時才有值，(recurrence_id, occurrence_at) 唯一
+	RecurrenceID *int       `gorm:"column:recurrence_id" json:"recurrence_id,omitempty"`
+	OccurrenceAt *time.Time `gorm:"column:occurrence_at" json:"occurrence_at,omitempty"`
+	// ICalUID 從 .ics 匯入時的 UID，訂閱中沿用，重複匯入同一個檔案時略過已匯入的項目
+	ICalUID *string `gorm:"column:ical_uid;type:varchar(255)" json:"ical_uid,omitempty"`
 
-	Users []User `gorm:"many2many:to_do_task_assignments;joinForeignKey:ToDoListDetailID;joinReferences:UserID"`
+	Users  []User               `gorm:"many2many:to_do_task_assignments;joinForeignKey:ToDoListDetailID;joinReferences:UserID"`
+	Labels []TodoLabels         `gorm:"many2many:to_do_list_detail_labels;joinForeignKey:ToDoListDetailID;joinReferences:LabelID" json:"labels"`
+	Items  []TodoChecklistItems `gorm:"foreignKey:TodoListDetailID" json:"items"`
+	// Watchers 關注的使用者，只在取得單一項目時載入
+	Watchers []User `gorm:"many2many:to_do_list_detail_watchers;joinForeignKey:ToDoListDetailID;joinReferences:UserID" json:"watchers,omitempty"`
+
+	// Blocks 被此項目擋住的項目；BlockedBy 擋住此項目的項目
+	Blocks    []TodoListDetails `gorm:"many2many:to_do_detail_dependencies;joinForeignKey:BlockerID;joinReferences:BlockedID" json:"blocks,omitempty"`
+	BlockedBy []TodoListDetails `gorm:"many2many:to_do_detail_dependencies;joinForeignKey:BlockedID;joinReferences:BlockerID" json:"blocked_by,omitempty"`
+
+	// Progress 由 Items 計算而來，不存入資料庫
+	Progress *Progress `gorm:"-" json:"progress,omitempty"`
 
 	base.TimeModel
 	base.OperatorModel
@@ -19,3 +63,40 @@ type TodoListDetails struct {
 func (TodoListDetails) TableName() string {
 	return "to_do_list_details"
 }
+
+// RenderDetail 將 Detail 轉成 DetailHTML
+func (d *TodoListDetails) RenderDetail() {
+	d.DetailHTML = markdown.Render(d.Detail)
+}
+
+// AfterFind 查詢（含 Preload）後一併轉出 DetailHTML
+func (d *TodoListDetails) AfterFind(tx *gorm.DB) error {
+	d.RenderDetail()
+	return nil
+}
+
+// RollUpProgress 依已載入的 Items 計算完成進度
+func (d *TodoListDetails) RollUpProgress() *Progress {
+	progress := &Progress{Total: len(d.Items)}
+	for _, item := range d.Items {
+		if item.IsDone {
+			progress.Done++
+		}
+	}
+	d.Progress = progress
+	return progress
+}
+
+// VersionEntity 實作 base.Versioned
+func (d *TodoListDetails) VersionEntity() (string, int) {
+	return VersionEntityDetail, d.ID
+}
+
+// VersionSnapshot 名稱、說明與狀態；完成時間隨狀態而定，不另外保存
+func (d *TodoListDetails) VersionSnapshot() map[string]interface{} {
+	return map[string]interface{}{
+		"name":   d.Name,
+		"detail": d.Detail,
+		"status": d.Status,
+	}
+}
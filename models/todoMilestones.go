@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// 燃盡圖的計算方式
+const (
+	MilestoneMetricCount    = "count"
+	MilestoneMetricEstimate = "estimate"
+)
+
+// TodoMilestones 里程碑（sprint），TodoList 與 TodoListDetails 都可以歸屬；
+// 項目沒有指定時沿用所屬 TodoList 的里程碑
+type TodoMilestones struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	StartDate time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate   time.Time `gorm:"type:date;not null" json:"end_date"`
+	// ClosedAt 關閉後不能再加入項目
+	ClosedAt *time.Time `gorm:"column:closed_at" json:"closed_at"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoMilestones) TableName() string {
+	return "to_do_milestones"
+}
+
+// TodoMilestoneRollovers 關閉里程碑時移到下一個里程碑的項目，
+// 讓已關閉里程碑的圖表仍包含這些未完成的項目
+type TodoMilestoneRollovers struct {
+	MilestoneID      int       `gorm:"primaryKey;column:milestone_id" json:"milestone_id"`
+	TodoListDetailID int       `gorm:"primaryKey;column:to_do_list_detail_id" json:"to_do_list_detail_id"`
+	NextMilestoneID  int       `gorm:"column:next_milestone_id;not null" json:"next_milestone_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (TodoMilestoneRollovers) TableName() string {
+	return "to_do_milestone_rollovers"
+}
+
+// TodoStatusChanges TodoListDetails 的狀態變更紀錄，用來重建每天的狀態
+type TodoStatusChanges struct {
+	ID               int       `gorm:"primaryKey" json:"id"`
+	TodoListDetailID int       `gorm:"column:to_do_list_detail_id;not null" json:"to_do_list_detail_id"`
+	FromStatus       string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus         string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedAt        time.Time `gorm:"not null" json:"changed_at"`
+	ChangedBy        *uint     `gorm:"column:changed_by" json:"changed_by"`
+}
+
+func (TodoStatusChanges) TableName() string {
+	return "to_do_status_changes"
+}
+
+// BurndownPoint 一天結束時剩餘的項目數或預估分鐘數，Ideal 為平均燒完的理想值
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// FlowPoint 一天結束時各狀態的項目數
+type FlowPoint struct {
+	Date     string         `json:"date"`
+	Statuses map[string]int `json:"statuses"`
+}
+
+// MilestoneChart 里程碑期間每天的燃盡與累積流量
+type MilestoneChart struct {
+	MilestoneID    int             `json:"milestone_id"`
+	Metric         string          `json:"metric"`
+	Burndown       []BurndownPoint `json:"burndown"`
+	CumulativeFlow []FlowPoint     `json:"cumulative_flow"`
+}
+
+// MilestoneCloseResult 關閉里程碑的結果，RolledOver 為移到下一個里程碑的項目 ID
+type MilestoneCloseResult struct {
+	Milestone  *TodoMilestones `json:"milestone"`
+	RolledOver []int           `json:"rolled_over"`
+}
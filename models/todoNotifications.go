@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// 通知類型，也是使用者可以個別關閉的項目
+const (
+	NotificationAssigned    = "assigned"
+	NotificationUnassigned  = "unassigned"
+	NotificationMentioned   = "mentioned"
+	NotificationDueSoon     = "due_soon"
+	NotificationOverdue     = "overdue"
+	NotificationComment     = "comment"
+	NotificationRoleChanged = "role_changed"
+	// NotificationEscalated 任務逾期多日仍未完成，通知清單建立者或 Admin
+	NotificationEscalated = "escalated"
+)
+
+var NotificationTypes = []string{
+	NotificationAssigned,
+	NotificationUnassigned,
+	NotificationMentioned,
+	NotificationDueSoon,
+	NotificationOverdue,
+	NotificationComment,
+	NotificationRoleChanged,
+	NotificationEscalated,
+}
+
+// TodoNotifications 使用者收件匣中的一則通知；(user_id, dedupe_key) 唯一，事件重送時不會重複通知
+type TodoNotifications struct {
+	ID               int        `gorm:"primaryKey" json:"id"`
+	UserID           int        `gorm:"column:user_id;not null" json:"user_id"`
+	Type             string     `gorm:"type:varchar(30);not null" json:"type"`
+	TodoListID       *int       `gorm:"column:to_do_list_id" json:"to_do_list_id"`
+	TodoListDetailID *int       `gorm:"column:to_do_list_detail_id" json:"to_do_list_detail_id"`
+	CommentID        *int       `gorm:"column:comment_id" json:"comment_id"`
+	ActorID          *int       `gorm:"column:actor_id" json:"actor_id"`
+	Title            string     `gorm:"type:varchar(255);not null" json:"title"`
+	DedupeKey        string     `gorm:"type:varchar(191);not null" json:"-"`
+	ReadAt           *time.Time `gorm:"column:read_at" json:"read_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (TodoNotifications) TableName() string {
+	return "to_do_notifications"
+}
+
+// TodoNotificationPreferences 使用者對某一類通知的設定，沒有紀錄時視為接收
+type TodoNotificationPreferences struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	UserID    int       `gorm:"column:user_id;not null" json:"user_id"`
+	Type      string    `gorm:"type:varchar(30);not null" json:"type"`
+	Enabled   bool      `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (TodoNotificationPreferences) TableName() string {
+	return "to_do_notification_preferences"
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+	"todolist/pkg/events"
+)
+
+// 領域事件
+const (
+	EventListCreated         = "list.created"
+	EventListUpdated         = "list.updated"
+	EventListDeleted         = "list.deleted"
+	EventDetailCreated       = "detail.created"
+	EventDetailUpdated       = "detail.updated"
+	EventDetailStatusChanged = "detail.status_changed"
+	EventDetailDeleted       = "detail.deleted"
+	EventDetailAssigned      = "detail.assigned"
+	EventDetailUnassigned    = "detail.unassigned"
+	EventCommentCreated      = "comment.created"
+	EventCommentUpdated      = "comment.updated"
+	EventCommentDeleted      = "comment.deleted"
+	EventMemberRoleChanged   = "member.role_changed"
+)
+
+// outbox 狀態
+const (
+	OutboxStatusPending     = "pending"
+	OutboxStatusDispatching = "dispatching"
+	OutboxStatusDispatched  = "dispatched"
+)
+
+// TodoOutboxEvents 與資料在同一個交易內寫入的領域事件，commit 後由 relay 送出
+type TodoOutboxEvents struct {
+	ID         int    `gorm:"primaryKey" json:"id"`
+	EventID    string `gorm:"type:char(32);not null;uniqueIndex" json:"event_id"`
+	EventType  string `gorm:"type:varchar(50);not null" json:"event_type"`
+	TodoListID *int   `gorm:"column:to_do_list_id" json:"to_do_list_id"`
+	ActorID    *int   `gorm:"column:actor_id" json:"actor_id"`
+	Payload    string `gorm:"type:mediumtext;not null" json:"payload"`
+	// Depth 寫入事件時的自動化規則連鎖層數
+	Depth         int        `gorm:"not null;default:0" json:"depth"`
+	OccurredAt    time.Time  `gorm:"not null" json:"occurred_at"`
+	Status        string     `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastAttemptAt *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at"`
+	Error         string     `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	DispatchedAt  *time.Time `gorm:"column:dispatched_at" json:"dispatched_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (TodoOutboxEvents) TableName() string {
+	return "to_do_outbox_events"
+}
+
+// Event 轉為送給 Broker 的事件
+func (o *TodoOutboxEvents) Event() events.Event {
+	return events.Event{
+		ID:         o.EventID,
+		Type:       o.EventType,
+		OccurredAt: o.OccurredAt,
+		ActorID:    o.ActorID,
+		TodoListID: o.TodoListID,
+		Data:       json.RawMessage(o.Payload),
+		Depth:      o.Depth,
+	}
+}
+
+// DetailAssignment detail.assigned 與 detail.unassigned 的內容，UserIDs 為這次新增或移除的負責人
+type DetailAssignment struct {
+	Detail  *TodoListDetails `json:"detail"`
+	UserIDs []int            `json:"user_ids"`
+}
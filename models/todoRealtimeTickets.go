@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TodoRealtimeTickets 即時更新連線用的一次性票證。EventSource 與 WebSocket 無法自訂 header，
+// 以網址中的票證取代 JWT：票證只能使用一次且很快過期，即使網址被寫入存取紀錄也無法再用。
+// 只保存票證的 SHA-256，原始值只在建立時回傳一次
+type TodoRealtimeTickets struct {
+	ID         int        `gorm:"primaryKey" json:"-"`
+	UserID     int        `gorm:"column:user_id;not null" json:"-"`
+	TicketHash string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	UsedAt     *time.Time `gorm:"column:used_at" json:"-"`
+	CreatedAt  time.Time  `json:"-"`
+
+	// Ticket 只在建立時有值，不存入資料庫
+	Ticket string `gorm:"-" json:"ticket"`
+}
+
+func (TodoRealtimeTickets) TableName() string {
+	return "to_do_realtime_tickets"
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+	"todolist/pkg/rrule"
+)
+
+// TodoRecurrences 週期性任務的排程規則，依 RRULE 產生 TodoListDetails
+type TodoRecurrences struct {
+	ID         int       `gorm:"primaryKey" json:"id"`
+	TodoListID int       `gorm:"column:to_do_list_id;not null" json:"to_do_list_id"`
+	Name       string    `gorm:"type:varchar(255);not null" json:"name"`
+	Detail     string    `gorm:"type:mediumtext;not null" json:"detail"`
+	RRule      string    `gorm:"column:rrule;type:varchar(255);not null" json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Timezone   string    `gorm:"type:varchar(64);not null;default:UTC" json:"timezone" example:"Asia/Taipei"`
+	DtStart    time.Time `gorm:"column:dtstart;not null" json:"dtstart"`
+
+	// NextAt 下一次尚未產生的發生時間，規則結束後為 nil
+	NextAt *time.Time `gorm:"column:next_at" json:"next_at"`
+	// LastOccurrenceAt 最近一次已產生的發生時間
+	LastOccurrenceAt *time.Time `gorm:"column:last_occurrence_at" json:"last_occurrence_at"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoRecurrences) TableName() string {
+	return "to_do_recurrences"
+}
+
+// Schedule 解析規則，並回傳換算到規則時區的 dtstart
+func (r *TodoRecurrences) Schedule() (*rrule.Rule, time.Time, error) {
+	rule, err := rrule.Parse(r.RRule)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return rule, r.DtStart.In(loc), nil
+}
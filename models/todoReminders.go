@@ -0,0 +1,138 @@
+package models
+
+import "time"
+
+// 提醒規則名稱；到期前的規則以 before_<時間> 命名，如 before_24h
+const (
+	ReminderRuleAtDue      = "at_due"
+	ReminderRuleOverdue    = "overdue"
+	ReminderRuleEscalation = "escalation"
+)
+
+// 提醒排程執行狀態
+const (
+	ReminderRunRunning   = "running"
+	ReminderRunSucceeded = "succeeded"
+	ReminderRunFailed    = "failed"
+)
+
+// ReminderRule 在到期時間 + Offset 時提醒，Offset 為負表示到期前；
+// Repeat 不為 0 時之後每隔 Repeat 再提醒一次，直到逾期超過 Until
+type ReminderRule struct {
+	Name   string        `json:"name"`
+	Offset time.Duration `json:"-"`
+	Repeat time.Duration `json:"-"`
+	Until  time.Duration `json:"-"`
+	// Window 提醒時間過後多久內仍會補發，排程停止一段時間後不會補發過時的提醒
+	Window time.Duration `json:"-"`
+	// Escalate 通知清單建立者或 Admin，而不是負責人
+	Escalate bool `json:"escalate"`
+}
+
+// TodoSchedulerLeases 背景排程的租約，同一時間只有持有租約的 instance 執行該排程；
+// 持有者停止後租約到期，由其他 instance 接手
+type TodoSchedulerLeases struct {
+	Name      string    `gorm:"primaryKey;type:varchar(64)" json:"name"`
+	Owner     string    `gorm:"type:varchar(64);not null" json:"owner"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null" json:"expires_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (TodoSchedulerLeases) TableName() string {
+	return "to_do_scheduler_leases"
+}
+
+// TodoReminderRuns 一次提醒排程的執行紀錄
+type TodoReminderRuns struct {
+	ID         int        `gorm:"primaryKey" json:"id"`
+	Instance   string     `gorm:"type:varchar(64);not null" json:"instance"`
+	Status     string     `gorm:"type:varchar(20);not null;default:running" json:"status"`
+	Sent       int        `gorm:"not null;default:0" json:"sent"`
+	Error      string     `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	StartedAt  time.Time  `gorm:"column:started_at;not null" json:"started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at" json:"finished_at"`
+
+	Logs []TodoReminderLogs `gorm:"foreignKey:RunID" json:"logs,omitempty"`
+}
+
+func (TodoReminderRuns) TableName() string {
+	return "to_do_reminder_runs"
+}
+
+// TodoReminderLogs 已送出的提醒；(任務, 規則, 到期時間, 第幾次, 使用者) 唯一，同一個提醒只送一次，
+// 改了到期時間會重新提醒
+type TodoReminderLogs struct {
+	ID               int       `gorm:"primaryKey" json:"id"`
+	RunID            *int      `gorm:"column:run_id" json:"run_id"`
+	Rule             string    `gorm:"type:varchar(30);not null" json:"rule"`
+	TodoListDetailID int       `gorm:"column:to_do_list_detail_id;not null" json:"to_do_list_detail_id"`
+	UserID           int       `gorm:"column:user_id;not null" json:"user_id"`
+	DueAt            time.Time `gorm:"column:due_at;not null" json:"due_at"`
+	// Occurrence 重複提醒的第幾次，從 0 開始
+	Occurrence int       `gorm:"not null;default:0" json:"occurrence"`
+	SentAt     time.Time `gorm:"column:sent_at;not null" json:"sent_at"`
+}
+
+func (TodoReminderLogs) TableName() string {
+	return "to_do_reminder_logs"
+}
+
+// ReminderTask 有到期時間、尚未完成的任務
+type ReminderTask struct {
+	TodoListDetailID int       `gorm:"column:to_do_list_detail_id"`
+	TodoListID       int       `gorm:"column:to_do_list_id"`
+	Name             string    `gorm:"column:name"`
+	DueAt            time.Time `gorm:"column:due_at"`
+	// OwnerID 清單建立者
+	OwnerID     *int  `gorm:"column:owner_id"`
+	AssigneeIDs []int `gorm:"-"`
+}
+
+// ReminderUpcoming 即將送出的提醒
+type ReminderUpcoming struct {
+	Rule             string    `json:"rule" example:"before_24h"`
+	TodoListDetailID int       `json:"to_do_list_detail_id" example:"5"`
+	TodoListID       int       `json:"to_do_list_id" example:"2"`
+	Name             string    `json:"name" example:"寫報告"`
+	DueAt            time.Time `json:"due_at"`
+	FireAt           time.Time `json:"fire_at"`
+	Occurrence       int       `json:"occurrence" example:"0"`
+	UserIDs          []int     `json:"user_ids"`
+}
+
+// Occurrence now 時最近一次應送出的提醒：第幾次與提醒時間；還沒到、已超過 Window 或 Until 時回傳 false
+func (r ReminderRule) Occurrence(dueAt, now time.Time) (int, time.Time, bool) {
+	first := dueAt.Add(r.Offset)
+	if now.Before(first) {
+		return 0, time.Time{}, false
+	}
+	k := 0
+	if r.Repeat > 0 {
+		if now.Sub(dueAt) > r.Until {
+			return 0, time.Time{}, false
+		}
+		k = int(now.Sub(first) / r.Repeat)
+	}
+	fireAt := first.Add(time.Duration(k) * r.Repeat)
+	if now.Sub(fireAt) >= r.Window {
+		return 0, time.Time{}, false
+	}
+	return k, fireAt, true
+}
+
+// Next after 之後的下一次提醒：第幾次與提醒時間，不會再提醒時回傳 false
+func (r ReminderRule) Next(dueAt, after time.Time) (int, time.Time, bool) {
+	first := dueAt.Add(r.Offset)
+	if first.After(after) {
+		return 0, first, true
+	}
+	if r.Repeat <= 0 {
+		return 0, time.Time{}, false
+	}
+	k := int(after.Sub(first)/r.Repeat) + 1
+	fireAt := first.Add(time.Duration(k) * r.Repeat)
+	if fireAt.Sub(dueAt) > r.Until {
+		return 0, time.Time{}, false
+	}
+	return k, fireAt, true
+}
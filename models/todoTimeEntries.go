@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+	"todolist/models/base"
+)
+
+// 工時彙總的分組方式
+const (
+	TimeGroupUser   = "user"
+	TimeGroupDay    = "day"
+	TimeGroupDetail = "detail"
+	TimeGroupList   = "list"
+	TimeGroupType   = "type"
+)
+
+// TodoTimeEntries TodoListDetails 上的工時紀錄；EndedAt 為 nil 的是計時中的計時器，每位使用者同時只能有一個
+type TodoTimeEntries struct {
+	ID               int        `gorm:"primaryKey" json:"id"`
+	TodoListDetailID int        `gorm:"column:to_do_list_detail_id;not null" json:"to_do_list_detail_id"`
+	UserID           int        `gorm:"column:user_id;not null" json:"user_id"`
+	StartedAt        time.Time  `gorm:"column:started_at;not null" json:"started_at"`
+	EndedAt          *time.Time `gorm:"column:ended_at" json:"ended_at"`
+	// DurationSeconds 結束時才計算，計時中為 nil
+	DurationSeconds *int   `gorm:"column:duration_seconds" json:"duration_seconds"`
+	Note            string `gorm:"type:varchar(255);not null;default:''" json:"note"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoTimeEntries) TableName() string {
+	return "to_do_time_entries"
+}
+
+// Running 是否仍在計時
+func (e *TodoTimeEntries) Running() bool {
+	return e.EndedAt == nil
+}
+
+// Finish 設定結束時間並計算工時
+func (e *TodoTimeEntries) Finish(end time.Time) {
+	seconds := int(end.Sub(e.StartedAt) / time.Second)
+	e.EndedAt = &end
+	e.DurationSeconds = &seconds
+}
+
+// TimeEntryFilter 工時彙總的篩選條件，0 與 nil 表示不篩選；To 不含當天以後
+type TimeEntryFilter struct {
+	UserID int
+	ListID int
+	TypeID int
+	From   *time.Time
+	To     *time.Time
+}
+
+// TimeSummary 一個分組的工時合計；依 TodoListDetails 分組（detail / list / type）時附上預估工時的合計，
+// 只計算期間內有紀錄的 TodoListDetails
+type TimeSummary struct {
+	Key                      string `json:"key" example:"3"`
+	Name                     string `json:"name" example:"官網改版"`
+	Seconds                  int64  `json:"seconds" example:"5400"`
+	Entries                  int64  `json:"entries" example:"2"`
+	OriginalEstimateMinutes  *int64 `json:"original_estimate_minutes,omitempty" example:"120"`
+	RemainingEstimateMinutes *int64 `json:"remaining_estimate_minutes,omitempty" example:"30"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// 版本紀錄的類型
+const (
+	VersionEntityList   = "to_do_list"
+	VersionEntityDetail = "to_do_list_details"
+)
+
+// TodoVersions TodoList / TodoListDetails 每個版本的快照，同一筆資料的版本號從 1 開始遞增
+type TodoVersions struct {
+	ID         int             `gorm:"primaryKey" json:"id"`
+	EntityType string          `gorm:"type:varchar(32);not null" json:"entity_type"`
+	EntityID   int             `gorm:"column:entity_id;not null" json:"entity_id"`
+	Version    int             `gorm:"not null" json:"version"`
+	Snapshot   json.RawMessage `gorm:"type:json;not null" json:"snapshot" swaggertype:"object"`
+	// RevertedFrom 由還原產生的版本，記錄還原的來源版本
+	RevertedFrom *int      `gorm:"column:reverted_from" json:"reverted_from"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    *uint     `gorm:"column:created_by" json:"created_by"`
+}
+
+func (TodoVersions) TableName() string {
+	return "to_do_versions"
+}
+
+// VersionChange 兩個版本間單一欄位的差異
+type VersionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DiffVersions 逐欄比較兩個快照，依欄位名稱排序回傳有差異的欄位
+func DiffVersions(from, to *TodoVersions) ([]VersionChange, error) {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(from.Snapshot, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(to.Snapshot, &after); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(before)+len(after))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []VersionChange{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, VersionChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// TodoListWatchers 關注 TodoList 的使用者，也會收到底下所有項目的更新
+type TodoListWatchers struct {
+	TodoListID int       `gorm:"column:to_do_list_id;primaryKey" json:"to_do_list_id"`
+	UserID     int       `gorm:"column:user_id;primaryKey" json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (TodoListWatchers) TableName() string {
+	return "to_do_list_watchers"
+}
+
+// TodoListDetailWatchers 關注項目的使用者；建立者、負責人與留言者會自動關注，可以自行取消
+type TodoListDetailWatchers struct {
+	TodoListDetailID int       `gorm:"column:to_do_list_detail_id;primaryKey" json:"to_do_list_detail_id"`
+	UserID           int       `gorm:"column:user_id;primaryKey" json:"user_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (TodoListDetailWatchers) TableName() string {
+	return "to_do_list_detail_watchers"
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+	"todolist/models/base"
+)
+
+// 送出狀態
+const (
+	DeliveryStatusPending    = "pending"
+	DeliveryStatusDelivering = "delivering"
+	DeliveryStatusSucceeded  = "succeeded"
+	// DeliveryStatusDead 重試次數用完，不再自動送出，可以手動重送
+	DeliveryStatusDead = "dead"
+)
+
+// TodoWebhooks 事件訂閱；TodoListID 有值時只接收該 TodoList 的事件，否則接收所有事件（包含成員角色變更）
+type TodoWebhooks struct {
+	ID          int      `gorm:"primaryKey" json:"id"`
+	TodoListID  *int     `gorm:"column:to_do_list_id" json:"to_do_list_id"`
+	URL         string   `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret      string   `gorm:"type:varchar(128);not null" json:"-"`
+	Events      []string `gorm:"type:json;serializer:json;not null" json:"events"`
+	Description string   `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	Active      bool     `gorm:"not null;default:true" json:"active"`
+
+	// SigningSecret 簽章用的密鑰，只在建立時回傳
+	SigningSecret string `gorm:"-" json:"signing_secret,omitempty"`
+
+	base.TimeModel
+	base.OperatorModel
+}
+
+func (TodoWebhooks) TableName() string {
+	return "to_do_webhooks"
+}
+
+// Subscribes 是否訂閱了事件
+func (w *TodoWebhooks) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// TodoWebhookDeliveries 一次送出的紀錄；同一個事件送給多個 webhook 時 EventID 相同
+type TodoWebhookDeliveries struct {
+	ID             int        `gorm:"primaryKey" json:"id"`
+	WebhookID      int        `gorm:"column:webhook_id;not null" json:"webhook_id"`
+	EventID        string     `gorm:"type:char(32);not null" json:"event_id"`
+	Event          string     `gorm:"type:varchar(50);not null" json:"event"`
+	Payload        string     `gorm:"type:mediumtext;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at"`
+	ResponseStatus int        `gorm:"not null;default:0" json:"response_status"`
+	ResponseBody   string     `gorm:"type:varchar(1000);not null;default:''" json:"response_body"`
+	Error          string     `gorm:"type:varchar(1000);not null;default:''" json:"error"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at" json:"delivered_at"`
+	// RedeliveryOf 手動重送時為原本的紀錄
+	RedeliveryOf *int      `gorm:"column:redelivery_of" json:"redelivery_of"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (TodoWebhookDeliveries) TableName() string {
+	return "to_do_webhook_deliveries"
+}
+
+// WebhookPayload 送出的內容
+type WebhookPayload struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	ActorID    *int            `json:"actor_id"`
+	TodoListID *int            `json:"to_do_list_id"`
+	WatcherIDs []int           `json:"watcher_ids,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// MemberRoleChange member.role_changed 的內容
+type MemberRoleChange struct {
+	UserID    int      `json:"user_id"`
+	Account   string   `json:"account"`
+	FromRoles []string `json:"from_roles"`
+	ToRole    string   `json:"to_role"`
+}
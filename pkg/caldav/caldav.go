@@ -0,0 +1,209 @@
+// Package caldav 實作 WebDAV / CalDAV（RFC 4918、RFC 4791）中同步 VTODO 需要的子集合：
+// 解析 PROPFIND 與 REPORT（calendar-query、calendar-multiget）的請求，輸出 207 Multi-Status。
+// 不處理 LOCK、PROPPATCH、MKCALENDAR 與 sync-collection，資源的內容由呼叫端以 pkg/ical 產生。
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// 命名空間
+const (
+	NamespaceDAV         = "DAV:"
+	NamespaceCalDAV      = "urn:ietf:params:xml:ns:caldav"
+	NamespaceCalendarSrv = "http://calendarserver.org/ns/"
+)
+
+// 支援的屬性
+var (
+	PropResourceType                  = xml.Name{Space: NamespaceDAV, Local: "resourcetype"}
+	PropDisplayName                   = xml.Name{Space: NamespaceDAV, Local: "displayname"}
+	PropGetETag                       = xml.Name{Space: NamespaceDAV, Local: "getetag"}
+	PropGetContentType                = xml.Name{Space: NamespaceDAV, Local: "getcontenttype"}
+	PropGetLastModified               = xml.Name{Space: NamespaceDAV, Local: "getlastmodified"}
+	PropCurrentUserPrincipal          = xml.Name{Space: NamespaceDAV, Local: "current-user-principal"}
+	PropPrincipalURL                  = xml.Name{Space: NamespaceDAV, Local: "principal-URL"}
+	PropSupportedReportSet            = xml.Name{Space: NamespaceDAV, Local: "supported-report-set"}
+	PropCalendarHomeSet               = xml.Name{Space: NamespaceCalDAV, Local: "calendar-home-set"}
+	PropSupportedCalendarComponentSet = xml.Name{Space: NamespaceCalDAV, Local: "supported-calendar-component-set"}
+	PropCalendarData                  = xml.Name{Space: NamespaceCalDAV, Local: "calendar-data"}
+	PropGetCTag                       = xml.Name{Space: NamespaceCalendarSrv, Local: "getctag"}
+)
+
+var (
+	ErrInvalidRequest    = errors.New("caldav: invalid request body")
+	ErrUnsupportedReport = errors.New("caldav: unsupported report")
+)
+
+const timeRangeLayout = "20060102T150405Z"
+
+// Propfind PROPFIND 的請求；AllProp 為 true 時 Props 為空
+type Propfind struct {
+	AllProp bool
+	Props   []xml.Name
+}
+
+// Filter calendar-query 的條件，只取 VCALENDAR 底下第一層的 comp-filter；
+// prop-filter 與更深的條件不處理，回傳的結果可能比條件寬，由用戶端再過濾
+type Filter struct {
+	// Component 例如 VTODO，空字串表示不限
+	Component string
+	// Start、End 為 time-range，零值表示沒有該端點
+	Start time.Time
+	End   time.Time
+}
+
+// Report REPORT 的請求
+type Report struct {
+	// Multiget 為 true 時是 calendar-multiget，否則是 calendar-query
+	Multiget bool
+	Propfind
+	Hrefs  []string
+	Filter Filter
+}
+
+type propXML struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type propfindXML struct {
+	XMLName  xml.Name  `xml:"DAV: propfind"`
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     *propXML  `xml:"DAV: prop"`
+}
+
+type timeRangeXML struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type compFilterXML struct {
+	Name        string          `xml:"name,attr"`
+	TimeRange   *timeRangeXML   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []compFilterXML `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type reportXML struct {
+	XMLName  xml.Name
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     *propXML  `xml:"DAV: prop"`
+	Hrefs    []string  `xml:"DAV: href"`
+	Filter   *struct {
+		CompFilter *compFilterXML `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+func (p *Propfind) fill(allProp, propName *struct{}, prop *propXML) {
+	// propname 只要求屬性名稱，以 allprop 回應的內容是它的超集合
+	if prop == nil || allProp != nil || propName != nil {
+		p.AllProp = true
+		return
+	}
+	for _, name := range prop.Names {
+		p.Props = append(p.Props, name.XMLName)
+	}
+}
+
+// ParsePropfind 解析 PROPFIND 的內容；沒有內容時視為 allprop
+func ParsePropfind(r io.Reader) (*Propfind, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return &Propfind{AllProp: true}, nil
+	}
+
+	var body propfindXML
+	if err := xml.Unmarshal(data, &body); err != nil {
+		return nil, ErrInvalidRequest
+	}
+
+	propfind := &Propfind{}
+	propfind.fill(body.AllProp, body.PropName, body.Prop)
+	return propfind, nil
+}
+
+// ParseReport 解析 calendar-query 或 calendar-multiget，其他 REPORT 回傳 ErrUnsupportedReport
+func ParseReport(r io.Reader) (*Report, error) {
+	var body reportXML
+	if err := xml.NewDecoder(r).Decode(&body); err != nil {
+		return nil, ErrInvalidRequest
+	}
+
+	report := &Report{}
+	switch body.XMLName {
+	case xml.Name{Space: NamespaceCalDAV, Local: "calendar-multiget"}:
+		report.Multiget = true
+		report.Hrefs = body.Hrefs
+	case xml.Name{Space: NamespaceCalDAV, Local: "calendar-query"}:
+		if body.Filter != nil && body.Filter.CompFilter != nil {
+			filter, err := parseFilter(body.Filter.CompFilter)
+			if err != nil {
+				return nil, err
+			}
+			report.Filter = filter
+		}
+	default:
+		return nil, ErrUnsupportedReport
+	}
+
+	report.fill(body.AllProp, body.PropName, body.Prop)
+	return report, nil
+}
+
+func parseFilter(root *compFilterXML) (Filter, error) {
+	var filter Filter
+	if !strings.EqualFold(root.Name, "VCALENDAR") {
+		return filter, ErrInvalidRequest
+	}
+	if len(root.CompFilters) == 0 {
+		return filter, nil
+	}
+
+	comp := root.CompFilters[0]
+	filter.Component = strings.ToUpper(comp.Name)
+	if comp.TimeRange != nil {
+		var err error
+		if filter.Start, err = parseTimeRange(comp.TimeRange.Start); err != nil {
+			return filter, err
+		}
+		if filter.End, err = parseTimeRange(comp.TimeRange.End); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+func parseTimeRange(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(timeRangeLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidRequest
+	}
+	return t, nil
+}
+
+// Match 判斷時間點是否在 time-range 內（開始含、結束不含）；沒有時間的項目一律符合
+func (f Filter) Match(at *time.Time) bool {
+	if at == nil {
+		return true
+	}
+	if !f.Start.IsZero() && at.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && !at.Before(f.End) {
+		return false
+	}
+	return true
+}
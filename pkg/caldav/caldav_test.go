@@ -0,0 +1,99 @@
+package caldav_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+	"todolist/pkg/caldav"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePropfind(t *testing.T) {
+	propfind, err := caldav.ParsePropfind(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.True(t, propfind.AllProp)
+
+	propfind, err = caldav.ParsePropfind(strings.NewReader(`<?xml version="1.0"?>
+<A:propfind xmlns:A="DAV:" xmlns:B="urn:ietf:params:xml:ns:caldav" xmlns:E="http://apple.com/ns/ical/">
+  <A:prop><A:getetag/><B:calendar-home-set/><E:calendar-color/></A:prop>
+</A:propfind>`))
+	require.NoError(t, err)
+	assert.False(t, propfind.AllProp)
+	assert.Equal(t, []xml.Name{
+		caldav.PropGetETag,
+		caldav.PropCalendarHomeSet,
+		{Space: "http://apple.com/ns/ical/", Local: "calendar-color"},
+	}, propfind.Props)
+
+	_, err = caldav.ParsePropfind(strings.NewReader("<propfind>"))
+	assert.ErrorIs(t, err, caldav.ErrInvalidRequest)
+}
+
+func TestParseReport(t *testing.T) {
+	report, err := caldav.ParseReport(strings.NewReader(`<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO">
+    <c:time-range start="20260101T000000Z" end="20260201T000000Z"/>
+  </c:comp-filter></c:comp-filter></c:filter>
+</c:calendar-query>`))
+	require.NoError(t, err)
+	assert.False(t, report.Multiget)
+	assert.Equal(t, []xml.Name{caldav.PropGetETag}, report.Props)
+	assert.Equal(t, "VTODO", report.Filter.Component)
+
+	inRange := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, report.Filter.Match(&inRange))
+	assert.False(t, report.Filter.Match(&end))
+	assert.True(t, report.Filter.Match(nil))
+
+	report, err = caldav.ParseReport(strings.NewReader(`<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <d:href>/caldav/calendars/2/a.ics</d:href>
+  <d:href>/caldav/calendars/2/b.ics</d:href>
+</c:calendar-multiget>`))
+	require.NoError(t, err)
+	assert.True(t, report.Multiget)
+	assert.Equal(t, []string{"/caldav/calendars/2/a.ics", "/caldav/calendars/2/b.ics"}, report.Hrefs)
+	assert.Equal(t, []xml.Name{caldav.PropGetETag, caldav.PropCalendarData}, report.Props)
+
+	_, err = caldav.ParseReport(strings.NewReader(`<d:sync-collection xmlns:d="DAV:"/>`))
+	assert.ErrorIs(t, err, caldav.ErrUnsupportedReport)
+}
+
+func TestMultistatus_Encode(t *testing.T) {
+	var ms caldav.Multistatus
+	ms.Add(caldav.Select("/caldav/calendars/2/", []caldav.Prop{
+		caldav.ResourceTypeProp(true, true, false),
+		caldav.TextProp(caldav.PropDisplayName, "工作 & 雜事"),
+		caldav.ComponentSetProp("VTODO"),
+	}, &caldav.Propfind{Props: []xml.Name{
+		caldav.PropResourceType,
+		caldav.PropDisplayName,
+		{Space: "http://apple.com/ns/ical/", Local: "calendar-color"},
+	}}))
+	ms.Add(caldav.Response{Href: "/caldav/calendars/2/gone.ics", Status: 404})
+
+	var buf bytes.Buffer
+	require.NoError(t, ms.Encode(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`)
+	assert.Contains(t, out, `<d:displayname>工作 &amp; 雜事</d:displayname>`)
+	assert.NotContains(t, out, "supported-calendar-component-set")
+	assert.Contains(t, out, `<x:calendar-color xmlns:x="http://apple.com/ns/ical/"/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status>`)
+	assert.Contains(t, out, `<d:href>/caldav/calendars/2/gone.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>`)
+
+	// 輸出為合法的 XML
+	var parsed struct {
+		Responses []struct {
+			Href string `xml:"href"`
+		} `xml:"response"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &parsed))
+	assert.Len(t, parsed.Responses, 2)
+}
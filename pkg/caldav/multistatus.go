@@ -0,0 +1,169 @@
+package caldav
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StatusMultiStatus 207 Multi-Status
+const StatusMultiStatus = http.StatusMultiStatus
+
+// 輸出時使用的前綴，其他命名空間在元素上另外宣告
+var prefixes = map[string]string{
+	NamespaceDAV:         "d",
+	NamespaceCalDAV:      "c",
+	NamespaceCalendarSrv: "cs",
+}
+
+// Prop 一個屬性；Inner 為已經是 XML 的內容，文字請用 TextProp 產生
+type Prop struct {
+	Name  xml.Name
+	Inner string
+}
+
+// Response multistatus 中的一個資源；Status 不為 0 時整個資源只回傳狀態（例如 multiget 找不到的 href）
+type Response struct {
+	Href     string
+	Props    []Prop
+	NotFound []xml.Name
+	Status   int
+}
+
+// Multistatus 207 的內容
+type Multistatus struct {
+	Responses []Response
+}
+
+// Add 加入一個資源
+func (m *Multistatus) Add(response Response) {
+	m.Responses = append(m.Responses, response)
+}
+
+// Select 依請求從可用的屬性中挑出要回傳的；allprop 時回傳全部，要求了但沒有的列在 NotFound
+func Select(href string, available []Prop, req *Propfind) Response {
+	response := Response{Href: href}
+	if req.AllProp {
+		response.Props = available
+		return response
+	}
+
+	for _, name := range req.Props {
+		found := false
+		for _, prop := range available {
+			if prop.Name == name {
+				response.Props = append(response.Props, prop)
+				found = true
+				break
+			}
+		}
+		if !found {
+			response.NotFound = append(response.NotFound, name)
+		}
+	}
+	return response
+}
+
+// TextProp 文字內容的屬性
+func TextProp(name xml.Name, text string) Prop {
+	return Prop{Name: name, Inner: escape(text)}
+}
+
+// HrefProp 內容為一個 href 的屬性，例如 current-user-principal
+func HrefProp(name xml.Name, href string) Prop {
+	return Prop{Name: name, Inner: "<d:href>" + escape(href) + "</d:href>"}
+}
+
+// ResourceTypeProp resourcetype；集合、日曆集合或 principal
+func ResourceTypeProp(collection, calendar, principal bool) Prop {
+	var b strings.Builder
+	if collection {
+		b.WriteString("<d:collection/>")
+	}
+	if calendar {
+		b.WriteString("<c:calendar/>")
+	}
+	if principal {
+		b.WriteString("<d:principal/>")
+	}
+	return Prop{Name: PropResourceType, Inner: b.String()}
+}
+
+// ComponentSetProp supported-calendar-component-set
+func ComponentSetProp(components ...string) Prop {
+	var b strings.Builder
+	for _, component := range components {
+		fmt.Fprintf(&b, `<c:comp name="%s"/>`, escape(component))
+	}
+	return Prop{Name: PropSupportedCalendarComponentSet, Inner: b.String()}
+}
+
+// ReportSetProp supported-report-set，列出 calendar-query 與 calendar-multiget
+func ReportSetProp() Prop {
+	return Prop{
+		Name: PropSupportedReportSet,
+		Inner: "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>",
+	}
+}
+
+// Encode 輸出 multistatus 的 XML
+func (m *Multistatus) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	fmt.Fprintf(bw, `<d:multistatus xmlns:d="%s" xmlns:c="%s" xmlns:cs="%s">`, NamespaceDAV, NamespaceCalDAV, NamespaceCalendarSrv)
+	for _, response := range m.Responses {
+		bw.WriteString("<d:response><d:href>" + escape(response.Href) + "</d:href>")
+		if response.Status != 0 {
+			bw.WriteString("<d:status>" + statusLine(response.Status) + "</d:status></d:response>")
+			continue
+		}
+		if len(response.Props) > 0 || len(response.NotFound) == 0 {
+			bw.WriteString("<d:propstat><d:prop>")
+			for _, prop := range response.Props {
+				open, end := element(prop.Name)
+				if prop.Inner == "" {
+					bw.WriteString(open[:len(open)-1] + "/>")
+					continue
+				}
+				bw.WriteString(open + prop.Inner + end)
+			}
+			bw.WriteString("</d:prop><d:status>" + statusLine(http.StatusOK) + "</d:status></d:propstat>")
+		}
+		if len(response.NotFound) > 0 {
+			bw.WriteString("<d:propstat><d:prop>")
+			for _, name := range response.NotFound {
+				open, _ := element(name)
+				bw.WriteString(open[:len(open)-1] + "/>")
+			}
+			bw.WriteString("</d:prop><d:status>" + statusLine(http.StatusNotFound) + "</d:status></d:propstat>")
+		}
+		bw.WriteString("</d:response>")
+	}
+	bw.WriteString("</d:multistatus>")
+	return bw.Flush()
+}
+
+// element 產生開始與結束標籤，不在預設前綴內的命名空間在元素上宣告
+func element(name xml.Name) (string, string) {
+	if prefix, ok := prefixes[name.Space]; ok {
+		return "<" + prefix + ":" + name.Local + ">", "</" + prefix + ":" + name.Local + ">"
+	}
+	if name.Space == "" {
+		return "<" + name.Local + ">", "</" + name.Local + ">"
+	}
+	return `<x:` + name.Local + ` xmlns:x="` + escape(name.Space) + `">`, "</x:" + name.Local + ">"
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
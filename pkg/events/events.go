@@ -0,0 +1,123 @@
+// Package events 提供領域事件的送出介面：服務在交易內把事件寫入 outbox，commit 後由 relay 交給 Broker。
+// 送出為 at-least-once，同一個事件可能送達多次，訂閱者需以事件 ID 排除重複。
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// All 訂閱所有事件
+const All = "*"
+
+// Event 已 commit 的領域事件；Data 為事件內容的 JSON
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	ActorID    *int            `json:"actor_id"`
+	TodoListID *int            `json:"to_do_list_id"`
+	Data       json.RawMessage `json:"data"`
+	// Depth 由自動化規則的動作引起時為連鎖的層數，使用者直接操作的為 0
+	Depth int `json:"depth,omitempty"`
+	// WatcherIDs 關注事件所屬 TodoList 或項目的使用者，由 relay 在送出前附上，可作為通知對象
+	WatcherIDs []int `json:"watcher_ids,omitempty"`
+}
+
+// Decode 把 Data 解碼到 v
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Handler 處理事件；回傳錯誤時事件稍後會再送一次
+type Handler func(ctx context.Context, e Event) error
+
+// Broker 事件的去處，外部的訊息佇列實作這個介面即可接到 relay 上；回傳錯誤時事件稍後會再送一次
+type Broker interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Bus 行程內的訂閱者
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe 訂閱事件類型，eventType 為 All 時收到所有事件
+func (b *Bus) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+// Publish 依訂閱順序呼叫訂閱者；其中一個失敗時其餘的仍會被呼叫，回傳合併後的錯誤。
+// 事件重送時所有訂閱者都會再收到一次
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.handlers[e.Type]...), b.handlers[All]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fanout 依序送給多個 Broker，其中一個失敗時其餘的仍會送出
+type Fanout []Broker
+
+func (f Fanout) Publish(ctx context.Context, e Event) error {
+	var errs []error
+	for _, broker := range f {
+		if err := broker.Publish(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Memory 把事件保存在記憶體的 Broker，用於測試或代替尚未接上的外部 broker
+type Memory struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Publish 保存事件；FailWith 設定的錯誤存在時不保存並回傳該錯誤
+func (m *Memory) Publish(_ context.Context, e Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, e)
+	return nil
+}
+
+// FailWith 之後的 Publish 都回傳 err，傳入 nil 時恢復
+func (m *Memory) FailWith(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// Events 目前收到的事件
+func (m *Memory) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Event{}, m.events...)
+}
@@ -0,0 +1,55 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todolist/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusPublish(t *testing.T) {
+	bus := events.NewBus()
+	var got []string
+	bus.Subscribe("list.created", func(_ context.Context, e events.Event) error {
+		got = append(got, "list:"+e.ID)
+		return errors.New("寫入失敗")
+	})
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		got = append(got, "all:"+e.ID)
+		return nil
+	})
+
+	// 其中一個訂閱者失敗時其餘的仍會收到
+	err := bus.Publish(context.Background(), events.Event{ID: "1", Type: "list.created"})
+	assert.EqualError(t, err, "寫入失敗")
+	assert.Equal(t, []string{"list:1", "all:1"}, got)
+
+	got = nil
+	require.NoError(t, bus.Publish(context.Background(), events.Event{ID: "2", Type: "detail.updated"}))
+	assert.Equal(t, []string{"all:2"}, got)
+}
+
+func TestMemoryAndFanout(t *testing.T) {
+	first, second := events.NewMemory(), events.NewMemory()
+	broker := events.Fanout{first, second}
+
+	first.FailWith(errors.New("broker 無法連線"))
+	err := broker.Publish(context.Background(), events.Event{ID: "1", Data: []byte(`{"name":"工作"}`)})
+	assert.Error(t, err)
+	assert.Empty(t, first.Events())
+	require.Len(t, second.Events(), 1)
+
+	var data struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, second.Events()[0].Decode(&data))
+	assert.Equal(t, "工作", data.Name)
+
+	first.FailWith(nil)
+	require.NoError(t, broker.Publish(context.Background(), events.Event{ID: "1"}))
+	assert.Len(t, first.Events(), 1)
+	assert.Len(t, second.Events(), 2)
+}
@@ -0,0 +1,140 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDepth 元件巢狀的上限，避免惡意檔案
+const maxDepth = 10
+
+// Decode 讀取第一個 VCALENDAR，接受 CRLF 或 LF 換行
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 第 %d 行：%v", ErrInvalidCalendar, i+1, err)
+		}
+
+		switch strings.ToUpper(p.Name) {
+		case "BEGIN":
+			if len(stack) == 0 && !strings.EqualFold(p.Value, Calendar) {
+				return nil, fmt.Errorf("%w: 不是 VCALENDAR", ErrInvalidCalendar)
+			}
+			if len(stack) >= maxDepth {
+				return nil, fmt.Errorf("%w: 巢狀太深", ErrInvalidCalendar)
+			}
+			child := NewComponent(strings.ToUpper(p.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].Add(child)
+			}
+			stack = append(stack, child)
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1].Name, p.Value) {
+				return nil, fmt.Errorf("%w: 第 %d 行的 END:%s 沒有對應的 BEGIN", ErrInvalidCalendar, i+1, p.Value)
+			}
+			if len(stack) == 1 {
+				return stack[0], nil
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: 不是 VCALENDAR", ErrInvalidCalendar)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, p)
+		}
+	}
+	return nil, fmt.Errorf("%w: 沒有結束的 VCALENDAR", ErrInvalidCalendar)
+}
+
+// unfold 將以空白或 tab 開頭的續行接回上一行
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && line != "" && (line[0] == ' ' || line[0] == '\t') {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCalendar, err)
+	}
+	return lines, nil
+}
+
+// parseLine 解析 NAME;PARAM=VALUE;PARAM="QUOTED":VALUE，參數名稱轉為大寫
+func parseLine(line string) (Property, error) {
+	p := Property{}
+
+	// 找出第一個不在引號內的冒號
+	colon, quoted := -1, false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf("缺少冒號")
+	}
+	p.Value = line[colon+1:]
+
+	parts := splitParams(line[:colon])
+	p.Name = strings.ToUpper(parts[0])
+	if p.Name == "" {
+		return p, fmt.Errorf("缺少屬性名稱")
+	}
+	for _, param := range parts[1:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return p, fmt.Errorf("無效的參數 %q", param)
+		}
+		if p.Params == nil {
+			p.Params = map[string]string{}
+		}
+		p.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return p, nil
+}
+
+// splitParams 以不在引號內的分號分隔
+func splitParams(s string) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,70 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineOctets 每行（不含 CRLF）最多的 octet 數，超過時折行
+const maxLineOctets = 75
+
+// Encode 以 CRLF 換行輸出元件
+func Encode(w io.Writer, c *Component) error {
+	buffered := bufio.NewWriter(w)
+	writeComponent(buffered, c)
+	return buffered.Flush()
+}
+
+func writeComponent(w *bufio.Writer, c *Component) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		writeLine(w, contentLine(p))
+	}
+	for _, child := range c.Components {
+		writeComponent(w, child)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+func contentLine(p Property) string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+
+	// 參數依名稱排序，輸出才會固定
+	names := make([]string, 0, len(p.Params))
+	for name := range p.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := p.Params[name]
+		if strings.ContainsAny(value, ":;,") {
+			value = `"` + value + `"`
+		}
+		b.WriteString(";" + name + "=" + value)
+	}
+
+	b.WriteString(":" + p.Value)
+	return b.String()
+}
+
+// writeLine 超過 75 octet 時折行，續行以一個空白開頭；不會切在 UTF-8 字元中間
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// 續行開頭的空白也算在 75 octet 內
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
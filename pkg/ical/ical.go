@@ -0,0 +1,137 @@
+// Package ical 實作 RFC 5545 iCalendar 的子集合：輸出時處理跳脫與 75 octet 折行，
+// 讀取時展開折行並解析巢狀的元件與屬性參數；不處理 RRULE 展開與 VTIMEZONE 定義，TZID 以 IANA 名稱載入。
+package ical
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// 常用的元件名稱
+const (
+	Calendar = "VCALENDAR"
+	Todo     = "VTODO"
+	Event    = "VEVENT"
+)
+
+const (
+	dateLayout      = "20060102"
+	dateTimeLayout  = "20060102T150405"
+	utcDateTimeForm = "20060102T150405Z"
+)
+
+var ErrInvalidCalendar = errors.New("ical: invalid calendar")
+
+// Property 一個內容行，例如 DUE;TZID=Asia/Taipei:20260105T090000
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component 一個 BEGIN/END 區塊，可以再包含子元件
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// NewComponent 建立空的元件
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Set 加上屬性；value 為原始值，文字需先以 EscapeText 跳脫
+func (c *Component) Set(name, value string, params ...string) {
+	p := Property{Name: name, Value: value}
+	if len(params) > 0 {
+		p.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			p.Params[params[i]] = params[i+1]
+		}
+	}
+	c.Properties = append(c.Properties, p)
+}
+
+// SetText 加上文字屬性（自動跳脫）
+func (c *Component) SetText(name, value string) {
+	c.Set(name, EscapeText(value))
+}
+
+// SetTime 加上 UTC 時間屬性
+func (c *Component) SetTime(name string, t time.Time) {
+	c.Set(name, t.UTC().Format(utcDateTimeForm))
+}
+
+// Add 加上子元件
+func (c *Component) Add(child *Component) {
+	c.Components = append(c.Components, child)
+}
+
+// Get 取得第一個同名屬性，沒有時回傳 nil
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if strings.EqualFold(c.Properties[i].Name, name) {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Text 取得文字屬性並還原跳脫，沒有時回傳空字串
+func (c *Component) Text(name string) string {
+	if p := c.Get(name); p != nil {
+		return UnescapeText(p.Value)
+	}
+	return ""
+}
+
+// Time 解析時間屬性：DATE 視為當天 00:00 UTC，結尾為 Z 的為 UTC，
+// 有 TZID 的以該時區解析，都沒有（floating）時以 UTC 解析；allDay 表示值為 DATE
+func (p *Property) Time() (t time.Time, allDay bool, err error) {
+	value := strings.TrimSpace(p.Value)
+	if p.Params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err = time.Parse(dateLayout, value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(utcDateTimeForm, value)
+		return t, false, err
+	}
+
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if loc, err = time.LoadLocation(strings.Trim(tzid, `"`)); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+	t, err = time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// EscapeText 依 RFC 5545 3.3.11 跳脫文字
+func EscapeText(s string) string {
+	return textEscaper.Replace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
+
+// UnescapeText 還原 EscapeText
+func UnescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
@@ -0,0 +1,92 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"todolist/pkg/ical"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_FoldsAndEscapes(t *testing.T) {
+	cal := ical.NewComponent(ical.Calendar)
+	cal.Set("VERSION", "2.0")
+	todo := ical.NewComponent(ical.Todo)
+	todo.SetText("SUMMARY", "整理文件, 備份; 上傳")
+	todo.SetText("DESCRIPTION", strings.Repeat("說明", 30)+"\n第二行")
+	todo.SetTime("DUE", time.Date(2026, 1, 5, 9, 0, 0, 0, time.FixedZone("CST", 8*3600)))
+	todo.Set("X-NOTE", "a", "LANGUAGE", "zh:TW")
+	cal.Add(todo)
+
+	var buf bytes.Buffer
+	require.NoError(t, ical.Encode(&buf, cal))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\n"))
+	assert.Contains(t, out, `SUMMARY:整理文件\, 備份\; 上傳`+"\r\n")
+	assert.Contains(t, out, "DUE:20260105T010000Z\r\n")
+	assert.Contains(t, out, `X-NOTE;LANGUAGE="zh:TW":a`+"\r\n")
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+
+	// 輸出的內容可以再讀回來
+	decoded, err := ical.Decode(&buf)
+	require.NoError(t, err)
+	require.Len(t, decoded.Components, 1)
+	got := decoded.Components[0]
+	assert.Equal(t, "整理文件, 備份; 上傳", got.Text("SUMMARY"))
+	assert.Equal(t, strings.Repeat("說明", 30)+"\n第二行", got.Text("DESCRIPTION"))
+	assert.Equal(t, "zh:TW", got.Get("X-NOTE").Params["LANGUAGE"])
+}
+
+func TestDecode_Times(t *testing.T) {
+	data := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\n" +
+		"DTSTART;TZID=Asia/Taipei:20260105T090000\n" +
+		"DTEND;VALUE=DATE:20260106\n" +
+		"DUE:20260105T010000Z\n" +
+		"X-FLOATING:20260105T090000\n" +
+		"END:VEVENT\n" +
+		"END:VCALENDAR\n"
+
+	cal, err := ical.Decode(strings.NewReader(data))
+	require.NoError(t, err)
+	event := cal.Components[0]
+	assert.Equal(t, ical.Event, event.Name)
+
+	start, allDay, err := event.Get("DTSTART").Time()
+	require.NoError(t, err)
+	assert.False(t, allDay)
+	assert.True(t, start.Equal(time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC)))
+
+	end, allDay, err := event.Get("DTEND").Time()
+	require.NoError(t, err)
+	assert.True(t, allDay)
+	assert.Equal(t, time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC), end)
+
+	due, _, err := event.Get("DUE").Time()
+	require.NoError(t, err)
+	assert.True(t, due.Equal(start))
+
+	floating, _, err := event.Get("X-FLOATING").Time()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), floating)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	cases := []string{
+		"",
+		"BEGIN:VTODO\nEND:VTODO\n",
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nSUMMARY\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\n",
+	}
+	for _, data := range cases {
+		_, err := ical.Decode(strings.NewReader(data))
+		assert.ErrorIs(t, err, ical.ErrInvalidCalendar, "%q", data)
+	}
+}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mapping 欄位對應：key 為 Fields 中的欄位，value 為 CSV 標題列的欄位名稱
+type Mapping map[string]string
+
+// Validate 檢查必要欄位與未知的欄位
+func (m Mapping) Validate() error {
+	for field := range m {
+		known := false
+		for _, f := range Fields {
+			known = known || f == field
+		}
+		if !known {
+			return fmt.Errorf("不支援的對應欄位 %q", field)
+		}
+	}
+	if m[FieldList] == "" || m[FieldName] == "" {
+		return errors.New("必須對應 list 與 name 欄位")
+	}
+	return nil
+}
+
+// ParseCSV 以第一列為標題列，依 mapping 取出每一列的欄位；mapping 中的欄位名稱不存在時回傳錯誤
+func ParseCSV(r io.Reader, mapping Mapping) ([]Record, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 略過 Excel 加上的 UTF-8 BOM
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\ufeff" {
+		_, _ = buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: 沒有標題列", ErrInvalidFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	index := make(map[string]int, len(mapping))
+	for field, name := range mapping {
+		if name == "" {
+			continue
+		}
+		i, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("CSV 沒有 %q 欄位（對應 %s）", name, field)
+		}
+		index[field] = i
+	}
+
+	records := []Record{}
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(values[i])
+		}
+
+		// 整列空白（例如檔案結尾多的空行）不列入
+		if strings.TrimSpace(strings.Join(values, "")) == "" {
+			continue
+		}
+
+		records = append(records, Record{
+			Row:       row,
+			List:      get(FieldList),
+			Type:      get(FieldType),
+			Name:      get(FieldName),
+			Detail:    get(FieldDetail),
+			Status:    get(FieldStatus),
+			Assignees: SplitAssignees(get(FieldAssignees)),
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package importer 將其他工具匯出的資料轉成統一的 Record，
+// 目前支援自訂欄位對應的 CSV 與 Trello 看板匯出的 JSON。
+//
+// 這裡只負責解析，不檢查資料是否合法，也不處理帳號、類型等對應，由呼叫端負責。
+package importer
+
+import (
+	"errors"
+	"strings"
+)
+
+// 可以對應的欄位
+const (
+	FieldList      = "list"
+	FieldType      = "type"
+	FieldName      = "name"
+	FieldDetail    = "detail"
+	FieldStatus    = "status"
+	FieldAssignees = "assignees"
+)
+
+// Fields 所有可以對應的欄位
+var Fields = []string{FieldList, FieldType, FieldName, FieldDetail, FieldStatus, FieldAssignees}
+
+// ErrInvalidFile 檔案內容無法解析
+var ErrInvalidFile = errors.New("importer: invalid file")
+
+// Record 一個要匯入的項目；Row 為來源中的列號（CSV 含標題列從 1 起算，JSON 為卡片順序從 1 起算），
+// Status 為來源的原始值，Skip 不為空時表示此項目應略過及其原因
+type Record struct {
+	Row       int
+	List      string
+	Type      string
+	Name      string
+	Detail    string
+	Status    string
+	Assignees []string
+	Skip      string
+}
+
+// SplitAssignees 以逗號、分號或空白分隔多個帳號
+func SplitAssignees(value string) []string {
+	accounts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n'
+	})
+	if accounts == nil {
+		return []string{}
+	}
+	return accounts
+}
@@ -0,0 +1,87 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"todolist/pkg/importer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := "\ufeffProject,Task,Notes,State,Owner\n" +
+		"Website,Landing page,\"first line\nsecond line\",Done,\"alice; bob\"\n" +
+		",,,,\n" +
+		"Website,Footer\n"
+	mapping := importer.Mapping{
+		importer.FieldList:      "Project",
+		importer.FieldName:      "Task",
+		importer.FieldDetail:    "Notes",
+		importer.FieldStatus:    "State",
+		importer.FieldAssignees: "Owner",
+	}
+
+	records, err := importer.ParseCSV(strings.NewReader(data), mapping)
+
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, importer.Record{
+		Row:       2,
+		List:      "Website",
+		Name:      "Landing page",
+		Detail:    "first line\nsecond line",
+		Status:    "Done",
+		Assignees: []string{"alice", "bob"},
+	}, records[0])
+	// 空白列不列入，但列號仍依檔案中的位置
+	assert.Equal(t, 4, records[1].Row)
+	assert.Equal(t, "Footer", records[1].Name)
+	assert.Empty(t, records[1].Assignees)
+}
+
+func TestParseCSV_MappingErrors(t *testing.T) {
+	_, err := importer.ParseCSV(strings.NewReader("a,b\n"), importer.Mapping{importer.FieldName: "a"})
+	assert.Error(t, err)
+
+	_, err = importer.ParseCSV(strings.NewReader("a,b\n"), importer.Mapping{importer.FieldList: "a", importer.FieldName: "c"})
+	assert.ErrorContains(t, err, `"c"`)
+
+	_, err = importer.ParseCSV(strings.NewReader("a,b\n"), importer.Mapping{importer.FieldList: "a", importer.FieldName: "b", "due": "a"})
+	assert.Error(t, err)
+
+	_, err = importer.ParseCSV(strings.NewReader(""), importer.Mapping{importer.FieldList: "a", importer.FieldName: "b"})
+	assert.ErrorIs(t, err, importer.ErrInvalidFile)
+}
+
+func TestParseTrello(t *testing.T) {
+	data := `{
+		"name": "Sprint",
+		"lists": [{"id": "l1", "name": "Doing"}, {"id": "l2", "name": "Old", "closed": true}],
+		"members": [{"id": "m1", "username": "alice"}],
+		"cards": [
+			{"name": "Write docs", "desc": "**md**", "idList": "l1", "idMembers": ["m1", "m9"], "dueComplete": true},
+			{"name": "Archived", "idList": "l1", "closed": true},
+			{"name": "In closed list", "idList": "l2"}
+		]
+	}`
+
+	records, err := importer.ParseTrello(strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, importer.Record{
+		Row:       1,
+		List:      "Doing",
+		Type:      "Sprint",
+		Name:      "Write docs",
+		Detail:    "**md**",
+		Status:    importer.TrelloDone,
+		Assignees: []string{"alice"},
+	}, records[0])
+	assert.Equal(t, "卡片已封存", records[1].Skip)
+	assert.Equal(t, "list 已封存", records[2].Skip)
+
+	_, err = importer.ParseTrello(strings.NewReader(`{"name": "not a board"}`))
+	assert.ErrorIs(t, err, importer.ErrInvalidFile)
+}
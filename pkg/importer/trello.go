@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TrelloDone Trello 卡片標記到期完成（dueComplete）時的狀態
+const TrelloDone = "done"
+
+type trelloBoard struct {
+	Name    string         `json:"name"`
+	Lists   []trelloList   `json:"lists"`
+	Cards   []trelloCard   `json:"cards"`
+	Members []trelloMember `json:"members"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloCard struct {
+	Name        string   `json:"name"`
+	Desc        string   `json:"desc"`
+	IDList      string   `json:"idList"`
+	IDMembers   []string `json:"idMembers"`
+	Closed      bool     `json:"closed"`
+	DueComplete bool     `json:"dueComplete"`
+}
+
+type trelloMember struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// ParseTrello 解析 Trello 看板匯出的 JSON：看板名稱為類型，每個 list 為一個 TodoList，卡片為項目，
+// 成員以 Trello 的 username 對應帳號；已封存的卡片或 list 會標記為略過
+func ParseTrello(r io.Reader) ([]Record, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if board.Lists == nil || board.Cards == nil {
+		return nil, fmt.Errorf("%w: 不是 Trello 看板匯出的 JSON", ErrInvalidFile)
+	}
+
+	lists := make(map[string]trelloList, len(board.Lists))
+	for _, list := range board.Lists {
+		lists[list.ID] = list
+	}
+	members := make(map[string]string, len(board.Members))
+	for _, member := range board.Members {
+		members[member.ID] = member.Username
+	}
+
+	records := make([]Record, 0, len(board.Cards))
+	for i, card := range board.Cards {
+		record := Record{
+			Row:       i + 1,
+			Type:      strings.TrimSpace(board.Name),
+			Name:      strings.TrimSpace(card.Name),
+			Detail:    card.Desc,
+			Assignees: []string{},
+		}
+
+		list, ok := lists[card.IDList]
+		switch {
+		case !ok:
+			record.Skip = "找不到卡片所屬的 list"
+		case list.Closed:
+			record.Skip = "list 已封存"
+		case card.Closed:
+			record.Skip = "卡片已封存"
+		}
+		record.List = strings.TrimSpace(list.Name)
+
+		if card.DueComplete {
+			record.Status = TrelloDone
+		}
+		for _, id := range card.IDMembers {
+			if username, ok := members[id]; ok {
+				record.Assignees = append(record.Assignees, username)
+			}
+		}
+
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,113 @@
+// Package mail 組成 HTML 與純文字並存的 MIME 信件，並透過 SMTP 寄出。
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sender 寄出信件；回傳錯誤時由呼叫端決定是否重試
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message 一封信件，Text 與 HTML 以 multipart/alternative 一起寄出，收件端自行選擇顯示哪一個
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers 額外的標頭，例如 List-Unsubscribe
+	Headers map[string]string
+}
+
+// Bytes 組成可直接交給 SMTP DATA 的內容，行尾為 CRLF
+func (m *Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("寄件者格式錯誤: %w", err)
+	}
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return nil, fmt.Errorf("收件者格式錯誤: %w", err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(toCRLF(part.content))); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"From":         from.String(),
+		"To":           to.String(),
+		"Subject":      mime.QEncoding.Encode("UTF-8", m.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"Message-ID":   messageID(from.Address),
+		"MIME-Version": "1.0",
+		"Content-Type": `multipart/alternative; boundary="` + parts.Boundary() + `"`,
+	}
+	for key, value := range m.Headers {
+		headers[textproto.CanonicalMIMEHeaderKey(key)] = value
+	}
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out bytes.Buffer
+	for _, key := range keys {
+		// 標頭值不可含換行，避免被插入額外的標頭
+		value := strings.NewReplacer("\r", "", "\n", "").Replace(headers[key])
+		fmt.Fprintf(&out, "%s: %s\r\n", key, value)
+	}
+	out.WriteString("\r\n")
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+func toCRLF(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
+
+func messageID(address string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		domain = address[at+1:]
+	}
+	raw := make([]byte, 16)
+	rand.Read(raw)
+	return "<" + hex.EncodeToString(raw) + "@" + domain + ">"
+}
@@ -0,0 +1,49 @@
+package mail_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"todolist/pkg/mail"
+	"todolist/pkg/mail/mailtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTP_Send(t *testing.T) {
+	server, err := mailtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+
+	host, port := server.Addr()
+	sender := mail.NewSMTP(mail.SMTPConfig{Host: host, Port: port})
+	msg := &mail.Message{
+		From:    "待辦清單 <no-reply@example.com>",
+		To:      "bob@example.com",
+		Subject: "你被指派負責「寫報告」",
+		Text:    "第一行\n.以點開頭的一行\n" + strings.Repeat("長", 100),
+		HTML:    `<p>請看 <a href="https://example.com/?a=1&amp;b=2">這裡</a></p>`,
+		Headers: map[string]string{"list-unsubscribe": "<https://example.com/u?token=x>\r\nBcc: evil@example.com"},
+	}
+	require.NoError(t, sender.Send(context.Background(), msg))
+
+	received := server.Messages()
+	require.Len(t, received, 1)
+	got := received[0]
+	assert.Equal(t, "no-reply@example.com", got.From)
+	assert.Equal(t, []string{"bob@example.com"}, got.To)
+	assert.Equal(t, msg.Subject, got.Subject())
+	assert.Equal(t, msg.Text, got.Part("text/plain"))
+	assert.Equal(t, msg.HTML, got.Part("text/html"))
+	// 標頭中的換行被移除，不會多出 Bcc
+	assert.Equal(t, "<https://example.com/u?token=x>Bcc: evil@example.com", got.Header().Get("List-Unsubscribe"))
+	assert.Empty(t, got.Header().Get("Bcc"))
+
+	server.FailNext(1)
+	assert.Error(t, sender.Send(context.Background(), msg))
+	assert.Len(t, server.Messages(), 1)
+
+	msg.To = "不是信箱"
+	assert.Error(t, sender.Send(context.Background(), msg))
+}
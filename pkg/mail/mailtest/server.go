@@ -0,0 +1,183 @@
+// Package mailtest 提供行程內的 SMTP 伺服器，收到的信件保存在記憶體中，用於測試或本機開發時代替 MailHog。
+package mailtest
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Received 收到的一封信件
+type Received struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Header 解析後的標頭，Subject 等編碼過的值需自行以 mime.WordDecoder 解碼
+func (r Received) Header() mail.Header {
+	msg, err := mail.ReadMessage(bytes.NewReader(r.Data))
+	if err != nil {
+		return mail.Header{}
+	}
+	return msg.Header
+}
+
+// Subject 解碼後的主旨
+func (r Received) Subject() string {
+	subject, err := new(mime.WordDecoder).DecodeHeader(r.Header().Get("Subject"))
+	if err != nil {
+		return ""
+	}
+	return subject
+}
+
+// Part 取出指定 Content-Type（如 text/plain）的內容並解碼；找不到時回傳空字串
+func (r Received) Part(contentType string) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(r.Data))
+	if err != nil {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextRawPart()
+		if err != nil {
+			return ""
+		}
+		if mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); mediaType != contentType {
+			continue
+		}
+		var body io.Reader = part
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(part)
+		}
+		content, _ := io.ReadAll(body)
+		return strings.ReplaceAll(string(content), "\r\n", "\n")
+	}
+}
+
+// Server 只實作寄送需要的指令，不支援 STARTTLS 與驗證
+type Server struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	messages []Received
+	failures int
+}
+
+// NewServer 在 127.0.0.1 的隨機埠開始接收，用完需呼叫 Close
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, nil
+}
+
+// Addr 伺服器的 host 與 port
+func (s *Server) Addr() (host, port string) {
+	host, port, _ = net.SplitHostPort(s.ln.Addr().String())
+	return host, port
+}
+
+func (s *Server) Close() error {
+	return s.ln.Close()
+}
+
+// Messages 目前收到的信件
+func (s *Server) Messages() []Received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Received(nil), s.messages...)
+}
+
+// FailNext 接下來 n 次寄送在收件者階段回應暫時性錯誤，用來測試重試
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 mailtest ready")
+
+	var current Received
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 mailtest")
+		case "MAIL":
+			current = Received{From: address(arg)}
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.mu.Lock()
+			fail := s.failures > 0
+			if fail {
+				s.failures--
+			}
+			s.mu.Unlock()
+			if fail {
+				tp.PrintfLine("451 temporary failure")
+				continue
+			}
+			current.To = append(current.To, address(arg))
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 end with <CRLF>.<CRLF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "RSET", "NOOP":
+			current = Received{}
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+// address 取出 FROM:<a@b> 或 TO:<a@b> 中的位址
+func address(arg string) string {
+	start, end := strings.Index(arg, "<"), strings.LastIndex(arg, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
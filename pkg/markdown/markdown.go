@@ -0,0 +1,65 @@
+// Package markdown 將任務說明的 Markdown 轉成可安全顯示的 HTML，並解析其中的 - [ ] 待辦清單。
+package markdown
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+const extensions = blackfriday.CommonExtensions
+
+// Render 將 Markdown 轉成 HTML，輸出一律經過 Sanitize 過濾，可直接嵌入頁面
+func Render(src string) string {
+	if src == "" {
+		return ""
+	}
+
+	renderer := &taskListRenderer{
+		HTMLRenderer: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+			Flags: blackfriday.Safelink | blackfriday.NofollowLinks | blackfriday.NoreferrerLinks,
+		}),
+	}
+	out := blackfriday.Run([]byte(src), blackfriday.WithExtensions(extensions), blackfriday.WithRenderer(renderer))
+	return Sanitize(string(out))
+}
+
+// taskListRenderer 將清單項目開頭的 [ ] / [x] 轉成唯讀的 checkbox
+type taskListRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+func (r *taskListRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	if node.Type == blackfriday.Text && isFirstTextInItem(node) {
+		if done, rest, ok := taskMarker(node.Literal); ok {
+			if done {
+				io.WriteString(w, `<input type="checkbox" checked="" disabled=""/> `)
+			} else {
+				io.WriteString(w, `<input type="checkbox" disabled=""/> `)
+			}
+			node.Literal = rest
+		}
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// isFirstTextInItem 是否為清單項目第一段的第一個文字節點
+func isFirstTextInItem(node *blackfriday.Node) bool {
+	paragraph := node.Parent
+	if paragraph == nil || paragraph.Type != blackfriday.Paragraph || paragraph.FirstChild != node {
+		return false
+	}
+	item := paragraph.Parent
+	return item != nil && item.Type == blackfriday.Item && item.FirstChild == paragraph
+}
+
+func taskMarker(text []byte) (done bool, rest []byte, ok bool) {
+	switch {
+	case bytes.HasPrefix(text, []byte("[ ] ")):
+		return false, text[4:], true
+	case bytes.HasPrefix(text, []byte("[x] ")), bytes.HasPrefix(text, []byte("[X] ")):
+		return true, text[4:], true
+	}
+	return false, nil, false
+}
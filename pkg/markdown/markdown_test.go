@@ -0,0 +1,56 @@
+package markdown_test
+
+import (
+	"testing"
+	"todolist/pkg/markdown"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	out := markdown.Render("# 標題\n\n**粗體** 與 [連結](https://example.com)\n\n```go\nfmt.Println(\"<hi>\")\n```")
+
+	assert.Contains(t, out, "<h1>標題</h1>")
+	assert.Contains(t, out, "<strong>粗體</strong>")
+	assert.Contains(t, out, `<a href="https://example.com" rel="nofollow noopener noreferrer">連結</a>`)
+	assert.Contains(t, out, `<code class="language-go">fmt.Println(&#34;&lt;hi&gt;&#34;)`)
+}
+
+func TestRender_TaskList(t *testing.T) {
+	out := markdown.Render("- [ ] 寫測試\n- [x] 寫程式\n- 一般項目")
+
+	assert.Contains(t, out, `<li><input type="checkbox" disabled=""/> 寫測試</li>`)
+	assert.Contains(t, out, `<li><input type="checkbox" checked="" disabled=""/> 寫程式</li>`)
+	assert.Contains(t, out, `<li>一般項目</li>`)
+}
+
+func TestRender_StripsXSS(t *testing.T) {
+	cases := []string{
+		`<script>alert(1)</script>`,
+		`<img src=x onerror=alert(1)>`,
+		`[點我](javascript:alert(1))`,
+		`<a href="jav&#x09;ascript:alert(1)">x</a>`,
+		`<a href="JAVASCRIPT:alert(1)">x</a>`,
+		`<iframe src="https://evil.example"></iframe>`,
+		`<div style="background:url(javascript:alert(1))">x</div>`,
+		`<svg><script>alert(1)</script></svg>`,
+		`<input type="text" onfocus="alert(1)" autofocus>`,
+	}
+	for _, src := range cases {
+		out := markdown.Render(src)
+		assert.NotContains(t, out, "<script", src)
+		assert.NotContains(t, out, "onerror", src)
+		assert.NotContains(t, out, "onfocus", src)
+		assert.NotContains(t, out, "javascript:", src)
+		assert.NotContains(t, out, "<iframe", src)
+		assert.NotContains(t, out, "style=", src)
+		assert.NotContains(t, out, `type="text"`, src)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, `<p>a<strong>b</strong></p>`, markdown.Sanitize(`<p onclick="x()">a<strong>b</p>`))
+	assert.Equal(t, `<img src="/a.png" alt="a"/>`, markdown.Sanitize(`<img src="/a.png" alt="a" width="1">`))
+	assert.Equal(t, `hello`, markdown.Sanitize(`<!-- c --><span>hello</span><style>p{}</style>`))
+	assert.Equal(t, `<td align="left">x</td>`, markdown.Sanitize(`<td align="left">x</td>`))
+}
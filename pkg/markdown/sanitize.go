@@ -0,0 +1,189 @@
+package markdown
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedAttrs 允許的標籤與各自可保留的屬性，不在清單內的標籤只保留文字內容
+var allowedAttrs = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.Del: nil, atom.S: nil,
+	atom.Sup: nil, atom.Sub: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: {"class"},
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil,
+	atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil,
+	atom.Th: {"align"}, atom.Td: {"align"},
+	atom.A:     {"href", "title"},
+	atom.Img:   {"src", "alt", "title"},
+	atom.Input: {"type", "checked"},
+}
+
+// droppedWithContent 連同內容一起移除的標籤
+var droppedWithContent = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Title: true,
+	atom.Svg: true, atom.Math: true, atom.Select: true,
+}
+
+var voidElements = map[atom.Atom]bool{atom.Br: true, atom.Hr: true, atom.Img: true, atom.Input: true}
+
+var (
+	languageClass = regexp.MustCompile(`^language-[A-Za-z0-9_+\-]+$`)
+	alignValue    = regexp.MustCompile(`^(left|center|right)$`)
+	digits        = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// Sanitize 依白名單過濾 HTML：只保留允許的標籤與屬性、連結只允許 http/https/mailto 與相對路徑，
+// 並補上未關閉的標籤，避免內容影響頁面其他部分
+func Sanitize(input string) string {
+	var b strings.Builder
+	var open []atom.Atom
+	skipDepth := 0
+
+	z := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedWithContent[tok.DataAtom] {
+				if tt == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			attrs, ok := allowedAttrs[tok.DataAtom]
+			if !ok {
+				continue
+			}
+			if tok.DataAtom == atom.Input && !isCheckbox(tok) {
+				continue
+			}
+
+			tok.Attr = filterAttrs(tok.DataAtom, tok.Attr, attrs)
+			if voidElements[tok.DataAtom] {
+				tok.Type = html.SelfClosingTagToken
+			} else {
+				tok.Type = html.StartTagToken
+				open = append(open, tok.DataAtom)
+			}
+			b.WriteString(tok.String())
+
+		case html.EndTagToken:
+			if droppedWithContent[tok.DataAtom] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			// 只關閉已開啟的標籤，中間沒關的一併補上
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == tok.DataAtom {
+					for j := len(open) - 1; j >= i; j-- {
+						b.WriteString("</" + open[j].String() + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				b.WriteString(html.EscapeString(tok.Data))
+			}
+		}
+		// 註解、DOCTYPE 一律略過
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i].String() + ">")
+	}
+	return b.String()
+}
+
+func isCheckbox(tok html.Token) bool {
+	for _, a := range tok.Attr {
+		if a.Key == "type" {
+			return strings.EqualFold(a.Val, "checkbox")
+		}
+	}
+	return false
+}
+
+func filterAttrs(tag atom.Atom, attrs []html.Attribute, allowed []string) []html.Attribute {
+	var out []html.Attribute
+	for _, a := range attrs {
+		if a.Namespace != "" || !contains(allowed, a.Key) {
+			continue
+		}
+		switch a.Key {
+		case "href", "src":
+			if !safeURL(a.Val) {
+				continue
+			}
+		case "class":
+			if !languageClass.MatchString(a.Val) {
+				continue
+			}
+		case "align":
+			if !alignValue.MatchString(a.Val) {
+				continue
+			}
+		case "start":
+			if !digits.MatchString(a.Val) {
+				continue
+			}
+		case "type":
+			a.Val = "checkbox"
+		}
+		out = append(out, a)
+	}
+
+	switch tag {
+	case atom.A:
+		out = append(out, html.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"})
+	case atom.Input:
+		// 說明中的 checkbox 只用來顯示，要勾選請改 checklist 項目
+		out = append(out, html.Attribute{Key: "disabled", Val: ""})
+	}
+	return out
+}
+
+// safeURL 只允許 http、https、mailto 與不帶 scheme 的相對路徑
+func safeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Task 說明中以 - [ ] / - [x] 寫成的待辦項目
+type Task struct {
+	Text string
+	Done bool
+}
+
+var (
+	taskLine   = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*?)\s*$`)
+	fenceStart = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// Tasks 依出現順序取出所有待辦項目，程式碼區塊內的不算
+func Tasks(src string) []Task {
+	var tasks []Task
+	eachTaskLine(src, func(m []string) {
+		tasks = append(tasks, Task{Text: m[4], Done: m[2] != " "})
+	})
+	return tasks
+}
+
+// SetTask 更新第 index 個待辦項目的完成狀態與文字，找不到時回傳 false
+func SetTask(src string, index int, text string, done bool) (string, bool) {
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	// 待辦只佔一行，文字中的換行改成空白
+	text = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+	return replaceTask(src, index, func(m []string) (string, bool) {
+		return m[1] + mark + m[3] + text, true
+	})
+}
+
+// RemoveTask 移除第 index 個待辦項目所在的那一行，找不到時回傳 false
+func RemoveTask(src string, index int) (string, bool) {
+	return replaceTask(src, index, func(m []string) (string, bool) {
+		return "", false
+	})
+}
+
+// replaceTask 以 fn 的結果取代第 index 個待辦項目那一行，keep 為 false 時整行移除
+func replaceTask(src string, index int, fn func(m []string) (line string, keep bool)) (string, bool) {
+	lines := strings.SplitAfter(src, "\n")
+	found := false
+
+	out := make([]string, 0, len(lines))
+	n := 0
+	inFence := ""
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r\n")
+		eol := raw[len(line):]
+
+		if fence := fenceOf(line, inFence); fence != "" || inFence != "" {
+			inFence = fence
+			out = append(out, raw)
+			continue
+		}
+
+		m := taskLine.FindStringSubmatch(line)
+		if m == nil {
+			out = append(out, raw)
+			continue
+		}
+		if n == index {
+			found = true
+			if replaced, keep := fn(m); keep {
+				out = append(out, replaced+eol)
+			}
+		} else {
+			out = append(out, raw)
+		}
+		n++
+	}
+
+	return strings.Join(out, ""), found
+}
+
+// eachTaskLine 對程式碼區塊外的每個待辦項目呼叫 fn
+func eachTaskLine(src string, fn func(m []string)) {
+	inFence := ""
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if fence := fenceOf(line, inFence); fence != "" || inFence != "" {
+			inFence = fence
+			continue
+		}
+		if m := taskLine.FindStringSubmatch(line); m != nil {
+			fn(m)
+		}
+	}
+}
+
+// fenceOf 回傳處理完這一行後所在的程式碼區塊標記（``` 或 ~~~），不在區塊內時為空字串
+func fenceOf(line, inFence string) string {
+	m := fenceStart.FindStringSubmatch(line)
+	if inFence == "" {
+		if m != nil {
+			return m[1]
+		}
+		return ""
+	}
+	if m != nil && m[1] == inFence {
+		return ""
+	}
+	return inFence
+}
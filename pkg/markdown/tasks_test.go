@@ -0,0 +1,39 @@
+package markdown_test
+
+import (
+	"testing"
+	"todolist/pkg/markdown"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const taskSource = "說明\n\n- [ ] 第一項\n* [x] 第二項  \n1. [X] 第三項\n\n```\n- [ ] 程式碼裡的不算\n```\n- [] 格式不對\n  - [ ] 巢狀\n"
+
+func TestTasks(t *testing.T) {
+	assert.Equal(t, []markdown.Task{
+		{Text: "第一項", Done: false},
+		{Text: "第二項", Done: true},
+		{Text: "第三項", Done: true},
+		{Text: "巢狀", Done: false},
+	}, markdown.Tasks(taskSource))
+}
+
+func TestSetTask(t *testing.T) {
+	out, ok := markdown.SetTask(taskSource, 3, "巢狀（改名）", true)
+	assert.True(t, ok)
+	assert.Contains(t, out, "  - [x] 巢狀（改名）\n")
+	assert.Contains(t, out, "- [ ] 程式碼裡的不算")
+
+	out, ok = markdown.SetTask("- [x] a\r\n- [x] b\r\n", 0, "a", false)
+	assert.True(t, ok)
+	assert.Equal(t, "- [ ] a\r\n- [x] b\r\n", out)
+
+	_, ok = markdown.SetTask(taskSource, 4, "x", true)
+	assert.False(t, ok)
+}
+
+func TestRemoveTask(t *testing.T) {
+	out, ok := markdown.RemoveTask("- [ ] a\n- [ ] b\n- [ ] c", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "- [ ] a\n- [ ] c", out)
+}
@@ -0,0 +1,118 @@
+// Package rank 產生可依字串排序的分數位置鍵（fractional index）。
+//
+// 鍵為 base62 字元組成、代表 0 到 1 之間的小數，且不以最小字元 '0' 結尾，
+// 因此任兩個鍵之間永遠可以再插入新的鍵，移動一個項目只需更新該筆資料。
+// 排序必須使用二進位比較（例如 MySQL 的 utf8mb4_bin），大小寫才會依 ASCII 排序。
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxLength 鍵超過此長度時應重新平均分配（rebalance）
+const MaxLength = 32
+
+var (
+	ErrInvalidKey   = errors.New("無效的位置鍵")
+	ErrInvalidRange = errors.New("位置鍵順序錯誤：前一個必須小於後一個")
+)
+
+// KeyBetween 回傳介於 a 與 b 之間的鍵；a 為空字串表示最前面，b 為空字串表示最後面
+func KeyBetween(a, b string) (string, error) {
+	if !valid(a) || !valid(b) {
+		return "", ErrInvalidKey
+	}
+	if b != "" && a >= b {
+		return "", ErrInvalidRange
+	}
+	return midpoint(a, b), nil
+}
+
+// Spread 產生 n 個平均分布且遞增的鍵，供 rebalance 使用
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	base := len(digits)
+	width, capacity := 1, base
+	for capacity <= n {
+		width++
+		capacity *= base
+	}
+
+	keys := make([]string, n)
+	step := capacity / (n + 1)
+	for i := range keys {
+		value := step * (i + 1)
+		buf := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%base]
+			value /= base
+		}
+		keys[i] = strings.TrimRight(string(buf), "0")
+	}
+	return keys
+}
+
+func valid(key string) bool {
+	if strings.HasSuffix(key, "0") {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// midpoint 假設 a < b（b 為空代表 1），回傳兩者之間最短的鍵
+func midpoint(a, b string) string {
+	if b != "" {
+		// 共同前綴直接保留，a 不足的部分視為 '0'
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(safeSlice(a, n), b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+
+	// 相鄰字元之間放不下，往下一位繼續找
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[digitA]) + midpoint(safeSlice(a, 1), "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return '0'
+}
+
+func safeSlice(s string, i int) string {
+	if i >= len(s) {
+		return ""
+	}
+	return s[i:]
+}
@@ -0,0 +1,88 @@
+package rank_test
+
+import (
+	"sort"
+	"testing"
+	"todolist/pkg/rank"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyBetween(t *testing.T) {
+	cases := []struct {
+		a, b, want string
+	}{
+		{"", "", "V"},
+		{"V", "", "l"},
+		{"", "V", "G"},
+		{"V", "W", "VV"},
+		{"V", "V1", "V0V"},
+		{"Vz", "W", "VzV"},
+		{"A", "A01", "A00V"},
+	}
+	for _, c := range cases {
+		got, err := rank.KeyBetween(c.a, c.b)
+		require.NoError(t, err, "%q %q", c.a, c.b)
+		assert.Equal(t, c.want, got, "%q %q", c.a, c.b)
+		assert.Less(t, c.a, got)
+		if c.b != "" {
+			assert.Less(t, got, c.b)
+		}
+	}
+}
+
+func TestKeyBetween_Errors(t *testing.T) {
+	_, err := rank.KeyBetween("W", "V")
+	assert.ErrorIs(t, err, rank.ErrInvalidRange)
+
+	_, err = rank.KeyBetween("V", "V")
+	assert.ErrorIs(t, err, rank.ErrInvalidRange)
+
+	_, err = rank.KeyBetween("V0", "")
+	assert.ErrorIs(t, err, rank.ErrInvalidKey)
+
+	_, err = rank.KeyBetween("V-", "")
+	assert.ErrorIs(t, err, rank.ErrInvalidKey)
+}
+
+func TestKeyBetween_RepeatedInsertKeepsOrder(t *testing.T) {
+	// 反覆插入在同一個位置，鍵會變長但順序永遠正確
+	low, high := "", ""
+	keys := []string{}
+	for i := 0; i < 200; i++ {
+		key, err := rank.KeyBetween(low, high)
+		require.NoError(t, err)
+		keys = append(keys, key)
+		if i%2 == 0 {
+			low = key
+		} else {
+			high = key
+		}
+	}
+
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	seen := map[string]bool{}
+	for _, k := range keys {
+		assert.False(t, seen[k], "duplicate key %q", k)
+		seen[k] = true
+	}
+	assert.Greater(t, len(keys[len(keys)-1]), rank.MaxLength)
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{1, 2, 61, 62, 1000} {
+		keys := rank.Spread(n)
+		require.Len(t, keys, n)
+		assert.True(t, sort.StringsAreSorted(keys))
+		for i, k := range keys {
+			assert.NotEmpty(t, k)
+			if i > 0 {
+				assert.NotEqual(t, keys[i-1], k)
+			}
+			_, err := rank.KeyBetween(k, "")
+			assert.NoError(t, err, k)
+		}
+	}
+}
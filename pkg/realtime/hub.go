@@ -0,0 +1,164 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"todolist/pkg/events"
+)
+
+// subscriptionBuffer 每個用戶端尚未送出的事件上限，超過時中斷該用戶端，由用戶端以最後的事件 ID 重新連線
+const subscriptionBuffer = 64
+
+// Hub 把事件透過 PubSub 廣播到所有 instance，再轉給本機連線中符合訂閱條件的用戶端。
+// 保留最近 size 個事件，用戶端斷線重連時可以從最後收到的事件 ID 接續；
+// 各 instance 從 PubSub 收到的順序相同，因此換到另一個 instance 也能接續。
+// PubSub 重新訂閱時中斷期間的事件已遺失，保留的事件不再連續，清空後只能從重新訂閱之後的事件接續
+type Hub struct {
+	pubsub  PubSub
+	channel string
+
+	mu      sync.Mutex
+	recent  []events.Event
+	next    int
+	seen    map[string]struct{}
+	clients map[*Subscription]struct{}
+}
+
+// Subscription 一個用戶端的訂閱；C 被關閉表示訂閱已結束（Close 或來不及接收）
+type Subscription struct {
+	C      <-chan events.Event
+	c      chan events.Event
+	filter func(events.Event) bool
+	hub    *Hub
+}
+
+func NewHub(pubsub PubSub, channel string, size int) *Hub {
+	return &Hub{
+		pubsub:  pubsub,
+		channel: channel,
+		recent:  make([]events.Event, 0, size),
+		seen:    make(map[string]struct{}, size),
+		clients: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish 實作 events.Broker，把事件送到 PubSub，由各 instance 的 Run 收到後轉給用戶端
+func (h *Hub) Publish(ctx context.Context, e events.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return h.pubsub.Publish(ctx, h.channel, payload)
+}
+
+// Run 接收 PubSub 的事件並轉給用戶端，直到 ctx 取消
+func (h *Hub) Run(ctx context.Context) error {
+	messages, err := h.pubsub.Subscribe(ctx, h.channel)
+	if err != nil {
+		return err
+	}
+	for payload := range messages {
+		if payload == nil {
+			h.reset()
+			continue
+		}
+		var e events.Event
+		if err := json.Unmarshal(payload, &e); err != nil {
+			continue
+		}
+		h.deliver(e)
+	}
+	return ctx.Err()
+}
+
+// deliver 保存事件並轉給符合條件的用戶端；outbox 重送的事件 ID 相同，已收過的略過
+func (h *Hub) deliver(e events.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.seen[e.ID]; ok {
+		return
+	}
+	if cap(h.recent) > 0 {
+		if len(h.recent) < cap(h.recent) {
+			h.recent = append(h.recent, e)
+		} else {
+			delete(h.seen, h.recent[h.next].ID)
+			h.recent[h.next] = e
+			h.next = (h.next + 1) % cap(h.recent)
+		}
+		h.seen[e.ID] = struct{}{}
+	}
+
+	for sub := range h.clients {
+		if !sub.filter(e) {
+			continue
+		}
+		select {
+		case sub.c <- e:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// reset PubSub 中斷期間的事件已遺失：清空保留的事件，讓帶舊事件 ID 接續的用戶端收到 reset；
+// 連線中的用戶端也漏收了事件，中斷它們的訂閱，重新連線後同樣收到 reset
+func (h *Hub) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.recent = h.recent[:0]
+	h.next = 0
+	h.seen = make(map[string]struct{}, cap(h.recent))
+	for sub := range h.clients {
+		h.remove(sub)
+	}
+}
+
+// Subscribe 新增用戶端，filter 決定用戶端可以收到哪些事件。
+// lastEventID 不為空時一併回傳之後符合條件的事件；事件已不在保留範圍內時 resumed 為 false，用戶端需重新取得完整資料
+func (h *Hub) Subscribe(lastEventID string, filter func(events.Event) bool) (sub *Subscription, replay []events.Event, resumed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	resumed = true
+	if lastEventID != "" {
+		resumed = false
+		ordered := append(append([]events.Event{}, h.recent[h.next:]...), h.recent[:h.next]...)
+		for i, e := range ordered {
+			if e.ID != lastEventID {
+				continue
+			}
+			resumed = true
+			for _, after := range ordered[i+1:] {
+				if filter(after) {
+					replay = append(replay, after)
+				}
+			}
+			break
+		}
+	}
+
+	c := make(chan events.Event, subscriptionBuffer)
+	sub = &Subscription{C: c, c: c, filter: filter, hub: h}
+	h.clients[sub] = struct{}{}
+	return sub, replay, resumed
+}
+
+// Close 結束訂閱並關閉 C，可以重複呼叫
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// remove 呼叫端需持有 mu
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.clients[sub]; !ok {
+		return
+	}
+	delete(h.clients, sub)
+	close(sub.c)
+}
@@ -0,0 +1,68 @@
+// Package realtime 把領域事件即時轉給連線中的用戶端：事件先透過 PubSub 廣播到所有 instance，
+// 各 instance 的 Hub 再依用戶端的訂閱條件轉送，並保留最近的事件讓斷線的用戶端接續。
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// PubSub 在多個 instance 之間廣播訊息；訊息不保存，訂閱前送出的訊息收不到
+type PubSub interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe 訂閱 channel，ctx 取消時停止並關閉回傳的 channel；連線中斷時由實作自行重連，
+	// 重新訂閱成功後先送出一個 nil 訊息，表示中斷期間的訊息已遺失
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+// Memory 只在同一個行程內廣播的 PubSub，單一 instance 或測試時使用
+type Memory struct {
+	mu   sync.RWMutex
+	subs map[string]map[*memorySub]struct{}
+}
+
+type memorySub struct {
+	ch   chan []byte
+	done chan struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{subs: map[string]map[*memorySub]struct{}{}}
+}
+
+// Publish 送給目前的訂閱者，訂閱者來不及接收時會等待直到 ctx 取消或訂閱者取消
+func (m *Memory) Publish(ctx context.Context, channel string, payload []byte) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for sub := range m.subs[channel] {
+		select {
+		case sub.ch <- payload:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := &memorySub{ch: make(chan []byte, 64), done: make(chan struct{})}
+
+	m.mu.Lock()
+	if m.subs[channel] == nil {
+		m.subs[channel] = map[*memorySub]struct{}{}
+	}
+	m.subs[channel][sub] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		// 先讓等待中的 Publish 放棄這個訂閱者，才能取得寫入鎖
+		close(sub.done)
+		m.mu.Lock()
+		delete(m.subs[channel], sub)
+		m.mu.Unlock()
+		close(sub.ch)
+	}()
+	return sub.ch, nil
+}
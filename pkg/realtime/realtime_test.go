@@ -0,0 +1,258 @@
+package realtime_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"todolist/pkg/events"
+	"todolist/pkg/realtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listEvent(id string, listID int) events.Event {
+	return events.Event{ID: id, Type: "detail.updated", TodoListID: &listID, Data: []byte(`{}`)}
+}
+
+func receive(t *testing.T, sub *realtime.Subscription) events.Event {
+	t.Helper()
+	select {
+	case e, ok := <-sub.C:
+		require.True(t, ok, "訂閱已結束")
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("沒有收到事件")
+		return events.Event{}
+	}
+}
+
+func TestHub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pubsub := realtime.NewMemory()
+	// 兩個 instance 共用同一個 PubSub
+	first, second := realtime.NewHub(pubsub, "events", 3), realtime.NewHub(pubsub, "events", 3)
+	go first.Run(ctx)
+	go second.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	onlyList2 := func(e events.Event) bool { return e.TodoListID != nil && *e.TodoListID == 2 }
+	sub, replay, resumed := second.Subscribe("", onlyList2)
+	assert.True(t, resumed)
+	assert.Empty(t, replay)
+
+	require.NoError(t, first.Publish(ctx, listEvent("a", 1)))
+	require.NoError(t, first.Publish(ctx, listEvent("b", 2)))
+	// outbox 重送的事件只轉送一次
+	require.NoError(t, first.Publish(ctx, listEvent("b", 2)))
+	require.NoError(t, first.Publish(ctx, listEvent("c", 2)))
+	assert.Equal(t, "b", receive(t, sub).ID)
+	assert.Equal(t, "c", receive(t, sub).ID)
+	sub.Close()
+	sub.Close()
+	_, open := <-sub.C
+	assert.False(t, open)
+
+	// 從另一個 instance 接續
+	require.Eventually(t, func() bool {
+		sub, replay, resumed = first.Subscribe("a", onlyList2)
+		sub.Close()
+		return resumed && len(replay) == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "b", replay[0].ID)
+	assert.Equal(t, "c", replay[1].ID)
+
+	// 超過保留數量後無法接續
+	require.NoError(t, first.Publish(ctx, listEvent("d", 2)))
+	require.Eventually(t, func() bool {
+		_, replay, resumed = second.Subscribe("a", onlyList2)
+		return !resumed && len(replay) == 0
+	}, time.Second, 5*time.Millisecond)
+	_, replay, resumed = second.Subscribe("b", onlyList2)
+	assert.True(t, resumed)
+	assert.Len(t, replay, 2)
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := realtime.NewHub(realtime.NewMemory(), "events", 10)
+	go hub.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	sub, _, _ := hub.Subscribe("", func(events.Event) bool { return true })
+	for i := 0; i < 100; i++ {
+		require.NoError(t, hub.Publish(ctx, listEvent(strconv.Itoa(i), 1)))
+	}
+
+	received := 0
+	for range sub.C {
+		received++
+	}
+	assert.Equal(t, 64, received)
+}
+
+// fakeRedis 只處理 AUTH、PUBLISH 與 SUBSCRIBE 的 Redis
+type fakeRedis struct {
+	net.Listener
+	mu          sync.Mutex
+	subscribers map[string][]net.Conn
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeRedis{Listener: ln, subscribers: map[string][]net.Conn{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			line, _ = r.ReadString('\n')
+			size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			data := make([]byte, size+2)
+			io.ReadFull(r, data)
+			args[i] = string(data[:size])
+		}
+
+		f.mu.Lock()
+		switch args[0] {
+		case "AUTH":
+			if args[1] == "secret" {
+				io.WriteString(conn, "+OK\r\n")
+			} else {
+				io.WriteString(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case "SUBSCRIBE":
+			f.subscribers[args[1]] = append(f.subscribers[args[1]], conn)
+			fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+		case "PUBLISH":
+			for _, sub := range f.subscribers[args[1]] {
+				fmt.Fprintf(sub, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(args[2]), args[2])
+			}
+			fmt.Fprintf(conn, ":%d\r\n", len(f.subscribers[args[1]]))
+		}
+		f.mu.Unlock()
+	}
+}
+
+// subscribed 等待 channel 有訂閱者
+func (f *fakeRedis) subscribed(t *testing.T, channel string) {
+	require.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return len(f.subscribers[channel]) > 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+// disconnect 中斷 channel 所有訂閱者的連線，模擬 Redis 重新啟動
+func (f *fakeRedis) disconnect(channel string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.subscribers[channel] {
+		conn.Close()
+	}
+	delete(f.subscribers, channel)
+}
+
+func TestRedis(t *testing.T) {
+	server := newFakeRedis(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pubsub := realtime.NewRedis(server.Addr().String(), "secret")
+	messages, err := pubsub.Subscribe(ctx, "events")
+	require.NoError(t, err)
+	server.subscribed(t, "events")
+
+	payload := "{\"name\":\"工作\"}\r\n第二行"
+	require.NoError(t, pubsub.Publish(ctx, "events", []byte(payload)))
+	select {
+	case got := <-messages:
+		assert.Equal(t, payload, string(got))
+	case <-time.After(time.Second):
+		t.Fatal("沒有收到訊息")
+	}
+
+	cancel()
+	for range messages {
+	}
+
+	err = realtime.NewRedis(server.Addr().String(), "wrong").Publish(context.Background(), "events", []byte("x"))
+	assert.EqualError(t, err, "redis: WRONGPASS invalid password")
+}
+
+func TestHub_RedisResubscribe(t *testing.T) {
+	server := newFakeRedis(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pubsub := realtime.NewRedis(server.Addr().String(), "")
+	hub := realtime.NewHub(pubsub, "events", 10)
+	go hub.Run(ctx)
+	server.subscribed(t, "events")
+
+	all := func(events.Event) bool { return true }
+	sub, _, _ := hub.Subscribe("", all)
+	require.NoError(t, hub.Publish(ctx, listEvent("a", 1)))
+	require.NoError(t, hub.Publish(ctx, listEvent("b", 1)))
+	assert.Equal(t, "a", receive(t, sub).ID)
+	assert.Equal(t, "b", receive(t, sub).ID)
+
+	// 中斷期間送出的 c 沒有任何 instance 收到
+	server.disconnect("events")
+	require.NoError(t, hub.Publish(ctx, listEvent("c", 1)))
+	require.Eventually(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.subscribers["events"]) > 0
+	}, 3*time.Second, 10*time.Millisecond)
+	require.NoError(t, hub.Publish(ctx, listEvent("d", 1)))
+
+	// 連線中的用戶端被中斷，不會在漏收 c 的情況下繼續收到 d
+	select {
+	case e, ok := <-sub.C:
+		assert.False(t, ok, "收到 %s", e.ID)
+	case <-time.After(time.Second):
+		t.Fatal("訂閱沒有被中斷")
+	}
+
+	// 從 b 接續會缺少 c，必須要求用戶端重新取得資料；從 d 之後則可以接續
+	require.Eventually(t, func() bool {
+		next, _, resumed := hub.Subscribe("d", all)
+		next.Close()
+		return resumed
+	}, time.Second, 5*time.Millisecond)
+	next, replay, resumed := hub.Subscribe("b", all)
+	next.Close()
+	assert.False(t, resumed)
+	assert.Empty(t, replay)
+}
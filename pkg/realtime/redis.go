@@ -0,0 +1,262 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// redisDialTimeout、redisRetryInterval 連線逾時與訂閱中斷後重連的間隔
+const (
+	redisDialTimeout   = 5 * time.Second
+	redisRetryInterval = time.Second
+)
+
+// Redis 以 Redis 的 PUBLISH / SUBSCRIBE 在多個 instance 之間廣播，只實作需要的指令。
+// 送出共用一條連線；每個 Subscribe 使用自己的連線，中斷時自動重連，中斷期間的訊息會遺失，
+// 重新訂閱後以 nil 訊息通知訂閱者
+type Redis struct {
+	addr     string
+	password string
+
+	// OnError 訂閱的連線中斷時呼叫，可用於記錄；之後會自動重連
+	OnError func(err error)
+
+	mu   sync.Mutex
+	conn *redisConn
+}
+
+func NewRedis(addr, password string) *Redis {
+	return &Redis{addr: addr, password: password}
+}
+
+func (r *Redis) Publish(ctx context.Context, channel string, payload []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 共用的連線可能已被伺服器關閉，失敗時重新連線再試一次
+	for attempt := 0; ; attempt++ {
+		if r.conn == nil {
+			conn, err := r.dial(ctx)
+			if err != nil {
+				return err
+			}
+			r.conn = conn
+		}
+
+		_, err := r.conn.do(ctx, "PUBLISH", []byte(channel), payload)
+		if err == nil {
+			return nil
+		}
+		r.conn.Close()
+		r.conn = nil
+
+		var replyErr redisError
+		if errors.As(err, &replyErr) || attempt > 0 {
+			return err
+		}
+	}
+}
+
+func (r *Redis) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	out := make(chan []byte, 64)
+	go func() {
+		defer close(out)
+		for resubscribe := false; ; resubscribe = true {
+			err := r.subscribe(ctx, channel, out, resubscribe)
+			if ctx.Err() != nil {
+				return
+			}
+			if r.OnError != nil {
+				r.OnError(err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(redisRetryInterval):
+			}
+		}
+	}()
+	return out, nil
+}
+
+// subscribe 連線並持續讀取訊息，直到連線中斷或 ctx 取消；resubscribe 為 true 時在訂閱確認後先送出 nil 訊息
+func (r *Redis) subscribe(ctx context.Context, channel string, out chan<- []byte, resubscribe bool) error {
+	conn, err := r.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// ctx 取消時關閉連線，讓阻塞中的讀取結束
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if err := conn.write("SUBSCRIBE", []byte(channel)); err != nil {
+		return err
+	}
+	for {
+		reply, err := conn.read()
+		if err != nil {
+			return err
+		}
+		// 訊息的格式為 ["message", channel, payload]，訂閱確認為 ["subscribe", channel, count]，其他回應略過
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		kind, _ := parts[0].([]byte)
+		payload, _ := parts[2].([]byte)
+		switch string(kind) {
+		case "message":
+		case "subscribe":
+			// 確認之後的訊息才收得到，中斷到這裡之間的訊息已遺失
+			if !resubscribe {
+				continue
+			}
+			payload = nil
+		default:
+			continue
+		}
+		select {
+		case out <- payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Redis) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: redisDialTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+	if r.password != "" {
+		if _, err := conn.do(ctx, "AUTH", []byte(r.password)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// redisError 伺服器回傳的錯誤（RESP 的 - 開頭）
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisConn 一條 RESP 連線
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// do 送出指令並讀取一個回應，ctx 的期限會套用到這次的讀寫
+func (c *redisConn) do(ctx context.Context, cmd string, args ...[]byte) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisDialTimeout)
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	defer c.SetDeadline(time.Time{})
+
+	if err := c.write(cmd, args...); err != nil {
+		return nil, err
+	}
+	reply, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	if replyErr, ok := reply.(redisError); ok {
+		return nil, replyErr
+	}
+	return reply, nil
+}
+
+func (c *redisConn) write(cmd string, args ...[]byte) error {
+	buf := []byte("*" + strconv.Itoa(len(args)+1) + "\r\n")
+	buf = appendBulk(buf, []byte(cmd))
+	for _, arg := range args {
+		buf = appendBulk(buf, arg)
+	}
+	_, err := c.Write(buf)
+	return err
+}
+
+func appendBulk(buf, data []byte) []byte {
+	buf = append(buf, '$')
+	buf = strconv.AppendInt(buf, int64(len(data)), 10)
+	buf = append(buf, '\r', '\n')
+	buf = append(buf, data...)
+	return append(buf, '\r', '\n')
+}
+
+// read 讀取一個回應：簡單字串與 bulk string 為 []byte，整數為 int64，陣列為 []interface{}，錯誤為 redisError
+func (c *redisConn) read() (interface{}, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+	kind, body := line[0], string(line[1:len(line)-2])
+
+	switch kind {
+	case '+':
+		return []byte(body), nil
+	case '-':
+		return redisError(body), nil
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = c.read(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+}
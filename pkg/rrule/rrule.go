@@ -0,0 +1,400 @@
+// Package rrule 實作 RFC 5545 RRULE 的子集合：
+// FREQ=DAILY/WEEKLY/MONTHLY、INTERVAL、BYDAY、BYMONTHDAY、UNTIL、COUNT。
+//
+// 所有時間都以 dtstart 所在的時區計算牆上時間（wall clock），
+// 因此跨越夏令時間時，每次發生的時刻仍維持相同的當地時間。
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxPeriods 避免永遠找不到下一次（例如 BYMONTHDAY=31 搭配 INTERVAL=12 的二月）時無限迴圈。
+// 沒有 COUNT 時從 after 所在的週期開始算，所以只限制 after 之後往後找的週期數
+const maxPeriods = 10000
+
+// UNTIL 的格式：帶 Z 的是 UTC 時間，其餘依 RFC 5545 為 dtstart 時區的當地時間
+const (
+	untilUTC      = "20060102T150405Z"
+	untilFloating = "20060102T150405"
+	untilDate     = "20060102"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum 對應 BYDAY 的值，例如 MO、1MO、-1FR；N 為 0 表示該月每個星期幾
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq       string
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	// Until 不帶 Z 時只保存牆上時間（以 UTC 表示），在 Next 時才以 dtstart 的時區解讀，見 until
+	Until *time.Time
+	Count int
+
+	untilLayout string
+}
+
+// Parse 解析 RRULE 字串，可帶或不帶 "RRULE:" 前綴
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("rrule 不能為空")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("rrule 格式錯誤：%s", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case Daily, Weekly, Monthly:
+				rule.Freq = value
+			default:
+				return nil, fmt.Errorf("不支援的 FREQ：%s", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("INTERVAL 必須為正整數：%s", value)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("COUNT 必須為正整數：%s", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, layout, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+			rule.untilLayout = layout
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(v)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("BYMONTHDAY 數值錯誤：%s", v)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "WKST":
+			if value != "MO" {
+				return nil, errors.New("目前只支援 WKST=MO")
+			}
+		default:
+			return nil, fmt.Errorf("不支援的 rrule 參數：%s", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("rrule 缺少 FREQ")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT 與 UNTIL 不能同時使用")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly {
+		return nil, errors.New("BYMONTHDAY 只能用於 FREQ=MONTHLY")
+	}
+	for _, wd := range rule.ByDay {
+		if wd.N != 0 && rule.Freq != Monthly {
+			return nil, errors.New("BYDAY 帶序數（例如 1MO）只能用於 FREQ=MONTHLY")
+		}
+	}
+
+	return rule, nil
+}
+
+// parseUntil 解析時尚未知道 dtstart 的時區，不帶 Z 的值先以 UTC 保存牆上時間
+func parseUntil(value string) (time.Time, string, error) {
+	for _, layout := range []string{untilUTC, untilFloating, untilDate} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("UNTIL 格式錯誤：%s", value)
+}
+
+// until 回傳 UNTIL 實際的時刻：不帶 Z 的以 loc（dtstart 的時區）解讀，只有日期時包含當天所有的發生時間
+func (r *Rule) until(loc *time.Location) *time.Time {
+	if r.Until == nil || r.untilLayout == "" || r.untilLayout == untilUTC {
+		return r.Until
+	}
+
+	y, m, d := r.Until.Date()
+	hh, mm, ss := r.Until.Clock()
+	t := time.Date(y, m, d, hh, mm, ss, 0, loc)
+	if r.untilLayout == untilDate {
+		t = time.Date(y, m, d+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	}
+	return &t
+}
+
+func parseWeekdayNum(v string) (WeekdayNum, error) {
+	if len(v) < 2 {
+		return WeekdayNum{}, fmt.Errorf("BYDAY 數值錯誤：%s", v)
+	}
+	wd, ok := weekdays[v[len(v)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("BYDAY 數值錯誤：%s", v)
+	}
+
+	n := 0
+	if prefix := v[:len(v)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("BYDAY 數值錯誤：%s", v)
+		}
+	}
+	return WeekdayNum{N: n, Weekday: wd}, nil
+}
+
+// String 回傳正規化後的 RRULE 字串（不含 "RRULE:" 前綴）
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = wd.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		if r.untilLayout == "" || r.untilLayout == untilUTC {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilUTC))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Format(r.untilLayout))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (w WeekdayNum) String() string {
+	code := strings.ToUpper(w.Weekday.String()[:2])
+	if w.N == 0 {
+		return code
+	}
+	return strconv.Itoa(w.N) + code
+}
+
+// Next 回傳 dtstart 起算、嚴格晚於 after 的下一次發生時間；沒有下一次時 ok 為 false。
+// dtstart 的時區決定牆上時間，dtstart 本身視為第一次發生（若符合規則）。
+func (r *Rule) Next(dtstart, after time.Time) (next time.Time, ok bool) {
+	until := r.until(dtstart.Location())
+	first := r.firstPeriod(dtstart, after)
+
+	count := 0
+	for period := first; period < first+maxPeriods; period++ {
+		for _, t := range r.candidates(dtstart, period) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if until != nil && t.After(*until) {
+				return time.Time{}, false
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return time.Time{}, false
+			}
+			if t.After(after) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// Between 回傳 [from, to) 之間的所有發生時間，主要供預覽使用
+func (r *Rule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var result []time.Time
+	cursor := from.Add(-time.Nanosecond)
+	for len(result) < limit {
+		next, ok := r.Next(dtstart, cursor)
+		if !ok || !next.Before(to) {
+			break
+		}
+		result = append(result, next)
+		cursor = next
+	}
+	return result
+}
+
+// firstPeriod 可以直接跳過、不會有晚於 after 的發生時間的週期數。
+// 有 COUNT 時必須從頭數，一律從 0 開始；往前多留一個週期，避免週的起點與時區造成的誤差
+func (r *Rule) firstPeriod(dtstart, after time.Time) int {
+	if r.Count > 0 || !after.After(dtstart) {
+		return 0
+	}
+
+	loc := dtstart.Location()
+	sy, sm, sd := dtstart.Date()
+	ay, am, ad := after.In(loc).Date()
+
+	var period int
+	switch r.Freq {
+	case Daily, Weekly:
+		// 以 UTC 的日期相減，不受夏令時間影響
+		days := int(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC).Sub(time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+		if r.Freq == Weekly {
+			days /= 7
+		}
+		period = days / r.Interval
+	case Monthly:
+		period = ((ay-sy)*12 + int(am-sm)) / r.Interval
+	}
+	if period--; period < 0 {
+		return 0
+	}
+	return period
+}
+
+// candidates 依週期序號產生該週期內所有候選時間（已排序）
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	y, m, d := dtstart.Date()
+	hh, mm, ss := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, 0, loc)
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		t := at(y, m, d+period*r.Interval)
+		if len(r.ByDay) == 0 || r.matchWeekday(t.Weekday()) {
+			days = append(days, t)
+		}
+	case Weekly:
+		// 以星期一為一週的開始
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := d - offset + period*r.Interval*7
+		if len(r.ByDay) == 0 {
+			days = append(days, at(y, m, monday+offset))
+			break
+		}
+		for i := 0; i < 7; i++ {
+			t := at(y, m, monday+i)
+			if r.matchWeekday(t.Weekday()) {
+				days = append(days, t)
+			}
+		}
+	case Monthly:
+		first := time.Date(y, m+time.Month(period*r.Interval), 1, 0, 0, 0, 0, loc)
+		days = r.monthDays(first, d, at)
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+func (r *Rule) matchWeekday(wd time.Weekday) bool {
+	for _, w := range r.ByDay {
+		if w.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays 回傳某月符合 BYMONTHDAY / BYDAY 的日期；都沒設定時沿用 dtstart 的日，該月沒有這天就略過
+func (r *Rule) monthDays(first time.Time, startDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	y, m := first.Year(), first.Month()
+	last := time.Date(y, m+1, 0, 0, 0, 0, 0, first.Location()).Day()
+
+	seen := map[int]bool{}
+	add := func(day int) {
+		if day >= 1 && day <= last {
+			seen[day] = true
+		}
+	}
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = last + day + 1
+			}
+			add(day)
+		}
+	case len(r.ByDay) > 0:
+		for _, w := range r.ByDay {
+			// 該月第一個符合的星期幾
+			firstDay := 1 + (int(w.Weekday)-int(first.Weekday())+7)%7
+			var matches []int
+			for day := firstDay; day <= last; day += 7 {
+				matches = append(matches, day)
+			}
+			switch {
+			case w.N == 0:
+				for _, day := range matches {
+					add(day)
+				}
+			case w.N > 0 && w.N <= len(matches):
+				add(matches[w.N-1])
+			case w.N < 0 && -w.N <= len(matches):
+				add(matches[len(matches)+w.N])
+			}
+		}
+	default:
+		add(startDay)
+	}
+
+	days := make([]time.Time, 0, len(seen))
+	for day := range seen {
+		days = append(days, at(y, m, day))
+	}
+	return days
+}
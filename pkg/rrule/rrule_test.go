@@ -0,0 +1,178 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+	"todolist/pkg/rrule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func occurrences(t *testing.T, s string, dtstart time.Time, n int) []time.Time {
+	rule, err := rrule.Parse(s)
+	require.NoError(t, err)
+
+	var result []time.Time
+	cursor := dtstart.Add(-time.Second)
+	for len(result) < n {
+		next, ok := rule.Next(dtstart, cursor)
+		if !ok {
+			break
+		}
+		result = append(result, next)
+		cursor = next
+	}
+	return result
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260101T000000Z",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;BYMONTHDAY=1",
+		"FREQ=DAILY;BYHOUR=9",
+	}
+	for _, c := range cases {
+		_, err := rrule.Parse(c)
+		assert.Error(t, err, c)
+	}
+}
+
+func TestParse_String(t *testing.T) {
+	rule, err := rrule.Parse("RRULE:freq=monthly;interval=2;byday=1MO,-1FR;count=4")
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;INTERVAL=2;BYDAY=1MO,-1FR;COUNT=4", rule.String())
+}
+
+func TestNext_DailyInterval(t *testing.T) {
+	start := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	got := occurrences(t, "FREQ=DAILY;INTERVAL=2", start, 3)
+
+	assert.Equal(t, []time.Time{
+		start,
+		time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestNext_WeeklyByDayWithCount(t *testing.T) {
+	// 2026-03-04 為星期三
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	got := occurrences(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4", start, 10)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 11, 10, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestNext_MonthlySkipsMissingDay(t *testing.T) {
+	start := time.Date(2026, 1, 31, 8, 0, 0, 0, time.UTC)
+	got := occurrences(t, "FREQ=MONTHLY", start, 3)
+
+	// 二月、四月沒有 31 日，依 RFC 5545 略過
+	assert.Equal(t, []time.Time{
+		start,
+		time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 8, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestNext_MonthlyLastFridayUntil(t *testing.T) {
+	start := time.Date(2026, 1, 1, 17, 0, 0, 0, time.UTC)
+	got := occurrences(t, "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260331T235959Z", start, 10)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 30, 17, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 27, 17, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 27, 17, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestNext_MonthlyNegativeMonthDay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	got := occurrences(t, "FREQ=MONTHLY;BYMONTHDAY=-1", start, 2)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestNext_KeepsWallClockAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	// 2026-03-08 美東進入夏令時間
+	start := time.Date(2026, 3, 6, 9, 0, 0, 0, ny)
+	got := occurrences(t, "FREQ=DAILY", start, 4)
+
+	for _, occ := range got {
+		assert.Equal(t, 9, occ.Hour())
+	}
+	assert.Equal(t, 14, got[0].UTC().Hour())
+	assert.Equal(t, 13, got[3].UTC().Hour())
+}
+
+func TestNext_AfterIsExclusive(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=WEEKLY")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(start, start)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestNext_UntilWithoutZUsesDtstartZone(t *testing.T) {
+	taipei := mustLoad(t, "Asia/Taipei")
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, taipei)
+
+	// 不帶 Z 的 UNTIL 為台北時間 1/5 08:00，當天的 09:00 不會發生；當作 UTC 會多出一次
+	got := occurrences(t, "FREQ=DAILY;UNTIL=20260105T080000", start, 10)
+	assert.Len(t, got, 4)
+	assert.Equal(t, time.Date(2026, 1, 4, 9, 0, 0, 0, taipei), got[3])
+
+	// 只有日期時包含當天
+	got = occurrences(t, "FREQ=DAILY;UNTIL=20260103", start, 10)
+	assert.Len(t, got, 3)
+	assert.Equal(t, time.Date(2026, 1, 3, 9, 0, 0, 0, taipei), got[2])
+}
+
+func TestParse_StringKeepsFloatingUntil(t *testing.T) {
+	for _, s := range []string{"FREQ=DAILY;UNTIL=20260105T090000", "FREQ=DAILY;UNTIL=20260105", "FREQ=DAILY;UNTIL=20260105T090000Z"} {
+		rule, err := rrule.Parse(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, rule.String())
+	}
+}
+
+func TestNext_FarAfterDtstart(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=DAILY")
+	require.NoError(t, err)
+
+	// 超過 maxPeriods 天以後仍找得到下一次
+	start := time.Date(1990, 1, 1, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(start, time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC))
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC), next)
+
+	rule, err = rrule.Parse("FREQ=MONTHLY;INTERVAL=5;BYDAY=-1FR")
+	require.NoError(t, err)
+	got := rule.Between(start, time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2501, 1, 1, 0, 0, 0, 0, time.UTC), 10)
+	assert.NotEmpty(t, got)
+}
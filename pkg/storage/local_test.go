@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Put(ctx, "blobs/ab/abc", strings.NewReader("hello"), 5, "text/plain"))
+
+	r, err := s.Get(ctx, "blobs/ab/abc")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "hello", string(data))
+
+	assert.NoError(t, s.Delete(ctx, "blobs/ab/abc"))
+	_, err = s.Get(ctx, "blobs/ab/abc")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 刪除不存在的 key 不是錯誤
+	assert.NoError(t, s.Delete(ctx, "blobs/ab/abc"))
+}
+
+func TestLocalStorage_SizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.Error(t, s.Put(ctx, "a", strings.NewReader("hello"), 10, ""))
+	_, err = s.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStorage_KeyStaysInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewLocalStorage(root)
+	assert.NoError(t, err)
+
+	path, err := s.path("../../etc/passwd")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, root))
+
+	_, err = s.path("")
+	assert.Error(t, err)
+}
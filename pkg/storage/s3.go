@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config S3 相容服務的連線設定；Endpoint 含 scheme，例如 http://minio:9000
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage 以 path-style URL（{endpoint}/{bucket}/{key}）存取 S3 相容服務，AWS S3 與 MinIO 皆可使用
+type S3Storage struct {
+	cfg    S3Config
+	base   *url.URL
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	base, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("storage: invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage: S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	return &S3Storage{
+		cfg:    cfg,
+		base:   base,
+		client: &http.Client{Timeout: 5 * time.Minute},
+		now:    time.Now,
+	}, nil
+}
+
+// EnsureBucket bucket 不存在時建立；已存在（含自己建立過的）不視為錯誤
+func (s *S3Storage) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, -1, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "", nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusConflict {
+		return nil
+	}
+	return responseError("create bucket", resp)
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError("put "+key, resp)
+	}
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, -1, "")
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError("get "+key, resp)
+	}
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, -1, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return responseError("delete "+key, resp)
+}
+
+// do 建立並簽署請求；內容不另外計算雜湊，以 UNSIGNED-PAYLOAD 送出
+func (s *S3Storage) do(ctx context.Context, method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	u := *s.base
+	u.Path = s.base.Path + "/" + s.cfg.Bucket
+	if key != "" {
+		u.Path += "/" + strings.TrimLeft(key, "/")
+	}
+	u.RawPath = canonicalPath(u.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, "s3", s.now())
+
+	return s.client.Do(req)
+}
+
+func responseError(op string, resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage: S3 %s failed: %s %s", op, resp.Status, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,18 @@
+// Package storage 提供附件內容的存放介面，實作有本機檔案系統與 S3 相容服務（如 MinIO）。
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound 指定的 key 不存在
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage 依 key 存取二進位內容；同一個 key 重複 Put 會覆蓋，Delete 不存在的 key 不視為錯誤
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,98 @@
+// Package webhook 產生與驗證 webhook 的 HMAC-SHA256 簽章。
+// 簽章標頭格式為 t=<Unix 秒>,v1=<hex>，簽章內容為 "<Unix 秒>.<內容>"，接收端以時間戳記拒絕過舊的請求以防重送。
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader 簽章的標頭名稱
+const SignatureHeader = "X-Todolist-Signature"
+
+var (
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrExpiredSignature = errors.New("webhook: signature timestamp out of tolerance")
+)
+
+func mac(secret string, timestamp int64, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Sign 產生簽章標頭的值
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := at.Unix()
+	return "t=" + strconv.FormatInt(timestamp, 10) + ",v1=" + mac(secret, timestamp, body)
+}
+
+// Verify 驗證簽章標頭；時間與 now 相差超過 tolerance 時回傳 ErrExpiredSignature，tolerance 為 0 時不檢查時間。
+// 輪替密鑰期間標頭可以有多個 v1，任一個符合即可
+func Verify(secret string, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = t
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := mac(secret, timestamp, body)
+	matched := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		diff := now.Sub(time.Unix(timestamp, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return ErrExpiredSignature
+		}
+	}
+	return nil
+}
+
+// Backoff 第 attempt 次（從 1 開始）失敗後到下一次重試的間隔：base 的 2^(attempt-1) 倍，不超過 max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
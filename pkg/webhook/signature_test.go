@@ -0,0 +1,33 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+	"todolist/pkg/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	at := time.Unix(1767225600, 0)
+	body := []byte(`{"event":"detail.updated"}`)
+
+	header := webhook.Sign("secret", at, body)
+	assert.Regexp(t, `^t=1767225600,v1=[0-9a-f]{64}$`, header)
+
+	assert.NoError(t, webhook.Verify("secret", header, body, 5*time.Minute, at.Add(time.Minute)))
+	assert.ErrorIs(t, webhook.Verify("other", header, body, 0, at), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("secret", header, []byte(`{}`), 0, at), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("secret", header, body, 5*time.Minute, at.Add(time.Hour)), webhook.ErrExpiredSignature)
+	assert.ErrorIs(t, webhook.Verify("secret", "v1=abc", body, 0, at), webhook.ErrInvalidSignature)
+
+	// 輪替密鑰時可以帶多個簽章
+	rotated := webhook.Sign("old", at, body) + ",v1=" + webhook.Sign("secret", at, body)[len("t=1767225600,v1="):]
+	assert.NoError(t, webhook.Verify("secret", rotated, body, 0, at))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1, 30*time.Second, time.Hour))
+	assert.Equal(t, 4*time.Minute, webhook.Backoff(4, 30*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, webhook.Backoff(20, 30*time.Second, time.Hour))
+}
@@ -29,3 +29,34 @@ func IsAccountExist(ctx context.Context, db *gorm.DB, account string) (bool, err
 	}
 	return count > 0, nil
 }
+
+// FindByIDs 依 ID 取出使用者（只帶 id、account）
+func (r *AuthRepository) FindByIDs(ctx context.Context, db *gorm.DB, ids []int) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := db.WithContext(ctx).Select("id", "account").Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// FindByAccounts 依帳號取出使用者（只帶 id、account），不存在的帳號會被略過
+func (r *AuthRepository) FindByAccounts(ctx context.Context, db *gorm.DB, accounts []string) ([]models.User, error) {
+	var users []models.User
+	if len(accounts) == 0 {
+		return users, nil
+	}
+	err := db.WithContext(ctx).Select("id", "account").Where("account IN ?", accounts).Find(&users).Error
+	return users, err
+}
+
+// HasRole 檢查使用者是否擁有指定角色（不分大小寫，與 RequireRoles 一致）
+func (r *AuthRepository) HasRole(ctx context.Context, db *gorm.DB, userID int, roleName string) (bool, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ? AND LOWER(roles.name) = LOWER(?)", userID, roleName).
+		Count(&count).Error
+	return count > 0, err
+}
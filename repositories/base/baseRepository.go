@@ -3,6 +3,7 @@ package base
 import (
 	"context"
 	"fmt"
+	modelbase "todolist/models/base"
 
 	"gorm.io/gorm"
 )
@@ -24,9 +25,36 @@ func (r *BaseRepository[T]) Create(ctx context.Context, db *gorm.DB, entity T) e
 type FindOptions struct {
 	PreloadFields  []string
 	PreloadSelects map[string][]string
+	PreloadOrders  map[string]string // 關聯資料排序，例如 "Items": "sort_order asc"
 	Debug          bool
 }
 
+// Apply 將 Preload 設定套用到查詢上
+func (opt *FindOptions) Apply(query *gorm.DB) *gorm.DB {
+	if opt.Debug {
+		query = query.Debug()
+	}
+
+	for _, field := range opt.PreloadFields {
+		selects, hasSelects := opt.PreloadSelects[field]
+		order, hasOrder := opt.PreloadOrders[field]
+		if hasSelects || hasOrder {
+			query = query.Preload(field, func(tx *gorm.DB) *gorm.DB {
+				if hasSelects {
+					tx = tx.Select(selects)
+				}
+				if hasOrder {
+					tx = tx.Order(order)
+				}
+				return tx
+			})
+		} else {
+			query = query.Preload(field)
+		}
+	}
+	return query
+}
+
 func (r *BaseRepository[T]) FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*FindOptions) (T, error) {
 	var zero T // T 的零值
 
@@ -38,21 +66,7 @@ func (r *BaseRepository[T]) FindByID(ctx context.Context, db *gorm.DB, id int, o
 	query := db.WithContext(ctx)
 
 	if len(opts) > 0 && opts[0] != nil {
-		opt := opts[0]
-
-		if opt.Debug {
-			query = query.Debug()
-		}
-
-		for _, field := range opt.PreloadFields {
-			if selects, ok := opt.PreloadSelects[field]; ok {
-				query = query.Preload(field, func(tx *gorm.DB) *gorm.DB {
-					return tx.Select(selects)
-				})
-			} else {
-				query = query.Preload(field)
-			}
-		}
+		query = opts[0].Apply(query)
 	}
 
 	if err := query.First(&model, id).Error; err != nil {
@@ -64,8 +78,16 @@ func (r *BaseRepository[T]) FindByID(ctx context.Context, db *gorm.DB, id int, o
 
 // Update 更新傳入的 entity 資料（只更新非零值欄位）。
 // 通常用於已經查詢過的實體做修改後再儲存。
+// entity 實作 base.Versioned 時會一併保存版本快照，見 updateVersioned。
 func (r *BaseRepository[T]) Update(ctx context.Context, db *gorm.DB, entity T) error {
-	return db.WithContext(ctx).Model(entity).Updates(entity).Error
+	update := func() error {
+		return db.WithContext(ctx).Model(entity).Updates(entity).Error
+	}
+
+	if versioned, ok := any(entity).(modelbase.Versioned); ok {
+		return updateVersioned(ctx, db, versioned, update)
+	}
+	return update()
 }
 
 // UpdateByID 根據 ID 更新指定欄位（使用 map 格式傳入欲更新的欄位與值）。
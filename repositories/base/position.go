@@ -0,0 +1,80 @@
+package base
+
+import (
+	"errors"
+	"todolist/pkg/rank"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 以下函式操作 pkg/rank 的位置鍵，scope 為已指定 Model 與排序範圍條件的查詢，
+// 例如 db.Model(&models.TodoListDetails{}).Where("to_do_list_id = ?", listID)。
+
+// LastPosition 取得範圍內最大的位置鍵，沒有資料時回傳空字串
+func LastPosition(scope *gorm.DB, excludeID int) (string, error) {
+	return pickPosition(scope.Where("position IS NOT NULL"), excludeID, "position desc")
+}
+
+// NextPosition 取得大於 after 的第一個位置鍵，沒有時回傳空字串
+func NextPosition(scope *gorm.DB, after string, excludeID int) (string, error) {
+	return pickPosition(scope.Where("position > ?", after), excludeID, "position asc")
+}
+
+// PrevPosition 取得小於 before 的最後一個位置鍵，沒有時回傳空字串
+func PrevPosition(scope *gorm.DB, before string, excludeID int) (string, error) {
+	return pickPosition(scope.Where("position < ?", before), excludeID, "position desc")
+}
+
+func pickPosition(query *gorm.DB, excludeID int, order string) (string, error) {
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var positions []string
+	if err := query.Order(order).Limit(1).Pluck("position", &positions).Error; err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[0], nil
+}
+
+// RebalancePositions 依目前順序（尚未排序的資料排在最後，依 id）重新平均分配位置鍵。
+// 先鎖定範圍內所有資料並清空位置，再逐筆寫入，避免過程中與唯一索引衝突。
+// scope 每次呼叫都必須回傳新的查詢。
+func RebalancePositions(scope func() *gorm.DB) error {
+	var ids []int
+	err := scope().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("position IS NULL, position asc, id asc").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// 位置只是排序資訊，不更新 updated_at / updated_by
+	if err := scope().Where("id IN ?", ids).UpdateColumn("position", nil).Error; err != nil {
+		return err
+	}
+
+	keys := rank.Spread(len(ids))
+	for i, id := range ids {
+		if err := scope().Where("id = ?", id).UpdateColumn("position", keys[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearPosition 清空指定資料的位置鍵，讓刪除後的位置可以再被使用
+func ClearPosition(scope *gorm.DB, id int) error {
+	if id == 0 {
+		return errors.New("ClearPosition requires a valid non-zero ID")
+	}
+	return scope.Where("id = ?", id).UpdateColumn("position", nil).Error
+}
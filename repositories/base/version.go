@@ -0,0 +1,110 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"todolist/models"
+	modelbase "todolist/models/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type revertedFromKey struct{}
+
+// WithRevertedFrom 標記這次更新是還原到指定版本，會記錄在更新後產生的版本上
+func WithRevertedFrom(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, revertedFromKey{}, version)
+}
+
+func revertedFrom(ctx context.Context) *int {
+	if version, ok := ctx.Value(revertedFromKey{}).(int); ok {
+		return &version
+	}
+	return nil
+}
+
+// updateVersioned 鎖定資料後保存更新前後的快照；與最新版本內容相同時不新增，
+// 所以只改排序位置之類的更新不會產生版本，而透過其他方式改過的內容會在這時補成一個版本。
+// 需在交易中呼叫，鎖定才能避免同一筆資料的版本號衝突。
+func updateVersioned(ctx context.Context, db *gorm.DB, entity modelbase.Versioned, update func() error) error {
+	clean := db.Session(&gorm.Session{NewDB: true, Context: ctx})
+
+	current, err := loadVersioned(clean, entity, true)
+	if err != nil {
+		return err
+	}
+	if err := recordVersion(clean, current, nil); err != nil {
+		return err
+	}
+
+	if err := update(); err != nil {
+		return err
+	}
+
+	if current, err = loadVersioned(clean, entity, false); err != nil {
+		return err
+	}
+	return recordVersion(clean, current, revertedFrom(ctx))
+}
+
+// loadVersioned 重新讀取資料庫中的內容，entity 本身可能只有部分欄位
+func loadVersioned(db *gorm.DB, entity modelbase.Versioned, lock bool) (modelbase.Versioned, error) {
+	_, id := entity.VersionEntity()
+	fresh := reflect.New(reflect.TypeOf(entity).Elem()).Interface()
+
+	query := db
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.First(fresh, id).Error; err != nil {
+		return nil, err
+	}
+	return fresh.(modelbase.Versioned), nil
+}
+
+func recordVersion(db *gorm.DB, entity modelbase.Versioned, reverted *int) error {
+	entityType, id := entity.VersionEntity()
+	snapshot, err := json.Marshal(entity.VersionSnapshot())
+	if err != nil {
+		return err
+	}
+
+	var latest models.TodoVersions
+	err = db.Where("entity_type = ? AND entity_id = ?", entityType, id).Order("version desc").Take(&latest).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// 還沒有版本，從 1 開始
+	case err != nil:
+		return err
+	default:
+		same, err := sameSnapshot(latest.Snapshot, snapshot)
+		if err != nil || same {
+			return err
+		}
+	}
+
+	return db.Create(&models.TodoVersions{
+		EntityType:   entityType,
+		EntityID:     id,
+		Version:      latest.Version + 1,
+		Snapshot:     snapshot,
+		RevertedFrom: reverted,
+		CreatedAt:    entity.ModifiedAt(),
+		CreatedBy:    entity.ModifiedBy(),
+	}).Error
+}
+
+// sameSnapshot 以解析後的內容比較，資料庫的 JSON 欄位不保留原本的格式與 key 順序
+func sameSnapshot(a, b []byte) (bool, error) {
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(x, y), nil
+}
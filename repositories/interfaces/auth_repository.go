@@ -15,4 +15,7 @@ type AuthRepository interface {
 	Create(ctx context.Context, db *gorm.DB, entity *models.User) error
 	Update(ctx context.Context, db *gorm.DB, entity *models.User) error
 	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.User) error
+	FindByIDs(ctx context.Context, db *gorm.DB, ids []int) ([]models.User, error)
+	FindByAccounts(ctx context.Context, db *gorm.DB, accounts []string) ([]models.User, error)
+	HasRole(ctx context.Context, db *gorm.DB, userID int, roleName string) (bool, error)
 }
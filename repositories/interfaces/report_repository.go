@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepository interface {
+	CountByPeriod(ctx context.Context, db *gorm.DB, column string, interval string, filter models.ReportFilter) ([]models.PeriodCount, error)
+	FindCompletedTimings(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]models.CompletedTiming, error)
+	Workload(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]*models.WorkloadRow, error)
+	TypeBreakdown(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]*models.TypeBreakdownRow, error)
+}
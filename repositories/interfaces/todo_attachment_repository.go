@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoAttachmentRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoAttachments, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoAttachments) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoAttachments) error
+	FindByTarget(ctx context.Context, db *gorm.DB, column string, targetID int) ([]*models.TodoAttachments, error)
+	LockBlobBySHA256(ctx context.Context, db *gorm.DB, sha256 string) (*models.TodoBlobs, error)
+	CreateBlob(ctx context.Context, db *gorm.DB, blob *models.TodoBlobs) error
+	FindOrphanBlobIDs(ctx context.Context, db *gorm.DB, before time.Time, limit int) ([]int, error)
+	LockOrphanBlob(ctx context.Context, db *gorm.DB, id int, before time.Time) (*models.TodoBlobs, error)
+	DeleteBlob(ctx context.Context, db *gorm.DB, id int) error
+}
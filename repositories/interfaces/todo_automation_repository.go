@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoAutomationRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoAutomationRules, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoAutomationRules) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoAutomationRules) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoAutomationRules) error
+	FindRules(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoAutomationRules, error)
+	FindActive(ctx context.Context, db *gorm.DB, trigger string, listID int) ([]*models.TodoAutomationRules, error)
+	FindTarget(ctx context.Context, db *gorm.DB, detailID int) (*models.AutomationTarget, error)
+	FindOverdue(ctx context.Context, db *gorm.DB, listID int, from, to time.Time) ([]*models.TodoListDetails, error)
+	CreateExecution(ctx context.Context, db *gorm.DB, execution *models.TodoAutomationExecutions) (bool, error)
+	UpdateExecution(ctx context.Context, db *gorm.DB, execution *models.TodoAutomationExecutions) error
+	FindExecutions(ctx context.Context, db *gorm.DB, ruleID int, page, pageSize int) ([]*models.TodoAutomationExecutions, int64, error)
+}
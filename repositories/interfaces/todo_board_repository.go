@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoBoardRepository interface {
+	FindColumns(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoBoardColumns, error)
+	LockColumnByStatus(ctx context.Context, db *gorm.DB, listID int, status string) (*models.TodoBoardColumns, error)
+	ReplaceColumns(ctx context.Context, db *gorm.DB, listID int, columns []*models.TodoBoardColumns) error
+	FindTransitions(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoBoardTransitions, error)
+	ReplaceTransitions(ctx context.Context, db *gorm.DB, listID int, transitions []*models.TodoBoardTransitions) error
+	CountByStatus(ctx context.Context, db *gorm.DB, listID int) (map[string]int64, error)
+	CountInStatus(ctx context.Context, db *gorm.DB, listID int, status string, excludeID int) (int64, error)
+	FindCards(ctx context.Context, db *gorm.DB, listID int, status string, page, pageSize int) ([]models.TodoListDetails, error)
+}
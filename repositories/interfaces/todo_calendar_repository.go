@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoCalendarRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoFeedTokens, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoFeedTokens) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoFeedTokens) error
+	FindActiveByHash(ctx context.Context, db *gorm.DB, hash string, scope string) (*models.TodoFeedTokens, error)
+	FindByUser(ctx context.Context, db *gorm.DB, userID int) ([]*models.TodoFeedTokens, error)
+	Touch(ctx context.Context, db *gorm.DB, id int, at time.Time) error
+	FeedVersion(ctx context.Context, db *gorm.DB, token *models.TodoFeedTokens) (int64, *time.Time, error)
+	FindFeedItems(ctx context.Context, db *gorm.DB, token *models.TodoFeedTokens) ([]*models.TodoListDetails, error)
+	FindImportedUIDs(ctx context.Context, db *gorm.DB, listID int, uids []string) ([]string, error)
+	SetCalendarFields(ctx context.Context, db *gorm.DB, detailID int, uid *string, dueAt *time.Time) error
+	FindLists(ctx context.Context, db *gorm.DB) ([]*models.TodoList, error)
+	FindList(ctx context.Context, db *gorm.DB, id int) (*models.TodoList, error)
+	FindResources(ctx context.Context, db *gorm.DB, listIDs []int) ([]*models.TodoListDetails, error)
+	FindResourceByUID(ctx context.Context, db *gorm.DB, listID int, uid string) (*models.TodoListDetails, error)
+	FindResourceByID(ctx context.Context, db *gorm.DB, listID int, id int) (*models.TodoListDetails, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoChecklistRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoChecklistItems, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoChecklistItems) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoChecklistItems) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoChecklistItems) error
+	FindByDetailID(ctx context.Context, db *gorm.DB, detailID int) ([]*models.TodoChecklistItems, error)
+	NextSortOrder(ctx context.Context, db *gorm.DB, detailID int) (int, error)
+	UpdateSortOrder(ctx context.Context, db *gorm.DB, id int, sortOrder int) error
+	LockDetailMarkdown(ctx context.Context, db *gorm.DB, detailID int) (string, error)
+	UpdateDetailMarkdown(ctx context.Context, db *gorm.DB, detailID int, src string) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoCommentRepository interface {
+	FindAllWithQuery(ctx context.Context, db *gorm.DB, page, pageSize int, orderBy ...string) ([]*models.TodoComments, int64, error)
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoComments, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoComments) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoComments) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoComments) error
+	ReplaceMentions(ctx context.Context, db *gorm.DB, comment *models.TodoComments, users []models.User) error
+	CreateRevision(ctx context.Context, db *gorm.DB, revision *models.TodoCommentRevisions) error
+	FindRevisions(ctx context.Context, db *gorm.DB, commentID int) ([]*models.TodoCommentRevisions, error)
+}
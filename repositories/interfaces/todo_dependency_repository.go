@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoDependencyRepository interface {
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoDetailDependency) error
+	Exists(ctx context.Context, db *gorm.DB, blockerID, blockedID int) (bool, error)
+	Delete(ctx context.Context, db *gorm.DB, blockerID, blockedID int) error
+	FindEdgesFrom(ctx context.Context, db *gorm.DB, blockerIDs []int) ([]*models.TodoDetailDependency, error)
+	FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoDetailDependency, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoEmailRepository interface {
+	FindSettings(ctx context.Context, db *gorm.DB, userID int) (*models.TodoEmailSettings, error)
+	FindSettingsByUsers(ctx context.Context, db *gorm.DB, userIDs []int) ([]*models.TodoEmailSettings, error)
+	FindSettingsByToken(ctx context.Context, db *gorm.DB, token string) (*models.TodoEmailSettings, error)
+	SaveSettings(ctx context.Context, db *gorm.DB, settings *models.TodoEmailSettings) error
+	LockDigestDue(ctx context.Context, db *gorm.DB, digest string, periodStart time.Time) (*models.TodoEmailSettings, error)
+	FindTasks(ctx context.Context, db *gorm.DB, detailIDs []int) ([]*models.EmailTask, error)
+	FindOpenTasks(ctx context.Context, db *gorm.DB, userID int) ([]*models.EmailTask, error)
+	CreateMessages(ctx context.Context, db *gorm.DB, messages []*models.TodoEmailMessages) error
+	LockNextMessage(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoEmailMessages, error)
+	UpdateMessage(ctx context.Context, db *gorm.DB, message *models.TodoEmailMessages) error
+}
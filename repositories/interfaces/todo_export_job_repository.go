@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoExportJobRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoExportJobs, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoExportJobs) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoExportJobs) error
+	LockNext(ctx context.Context, db *gorm.DB, staleBefore time.Time) (*models.TodoExportJobs, error)
+}
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoImportRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoImports, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoImports) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoImports) error
+	LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoImports, error)
+	CreateRows(ctx context.Context, db *gorm.DB, rows []*models.TodoImportRows) error
+	FindRows(ctx context.Context, db *gorm.DB, importID int, problems bool, page, pageSize int) ([]*models.TodoImportRows, int64, error)
+	PendingListNames(ctx context.Context, db *gorm.DB, importID int) ([]string, error)
+	LockPendingRows(ctx context.Context, db *gorm.DB, importID int, listName string) ([]*models.TodoImportRows, error)
+	MarkRowImported(ctx context.Context, db *gorm.DB, rowID, detailID int) error
+	FindTypeByName(ctx context.Context, db *gorm.DB, name string) (*models.TodoTypes, error)
+	FindListByName(ctx context.Context, db *gorm.DB, name string) (*models.TodoList, error)
+	FindExistingListNames(ctx context.Context, db *gorm.DB, names []string) ([]string, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoLabelRepository interface {
+	FindAllWithQuery(ctx context.Context, db *gorm.DB, page, pageSize int, orderBy ...string) ([]*models.TodoLabels, int64, error)
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoLabels, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoLabels) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoLabels) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoLabels) error
+	IsNameExist(ctx context.Context, db *gorm.DB, name string, excludeID int) (bool, error)
+	DetachAll(ctx context.Context, db *gorm.DB, id int) error
+	Usage(ctx context.Context, db *gorm.DB) ([]*models.TodoLabelUsage, error)
+}
@@ -15,4 +15,13 @@ type TodoListDetailsRepository interface {
 	Create(ctx context.Context, db *gorm.DB, entity *models.TodoListDetails) error
 	Update(ctx context.Context, db *gorm.DB, entity *models.TodoListDetails) error
 	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoListDetails) error
+	FindOpenBlockers(ctx context.Context, db *gorm.DB, id int) ([]*models.TodoListDetails, error)
+	FindByIDs(ctx context.Context, db *gorm.DB, ids []int) ([]*models.TodoListDetails, error)
+	FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoListDetails, error)
+	LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoListDetails, error)
+	LastPosition(ctx context.Context, db *gorm.DB, listID int, excludeID int) (string, error)
+	NextPosition(ctx context.Context, db *gorm.DB, listID int, after string, excludeID int) (string, error)
+	PrevPosition(ctx context.Context, db *gorm.DB, listID int, before string, excludeID int) (string, error)
+	RebalancePositions(ctx context.Context, db *gorm.DB, listID int) error
+	CreateStatusChange(ctx context.Context, db *gorm.DB, change *models.TodoStatusChanges) error
 }
@@ -16,4 +16,10 @@ type TodoListRepository interface {
 	Update(ctx context.Context, db *gorm.DB, entity *models.TodoList) error
 	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoList) error
 	IsNameExist(ctx context.Context, db *gorm.DB, name string, excludeID int) (bool, error)
+	LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoList, error)
+	LastPosition(ctx context.Context, db *gorm.DB, excludeID int) (string, error)
+	NextPosition(ctx context.Context, db *gorm.DB, after string, excludeID int) (string, error)
+	PrevPosition(ctx context.Context, db *gorm.DB, before string, excludeID int) (string, error)
+	RebalancePositions(ctx context.Context, db *gorm.DB) error
+	FindBatch(ctx context.Context, db *gorm.DB, afterID, limit int, opts *base.FindOptions) ([]*models.TodoList, error)
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoMilestoneRepository interface {
+	FindAllWithQuery(ctx context.Context, db *gorm.DB, page, pageSize int, orderBy ...string) ([]*models.TodoMilestones, int64, error)
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoMilestones, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoMilestones) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoMilestones) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoMilestones) error
+	LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoMilestones, error)
+	AssignList(ctx context.Context, db *gorm.DB, listID int, milestoneID *int) error
+	AssignDetail(ctx context.Context, db *gorm.DB, detailID int, milestoneID *int) error
+	Detach(ctx context.Context, db *gorm.DB, id int) error
+	FindMembers(ctx context.Context, db *gorm.DB, milestoneID int) ([]*models.TodoListDetails, error)
+	LockUnfinished(ctx context.Context, db *gorm.DB, milestoneID int) ([]int, error)
+	Rollover(ctx context.Context, db *gorm.DB, fromID, toID int, detailIDs []int) error
+	FindStatusChanges(ctx context.Context, db *gorm.DB, detailIDs []int, before time.Time) ([]*models.TodoStatusChanges, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoNotificationRepository interface {
+	CreateNotifications(ctx context.Context, db *gorm.DB, notifications []*models.TodoNotifications) error
+	FindByUser(ctx context.Context, db *gorm.DB, userID int, unreadOnly bool, page, pageSize int) ([]*models.TodoNotifications, int64, error)
+	CountUnread(ctx context.Context, db *gorm.DB, userID int) (int64, error)
+	MarkRead(ctx context.Context, db *gorm.DB, userID int, ids []int, at time.Time) (int64, error)
+	FindPreferences(ctx context.Context, db *gorm.DB, userID int) ([]*models.TodoNotificationPreferences, error)
+	SavePreferences(ctx context.Context, db *gorm.DB, prefs []*models.TodoNotificationPreferences) error
+	FindOptedOut(ctx context.Context, db *gorm.DB, notificationType string, userIDs []int) ([]int, error)
+	FindDetail(ctx context.Context, db *gorm.DB, detailID int) (*models.TodoListDetails, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoOutboxRepository interface {
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoOutboxEvents) error
+	LockNext(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoOutboxEvents, error)
+	UpdateStatus(ctx context.Context, db *gorm.DB, event *models.TodoOutboxEvents) error
+	DeleteDispatched(ctx context.Context, db *gorm.DB, before time.Time) (int64, error)
+}
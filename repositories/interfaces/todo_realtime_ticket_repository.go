@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoRealtimeTicketRepository interface {
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoRealtimeTickets) error
+	Redeem(ctx context.Context, db *gorm.DB, hash string, now time.Time) (int, error)
+	DeleteExpired(ctx context.Context, db *gorm.DB, before time.Time) error
+}
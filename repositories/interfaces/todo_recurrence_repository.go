@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoRecurrenceRepository interface {
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoRecurrences) error
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoRecurrences, error)
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoRecurrences) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoRecurrences) error
+	FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoRecurrences, error)
+	FindDueIDs(ctx context.Context, db *gorm.DB, now time.Time, limit int) ([]int, error)
+	LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoRecurrences, error)
+	CreateOccurrence(ctx context.Context, db *gorm.DB, detail *models.TodoListDetails) (bool, error)
+}
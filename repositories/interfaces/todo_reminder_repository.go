@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoReminderRepository interface {
+	AcquireLease(ctx context.Context, db *gorm.DB, name, owner string, now time.Time, ttl time.Duration) (bool, error)
+	FindDueTasks(ctx context.Context, db *gorm.DB, from, to time.Time) ([]*models.ReminderTask, error)
+	FindUserIDsByRole(ctx context.Context, db *gorm.DB, roleName string) ([]int, error)
+	CreateLog(ctx context.Context, db *gorm.DB, log *models.TodoReminderLogs) (bool, error)
+	CreateRun(ctx context.Context, db *gorm.DB, run *models.TodoReminderRuns) error
+	UpdateRun(ctx context.Context, db *gorm.DB, run *models.TodoReminderRuns) error
+	FindRuns(ctx context.Context, db *gorm.DB, page, pageSize int) ([]*models.TodoReminderRuns, int64, error)
+	FindRun(ctx context.Context, db *gorm.DB, id int) (*models.TodoReminderRuns, error)
+	DeleteRuns(ctx context.Context, db *gorm.DB, before time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoTimeEntryRepository interface {
+	FindAllWithQuery(ctx context.Context, db *gorm.DB, page, pageSize int, orderBy ...string) ([]*models.TodoTimeEntries, int64, error)
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoTimeEntries, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoTimeEntries) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoTimeEntries) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoTimeEntries) error
+	FindRunning(ctx context.Context, db *gorm.DB, userID int) (*models.TodoTimeEntries, error)
+	LockRunning(ctx context.Context, db *gorm.DB, userID int) (*models.TodoTimeEntries, error)
+	Summarize(ctx context.Context, db *gorm.DB, groupBy string, filter models.TimeEntryFilter) ([]*models.TimeSummary, error)
+}
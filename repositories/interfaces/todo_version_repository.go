@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoVersionRepository interface {
+	FindAllWithQuery(ctx context.Context, db *gorm.DB, page, pageSize int, orderBy ...string) ([]*models.TodoVersions, int64, error)
+	FindVersion(ctx context.Context, db *gorm.DB, entityType string, entityID int, version int) (*models.TodoVersions, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoWatcherRepository interface {
+	AddListWatchers(ctx context.Context, db *gorm.DB, listID int, userIDs []int) error
+	RemoveListWatcher(ctx context.Context, db *gorm.DB, listID int, userID int) error
+	FindListWatchers(ctx context.Context, db *gorm.DB, listID int) ([]models.User, error)
+	AddDetailWatchers(ctx context.Context, db *gorm.DB, detailID int, userIDs []int) error
+	RemoveDetailWatcher(ctx context.Context, db *gorm.DB, detailID int, userID int) error
+	FindDetailWatchers(ctx context.Context, db *gorm.DB, detailID int) ([]models.User, error)
+	FindWatcherIDs(ctx context.Context, db *gorm.DB, listID int, detailID int) ([]int, error)
+}
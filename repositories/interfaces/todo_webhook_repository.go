@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"todolist/repositories/base"
+
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+type TodoWebhookRepository interface {
+	FindByID(ctx context.Context, db *gorm.DB, id int, opts ...*base.FindOptions) (*models.TodoWebhooks, error)
+	Create(ctx context.Context, db *gorm.DB, entity *models.TodoWebhooks) error
+	Update(ctx context.Context, db *gorm.DB, entity *models.TodoWebhooks) error
+	SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoWebhooks) error
+	FindWebhooks(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoWebhooks, error)
+	FindActive(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoWebhooks, error)
+	CreateDeliveries(ctx context.Context, db *gorm.DB, deliveries []*models.TodoWebhookDeliveries) error
+	FindEventWebhookIDs(ctx context.Context, db *gorm.DB, eventID string) ([]int, error)
+	FindDelivery(ctx context.Context, db *gorm.DB, id int) (*models.TodoWebhookDeliveries, error)
+	FindDeliveries(ctx context.Context, db *gorm.DB, webhookID int, page, pageSize int) ([]*models.TodoWebhookDeliveries, int64, error)
+	LockNextDelivery(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoWebhookDeliveries, error)
+	UpdateDelivery(ctx context.Context, db *gorm.DB, delivery *models.TodoWebhookDeliveries) error
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"todolist/models"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository 報表用的彙總查詢，只計算未刪除的 TodoList 與 TodoListDetails
+type ReportRepository struct{}
+
+func NewReportRepository() *ReportRepository {
+	return &ReportRepository{}
+}
+
+// details 套用 TodoList / 類型篩選的 TodoListDetails 查詢
+func (r *ReportRepository) details(ctx context.Context, db *gorm.DB, filter models.ReportFilter) *gorm.DB {
+	query := db.WithContext(ctx).
+		Table("to_do_list_details AS d").
+		Joins("JOIN to_do_list AS l ON l.id = d.to_do_list_id AND l.deleted_at IS NULL").
+		Where("d.deleted_at IS NULL")
+	if filter.ListID > 0 {
+		query = query.Where("l.id = ?", filter.ListID)
+	}
+	if filter.TypeID > 0 {
+		query = query.Where("l.type_id = ?", filter.TypeID)
+	}
+	return query
+}
+
+// rangeCondition column 落在篩選期間內的條件
+func rangeCondition(column string, filter models.ReportFilter) (string, []interface{}) {
+	sql := column + " IS NOT NULL"
+	var args []interface{}
+	if filter.From != nil {
+		sql += " AND " + column + " >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		sql += " AND " + column + " < ?"
+		args = append(args, *filter.To)
+	}
+	return sql, args
+}
+
+// CountByPeriod 依日或週（以週一為起點）計算 column 落在各區間的任務數
+func (r *ReportRepository) CountByPeriod(ctx context.Context, db *gorm.DB, column string, interval string, filter models.ReportFilter) ([]models.PeriodCount, error) {
+	var period string
+	switch interval {
+	case models.ReportIntervalDay:
+		period = fmt.Sprintf("DATE_FORMAT(d.%s, '%%Y-%%m-%%d')", column)
+	case models.ReportIntervalWeek:
+		period = fmt.Sprintf("DATE_FORMAT(DATE_SUB(DATE(d.%[1]s), INTERVAL WEEKDAY(d.%[1]s) DAY), '%%Y-%%m-%%d')", column)
+	default:
+		return nil, fmt.Errorf("不支援的區間：%s", interval)
+	}
+
+	cond, args := rangeCondition("d."+column, filter)
+	var rows []models.PeriodCount
+	err := r.details(ctx, db, filter).
+		Select(period+" AS period, COUNT(*) AS total").
+		Where(cond, args...).
+		Group(period).
+		Order("period asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// FindCompletedTimings 期間內完成的任務
+func (r *ReportRepository) FindCompletedTimings(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]models.CompletedTiming, error) {
+	cond, args := rangeCondition("d.completed_at", filter)
+	var rows []models.CompletedTiming
+	err := r.details(ctx, db, filter).
+		Select("d.created_at, d.started_at, d.completed_at").
+		Where("d.status = ?", models.DetailStatusDone).
+		Where(cond, args...).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// Workload 依 to_do_task_assignments 計算每位使用者尚未完成的任務，期間以任務建立時間計算
+func (r *ReportRepository) Workload(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]*models.WorkloadRow, error) {
+	cond, args := rangeCondition("d.created_at", filter)
+	var rows []*models.WorkloadRow
+	err := r.details(ctx, db, filter).
+		Select("u.id AS user_id, u.account AS account, "+
+			"SUM(CASE WHEN d.status = ? THEN 1 ELSE 0 END) AS todo, "+
+			"SUM(CASE WHEN d.status = ? THEN 1 ELSE 0 END) AS in_progress, "+
+			"COUNT(*) AS open", models.DetailStatusTodo, models.DetailStatusInProgress).
+		Joins("JOIN to_do_task_assignments AS a ON a.to_do_list_detail_id = d.id").
+		Joins("JOIN users AS u ON u.id = a.user_id AND u.deleted_at IS NULL").
+		Where("d.status <> ?", models.DetailStatusDone).
+		Where(cond, args...).
+		Group("u.id, u.account").
+		Order("open desc, u.id asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// TypeBreakdown 依 TodoTypes 計算期間內新增、完成的任務，以及期間內新增且尚未完成的任務
+func (r *ReportRepository) TypeBreakdown(ctx context.Context, db *gorm.DB, filter models.ReportFilter) ([]*models.TypeBreakdownRow, error) {
+	created, createdArgs := rangeCondition("d.created_at", filter)
+	completed, completedArgs := rangeCondition("d.completed_at", filter)
+
+	args := append([]interface{}{}, createdArgs...)
+	args = append(args, completedArgs...)
+	args = append(args, models.DetailStatusDone)
+	args = append(args, createdArgs...)
+
+	var rows []*models.TypeBreakdownRow
+	err := r.details(ctx, db, filter).
+		Select(fmt.Sprintf("ty.id AS type_id, ty.name AS name, "+
+			"SUM(CASE WHEN %s THEN 1 ELSE 0 END) AS created, "+
+			"SUM(CASE WHEN %s THEN 1 ELSE 0 END) AS completed, "+
+			"SUM(CASE WHEN d.status <> ? AND %s THEN 1 ELSE 0 END) AS open", created, completed, created), args...).
+		Joins("JOIN to_do_types AS ty ON ty.id = l.type_id").
+		Group("ty.id, ty.name").
+		Order("ty.id asc").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoAttachmentRepository struct {
+	*base.BaseRepository[*models.TodoAttachments]
+}
+
+func NewTodoAttachmentRepository() *TodoAttachmentRepository {
+	return &TodoAttachmentRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoAttachments](),
+	}
+}
+
+// FindByTarget 依上傳時間取出掛在指定對象上的附件，column 為 to_do_list_detail_id 或 comment_id
+func (r *TodoAttachmentRepository) FindByTarget(ctx context.Context, db *gorm.DB, column string, targetID int) ([]*models.TodoAttachments, error) {
+	var items []*models.TodoAttachments
+	err := db.WithContext(ctx).
+		Where(clause.Eq{Column: clause.Column{Name: column}, Value: targetID}).
+		Order("id asc").
+		Find(&items).Error
+	return items, err
+}
+
+// LockBlobBySHA256 以 FOR UPDATE 鎖定相同內容的 blob，避免和清除排程同時進行；不存在時回傳 nil
+func (r *TodoAttachmentRepository) LockBlobBySHA256(ctx context.Context, db *gorm.DB, sha256 string) (*models.TodoBlobs, error) {
+	var blob models.TodoBlobs
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sha256 = ?", sha256).
+		First(&blob).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+func (r *TodoAttachmentRepository) CreateBlob(ctx context.Context, db *gorm.DB, blob *models.TodoBlobs) error {
+	return db.WithContext(ctx).Create(blob).Error
+}
+
+// orphanBlobs 沒有未刪除的附件、且最後一個附件在 before 之前就已刪除的 blob
+func orphanBlobs(db *gorm.DB, before time.Time) *gorm.DB {
+	return db.Model(&models.TodoBlobs{}).
+		Where("NOT EXISTS (SELECT 1 FROM to_do_attachments a WHERE a.blob_id = to_do_blobs.id AND (a.deleted_at IS NULL OR a.deleted_at >= ?))", before)
+}
+
+// FindOrphanBlobIDs 取出已沒有附件引用的 blob ID
+func (r *TodoAttachmentRepository) FindOrphanBlobIDs(ctx context.Context, db *gorm.DB, before time.Time, limit int) ([]int, error) {
+	var ids []int
+	err := orphanBlobs(db.WithContext(ctx), before).
+		Order("to_do_blobs.id asc").
+		Limit(limit).
+		Pluck("to_do_blobs.id", &ids).Error
+	return ids, err
+}
+
+// LockOrphanBlob 以 FOR UPDATE 鎖定 blob 並再次確認沒有附件引用；已被重新引用或不存在時回傳 nil
+func (r *TodoAttachmentRepository) LockOrphanBlob(ctx context.Context, db *gorm.DB, id int, before time.Time) (*models.TodoBlobs, error) {
+	var blob models.TodoBlobs
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&blob, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := orphanBlobs(db.WithContext(ctx), before).Where("to_do_blobs.id = ?", id).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return &blob, nil
+}
+
+// DeleteBlob 刪除 blob 紀錄；已軟刪除的附件仍引用它，一併移除
+func (r *TodoAttachmentRepository) DeleteBlob(ctx context.Context, db *gorm.DB, id int) error {
+	if err := db.WithContext(ctx).Exec("DELETE FROM to_do_attachments WHERE blob_id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return err
+	}
+	return db.WithContext(ctx).Delete(&models.TodoBlobs{}, id).Error
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoAutomationRepository struct {
+	*base.BaseRepository[*models.TodoAutomationRules]
+}
+
+func NewTodoAutomationRepository() *TodoAutomationRepository {
+	return &TodoAutomationRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoAutomationRules](),
+	}
+}
+
+// FindRules 取出規則，listID 為 0 時取出全部
+func (r *TodoAutomationRepository) FindRules(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoAutomationRules, error) {
+	var rules []*models.TodoAutomationRules
+	query := db.WithContext(ctx).Order("id asc")
+	if listID > 0 {
+		query = query.Where("to_do_list_id = ?", listID)
+	}
+	err := query.Find(&rules).Error
+	return rules, err
+}
+
+// FindActive 取出觸發條件為 trigger 的啟用中規則：屬於該 TodoList 的與不限 TodoList 的；listID 為 0 時取出所有 TodoList 的
+func (r *TodoAutomationRepository) FindActive(ctx context.Context, db *gorm.DB, trigger string, listID int) ([]*models.TodoAutomationRules, error) {
+	var rules []*models.TodoAutomationRules
+	query := db.WithContext(ctx).Where("`trigger` = ? AND active = ?", trigger, true)
+	if listID > 0 {
+		query = query.Where("to_do_list_id IS NULL OR to_do_list_id = ?", listID)
+	}
+	err := query.Order("id asc").Find(&rules).Error
+	return rules, err
+}
+
+// FindTarget 取出項目與標籤、負責人及所屬 TodoList 的類型
+func (r *TodoAutomationRepository) FindTarget(ctx context.Context, db *gorm.DB, detailID int) (*models.AutomationTarget, error) {
+	var detail models.TodoListDetails
+	if err := db.WithContext(ctx).Preload("Labels").Preload("Users").Take(&detail, detailID).Error; err != nil {
+		return nil, err
+	}
+	var list models.TodoList
+	if err := db.WithContext(ctx).Select("id", "type_id").Take(&list, detail.TodoListID).Error; err != nil {
+		return nil, err
+	}
+	return &models.AutomationTarget{Detail: &detail, TypeID: list.TypeID}, nil
+}
+
+// FindOverdue 到期時間在 [from, to) 之間、尚未完成的項目，listID 為 0 時不限 TodoList
+func (r *TodoAutomationRepository) FindOverdue(ctx context.Context, db *gorm.DB, listID int, from, to time.Time) ([]*models.TodoListDetails, error) {
+	var details []*models.TodoListDetails
+	query := db.WithContext(ctx).Select("id", "to_do_list_id", "due_at").
+		Where("status <> ? AND due_at >= ? AND due_at < ?", models.DetailStatusDone, from, to)
+	if listID > 0 {
+		query = query.Where("to_do_list_id = ?", listID)
+	}
+	err := query.Order("due_at asc, id asc").Find(&details).Error
+	return details, err
+}
+
+// CreateExecution 建立執行紀錄，同一個規則已經處理過同一個觸發時回傳 false
+func (r *TodoAutomationRepository) CreateExecution(ctx context.Context, db *gorm.DB, execution *models.TodoAutomationExecutions) (bool, error) {
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(execution)
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdateExecution 寫入執行結果
+func (r *TodoAutomationRepository) UpdateExecution(ctx context.Context, db *gorm.DB, execution *models.TodoAutomationExecutions) error {
+	return db.WithContext(ctx).Model(execution).Select("status", "results", "error").Updates(execution).Error
+}
+
+// FindExecutions 分頁取出規則的執行紀錄，新的在前
+func (r *TodoAutomationRepository) FindExecutions(ctx context.Context, db *gorm.DB, ruleID int, page, pageSize int) ([]*models.TodoAutomationExecutions, int64, error) {
+	var executions []*models.TodoAutomationExecutions
+	var total int64
+	query := db.WithContext(ctx).Model(&models.TodoAutomationExecutions{}).Where("rule_id = ?", ruleID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id desc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&executions).Error
+	return executions, total, err
+}
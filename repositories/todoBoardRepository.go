@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"todolist/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoBoardRepository struct{}
+
+func NewTodoBoardRepository() *TodoBoardRepository {
+	return &TodoBoardRepository{}
+}
+
+// FindColumns 依 sort_order 取出 TodoList 的看板欄位
+func (r *TodoBoardRepository) FindColumns(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoBoardColumns, error) {
+	var columns []*models.TodoBoardColumns
+	err := db.WithContext(ctx).
+		Where("to_do_list_id = ?", listID).
+		Order("sort_order asc, id asc").
+		Find(&columns).Error
+	return columns, err
+}
+
+// LockColumnByStatus 以 FOR UPDATE 鎖定狀態對應的欄位，用來序列化 WIP 上限檢查；沒有對應欄位時回傳 nil
+func (r *TodoBoardRepository) LockColumnByStatus(ctx context.Context, db *gorm.DB, listID int, status string) (*models.TodoBoardColumns, error) {
+	var column models.TodoBoardColumns
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("to_do_list_id = ? AND status = ?", listID, status).
+		First(&column).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// ReplaceColumns 以新的欄位設定取代舊設定（欄位為設定資料，直接刪除）
+func (r *TodoBoardRepository) ReplaceColumns(ctx context.Context, db *gorm.DB, listID int, columns []*models.TodoBoardColumns) error {
+	if err := db.WithContext(ctx).Unscoped().Where("to_do_list_id = ?", listID).Delete(&models.TodoBoardColumns{}).Error; err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&columns).Error
+}
+
+func (r *TodoBoardRepository) FindTransitions(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoBoardTransitions, error) {
+	var transitions []*models.TodoBoardTransitions
+	err := db.WithContext(ctx).
+		Where("to_do_list_id = ?", listID).
+		Order("from_status asc, to_status asc").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *TodoBoardRepository) ReplaceTransitions(ctx context.Context, db *gorm.DB, listID int, transitions []*models.TodoBoardTransitions) error {
+	if err := db.WithContext(ctx).Where("to_do_list_id = ?", listID).Delete(&models.TodoBoardTransitions{}).Error; err != nil {
+		return err
+	}
+	if len(transitions) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&transitions).Error
+}
+
+// CountByStatus 統計 TodoList 底下各狀態的項目數
+func (r *TodoBoardRepository) CountByStatus(ctx context.Context, db *gorm.DB, listID int) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+	err := db.WithContext(ctx).
+		Model(&models.TodoListDetails{}).
+		Select("status, COUNT(*) AS total").
+		Where("to_do_list_id = ?", listID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
+
+// CountInStatus 統計 TodoList 中某狀態的項目數，可排除正在移動的項目
+func (r *TodoBoardRepository) CountInStatus(ctx context.Context, db *gorm.DB, listID int, status string, excludeID int) (int64, error) {
+	var count int64
+	query := db.WithContext(ctx).
+		Model(&models.TodoListDetails{}).
+		Where("to_do_list_id = ? AND status = ?", listID, status)
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// FindCards 依位置分頁取出某狀態的卡片，並帶出負責人與標籤
+func (r *TodoBoardRepository) FindCards(ctx context.Context, db *gorm.DB, listID int, status string, page, pageSize int) ([]models.TodoListDetails, error) {
+	var cards []models.TodoListDetails
+	err := db.WithContext(ctx).
+		Preload("Users", func(tx *gorm.DB) *gorm.DB { return tx.Select("id", "account") }).
+		Preload("Labels").
+		Where("to_do_list_id = ? AND status = ?", listID, status).
+		Order("position IS NULL, position asc, id asc").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&cards).Error
+	return cards, err
+}
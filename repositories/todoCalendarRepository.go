@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoCalendarRepository struct {
+	*base.BaseRepository[*models.TodoFeedTokens]
+}
+
+func NewTodoCalendarRepository() *TodoCalendarRepository {
+	return &TodoCalendarRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoFeedTokens](),
+	}
+}
+
+// FindActiveByHash 依憑證的 SHA-256 與用途取出尚未撤銷的憑證
+func (r *TodoCalendarRepository) FindActiveByHash(ctx context.Context, db *gorm.DB, hash string, scope string) (*models.TodoFeedTokens, error) {
+	var token models.TodoFeedTokens
+	err := db.WithContext(ctx).Where("token_hash = ? AND scope = ? AND revoked_at IS NULL", hash, scope).Take(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUser 取出使用者建立的所有憑證，包含已撤銷的
+func (r *TodoCalendarRepository) FindByUser(ctx context.Context, db *gorm.DB, userID int) ([]*models.TodoFeedTokens, error) {
+	var tokens []*models.TodoFeedTokens
+	err := db.WithContext(ctx).Where("user_id = ?", userID).Order("id desc").Find(&tokens).Error
+	return tokens, err
+}
+
+// Touch 記錄最後使用時間，不更新 updated_at
+func (r *TodoCalendarRepository) Touch(ctx context.Context, db *gorm.DB, id int, at time.Time) error {
+	return db.WithContext(ctx).Model(&models.TodoFeedTokens{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
+
+// feedItems 訂閱範圍內有到期時間的項目：TodoList 的訂閱為該 TodoList 的項目，否則為指派給使用者的項目
+func feedItems(db *gorm.DB, token *models.TodoFeedTokens) *gorm.DB {
+	query := db.Model(&models.TodoListDetails{}).
+		Joins("JOIN to_do_list AS l ON l.id = to_do_list_details.to_do_list_id AND l.deleted_at IS NULL").
+		Where("to_do_list_details.due_at IS NOT NULL")
+	if token.TodoListID != nil {
+		return query.Where("to_do_list_details.to_do_list_id = ?", *token.TodoListID)
+	}
+	return query.Joins("JOIN to_do_task_assignments AS a ON a.to_do_list_detail_id = to_do_list_details.id AND a.user_id = ?", token.UserID)
+}
+
+// FeedVersion 訂閱範圍內的項目數與最後修改時間，用來判斷內容是否有變動而不必取出所有項目
+func (r *TodoCalendarRepository) FeedVersion(ctx context.Context, db *gorm.DB, token *models.TodoFeedTokens) (int64, *time.Time, error) {
+	var version struct {
+		Count        int64
+		LastModified *time.Time
+	}
+	err := feedItems(db.WithContext(ctx), token).
+		Select("COUNT(*) AS count, MAX(to_do_list_details.updated_at) AS last_modified").
+		Scan(&version).Error
+	return version.Count, version.LastModified, err
+}
+
+// FindFeedItems 依到期時間取出訂閱範圍內的項目
+func (r *TodoCalendarRepository) FindFeedItems(ctx context.Context, db *gorm.DB, token *models.TodoFeedTokens) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	err := feedItems(db.WithContext(ctx), token).
+		Select("to_do_list_details.*").
+		Order("to_do_list_details.due_at asc, to_do_list_details.id asc").
+		Find(&items).Error
+	return items, err
+}
+
+// FindImportedUIDs 從 uids 中找出 TodoList 已匯入過的 UID
+func (r *TodoCalendarRepository) FindImportedUIDs(ctx context.Context, db *gorm.DB, listID int, uids []string) ([]string, error) {
+	imported := []string{}
+	if len(uids) == 0 {
+		return imported, nil
+	}
+	err := db.WithContext(ctx).Model(&models.TodoListDetails{}).
+		Where("to_do_list_id = ? AND ical_uid IN ?", listID, uids).
+		Pluck("ical_uid", &imported).Error
+	return imported, err
+}
+
+// SetCalendarFields 設定從 .ics 匯入項目的 UID（沒有時為 nil）與到期時間
+func (r *TodoCalendarRepository) SetCalendarFields(ctx context.Context, db *gorm.DB, detailID int, uid *string, dueAt *time.Time) error {
+	return db.WithContext(ctx).Model(&models.TodoListDetails{}).Where("id = ?", detailID).
+		Updates(map[string]interface{}{"ical_uid": uid, "due_at": dueAt}).Error
+}
+
+// FindLists 取出所有 TodoList，CalDAV 以每個 TodoList 為一個日曆集合
+func (r *TodoCalendarRepository) FindLists(ctx context.Context, db *gorm.DB) ([]*models.TodoList, error) {
+	var lists []*models.TodoList
+	err := db.WithContext(ctx).Select("id", "name", "created_at", "updated_at").Order("id asc").Find(&lists).Error
+	return lists, err
+}
+
+// FindList 取出一個 TodoList，不存在時回傳 nil
+func (r *TodoCalendarRepository) FindList(ctx context.Context, db *gorm.DB, id int) (*models.TodoList, error) {
+	var lists []*models.TodoList
+	err := db.WithContext(ctx).Select("id", "name", "created_at", "updated_at").Where("id = ?", id).Limit(1).Find(&lists).Error
+	if err != nil || len(lists) == 0 {
+		return nil, err
+	}
+	return lists[0], nil
+}
+
+// FindResources 取出 TodoList 底下的所有項目
+func (r *TodoCalendarRepository) FindResources(ctx context.Context, db *gorm.DB, listIDs []int) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	if len(listIDs) == 0 {
+		return items, nil
+	}
+	err := db.WithContext(ctx).Where("to_do_list_id IN ?", listIDs).Order("id asc").Find(&items).Error
+	return items, err
+}
+
+// FindResourceByUID 以匯入或 CalDAV 建立時的 UID 取出項目，不存在時回傳 nil
+func (r *TodoCalendarRepository) FindResourceByUID(ctx context.Context, db *gorm.DB, listID int, uid string) (*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	err := db.WithContext(ctx).Where("to_do_list_id = ? AND ical_uid = ?", listID, uid).Limit(1).Find(&items).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// FindResourceByID 取出沒有 UID 的項目，不存在時回傳 nil
+func (r *TodoCalendarRepository) FindResourceByID(ctx context.Context, db *gorm.DB, listID int, id int) (*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	err := db.WithContext(ctx).Where("to_do_list_id = ? AND id = ? AND ical_uid IS NULL", listID, id).Limit(1).Find(&items).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoChecklistRepository struct {
+	*base.BaseRepository[*models.TodoChecklistItems]
+}
+
+func NewTodoChecklistRepository() *TodoChecklistRepository {
+	return &TodoChecklistRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoChecklistItems](),
+	}
+}
+
+// FindByDetailID 依排序取出某個 TodoListDetails 底下的所有項目
+func (r *TodoChecklistRepository) FindByDetailID(ctx context.Context, db *gorm.DB, detailID int) ([]*models.TodoChecklistItems, error) {
+	var items []*models.TodoChecklistItems
+	err := db.WithContext(ctx).
+		Where("to_do_list_detail_id = ?", detailID).
+		Order("sort_order asc, id asc").
+		Find(&items).Error
+	return items, err
+}
+
+// NextSortOrder 取得新項目要排在最後面的排序值
+func (r *TodoChecklistRepository) NextSortOrder(ctx context.Context, db *gorm.DB, detailID int) (int, error) {
+	var max int
+	err := db.WithContext(ctx).
+		Model(&models.TodoChecklistItems{}).
+		Where("to_do_list_detail_id = ?", detailID).
+		Select("COALESCE(MAX(sort_order), 0)").
+		Scan(&max).Error
+	return max + 1, err
+}
+
+// UpdateSortOrder 更新單一項目的排序值
+func (r *TodoChecklistRepository) UpdateSortOrder(ctx context.Context, db *gorm.DB, id int, sortOrder int) error {
+	return db.WithContext(ctx).
+		Model(&models.TodoChecklistItems{}).
+		Where("id = ?", id).
+		Update("sort_order", sortOrder).Error
+}
+
+// LockDetailMarkdown 以 FOR UPDATE 鎖定 TodoListDetails 並取出說明原文，避免同時回寫互相覆蓋
+func (r *TodoChecklistRepository) LockDetailMarkdown(ctx context.Context, db *gorm.DB, detailID int) (string, error) {
+	var detail models.TodoListDetails
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "detail").
+		First(&detail, detailID).Error
+	return detail.Detail, err
+}
+
+// UpdateDetailMarkdown 回寫 TodoListDetails 的說明原文
+func (r *TodoChecklistRepository) UpdateDetailMarkdown(ctx context.Context, db *gorm.DB, detailID int, src string) error {
+	return db.WithContext(ctx).
+		Model(&models.TodoListDetails{}).
+		Where("id = ?", detailID).
+		Update("detail", src).Error
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoCommentRepository struct {
+	*base.BaseRepository[*models.TodoComments]
+}
+
+func NewTodoCommentRepository() *TodoCommentRepository {
+	return &TodoCommentRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoComments](),
+	}
+}
+
+// ReplaceMentions 以新的被提及使用者取代原本的關聯
+func (r *TodoCommentRepository) ReplaceMentions(ctx context.Context, db *gorm.DB, comment *models.TodoComments, users []models.User) error {
+	return db.WithContext(ctx).Model(comment).Association("Mentions").Replace(users)
+}
+
+// CreateRevision 保存修改前的內容
+func (r *TodoCommentRepository) CreateRevision(ctx context.Context, db *gorm.DB, revision *models.TodoCommentRevisions) error {
+	return db.WithContext(ctx).Create(revision).Error
+}
+
+// FindRevisions 依時間先後取出留言的修改紀錄
+func (r *TodoCommentRepository) FindRevisions(ctx context.Context, db *gorm.DB, commentID int) ([]*models.TodoCommentRevisions, error) {
+	var revisions []*models.TodoCommentRevisions
+	err := db.WithContext(ctx).Where("comment_id = ?", commentID).Order("id asc").Find(&revisions).Error
+	return revisions, err
+}
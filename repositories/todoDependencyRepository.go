@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoDependencyRepository struct {
+	*base.BaseRepository[*models.TodoDetailDependency]
+}
+
+func NewTodoDependencyRepository() *TodoDependencyRepository {
+	return &TodoDependencyRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoDetailDependency](),
+	}
+}
+
+// Exists 檢查相依關係是否已存在
+func (r *TodoDependencyRepository) Exists(ctx context.Context, db *gorm.DB, blockerID, blockedID int) (bool, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Model(&models.TodoDetailDependency{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Delete 移除相依關係（關聯表沒有軟刪除）
+func (r *TodoDependencyRepository) Delete(ctx context.Context, db *gorm.DB, blockerID, blockedID int) error {
+	return db.WithContext(ctx).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&models.TodoDetailDependency{}).Error
+}
+
+// FindEdgesFrom 取出 blockerIDs 擋住的所有相依關係，忽略已刪除的項目
+func (r *TodoDependencyRepository) FindEdgesFrom(ctx context.Context, db *gorm.DB, blockerIDs []int) ([]*models.TodoDetailDependency, error) {
+	var edges []*models.TodoDetailDependency
+	err := db.WithContext(ctx).
+		Table("to_do_detail_dependencies AS dep").
+		Select("dep.*").
+		Joins("JOIN to_do_list_details AS d ON d.id = dep.blocked_id AND d.deleted_at IS NULL").
+		Where("dep.blocker_id IN ?", blockerIDs).
+		Find(&edges).Error
+	return edges, err
+}
+
+// FindByListID 取出任一端屬於指定 TodoList 的相依關係，兩端都必須未刪除
+func (r *TodoDependencyRepository) FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoDetailDependency, error) {
+	var edges []*models.TodoDetailDependency
+	err := db.WithContext(ctx).
+		Table("to_do_detail_dependencies AS dep").
+		Select("dep.*").
+		Joins("JOIN to_do_list_details AS a ON a.id = dep.blocker_id AND a.deleted_at IS NULL").
+		Joins("JOIN to_do_list_details AS b ON b.id = dep.blocked_id AND b.deleted_at IS NULL").
+		Where("a.to_do_list_id = ? OR b.to_do_list_id = ?", listID, listID).
+		Order("dep.blocker_id asc, dep.blocked_id asc").
+		Find(&edges).Error
+	return edges, err
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoEmailRepository struct {
+	*base.BaseRepository[*models.TodoEmailMessages]
+}
+
+func NewTodoEmailRepository() *TodoEmailRepository {
+	return &TodoEmailRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoEmailMessages](),
+	}
+}
+
+// FindSettings 取出使用者的設定，沒有時回傳 gorm.ErrRecordNotFound
+func (r *TodoEmailRepository) FindSettings(ctx context.Context, db *gorm.DB, userID int) (*models.TodoEmailSettings, error) {
+	var settings models.TodoEmailSettings
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).Take(&settings).Error; err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// FindSettingsByUsers 取出有設定的使用者
+func (r *TodoEmailRepository) FindSettingsByUsers(ctx context.Context, db *gorm.DB, userIDs []int) ([]*models.TodoEmailSettings, error) {
+	var settings []*models.TodoEmailSettings
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	err := db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&settings).Error
+	return settings, err
+}
+
+// FindSettingsByToken 以取消訂閱的 token 取出設定
+func (r *TodoEmailRepository) FindSettingsByToken(ctx context.Context, db *gorm.DB, token string) (*models.TodoEmailSettings, error) {
+	var settings models.TodoEmailSettings
+	if err := db.WithContext(ctx).Where("unsubscribe_token = ?", token).Take(&settings).Error; err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveSettings 新增或更新設定
+func (r *TodoEmailRepository) SaveSettings(ctx context.Context, db *gorm.DB, settings *models.TodoEmailSettings) error {
+	return db.WithContext(ctx).Save(settings).Error
+}
+
+// LockDigestDue 鎖定下一個需要寄出摘要的設定：頻率為 digest、仍訂閱中且上次寄出早於 periodStart；
+// 以 SKIP LOCKED 略過其他 instance 正在處理的，沒有時回傳 nil
+func (r *TodoEmailRepository) LockDigestDue(ctx context.Context, db *gorm.DB, digest string, periodStart time.Time) (*models.TodoEmailSettings, error) {
+	var settings models.TodoEmailSettings
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("digest = ? AND email <> '' AND unsubscribed_at IS NULL", digest).
+		Where("last_digest_at IS NULL OR last_digest_at < ?", periodStart).
+		Order("id asc").
+		Take(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// FindTasks 取出任務與所屬 TodoList 的名稱，已刪除的略過
+func (r *TodoEmailRepository) FindTasks(ctx context.Context, db *gorm.DB, detailIDs []int) ([]*models.EmailTask, error) {
+	var tasks []*models.EmailTask
+	if len(detailIDs) == 0 {
+		return tasks, nil
+	}
+	err := r.tasks(ctx, db).Where("d.id IN ?", detailIDs).Scan(&tasks).Error
+	return tasks, err
+}
+
+// FindOpenTasks 指派給使用者且尚未完成的任務，有到期時間的依到期時間排在前面
+func (r *TodoEmailRepository) FindOpenTasks(ctx context.Context, db *gorm.DB, userID int) ([]*models.EmailTask, error) {
+	var tasks []*models.EmailTask
+	err := r.tasks(ctx, db).
+		Joins("JOIN to_do_task_assignments AS a ON a.to_do_list_detail_id = d.id").
+		Where("a.user_id = ? AND d.status <> ?", userID, models.DetailStatusDone).
+		Order("d.due_at IS NULL, d.due_at asc, d.id asc").
+		Scan(&tasks).Error
+	return tasks, err
+}
+
+func (r *TodoEmailRepository) tasks(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.WithContext(ctx).Table("to_do_list_details AS d").
+		Select("d.id, d.name, l.name AS list_name, d.status, d.due_at").
+		Joins("JOIN to_do_list AS l ON l.id = d.to_do_list_id").
+		Where("d.deleted_at IS NULL AND l.deleted_at IS NULL")
+}
+
+// CreateMessages 建立待寄出的信件，dedupe_key 已存在的略過
+func (r *TodoEmailRepository) CreateMessages(ctx context.Context, db *gorm.DB, messages []*models.TodoEmailMessages) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&messages).Error
+}
+
+// LockNextMessage 鎖定下一封到期的信件，寄送中但上次嘗試早於 staleBefore 的視為中斷而重新寄送；
+// 以 SKIP LOCKED 略過其他 instance 正在領取的信件，沒有時回傳 nil
+func (r *TodoEmailRepository) LockNextMessage(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoEmailMessages, error) {
+	var message models.TodoEmailMessages
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND last_attempt_at < ?)",
+			models.EmailStatusPending, now, models.EmailStatusSending, staleBefore).
+		Order("next_attempt_at asc, id asc").
+		Take(&message).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// UpdateMessage 寫入寄送的狀態與結果
+func (r *TodoEmailRepository) UpdateMessage(ctx context.Context, db *gorm.DB, message *models.TodoEmailMessages) error {
+	return db.WithContext(ctx).Model(message).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "error", "sent_at", "updated_at").
+		Updates(message).Error
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoExportJobRepository struct {
+	*base.BaseRepository[*models.TodoExportJobs]
+}
+
+func NewTodoExportJobRepository() *TodoExportJobRepository {
+	return &TodoExportJobRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoExportJobs](),
+	}
+}
+
+// LockNext 鎖定下一個待執行的工作，執行中但開始時間早於 staleBefore 的視為中斷而重新執行；
+// 以 SKIP LOCKED 略過其他 instance 正在領取的工作，沒有時回傳 nil
+func (r *TodoExportJobRepository) LockNext(ctx context.Context, db *gorm.DB, staleBefore time.Time) (*models.TodoExportJobs, error) {
+	var job models.TodoExportJobs
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? OR (status = ? AND started_at < ?)", models.ExportStatusPending, models.ExportStatusRunning, staleBefore).
+		Order("id asc").
+		Take(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importRowBatchSize 一次寫入的匯入列數
+const importRowBatchSize = 200
+
+type TodoImportRepository struct {
+	*base.BaseRepository[*models.TodoImports]
+}
+
+func NewTodoImportRepository() *TodoImportRepository {
+	return &TodoImportRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoImports](),
+	}
+}
+
+// LockByID 以 FOR UPDATE 鎖定並取出匯入，避免同一個匯入同時被確認兩次
+func (r *TodoImportRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoImports, error) {
+	var item models.TodoImports
+	err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateRows 分批寫入匯入列
+func (r *TodoImportRepository) CreateRows(ctx context.Context, db *gorm.DB, rows []*models.TodoImportRows) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).CreateInBatches(rows, importRowBatchSize).Error
+}
+
+// FindRows 依列號分頁取出匯入列，problems 為 true 時只取有錯誤或警告的列
+func (r *TodoImportRepository) FindRows(ctx context.Context, db *gorm.DB, importID int, problems bool, page, pageSize int) ([]*models.TodoImportRows, int64, error) {
+	var rows []*models.TodoImportRows
+	var count int64
+
+	query := db.WithContext(ctx).Model(&models.TodoImportRows{}).Where("import_id = ?", importID)
+	if problems {
+		query = query.Where("(valid = ? OR JSON_LENGTH(warnings) > 0)", false)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("row_no asc").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&rows).Error
+	return rows, count, err
+}
+
+// PendingListNames 還有未建立項目的 TodoList 名稱，依在檔案中第一次出現的順序
+func (r *TodoImportRepository) PendingListNames(ctx context.Context, db *gorm.DB, importID int) ([]string, error) {
+	var names []string
+	err := db.WithContext(ctx).Model(&models.TodoImportRows{}).
+		Where("import_id = ? AND valid = ? AND to_do_list_detail_id IS NULL", importID, true).
+		Group("list_name").
+		Order("MIN(row_no) asc").
+		Pluck("list_name", &names).Error
+	return names, err
+}
+
+// LockPendingRows 鎖定並取出某個 TodoList 還沒建立的匯入列
+func (r *TodoImportRepository) LockPendingRows(ctx context.Context, db *gorm.DB, importID int, listName string) ([]*models.TodoImportRows, error) {
+	var rows []*models.TodoImportRows
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("import_id = ? AND list_name = ? AND valid = ? AND to_do_list_detail_id IS NULL", importID, listName, true).
+		Order("row_no asc").
+		Find(&rows).Error
+	return rows, err
+}
+
+// MarkRowImported 記錄匯入列建立的 TodoListDetails
+func (r *TodoImportRepository) MarkRowImported(ctx context.Context, db *gorm.DB, rowID, detailID int) error {
+	return db.WithContext(ctx).Model(&models.TodoImportRows{}).Where("id = ?", rowID).Update("to_do_list_detail_id", detailID).Error
+}
+
+// FindTypeByName 依名稱取出 TodoTypes，沒有時回傳 nil
+func (r *TodoImportRepository) FindTypeByName(ctx context.Context, db *gorm.DB, name string) (*models.TodoTypes, error) {
+	var item models.TodoTypes
+	err := db.WithContext(ctx).Where("name = ?", name).Take(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindListByName 依名稱取出 TodoList，沒有時回傳 nil
+func (r *TodoImportRepository) FindListByName(ctx context.Context, db *gorm.DB, name string) (*models.TodoList, error) {
+	var item models.TodoList
+	err := db.WithContext(ctx).Where("name = ?", name).Take(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindExistingListNames 從 names 中找出已經存在的 TodoList 名稱
+func (r *TodoImportRepository) FindExistingListNames(ctx context.Context, db *gorm.DB, names []string) ([]string, error) {
+	existing := []string{}
+	if len(names) == 0 {
+		return existing, nil
+	}
+	err := db.WithContext(ctx).Model(&models.TodoList{}).Where("name IN ?", names).Pluck("name", &existing).Error
+	return existing, err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoLabelRepository struct {
+	*base.BaseRepository[*models.TodoLabels]
+}
+
+func NewTodoLabelRepository() *TodoLabelRepository {
+	return &TodoLabelRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoLabels](), // 回傳 *BaseRepository[T]
+	}
+}
+
+// 檢查名稱是否存在，排除指定 ID（可為 0 代表不排除）
+func (r *TodoLabelRepository) IsNameExist(ctx context.Context, db *gorm.DB, name string, excludeID int) (bool, error) {
+	var count int64
+	query := db.WithContext(ctx).Model(&models.TodoLabels{}).Where("name = ?", name)
+	if excludeID > 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DetachAll 移除標籤在 TodoList / TodoListDetails 上的所有關聯，不影響任務本身
+func (r *TodoLabelRepository) DetachAll(ctx context.Context, db *gorm.DB, id int) error {
+	tx := db.WithContext(ctx)
+	if err := tx.Exec("DELETE FROM to_do_list_labels WHERE label_id = ?", id).Error; err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM to_do_list_detail_labels WHERE label_id = ?", id).Error
+}
+
+// Usage 統計每個標籤被未刪除的 TodoList / TodoListDetails 使用的次數
+func (r *TodoLabelRepository) Usage(ctx context.Context, db *gorm.DB) ([]*models.TodoLabelUsage, error) {
+	var results []*models.TodoLabelUsage
+
+	listCount := db.Table("to_do_list_labels AS ll").
+		Select("COUNT(*)").
+		Joins("JOIN to_do_list AS l ON l.id = ll.to_do_list_id AND l.deleted_at IS NULL").
+		Where("ll.label_id = to_do_labels.id")
+
+	detailCount := db.Table("to_do_list_detail_labels AS dl").
+		Select("COUNT(*)").
+		Joins("JOIN to_do_list_details AS d ON d.id = dl.to_do_list_detail_id AND d.deleted_at IS NULL").
+		Where("dl.label_id = to_do_labels.id")
+
+	err := db.WithContext(ctx).
+		Model(&models.TodoLabels{}).
+		Select("to_do_labels.id AS label_id, to_do_labels.name, to_do_labels.color, (?) AS list_count, (?) AS detail_count", listCount, detailCount).
+		Order("to_do_labels.name asc").
+		Scan(&results).Error
+
+	return results, err
+}
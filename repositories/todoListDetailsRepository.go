@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"context"
 	"todolist/models"
 	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TodoListDetailsRepository struct {
@@ -14,3 +18,76 @@ func NewTodoListDetailsRepository() *TodoListDetailsRepository {
 		BaseRepository: base.NewBaseRepository[*models.TodoListDetails](),
 	}
 }
+
+// FindOpenBlockers 取出擋住指定項目且尚未完成的項目
+func (r *TodoListDetailsRepository) FindOpenBlockers(ctx context.Context, db *gorm.DB, id int) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	err := db.WithContext(ctx).
+		Joins("JOIN to_do_detail_dependencies AS dep ON dep.blocker_id = to_do_list_details.id").
+		Where("dep.blocked_id = ? AND to_do_list_details.status <> ?", id, models.DetailStatusDone).
+		Order("to_do_list_details.id asc").
+		Find(&items).Error
+	return items, err
+}
+
+// FindByIDs 依 ID 取出多筆項目
+func (r *TodoListDetailsRepository) FindByIDs(ctx context.Context, db *gorm.DB, ids []int) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	if len(ids) == 0 {
+		return items, nil
+	}
+	err := db.WithContext(ctx).Where("id IN ?", ids).Order("id asc").Find(&items).Error
+	return items, err
+}
+
+// FindByListID 取出指定 TodoList 底下的所有項目
+func (r *TodoListDetailsRepository) FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+	err := db.WithContext(ctx).Where("to_do_list_id = ?", listID).Order("id asc").Find(&items).Error
+	return items, err
+}
+
+// SoftDelete 軟刪除前先清空位置鍵，避免佔用唯一索引
+func (r *TodoListDetailsRepository) SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoListDetails) error {
+	if err := base.ClearPosition(db.WithContext(ctx).Model(&models.TodoListDetails{}), entity.ID); err != nil {
+		return err
+	}
+	return r.BaseRepository.SoftDelete(ctx, db, entity)
+}
+
+// LockByID 以 FOR UPDATE 鎖定並取出項目
+func (r *TodoListDetailsRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoListDetails, error) {
+	var item models.TodoListDetails
+	if err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *TodoListDetailsRepository) listScope(ctx context.Context, db *gorm.DB, listID int) *gorm.DB {
+	return db.WithContext(ctx).Model(&models.TodoListDetails{}).Where("to_do_list_id = ?", listID)
+}
+
+func (r *TodoListDetailsRepository) LastPosition(ctx context.Context, db *gorm.DB, listID int, excludeID int) (string, error) {
+	return base.LastPosition(r.listScope(ctx, db, listID), excludeID)
+}
+
+func (r *TodoListDetailsRepository) NextPosition(ctx context.Context, db *gorm.DB, listID int, after string, excludeID int) (string, error) {
+	return base.NextPosition(r.listScope(ctx, db, listID), after, excludeID)
+}
+
+func (r *TodoListDetailsRepository) PrevPosition(ctx context.Context, db *gorm.DB, listID int, before string, excludeID int) (string, error) {
+	return base.PrevPosition(r.listScope(ctx, db, listID), before, excludeID)
+}
+
+// RebalancePositions 重新平均分配指定 TodoList 底下項目的位置鍵
+func (r *TodoListDetailsRepository) RebalancePositions(ctx context.Context, db *gorm.DB, listID int) error {
+	return base.RebalancePositions(func() *gorm.DB {
+		return r.listScope(ctx, db, listID)
+	})
+}
+
+// CreateStatusChange 新增一筆狀態變更紀錄
+func (r *TodoListDetailsRepository) CreateStatusChange(ctx context.Context, db *gorm.DB, change *models.TodoStatusChanges) error {
+	return db.WithContext(ctx).Create(change).Error
+}
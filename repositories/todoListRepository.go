@@ -6,6 +6,7 @@ import (
 	"todolist/repositories/base"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TodoListRepository struct {
@@ -30,3 +31,50 @@ func (r *TodoListRepository) IsNameExist(ctx context.Context, db *gorm.DB, name
 	}
 	return count > 0, nil
 }
+
+// SoftDelete 軟刪除前先清空位置鍵，避免佔用唯一索引
+func (r *TodoListRepository) SoftDelete(ctx context.Context, db *gorm.DB, entity *models.TodoList) error {
+	if err := base.ClearPosition(db.WithContext(ctx).Model(&models.TodoList{}), entity.ID); err != nil {
+		return err
+	}
+	return r.BaseRepository.SoftDelete(ctx, db, entity)
+}
+
+// LockByID 以 FOR UPDATE 鎖定並取出 TodoList
+func (r *TodoListRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoList, error) {
+	var item models.TodoList
+	if err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *TodoListRepository) LastPosition(ctx context.Context, db *gorm.DB, excludeID int) (string, error) {
+	return base.LastPosition(db.WithContext(ctx).Model(&models.TodoList{}), excludeID)
+}
+
+func (r *TodoListRepository) NextPosition(ctx context.Context, db *gorm.DB, after string, excludeID int) (string, error) {
+	return base.NextPosition(db.WithContext(ctx).Model(&models.TodoList{}), after, excludeID)
+}
+
+func (r *TodoListRepository) PrevPosition(ctx context.Context, db *gorm.DB, before string, excludeID int) (string, error) {
+	return base.PrevPosition(db.WithContext(ctx).Model(&models.TodoList{}), before, excludeID)
+}
+
+// RebalancePositions 重新平均分配所有 TodoList 的位置鍵
+func (r *TodoListRepository) RebalancePositions(ctx context.Context, db *gorm.DB) error {
+	return base.RebalancePositions(func() *gorm.DB {
+		return db.WithContext(ctx).Model(&models.TodoList{})
+	})
+}
+
+// FindBatch 依 ID 順序取出 afterID 之後的下一批 TodoList，db 可帶篩選條件
+func (r *TodoListRepository) FindBatch(ctx context.Context, db *gorm.DB, afterID, limit int, opts *base.FindOptions) ([]*models.TodoList, error) {
+	var items []*models.TodoList
+	query := db.WithContext(ctx)
+	if opts != nil {
+		query = opts.Apply(query)
+	}
+	err := query.Where("to_do_list.id > ?", afterID).Order("to_do_list.id asc").Limit(limit).Find(&items).Error
+	return items, err
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoMilestoneRepository struct {
+	*base.BaseRepository[*models.TodoMilestones]
+}
+
+func NewTodoMilestoneRepository() *TodoMilestoneRepository {
+	return &TodoMilestoneRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoMilestones](),
+	}
+}
+
+// LockByID 以 FOR UPDATE 鎖定並取出里程碑
+func (r *TodoMilestoneRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoMilestones, error) {
+	var item models.TodoMilestones
+	err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// AssignList 設定 TodoList 的里程碑，nil 表示移除
+func (r *TodoMilestoneRepository) AssignList(ctx context.Context, db *gorm.DB, listID int, milestoneID *int) error {
+	return db.WithContext(ctx).Model(&models.TodoList{}).Where("id = ?", listID).Update("milestone_id", milestoneID).Error
+}
+
+// AssignDetail 設定 TodoListDetails 的里程碑，nil 表示改為沿用 TodoList 的里程碑
+func (r *TodoMilestoneRepository) AssignDetail(ctx context.Context, db *gorm.DB, detailID int, milestoneID *int) error {
+	return db.WithContext(ctx).Model(&models.TodoListDetails{}).Where("id = ?", detailID).Update("milestone_id", milestoneID).Error
+}
+
+// Detach 移除所有 TodoList / TodoListDetails 與里程碑的關聯
+func (r *TodoMilestoneRepository) Detach(ctx context.Context, db *gorm.DB, id int) error {
+	tx := db.WithContext(ctx)
+	if err := tx.Model(&models.TodoList{}).Where("milestone_id = ?", id).Update("milestone_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.TodoListDetails{}).Where("milestone_id = ?", id).Update("milestone_id", nil).Error
+}
+
+// members 目前屬於里程碑的項目：自己指定的，或沒有指定而沿用 TodoList 的
+func members(db *gorm.DB, milestoneID int) *gorm.DB {
+	return db.Model(&models.TodoListDetails{}).
+		Joins("JOIN to_do_list AS l ON l.id = to_do_list_details.to_do_list_id AND l.deleted_at IS NULL").
+		Where("(to_do_list_details.milestone_id = ? OR (to_do_list_details.milestone_id IS NULL AND l.milestone_id = ?))", milestoneID, milestoneID)
+}
+
+// FindMembers 取出里程碑的項目，包含關閉時已移到下一個里程碑的項目
+func (r *TodoMilestoneRepository) FindMembers(ctx context.Context, db *gorm.DB, milestoneID int) ([]*models.TodoListDetails, error) {
+	var items []*models.TodoListDetails
+
+	rolledOver := db.Model(&models.TodoMilestoneRollovers{}).
+		Select("to_do_list_detail_id").
+		Where("milestone_id = ?", milestoneID)
+
+	err := members(db.WithContext(ctx), milestoneID).
+		Or("to_do_list_details.id IN (?)", rolledOver).
+		Select("to_do_list_details.id", "to_do_list_details.status", "to_do_list_details.created_at",
+			"to_do_list_details.original_estimate_minutes", "to_do_list_details.remaining_estimate_minutes").
+		Order("to_do_list_details.id asc").
+		Find(&items).Error
+	return items, err
+}
+
+// LockUnfinished 鎖定並取出目前屬於里程碑且尚未完成的項目 ID
+func (r *TodoMilestoneRepository) LockUnfinished(ctx context.Context, db *gorm.DB, milestoneID int) ([]int, error) {
+	var ids []int
+	err := members(db.WithContext(ctx), milestoneID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("to_do_list_details.status <> ?", models.DetailStatusDone).
+		Order("to_do_list_details.id asc").
+		Pluck("to_do_list_details.id", &ids).Error
+	return ids, err
+}
+
+// Rollover 將項目移到下一個里程碑，並記錄它們原本屬於哪個里程碑
+func (r *TodoMilestoneRepository) Rollover(ctx context.Context, db *gorm.DB, fromID, toID int, detailIDs []int) error {
+	if len(detailIDs) == 0 {
+		return nil
+	}
+	tx := db.WithContext(ctx)
+
+	rows := make([]models.TodoMilestoneRollovers, len(detailIDs))
+	for i, id := range detailIDs {
+		rows[i] = models.TodoMilestoneRollovers{MilestoneID: fromID, TodoListDetailID: id, NextMilestoneID: toID}
+	}
+	// 移出後又移回來再關閉一次時，以最後一次為準
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"next_milestone_id", "created_at"}),
+	}).Create(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.TodoListDetails{}).Where("id IN ?", detailIDs).Update("milestone_id", toID).Error
+}
+
+// FindStatusChanges 取出項目在 before 之前的狀態變更，依項目與時間排序
+func (r *TodoMilestoneRepository) FindStatusChanges(ctx context.Context, db *gorm.DB, detailIDs []int, before time.Time) ([]*models.TodoStatusChanges, error) {
+	var changes []*models.TodoStatusChanges
+	if len(detailIDs) == 0 {
+		return changes, nil
+	}
+	err := db.WithContext(ctx).
+		Where("to_do_list_detail_id IN ? AND changed_at < ?", detailIDs, before).
+		Order("to_do_list_detail_id asc, changed_at asc, id asc").
+		Find(&changes).Error
+	return changes, err
+}
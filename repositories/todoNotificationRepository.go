@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoNotificationRepository struct {
+	*base.BaseRepository[*models.TodoNotifications]
+}
+
+func NewTodoNotificationRepository() *TodoNotificationRepository {
+	return &TodoNotificationRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoNotifications](),
+	}
+}
+
+// CreateNotifications 建立通知，同一個使用者已有相同 dedupe_key 的略過
+func (r *TodoNotificationRepository) CreateNotifications(ctx context.Context, db *gorm.DB, notifications []*models.TodoNotifications) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&notifications).Error
+}
+
+// FindByUser 分頁取出使用者的通知，新的在前；unreadOnly 時只取未讀
+func (r *TodoNotificationRepository) FindByUser(ctx context.Context, db *gorm.DB, userID int, unreadOnly bool, page, pageSize int) ([]*models.TodoNotifications, int64, error) {
+	var notifications []*models.TodoNotifications
+	var total int64
+	query := db.WithContext(ctx).Model(&models.TodoNotifications{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id desc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// CountUnread 使用者的未讀通知數
+func (r *TodoNotificationRepository) CountUnread(ctx context.Context, db *gorm.DB, userID int) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).Model(&models.TodoNotifications{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead 將使用者的未讀通知標為已讀，ids 為空時標記全部，回傳標記的筆數
+func (r *TodoNotificationRepository) MarkRead(ctx context.Context, db *gorm.DB, userID int, ids []int, at time.Time) (int64, error) {
+	query := db.WithContext(ctx).Model(&models.TodoNotifications{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// FindPreferences 使用者有設定過的通知類型
+func (r *TodoNotificationRepository) FindPreferences(ctx context.Context, db *gorm.DB, userID int) ([]*models.TodoNotificationPreferences, error) {
+	var prefs []*models.TodoNotificationPreferences
+	err := db.WithContext(ctx).Where("user_id = ?", userID).Order("id asc").Find(&prefs).Error
+	return prefs, err
+}
+
+// SavePreferences 新增或更新設定
+func (r *TodoNotificationRepository) SavePreferences(ctx context.Context, db *gorm.DB, prefs []*models.TodoNotificationPreferences) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&prefs).Error
+}
+
+// FindOptedOut userIDs 中關閉了該類通知的使用者
+func (r *TodoNotificationRepository) FindOptedOut(ctx context.Context, db *gorm.DB, notificationType string, userIDs []int) ([]int, error) {
+	var ids []int
+	if len(userIDs) == 0 {
+		return ids, nil
+	}
+	err := db.WithContext(ctx).Model(&models.TodoNotificationPreferences{}).
+		Where("type = ? AND enabled = ? AND user_id IN ?", notificationType, false, userIDs).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// FindDetail 取出項目的名稱與所屬 TodoList，已刪除的項目回傳 gorm.ErrRecordNotFound
+func (r *TodoNotificationRepository) FindDetail(ctx context.Context, db *gorm.DB, detailID int) (*models.TodoListDetails, error) {
+	var detail models.TodoListDetails
+	err := db.WithContext(ctx).
+		Select("id", "to_do_list_id", "name").
+		Take(&detail, detailID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoOutboxRepository struct {
+	*base.BaseRepository[*models.TodoOutboxEvents]
+}
+
+func NewTodoOutboxRepository() *TodoOutboxRepository {
+	return &TodoOutboxRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoOutboxEvents](),
+	}
+}
+
+// LockNext 鎖定下一個到期的事件，送出中但上次嘗試早於 staleBefore 的視為中斷而重新送出；
+// 以 SKIP LOCKED 略過其他 instance 正在領取的事件，沒有時回傳 nil
+func (r *TodoOutboxRepository) LockNext(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoOutboxEvents, error) {
+	var event models.TodoOutboxEvents
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND last_attempt_at < ?)",
+			models.OutboxStatusPending, now, models.OutboxStatusDispatching, staleBefore).
+		Order("next_attempt_at asc, id asc").
+		Take(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// UpdateStatus 寫入送出的狀態與結果
+func (r *TodoOutboxRepository) UpdateStatus(ctx context.Context, db *gorm.DB, event *models.TodoOutboxEvents) error {
+	return db.WithContext(ctx).Model(event).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "error", "dispatched_at", "updated_at").
+		Updates(event).Error
+}
+
+// DeleteDispatched 刪除早於 before 已送出的事件，回傳刪除的筆數
+func (r *TodoOutboxRepository) DeleteDispatched(ctx context.Context, db *gorm.DB, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).
+		Where("status = ? AND dispatched_at < ?", models.OutboxStatusDispatched, before).
+		Delete(&models.TodoOutboxEvents{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoRealtimeTicketRepository struct {
+	*base.BaseRepository[*models.TodoRealtimeTickets]
+}
+
+func NewTodoRealtimeTicketRepository() *TodoRealtimeTicketRepository {
+	return &TodoRealtimeTicketRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoRealtimeTickets](),
+	}
+}
+
+// Redeem 將未使用且未過期的票證標記為已使用並回傳使用者 ID；以條件式 UPDATE 標記，
+// 同一張票證同時兌換時只有一個會成功。票證不存在、已使用或已過期時回傳 gorm.ErrRecordNotFound
+func (r *TodoRealtimeTicketRepository) Redeem(ctx context.Context, db *gorm.DB, hash string, now time.Time) (int, error) {
+	result := db.WithContext(ctx).Model(&models.TodoRealtimeTickets{}).
+		Where("ticket_hash = ? AND used_at IS NULL AND expires_at > ?", hash, now).
+		UpdateColumn("used_at", now)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	var ticket models.TodoRealtimeTickets
+	if err := db.WithContext(ctx).Select("user_id").Where("ticket_hash = ?", hash).Take(&ticket).Error; err != nil {
+		return 0, err
+	}
+	return ticket.UserID, nil
+}
+
+// DeleteExpired 刪除在 before 之前過期的票證，已使用的票證過期後也一併刪除
+func (r *TodoRealtimeTicketRepository) DeleteExpired(ctx context.Context, db *gorm.DB, before time.Time) error {
+	return db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&models.TodoRealtimeTickets{}).Error
+}
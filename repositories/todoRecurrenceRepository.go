@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"todolist/models"
+	"todolist/pkg/rank"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoRecurrenceRepository struct {
+	*base.BaseRepository[*models.TodoRecurrences]
+}
+
+func NewTodoRecurrenceRepository() *TodoRecurrenceRepository {
+	return &TodoRecurrenceRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoRecurrences](),
+	}
+}
+
+// FindByListID 取出指定 TodoList 的所有週期規則
+func (r *TodoRecurrenceRepository) FindByListID(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoRecurrences, error) {
+	var items []*models.TodoRecurrences
+	err := db.WithContext(ctx).Where("to_do_list_id = ?", listID).Order("id asc").Find(&items).Error
+	return items, err
+}
+
+// FindDueIDs 取出 next_at 已到期的規則 ID
+func (r *TodoRecurrenceRepository) FindDueIDs(ctx context.Context, db *gorm.DB, now time.Time, limit int) ([]int, error) {
+	var ids []int
+	err := db.WithContext(ctx).
+		Model(&models.TodoRecurrences{}).
+		Where("next_at IS NOT NULL AND next_at <= ?", now).
+		Order("next_at asc").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// LockByID 以 FOR UPDATE 鎖定規則，避免多個 instance 同時產生同一次任務
+func (r *TodoRecurrenceRepository) LockByID(ctx context.Context, db *gorm.DB, id int) (*models.TodoRecurrences, error) {
+	var item models.TodoRecurrences
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateOccurrence 建立某次發生的 TodoListDetails，並排在 TodoList 最後面。
+// (recurrence_id, occurrence_at) 已存在（包含已刪除）時不會重複建立，回傳 false；
+// 呼叫端須先鎖定規則，唯一索引則是最後一道防線。
+func (r *TodoRecurrenceRepository) CreateOccurrence(ctx context.Context, db *gorm.DB, detail *models.TodoListDetails) (bool, error) {
+	var count int64
+	err := db.WithContext(ctx).
+		Unscoped().
+		Model(&models.TodoListDetails{}).
+		Where("recurrence_id = ? AND occurrence_at = ?", detail.RecurrenceID, detail.OccurrenceAt).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return false, err
+	}
+
+	last, err := base.LastPosition(db.WithContext(ctx).Model(&models.TodoListDetails{}).Where("to_do_list_id = ?", detail.TodoListID), 0)
+	if err != nil {
+		return false, err
+	}
+	position, err := rank.KeyBetween(last, "")
+	if err != nil {
+		return false, err
+	}
+	detail.Position = &position
+
+	if err := db.WithContext(ctx).Create(detail).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoReminderRepository struct {
+	*base.BaseRepository[*models.TodoReminderRuns]
+}
+
+func NewTodoReminderRepository() *TodoReminderRepository {
+	return &TodoReminderRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoReminderRuns](),
+	}
+}
+
+// AcquireLease 取得或延長名為 name 的租約到 now + ttl；租約由其他 instance 持有且尚未到期時回傳 false
+func (r *TodoReminderRepository) AcquireLease(ctx context.Context, db *gorm.DB, name, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	acquired := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lease models.TodoSchedulerLeases
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).Take(&lease).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 同時建立時只有一個 instance 會成功
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.TodoSchedulerLeases{Name: name, Owner: owner, ExpiresAt: now.Add(ttl)})
+			acquired = result.RowsAffected == 1
+			return result.Error
+		}
+		if err != nil {
+			return err
+		}
+		if lease.Owner != owner && lease.ExpiresAt.After(now) {
+			return nil
+		}
+
+		acquired = true
+		return tx.Model(&lease).Updates(map[string]interface{}{"owner": owner, "expires_at": now.Add(ttl)}).Error
+	})
+	return acquired, err
+}
+
+// FindDueTasks 到期時間在 [from, to) 之間、尚未完成的任務，附上負責人與清單建立者
+func (r *TodoReminderRepository) FindDueTasks(ctx context.Context, db *gorm.DB, from, to time.Time) ([]*models.ReminderTask, error) {
+	var tasks []*models.ReminderTask
+	err := db.WithContext(ctx).Table("to_do_list_details AS d").
+		Select("d.id AS to_do_list_detail_id, d.to_do_list_id, d.name, d.due_at, l.created_by AS owner_id").
+		Joins("JOIN to_do_list AS l ON l.id = d.to_do_list_id AND l.deleted_at IS NULL").
+		Where("d.deleted_at IS NULL AND d.status <> ? AND d.due_at >= ? AND d.due_at < ?", models.DetailStatusDone, from, to).
+		Order("d.due_at asc, d.id asc").
+		Scan(&tasks).Error
+	if err != nil || len(tasks) == 0 {
+		return tasks, err
+	}
+
+	ids := make([]int, 0, len(tasks))
+	byID := make(map[int]*models.ReminderTask, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.TodoListDetailID)
+		byID[task.TodoListDetailID] = task
+	}
+	var assignments []struct {
+		TodoListDetailID int `gorm:"column:to_do_list_detail_id"`
+		UserID           int `gorm:"column:user_id"`
+	}
+	err = db.WithContext(ctx).Table("to_do_task_assignments").
+		Select("to_do_list_detail_id, user_id").
+		Where("to_do_list_detail_id IN ?", ids).
+		Order("to_do_list_detail_id asc, user_id asc").
+		Scan(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		task := byID[a.TodoListDetailID]
+		task.AssigneeIDs = append(task.AssigneeIDs, a.UserID)
+	}
+	return tasks, nil
+}
+
+// FindUserIDsByRole 擁有指定角色的使用者
+func (r *TodoReminderRepository) FindUserIDsByRole(ctx context.Context, db *gorm.DB, roleName string) ([]int, error) {
+	var ids []int
+	err := db.WithContext(ctx).Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("LOWER(roles.name) = LOWER(?)", roleName).
+		Order("user_roles.user_id asc").
+		Pluck("user_roles.user_id", &ids).Error
+	return ids, err
+}
+
+// CreateLog 記錄送出的提醒，已經送過時回傳 false
+func (r *TodoReminderRepository) CreateLog(ctx context.Context, db *gorm.DB, log *models.TodoReminderLogs) (bool, error) {
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(log)
+	return result.RowsAffected == 1, result.Error
+}
+
+// CreateRun 建立執行紀錄
+func (r *TodoReminderRepository) CreateRun(ctx context.Context, db *gorm.DB, run *models.TodoReminderRuns) error {
+	return db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateRun 寫入執行結果
+func (r *TodoReminderRepository) UpdateRun(ctx context.Context, db *gorm.DB, run *models.TodoReminderRuns) error {
+	return db.WithContext(ctx).Model(run).Select("status", "sent", "error", "finished_at").Updates(run).Error
+}
+
+// FindRuns 最近的執行紀錄，新的在前
+func (r *TodoReminderRepository) FindRuns(ctx context.Context, db *gorm.DB, page, pageSize int) ([]*models.TodoReminderRuns, int64, error) {
+	var runs []*models.TodoReminderRuns
+	var total int64
+	query := db.WithContext(ctx).Model(&models.TodoReminderRuns{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id desc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&runs).Error
+	return runs, total, err
+}
+
+// FindRun 取出執行紀錄與該次送出的提醒
+func (r *TodoReminderRepository) FindRun(ctx context.Context, db *gorm.DB, id int) (*models.TodoReminderRuns, error) {
+	var run models.TodoReminderRuns
+	err := db.WithContext(ctx).
+		Preload("Logs", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Take(&run, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// DeleteRuns 刪除早於 before 開始的執行紀錄，回傳刪除的筆數；送出的提醒保留，避免重複提醒
+func (r *TodoReminderRepository) DeleteRuns(ctx context.Context, db *gorm.DB, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).Where("started_at < ?", before).Delete(&models.TodoReminderRuns{})
+	return result.RowsAffected, result.Error
+}
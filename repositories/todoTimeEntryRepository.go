@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoTimeEntryRepository struct {
+	*base.BaseRepository[*models.TodoTimeEntries]
+}
+
+func NewTodoTimeEntryRepository() *TodoTimeEntryRepository {
+	return &TodoTimeEntryRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoTimeEntries](),
+	}
+}
+
+// FindRunning 取得使用者計時中的紀錄，沒有時回傳 nil
+func (r *TodoTimeEntryRepository) FindRunning(ctx context.Context, db *gorm.DB, userID int) (*models.TodoTimeEntries, error) {
+	return r.findRunning(db.WithContext(ctx), userID)
+}
+
+// LockRunning 同 FindRunning，並鎖定該筆紀錄
+func (r *TodoTimeEntryRepository) LockRunning(ctx context.Context, db *gorm.DB, userID int) (*models.TodoTimeEntries, error) {
+	return r.findRunning(db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), userID)
+}
+
+func (r *TodoTimeEntryRepository) findRunning(query *gorm.DB, userID int) (*models.TodoTimeEntries, error) {
+	var item models.TodoTimeEntries
+	err := query.Where("user_id = ? AND ended_at IS NULL", userID).Take(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Summarize 依 groupBy 彙總已結束的工時；依 TodoListDetails 分組時先逐筆合計，預估工時才不會重複計算
+func (r *TodoTimeEntryRepository) Summarize(ctx context.Context, db *gorm.DB, groupBy string, filter models.TimeEntryFilter) ([]*models.TimeSummary, error) {
+	entries := db.WithContext(ctx).
+		Table("to_do_time_entries AS e").
+		Joins("JOIN to_do_list_details AS d ON d.id = e.to_do_list_detail_id").
+		Joins("JOIN to_do_list AS l ON l.id = d.to_do_list_id").
+		Where("e.deleted_at IS NULL AND e.ended_at IS NOT NULL")
+	if filter.UserID > 0 {
+		entries = entries.Where("e.user_id = ?", filter.UserID)
+	}
+	if filter.ListID > 0 {
+		entries = entries.Where("l.id = ?", filter.ListID)
+	}
+	if filter.TypeID > 0 {
+		entries = entries.Where("l.type_id = ?", filter.TypeID)
+	}
+	if filter.From != nil {
+		entries = entries.Where("e.started_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		entries = entries.Where("e.started_at < ?", *filter.To)
+	}
+
+	var query *gorm.DB
+	switch groupBy {
+	case models.TimeGroupUser:
+		query = entries.
+			Select("e.user_id AS `key`, u.account AS name, SUM(e.duration_seconds) AS seconds, COUNT(*) AS entries").
+			Joins("JOIN users AS u ON u.id = e.user_id").
+			Group("e.user_id, u.account").
+			Order("seconds desc")
+	case models.TimeGroupDay:
+		query = entries.
+			Select("DATE_FORMAT(e.started_at, '%Y-%m-%d') AS `key`, '' AS name, SUM(e.duration_seconds) AS seconds, COUNT(*) AS entries").
+			Group("DATE_FORMAT(e.started_at, '%Y-%m-%d')").
+			Order("`key` asc")
+	case models.TimeGroupDetail, models.TimeGroupList, models.TimeGroupType:
+		perDetail := entries.
+			Select("e.to_do_list_detail_id, SUM(e.duration_seconds) AS seconds, COUNT(*) AS entries").
+			Group("e.to_do_list_detail_id")
+
+		key, name, group := "d.id", "d.name", "d.id, d.name"
+		switch groupBy {
+		case models.TimeGroupList:
+			key, name, group = "l.id", "l.name", "l.id, l.name"
+		case models.TimeGroupType:
+			key, name, group = "ty.id", "ty.name", "ty.id, ty.name"
+		}
+
+		query = db.WithContext(ctx).
+			Table("(?) AS t", perDetail).
+			Select(fmt.Sprintf("%s AS `key`, %s AS name, SUM(t.seconds) AS seconds, SUM(t.entries) AS entries, "+
+				"SUM(d.original_estimate_minutes) AS original_estimate_minutes, SUM(d.remaining_estimate_minutes) AS remaining_estimate_minutes", key, name)).
+			Joins("JOIN to_do_list_details AS d ON d.id = t.to_do_list_detail_id").
+			Joins("JOIN to_do_list AS l ON l.id = d.to_do_list_id").
+			Joins("JOIN to_do_types AS ty ON ty.id = l.type_id").
+			Group(group).
+			Order("seconds desc")
+	default:
+		return nil, fmt.Errorf("不支援的分組方式：%s", groupBy)
+	}
+
+	var rows []*models.TimeSummary
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+)
+
+type TodoVersionRepository struct {
+	*base.BaseRepository[*models.TodoVersions]
+}
+
+func NewTodoVersionRepository() *TodoVersionRepository {
+	return &TodoVersionRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoVersions](),
+	}
+}
+
+// FindVersion 取得指定資料的某個版本
+func (r *TodoVersionRepository) FindVersion(ctx context.Context, db *gorm.DB, entityType string, entityID int, version int) (*models.TodoVersions, error) {
+	var item models.TodoVersions
+	err := db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ? AND version = ?", entityType, entityID, version).
+		Take(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"todolist/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoWatcherRepository struct{}
+
+func NewTodoWatcherRepository() *TodoWatcherRepository {
+	return &TodoWatcherRepository{}
+}
+
+// AddListWatchers 加入 TodoList 的關注者，已經關注的略過
+func (r *TodoWatcherRepository) AddListWatchers(ctx context.Context, db *gorm.DB, listID int, userIDs []int) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	rows := make([]models.TodoListWatchers, 0, len(userIDs))
+	for _, userID := range userIDs {
+		rows = append(rows, models.TodoListWatchers{TodoListID: listID, UserID: userID})
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// RemoveListWatcher 取消關注 TodoList，沒有關注時不處理
+func (r *TodoWatcherRepository) RemoveListWatcher(ctx context.Context, db *gorm.DB, listID int, userID int) error {
+	return db.WithContext(ctx).Where("to_do_list_id = ? AND user_id = ?", listID, userID).Delete(&models.TodoListWatchers{}).Error
+}
+
+// FindListWatchers TodoList 的關注者，依關注時間排序
+func (r *TodoWatcherRepository) FindListWatchers(ctx context.Context, db *gorm.DB, listID int) ([]models.User, error) {
+	users := []models.User{}
+	err := db.WithContext(ctx).Select("users.id", "users.account").
+		Joins("JOIN to_do_list_watchers AS w ON w.user_id = users.id").
+		Where("w.to_do_list_id = ?", listID).
+		Order("w.created_at asc, users.id asc").
+		Find(&users).Error
+	return users, err
+}
+
+// AddDetailWatchers 加入項目的關注者，已經關注的略過
+func (r *TodoWatcherRepository) AddDetailWatchers(ctx context.Context, db *gorm.DB, detailID int, userIDs []int) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	rows := make([]models.TodoListDetailWatchers, 0, len(userIDs))
+	for _, userID := range userIDs {
+		rows = append(rows, models.TodoListDetailWatchers{TodoListDetailID: detailID, UserID: userID})
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// RemoveDetailWatcher 取消關注項目，沒有關注時不處理
+func (r *TodoWatcherRepository) RemoveDetailWatcher(ctx context.Context, db *gorm.DB, detailID int, userID int) error {
+	return db.WithContext(ctx).Where("to_do_list_detail_id = ? AND user_id = ?", detailID, userID).Delete(&models.TodoListDetailWatchers{}).Error
+}
+
+// FindDetailWatchers 項目的關注者，依關注時間排序；不含只關注所屬 TodoList 的使用者
+func (r *TodoWatcherRepository) FindDetailWatchers(ctx context.Context, db *gorm.DB, detailID int) ([]models.User, error) {
+	users := []models.User{}
+	err := db.WithContext(ctx).Select("users.id", "users.account").
+		Joins("JOIN to_do_list_detail_watchers AS w ON w.user_id = users.id").
+		Where("w.to_do_list_detail_id = ?", detailID).
+		Order("w.created_at asc, users.id asc").
+		Find(&users).Error
+	return users, err
+}
+
+// FindWatcherIDs 關注項目或其所屬 TodoList 的使用者，依 ID 排序；detailID 為 0 時只有 TodoList 的關注者
+func (r *TodoWatcherRepository) FindWatcherIDs(ctx context.Context, db *gorm.DB, listID int, detailID int) ([]int, error) {
+	var ids []int
+	if listID > 0 {
+		if err := db.WithContext(ctx).Model(&models.TodoListWatchers{}).
+			Where("to_do_list_id = ?", listID).
+			Pluck("user_id", &ids).Error; err != nil {
+			return nil, err
+		}
+	}
+	if detailID > 0 {
+		var detailIDs []int
+		if err := db.WithContext(ctx).Model(&models.TodoListDetailWatchers{}).
+			Where("to_do_list_detail_id = ?", detailID).
+			Pluck("user_id", &detailIDs).Error; err != nil {
+			return nil, err
+		}
+		ids = append(ids, detailIDs...)
+	}
+
+	seen := make(map[int]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Ints(unique)
+	return unique, nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/base"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TodoWebhookRepository struct {
+	*base.BaseRepository[*models.TodoWebhooks]
+}
+
+func NewTodoWebhookRepository() *TodoWebhookRepository {
+	return &TodoWebhookRepository{
+		BaseRepository: base.NewBaseRepository[*models.TodoWebhooks](),
+	}
+}
+
+// FindWebhooks 取出 webhook，listID 為 0 時取出全部
+func (r *TodoWebhookRepository) FindWebhooks(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoWebhooks, error) {
+	var hooks []*models.TodoWebhooks
+	query := db.WithContext(ctx).Order("id asc")
+	if listID > 0 {
+		query = query.Where("to_do_list_id = ?", listID)
+	}
+	err := query.Find(&hooks).Error
+	return hooks, err
+}
+
+// FindActive 取出會收到 TodoList 事件的啟用中 webhook：訂閱該 TodoList 的與不限 TodoList 的；listID 為 0 時只有後者
+func (r *TodoWebhookRepository) FindActive(ctx context.Context, db *gorm.DB, listID int) ([]*models.TodoWebhooks, error) {
+	var hooks []*models.TodoWebhooks
+	query := db.WithContext(ctx).Where("active = ?", true)
+	if listID > 0 {
+		query = query.Where("to_do_list_id IS NULL OR to_do_list_id = ?", listID)
+	} else {
+		query = query.Where("to_do_list_id IS NULL")
+	}
+	err := query.Order("id asc").Find(&hooks).Error
+	return hooks, err
+}
+
+// CreateDeliveries 建立待送出的紀錄
+func (r *TodoWebhookRepository) CreateDeliveries(ctx context.Context, db *gorm.DB, deliveries []*models.TodoWebhookDeliveries) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&deliveries).Error
+}
+
+// FindEventWebhookIDs 已經為事件建立過送出紀錄的 webhook，不含手動重送
+func (r *TodoWebhookRepository) FindEventWebhookIDs(ctx context.Context, db *gorm.DB, eventID string) ([]int, error) {
+	var ids []int
+	err := db.WithContext(ctx).Model(&models.TodoWebhookDeliveries{}).
+		Where("event_id = ? AND redelivery_of IS NULL", eventID).
+		Pluck("webhook_id", &ids).Error
+	return ids, err
+}
+
+// FindDelivery 取出一筆送出紀錄
+func (r *TodoWebhookRepository) FindDelivery(ctx context.Context, db *gorm.DB, id int) (*models.TodoWebhookDeliveries, error) {
+	var delivery models.TodoWebhookDeliveries
+	if err := db.WithContext(ctx).Take(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveries 分頁取出 webhook 的送出紀錄，新的在前
+func (r *TodoWebhookRepository) FindDeliveries(ctx context.Context, db *gorm.DB, webhookID int, page, pageSize int) ([]*models.TodoWebhookDeliveries, int64, error) {
+	var deliveries []*models.TodoWebhookDeliveries
+	var total int64
+	query := db.WithContext(ctx).Model(&models.TodoWebhookDeliveries{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id desc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// LockNextDelivery 鎖定下一筆到期的送出紀錄，送出中但上次嘗試早於 staleBefore 的視為中斷而重新送出；
+// 以 SKIP LOCKED 略過其他 instance 正在領取的紀錄，沒有時回傳 nil
+func (r *TodoWebhookRepository) LockNextDelivery(ctx context.Context, db *gorm.DB, now time.Time, staleBefore time.Time) (*models.TodoWebhookDeliveries, error) {
+	var delivery models.TodoWebhookDeliveries
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND last_attempt_at < ?)",
+			models.DeliveryStatusPending, now, models.DeliveryStatusDelivering, staleBefore).
+		Order("next_attempt_at asc, id asc").
+		Take(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// UpdateDelivery 寫入送出的狀態與結果
+func (r *TodoWebhookRepository) UpdateDelivery(ctx context.Context, db *gorm.DB, delivery *models.TodoWebhookDeliveries) error {
+	return db.WithContext(ctx).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "response_body", "error", "delivered_at", "updated_at").
+		Updates(delivery).Error
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"encoding/csv"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// utf8BOM 讓 Excel 以 UTF-8 開啟含中文的 CSV
+const utf8BOM = "\ufeff"
+
+// CSV 以附件方式回傳 CSV，第一列為 header
+func CSV(c *gin.Context, filename string, header []string, records [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Status(http.StatusOK)
+
+	_, _ = c.Writer.WriteString(utf8BOM)
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(header)
+	_ = w.WriteAll(records)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"todolist/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentRoutes 不需登入的附件下載，以簽章與期限驗證
+func AttachmentRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoAttachmentController{}
+
+	r.GET("/attachments/:id/download", controller.SignedDownload)
+}
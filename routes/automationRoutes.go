@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AutomationRoutes 只有建立者或 Admin 可以修改、試跑與查看執行紀錄，不限 TodoList 的規則只有 Admin 可以建立
+func AutomationRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoAutomationController{}
+
+	automations := r.Group("/automations", middleware.JwtAuthMiddleware())
+	{
+		automations.POST("", controller.Create)
+		automations.GET("", controller.Index)
+		automations.GET("/:id", controller.Show)
+		automations.PUT("/:id", controller.Edit)
+		automations.DELETE("/:id", controller.Delete)
+		automations.POST("/:id/test", controller.Test)
+		automations.GET("/:id/executions", controller.Executions)
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalDAVRoutes CalDAV 同步，以帳號與 CalDAV 憑證做 HTTP Basic 驗證；日曆程式以 /.well-known/caldav 尋找位置，
+// 因此不放在 /api 底下
+func CalDAVRoutes(r *gin.Engine) {
+	controller := controllers.TodoCalDAVController{}
+
+	r.GET("/.well-known/caldav", controller.WellKnown)
+	r.OPTIONS("/caldav/*path", controller.Options)
+
+	caldav := r.Group("/caldav", middleware.CalDAVAuthMiddleware("todolist"))
+	{
+		caldav.Handle("PROPFIND", "/*path", controller.Propfind)
+		caldav.Handle("REPORT", "/*path", controller.Report)
+		caldav.GET("/*path", controller.Get)
+		caldav.HEAD("/*path", controller.Get)
+		caldav.PUT("/*path", controller.Put)
+		caldav.DELETE("/*path", controller.Delete)
+	}
+}
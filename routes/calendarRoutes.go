@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"todolist/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarRoutes 不需登入的 iCalendar 訂閱，以網址中的訂閱憑證驗證
+func CalendarRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoCalendarController{}
+
+	r.GET("/feeds/:token", controller.Feed)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailRoutes 設定只能存取自己的；取消訂閱以信件中的 token 驗證，不需要登入
+func EmailRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoEmailController{}
+
+	email := r.Group("/email")
+	{
+		email.GET("/unsubscribe", controller.UnsubscribePage)
+		email.POST("/unsubscribe", controller.Unsubscribe)
+	}
+
+	settings := email.Group("/settings", middleware.JwtAuthMiddleware())
+	{
+		settings.GET("", controller.Settings)
+		settings.PUT("", controller.SaveSettings)
+	}
+}
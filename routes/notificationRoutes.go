@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationRoutes 只能存取自己的通知
+func NotificationRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoNotificationController{}
+
+	notifications := r.Group("/notifications", middleware.JwtAuthMiddleware())
+	{
+		notifications.GET("", controller.Index)
+		notifications.GET("/unread-count", controller.UnreadCount)
+		notifications.PUT("/read", controller.MarkRead)
+		notifications.PUT("/read-all", controller.MarkAllRead)
+		notifications.GET("/preferences", controller.Preferences)
+		notifications.PUT("/preferences", controller.SetPreferences)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RealtimeRoutes 即時更新；瀏覽器無法自訂 header，先以 JWT 取得一次性票證，再放在 ticket 查詢參數連線
+func RealtimeRoutes(r *gin.RouterGroup) {
+	controller := controllers.RealtimeController{}
+
+	r.POST("/realtime/ticket", middleware.JwtAuthMiddleware(), controller.Ticket)
+
+	realtime := r.Group("/realtime", middleware.RealtimeAuthMiddleware())
+	{
+		realtime.GET("/events", controller.Stream)
+		realtime.GET("/ws", controller.WebSocket)
+	}
+}
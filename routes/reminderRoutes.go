@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReminderRoutes 提醒排程的狀態，只開放給 Admin
+func ReminderRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoReminderController{}
+
+	admin := r.Group("/reminders", middleware.JwtAuthMiddleware(), middleware.RequireRoles("Admin"))
+	{
+		admin.GET("/upcoming", controller.Upcoming)
+		admin.GET("/runs", controller.Runs)
+		admin.GET("/runs/:id", controller.Run)
+	}
+}
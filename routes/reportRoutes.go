@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReportRoutes(r *gin.RouterGroup) {
+	controller := controllers.ReportController{}
+
+	reports := r.Group("/reports", middleware.JwtAuthMiddleware())
+	{
+		reports.GET("/throughput", controller.Throughput)
+		reports.GET("/cycle-time", controller.CycleTime)
+		reports.GET("/workload", controller.Workload)
+		reports.GET("/types", controller.Types)
+	}
+}
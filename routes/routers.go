@@ -13,5 +13,15 @@ func RegisterRoutes(r *gin.Engine) {
 	AuthRoutes(api)
 	TodoRoutes(api)
 	MemberRoutes(api)
+	AttachmentRoutes(api)
+	CalendarRoutes(api)
+	ReportRoutes(api)
+	WebhookRoutes(api)
+	RealtimeRoutes(api)
+	NotificationRoutes(api)
+	EmailRoutes(api)
+	ReminderRoutes(api)
+	AutomationRoutes(api)
+	CalDAVRoutes(r)
 	// 其他模組路由也可以在這邊加
 }
@@ -11,6 +11,20 @@ func TodoRoutes(r *gin.RouterGroup) {
 	todoTypeController := controllers.TodoTypeController{}
 	todoListController := controllers.TodoListController{}
 	todoListDetailsController := controllers.TodoListDetailsController{}
+	todoLabelController := controllers.TodoLabelController{}
+	todoChecklistController := controllers.TodoChecklistController{}
+	todoDependencyController := controllers.TodoDependencyController{}
+	todoRecurrenceController := controllers.TodoRecurrenceController{}
+	todoBoardController := controllers.TodoBoardController{}
+	todoCommentController := controllers.TodoCommentController{}
+	todoAttachmentController := controllers.TodoAttachmentController{}
+	todoVersionController := controllers.TodoVersionController{}
+	todoTimeEntryController := controllers.TodoTimeEntryController{}
+	todoMilestoneController := controllers.TodoMilestoneController{}
+	todoExportController := controllers.TodoExportController{}
+	todoImportController := controllers.TodoImportController{}
+	todoCalendarController := controllers.TodoCalendarController{}
+	todoWatcherController := controllers.TodoWatcherController{}
 
 	todo := r.Group("/todo", middleware.JwtAuthMiddleware())
 	{
@@ -20,14 +34,112 @@ func TodoRoutes(r *gin.RouterGroup) {
 		todo.PUT("/type/:id", todoTypeController.Edit)
 		todo.DELETE("/type/:id", todoTypeController.Delete)
 
+		todo.POST("/label", todoLabelController.Create)
+		todo.GET("/label", todoLabelController.Index)
+		todo.GET("/label/usage", todoLabelController.Usage)
+		todo.GET("/label/:id", todoLabelController.Show)
+		todo.PUT("/label/:id", todoLabelController.Edit)
+		todo.DELETE("/label/:id", todoLabelController.Delete)
+
+		todo.POST("/milestone", todoMilestoneController.Create)
+		todo.GET("/milestone", todoMilestoneController.Index)
+		todo.GET("/milestone/:id", todoMilestoneController.Show)
+		todo.PUT("/milestone/:id", todoMilestoneController.Edit)
+		todo.DELETE("/milestone/:id", todoMilestoneController.Delete)
+		todo.GET("/milestone/:id/chart", todoMilestoneController.Chart)
+		todo.POST("/milestone/:id/close", todoMilestoneController.Close)
+
 		todo.POST("/list", todoListController.Create)
 		todo.GET("/list", todoListController.Index)
+		todo.POST("/list/rebalance", todoListController.Rebalance)
+		todo.GET("/list/export", todoExportController.Export)
 		todo.GET("/list/:id", todoListController.Show)
 		todo.PUT("/list/:id", todoListController.Edit)
 		todo.DELETE("/list/:id", todoListController.Delete)
+		todo.PUT("/list/:id/move", todoListController.Move)
+		todo.PUT("/list/:id/milestone", todoMilestoneController.AssignList)
+		todo.GET("/list/:id/export", todoExportController.ExportList)
+		todo.POST("/list/:id/ical", todoCalendarController.Import)
+		todo.POST("/list/:id/details/rebalance", todoListDetailsController.Rebalance)
+		todo.POST("/list/:id/labels", todoListController.AttachLabels)
+		todo.DELETE("/list/:id/labels/:label_id", todoListController.DetachLabel)
+		todo.GET("/list/:id/graph", todoDependencyController.Graph)
+		todo.GET("/list/:id/board", todoBoardController.Show)
+		todo.PUT("/list/:id/board", todoBoardController.Configure)
+		todo.GET("/list/:id/versions", todoVersionController.ListVersions)
+		todo.GET("/list/:id/versions/diff", todoVersionController.ListDiff)
+		todo.POST("/list/:id/versions/:version/revert", todoVersionController.ListRevert)
+		todo.POST("/list/:id/watch", todoWatcherController.WatchList)
+		todo.DELETE("/list/:id/watch", todoWatcherController.UnwatchList)
+		todo.GET("/list/:id/watchers", todoWatcherController.ListWatchers)
+
+		todo.POST("/export-jobs", todoExportController.CreateJob)
+		todo.GET("/export-jobs/:id", todoExportController.ShowJob)
+		todo.GET("/export-jobs/:id/download", todoExportController.DownloadJob)
+
+		todo.POST("/imports", todoImportController.Create)
+		todo.GET("/imports/:id", todoImportController.Show)
+		todo.GET("/imports/:id/rows", todoImportController.Rows)
+		todo.POST("/imports/:id/commit", todoImportController.Commit)
+
+		todo.POST("/feed-tokens", todoCalendarController.CreateToken)
+		todo.GET("/feed-tokens", todoCalendarController.IndexTokens)
+		todo.DELETE("/feed-tokens/:id", todoCalendarController.RevokeToken)
+
+		todo.POST("/list/:id/recurrences", todoRecurrenceController.Create)
+		todo.GET("/list/:id/recurrences", todoRecurrenceController.Index)
+		todo.GET("/list/recurrences/:recurrence_id", todoRecurrenceController.Show)
+		todo.PUT("/list/recurrences/:recurrence_id", todoRecurrenceController.Edit)
+		todo.DELETE("/list/recurrences/:recurrence_id", todoRecurrenceController.Delete)
 
 		todo.POST("/list/details", todoListDetailsController.Create)
+		todo.GET("/list/details/:id", todoListDetailsController.Show)
 		todo.PUT("/list/details/:id", todoListDetailsController.Edit)
 		todo.DELETE("list/details/:id", todoListDetailsController.Delete)
+		todo.PUT("/list/details/:id/move", todoListDetailsController.Move)
+		todo.POST("/list/details/:id/labels", todoListDetailsController.AttachLabels)
+		todo.DELETE("/list/details/:id/labels/:label_id", todoListDetailsController.DetachLabel)
+		todo.PUT("/list/details/:id/status", todoListDetailsController.ChangeStatus)
+		todo.PUT("/list/details/:id/board/move", todoListDetailsController.MoveCard)
+		todo.PUT("/list/details/:id/estimate", todoListDetailsController.SetEstimate)
+		todo.PUT("/list/details/:id/due", todoListDetailsController.SetDue)
+		todo.PUT("/list/details/:id/assignees", todoListDetailsController.SetAssignees)
+		todo.PUT("/list/details/:id/milestone", todoMilestoneController.AssignDetail)
+		todo.POST("/list/details/:id/blockers", todoDependencyController.AddBlocker)
+		todo.DELETE("/list/details/:id/blockers/:blocker_id", todoDependencyController.RemoveBlocker)
+		todo.GET("/list/details/:id/versions", todoVersionController.DetailVersions)
+		todo.GET("/list/details/:id/versions/diff", todoVersionController.DetailDiff)
+		todo.POST("/list/details/:id/versions/:version/revert", todoVersionController.DetailRevert)
+		todo.POST("/list/details/:id/watch", todoWatcherController.WatchDetail)
+		todo.DELETE("/list/details/:id/watch", todoWatcherController.UnwatchDetail)
+		todo.GET("/list/details/:id/watchers", todoWatcherController.DetailWatchers)
+
+		todo.POST("/list/details/:id/items", todoChecklistController.Create)
+		todo.PUT("/list/details/:id/items/order", todoChecklistController.Reorder)
+		todo.PUT("/list/details/items/:item_id", todoChecklistController.Edit)
+		todo.DELETE("/list/details/items/:item_id", todoChecklistController.Delete)
+
+		todo.POST("/list/details/:id/comments", todoCommentController.Create)
+		todo.GET("/list/details/:id/comments", todoCommentController.Index)
+		todo.PUT("/list/details/comments/:comment_id", todoCommentController.Edit)
+		todo.DELETE("/list/details/comments/:comment_id", todoCommentController.Delete)
+		todo.GET("/list/details/comments/:comment_id/revisions", todoCommentController.Revisions)
+
+		todo.POST("/list/details/:id/attachments", todoAttachmentController.UploadToDetail)
+		todo.GET("/list/details/:id/attachments", todoAttachmentController.IndexByDetail)
+		todo.POST("/list/details/comments/:comment_id/attachments", todoAttachmentController.UploadToComment)
+		todo.GET("/list/details/comments/:comment_id/attachments", todoAttachmentController.IndexByComment)
+		todo.GET("/list/details/attachments/:attachment_id/download", todoAttachmentController.Download)
+		todo.GET("/list/details/attachments/:attachment_id/url", todoAttachmentController.SignedURL)
+		todo.DELETE("/list/details/attachments/:attachment_id", todoAttachmentController.Delete)
+
+		todo.POST("/list/details/:id/timer/start", todoTimeEntryController.Start)
+		todo.POST("/timer/stop", todoTimeEntryController.Stop)
+		todo.GET("/timer", todoTimeEntryController.Current)
+		todo.POST("/list/details/:id/time-entries", todoTimeEntryController.Create)
+		todo.GET("/list/details/:id/time-entries", todoTimeEntryController.Index)
+		todo.PUT("/list/details/time-entries/:entry_id", todoTimeEntryController.Edit)
+		todo.DELETE("/list/details/time-entries/:entry_id", todoTimeEntryController.Delete)
+		todo.GET("/time-entries/summary", todoTimeEntryController.Summary)
 	}
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"todolist/controllers"
+	"todolist/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRoutes webhook 含有簽章密鑰與事件內容，只開放給 Admin
+func WebhookRoutes(r *gin.RouterGroup) {
+	controller := controllers.TodoWebhookController{}
+
+	admin := r.Group("", middleware.JwtAuthMiddleware(), middleware.RequireRoles("Admin"))
+	{
+		admin.POST("/webhooks", controller.Create)
+		admin.GET("/webhooks", controller.Index)
+		admin.PUT("/webhooks/:id", controller.Edit)
+		admin.DELETE("/webhooks/:id", controller.Delete)
+		admin.GET("/webhooks/:id/deliveries", controller.Deliveries)
+		admin.POST("/webhook-deliveries/:id/redeliver", controller.Redeliver)
+	}
+}
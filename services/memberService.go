@@ -12,8 +12,9 @@ import (
 )
 
 type MemberService struct {
-	ctx  context.Context
-	repo interfaces.AuthRepository
+	ctx    context.Context
+	repo   interfaces.AuthRepository
+	events *TodoOutboxService
 }
 
 func NewMemberService(ctx context.Context, repo interfaces.AuthRepository) *MemberService {
@@ -23,6 +24,12 @@ func NewMemberService(ctx context.Context, repo interfaces.AuthRepository) *Memb
 	}
 }
 
+// WithEvents 設定後，角色變更時會在同一個交易內寫入領域事件
+func (s *MemberService) WithEvents(events *TodoOutboxService) *MemberService {
+	s.events = events
+	return s
+}
+
 func (s *MemberService) Index(db *gorm.DB, keyword string, page int, pageSize int, orderBy []string) (*utils.PaginatedResult[*models.User], error) {
 	query := db.Model(&models.User{})
 	if keyword != "" {
@@ -63,12 +70,25 @@ func (s *MemberService) Edit(db *gorm.DB, userID int, roleID int) (*models.User,
 			return fmt.Errorf("找不到 user: %w", err)
 		}
 
+		fromRoles := make([]string, len(user.Roles))
+		for i, r := range user.Roles {
+			fromRoles[i] = r.Name
+		}
+
 		// 替換使用者的角色，等於只保留這個角色
 		if err := tx.Model(&user).Association("Roles").Replace(&role); err != nil {
 			return fmt.Errorf("更新角色失敗: %w", err)
 		}
 
-		return nil
+		if s.events == nil || (len(fromRoles) == 1 && fromRoles[0] == role.Name) {
+			return nil
+		}
+		return s.events.Publish(tx, models.EventMemberRoleChanged, 0, &models.MemberRoleChange{
+			UserID:    user.ID,
+			Account:   user.Account,
+			FromRoles: fromRoles,
+			ToRole:    role.Name,
+		})
 	})
 
 	if err != nil {
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+// adminRole 可以修改、刪除任何人資料的角色
+const adminRole = "Admin"
+
+var ErrNotLoggedIn = errors.New("未登入")
+
+// authorizeOwner 只有建立者本人或 Admin 可以通過，否則回傳 forbidden
+func authorizeOwner(ctx context.Context, tx *gorm.DB, users interfaces.AuthRepository, createdBy *uint, forbidden error) error {
+	userID, ok := utils.CurrentUserID(ctx)
+	if !ok {
+		return ErrNotLoggedIn
+	}
+	if createdBy != nil && int(*createdBy) == userID {
+		return nil
+	}
+
+	isAdmin, err := users.HasRole(ctx, tx, userID, adminRole)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return forbidden
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"todolist/pkg/rank"
+
+	"gorm.io/gorm"
+)
+
+// positionRetries 多人同時移動到同一個空隙時，唯一索引衝突後重新執行的次數
+const positionRetries = 3
+
+// retryOnDuplicatePosition 位置鍵違反唯一索引時重新執行整個交易，重新讀取鄰居後再計算
+func retryOnDuplicatePosition(fn func() error) error {
+	var err error
+	for i := 0; i < positionRetries; i++ {
+		err = fn()
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+	return errors.New("位置衝突，請稍後再試")
+}
+
+// positionScope 同一個排序範圍內（已排除移動中的項目）的位置鍵查詢
+type positionScope struct {
+	next func(after string) (string, error)
+	prev func(before string) (string, error)
+	last func() (string, error)
+}
+
+// resolvePosition 依鄰居位置算出新的位置鍵：after 為要排在其後的項目，before 為要排在其前的項目，
+// 只給一邊時另一邊取相鄰的項目，兩者皆為 nil 時排到最後
+func resolvePosition(scope positionScope, after, before *string) (string, error) {
+	var low, high string
+	var err error
+
+	switch {
+	case after != nil && before != nil:
+		low, high = *after, *before
+	case after != nil:
+		low = *after
+		high, err = scope.next(low)
+	case before != nil:
+		high = *before
+		low, err = scope.prev(high)
+	default:
+		low, err = scope.last()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	key, err := rank.KeyBetween(low, high)
+	if errors.Is(err, rank.ErrInvalidRange) {
+		return "", errors.New("after_id 必須排在 before_id 之前")
+	}
+	return key, err
+}
+
+// notFoundAs 將找不到資料的錯誤換成指定訊息，其餘錯誤原樣回傳
+func notFoundAs(err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New(message)
+	}
+	return err
+}
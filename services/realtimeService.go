@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/pkg/realtime"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+type RealtimeService struct {
+	ctx   context.Context
+	users interfaces.AuthRepository
+	hub   *realtime.Hub
+}
+
+func NewRealtimeService(ctx context.Context, users interfaces.AuthRepository, hub *realtime.Hub) *RealtimeService {
+	return &RealtimeService{
+		ctx:   ctx,
+		users: users,
+		hub:   hub,
+	}
+}
+
+// Subscribe 訂閱即時事件；listIDs 為空時訂閱整個工作區，否則只收指定 TodoList 的事件。
+// 可見範圍與 REST API 相同：登入者都能讀取清單、細項與留言，成員的角色異動只有 Admin 看得到。
+// lastEventID 不為空時一併回傳斷線期間的事件，resumed 為 false 表示無法接續，用戶端需重新取得資料
+func (s *RealtimeService) Subscribe(db *gorm.DB, listIDs []int, lastEventID string) (*realtime.Subscription, []events.Event, bool, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, nil, false, ErrNotLoggedIn
+	}
+
+	lists := make(map[int]bool, len(listIDs))
+	for _, id := range listIDs {
+		lists[id] = true
+	}
+	if len(lists) > 0 {
+		var count int64
+		ids := make([]int, 0, len(lists))
+		for id := range lists {
+			ids = append(ids, id)
+		}
+		if err := db.Model(&models.TodoList{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+			return nil, nil, false, err
+		}
+		if int(count) != len(ids) {
+			return nil, nil, false, gorm.ErrRecordNotFound
+		}
+	}
+
+	isAdmin, err := s.users.HasRole(s.ctx, db, userID, adminRole)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	filter := func(e events.Event) bool {
+		if e.Type == models.EventMemberRoleChanged {
+			return isAdmin
+		}
+		if len(lists) == 0 {
+			return true
+		}
+		return e.TodoListID != nil && lists[*e.TodoListID]
+	}
+	sub, replay, resumed := s.hub.Subscribe(lastEventID, filter)
+	return sub, replay, resumed, nil
+}
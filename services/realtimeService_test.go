@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/pkg/realtime"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRealtimeService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	hub := realtime.NewHub(realtime.NewMemory(), "events", 10)
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(runCtx)
+	time.Sleep(10 * time.Millisecond)
+
+	svc := services.NewRealtimeService(ctx, mockUsers, hub)
+	db, mock := setupMockDB(t)
+
+	// 指定的 TodoList 不存在
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	_, _, _, err := svc.Subscribe(db, []int{2, 5}, "")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 3, "Admin").Return(false, nil)
+	sub, _, resumed, err := svc.Subscribe(db, []int{2, 2}, "")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	defer sub.Close()
+
+	publish := func(id, eventType string, listID *int) {
+		require.NoError(t, hub.Publish(context.Background(), events.Event{ID: id, Type: eventType, TodoListID: listID, Data: []byte(`{}`)}))
+	}
+	one, two := 1, 2
+	publish("a", models.EventDetailCreated, &one)
+	publish("b", models.EventMemberRoleChanged, nil)
+	publish("c", models.EventCommentCreated, &two)
+
+	select {
+	case e := <-sub.C:
+		assert.Equal(t, "c", e.ID)
+	case <-time.After(time.Second):
+		t.Fatal("沒有收到事件")
+	}
+
+	// Admin 訂閱整個工作區，從 a 之後接續
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 3, "Admin").Return(true, nil)
+	admin, replay, resumed, err := svc.Subscribe(db, nil, "a")
+	require.NoError(t, err)
+	defer admin.Close()
+	assert.True(t, resumed)
+	require.Len(t, replay, 2)
+	assert.Equal(t, "b", replay[0].ID)
+	assert.Equal(t, "c", replay[1].ID)
+
+	_, _, _, err = services.NewRealtimeService(context.Background(), mockUsers, hub).Subscribe(db, nil, "")
+	assert.ErrorIs(t, err, services.ErrNotLoggedIn)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRealtimeTicket = errors.New("票證無效、已使用或已過期")
+
+// realtimeTicketTTL 票證只需涵蓋取得票證到建立連線之間，過期後需重新取得
+const realtimeTicketTTL = 30 * time.Second
+
+// realtimeTicketBytes 票證的亂數長度
+const realtimeTicketBytes = 32
+
+type RealtimeTicketService struct {
+	ctx  context.Context
+	repo interfaces.TodoRealtimeTicketRepository
+}
+
+func NewRealtimeTicketService(ctx context.Context, repo interfaces.TodoRealtimeTicketRepository) *RealtimeTicketService {
+	return &RealtimeTicketService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// Issue 為目前的使用者發出連線用的一次性票證，原始票證只在這裡回傳一次；同時清除已過期的票證
+func (s *RealtimeTicketService) Issue(db *gorm.DB) (*models.TodoRealtimeTickets, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	raw, err := randomHex(realtimeTicketBytes)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.repo.DeleteExpired(s.ctx, db, now); err != nil {
+		return nil, err
+	}
+
+	ticket := &models.TodoRealtimeTickets{
+		UserID:     userID,
+		TicketHash: hashFeedToken(raw),
+		ExpiresAt:  now.Add(realtimeTicketTTL),
+		Ticket:     raw,
+	}
+	if err := s.repo.Create(s.ctx, db, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// Redeem 兌換票證並回傳發出票證的使用者 ID，每張票證只能兌換一次；
+// 票證不存在、已使用或已過期時回傳 ErrInvalidRealtimeTicket
+func (s *RealtimeTicketService) Redeem(db *gorm.DB, raw string) (int, error) {
+	userID, err := s.repo.Redeem(s.ctx, db, hashFeedToken(raw), time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, ErrInvalidRealtimeTicket
+	}
+	if err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
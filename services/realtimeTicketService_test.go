@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRealtimeTicketService_IssueAndRedeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockRepo := mocks.NewMockTodoRealtimeTicketRepository(ctrl)
+	svc := services.NewRealtimeTicketService(ctx, mockRepo)
+
+	var stored *models.TodoRealtimeTickets
+	mockRepo.EXPECT().DeleteExpired(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *gorm.DB, ticket *models.TodoRealtimeTickets) error {
+			stored = ticket
+			return nil
+		})
+
+	before := time.Now()
+	ticket, err := svc.Issue(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, ticket.Ticket)
+	assert.Equal(t, 3, stored.UserID)
+	// 資料庫只保存雜湊，票證很快過期
+	sum := sha256.Sum256([]byte(ticket.Ticket))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.TicketHash)
+	assert.NotContains(t, stored.TicketHash, ticket.Ticket)
+	assert.WithinDuration(t, before.Add(30*time.Second), stored.ExpiresAt, time.Second)
+
+	// 第一次兌換成功，同一張票證再次兌換時 repository 已找不到未使用的票證
+	gomock.InOrder(
+		mockRepo.EXPECT().Redeem(ctx, gomock.Any(), stored.TicketHash, gomock.Any()).Return(3, nil),
+		mockRepo.EXPECT().Redeem(ctx, gomock.Any(), stored.TicketHash, gomock.Any()).Return(0, gorm.ErrRecordNotFound),
+	)
+	userID, err := svc.Redeem(nil, ticket.Ticket)
+	require.NoError(t, err)
+	assert.Equal(t, 3, userID)
+	_, err = svc.Redeem(nil, ticket.Ticket)
+	assert.ErrorIs(t, err, services.ErrInvalidRealtimeTicket)
+
+	// 資料庫錯誤不視為票證無效
+	dbErr := errors.New("connection refused")
+	mockRepo.EXPECT().Redeem(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(0, dbErr)
+	_, err = svc.Redeem(nil, "other")
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotErrorIs(t, err, services.ErrInvalidRealtimeTicket)
+
+	_, err = services.NewRealtimeTicketService(context.Background(), mockRepo).Issue(nil)
+	assert.ErrorIs(t, err, services.ErrNotLoggedIn)
+}
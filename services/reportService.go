@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+type ReportService struct {
+	ctx  context.Context
+	repo interfaces.ReportRepository
+}
+
+func NewReportService(ctx context.Context, repo interfaces.ReportRepository) *ReportService {
+	return &ReportService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// parseDateRange 解析 from、to 日期（含當天），回傳的 to 為隔天 0 點，空字串表示不限
+func parseDateRange(from, to string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if from != "" {
+		day, err := time.ParseInLocation(entryDateLayout, from, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("from 格式必須為 %s", entryDateLayout)
+		}
+		start = &day
+	}
+	if to != "" {
+		day, err := time.ParseInLocation(entryDateLayout, to, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("to 格式必須為 %s", entryDateLayout)
+		}
+		next := day.AddDate(0, 0, 1)
+		end = &next
+	}
+	if start != nil && end != nil && !end.After(*start) {
+		return nil, nil, errors.New("to 不能早於 from")
+	}
+	return start, end, nil
+}
+
+// NewReportFilter 建立報表篩選條件；目前所有 TodoList 同屬一個工作區，不指定 listID、typeID 即為整個工作區
+func NewReportFilter(listID, typeID int, from, to string) (models.ReportFilter, error) {
+	start, end, err := parseDateRange(from, to)
+	if err != nil {
+		return models.ReportFilter{}, err
+	}
+	return models.ReportFilter{ListID: listID, TypeID: typeID, From: start, To: end}, nil
+}
+
+// Throughput 依日或週列出新增與完成的任務數，期間內沒有資料的區間補 0
+func (s *ReportService) Throughput(db *gorm.DB, interval string, filter models.ReportFilter) ([]models.ThroughputRow, error) {
+	created, err := s.repo.CountByPeriod(s.ctx, db, "created_at", interval, filter)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := s.repo.CountByPeriod(s.ctx, db, "completed_at", interval, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]*models.ThroughputRow)
+	row := func(period string) *models.ThroughputRow {
+		if rows[period] == nil {
+			rows[period] = &models.ThroughputRow{Period: period}
+		}
+		return rows[period]
+	}
+	for _, c := range created {
+		row(c.Period).Created = c.Total
+	}
+	for _, c := range completed {
+		row(c.Period).Completed = c.Total
+	}
+
+	periods := make([]string, 0, len(rows))
+	for period := range rows {
+		periods = append(periods, period)
+	}
+	sort.Strings(periods)
+
+	// 補齊區間：有指定期間時以期間為準，否則從第一筆到最後一筆
+	var first, last time.Time
+	if filter.From != nil {
+		first = *filter.From
+	} else if len(periods) > 0 {
+		first, _ = time.ParseInLocation(entryDateLayout, periods[0], time.Local)
+	}
+	if filter.To != nil {
+		last = filter.To.AddDate(0, 0, -1)
+	} else if len(periods) > 0 {
+		last, _ = time.ParseInLocation(entryDateLayout, periods[len(periods)-1], time.Local)
+	}
+	if first.IsZero() || last.IsZero() {
+		return []models.ThroughputRow{}, nil
+	}
+
+	step := 1
+	if interval == models.ReportIntervalWeek {
+		step = 7
+		first = weekStart(first)
+		last = weekStart(last)
+	}
+
+	result := []models.ThroughputRow{}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, step) {
+		result = append(result, *row(day.Format(entryDateLayout)))
+	}
+	return result, nil
+}
+
+// weekStart 該週的週一
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, day.Location())
+}
+
+// CycleTime 計算期間內完成任務的 lead time 與 cycle time 百分位數
+func (s *ReportService) CycleTime(db *gorm.DB, filter models.ReportFilter) (*models.CycleTimeReport, error) {
+	timings, err := s.repo.FindCompletedTimings(s.ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var lead, cycle []int64
+	for _, t := range timings {
+		lead = append(lead, durationSeconds(t.CreatedAt, t.CompletedAt))
+		if t.StartedAt != nil {
+			cycle = append(cycle, durationSeconds(*t.StartedAt, t.CompletedAt))
+		}
+	}
+
+	return &models.CycleTimeReport{
+		Completed:      int64(len(lead)),
+		LeadTime:       percentiles(lead),
+		CycleTimeCount: int64(len(cycle)),
+		CycleTime:      percentiles(cycle),
+	}, nil
+}
+
+// durationSeconds 資料被手動修改過時可能出現負值，以 0 計
+func durationSeconds(from, to time.Time) int64 {
+	seconds := int64(to.Sub(from) / time.Second)
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// percentiles 以 nearest-rank 計算，沒有資料時皆為 0
+func percentiles(values []int64) models.Percentiles {
+	if len(values) == 0 {
+		return models.Percentiles{}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	rank := func(p float64) int64 {
+		index := int(math.Ceil(p/100*float64(len(values)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		return values[index]
+	}
+	return models.Percentiles{P50: rank(50), P75: rank(75), P90: rank(90), P95: rank(95)}
+}
+
+// Workload 每位使用者被指派且尚未完成的任務數
+func (s *ReportService) Workload(db *gorm.DB, filter models.ReportFilter) ([]*models.WorkloadRow, error) {
+	return s.repo.Workload(s.ctx, db, filter)
+}
+
+// TypeBreakdown 依 TodoTypes 分類的任務數
+func (s *ReportService) TypeBreakdown(db *gorm.DB, filter models.ReportFilter) ([]*models.TypeBreakdownRow, error) {
+	return s.repo.TypeBreakdown(s.ctx, db, filter)
+}
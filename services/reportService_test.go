@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_Throughput_FillsEmptyWeeks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewReportService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	// 2026-01-07 為週三，補齊後從該週週一開始
+	filter, err := services.NewReportFilter(0, 0, "2026-01-07", "2026-01-25")
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().CountByPeriod(ctx, gomock.Any(), "created_at", models.ReportIntervalWeek, filter).
+		Return([]models.PeriodCount{{Period: "2026-01-05", Total: 4}, {Period: "2026-01-19", Total: 1}}, nil)
+	mockRepo.EXPECT().CountByPeriod(ctx, gomock.Any(), "completed_at", models.ReportIntervalWeek, filter).
+		Return([]models.PeriodCount{{Period: "2026-01-19", Total: 3}}, nil)
+
+	rows, err := svc.Throughput(db, models.ReportIntervalWeek, filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ThroughputRow{
+		{Period: "2026-01-05", Created: 4},
+		{Period: "2026-01-12"},
+		{Period: "2026-01-19", Created: 1, Completed: 3},
+	}, rows)
+}
+
+func TestReportService_CycleTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewReportService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.Local)
+	var timings []models.CompletedTiming
+	for i := 1; i <= 4; i++ {
+		started := base.Add(time.Duration(i) * time.Hour)
+		timings = append(timings, models.CompletedTiming{
+			CreatedAt:   base,
+			StartedAt:   &started,
+			CompletedAt: base.Add(time.Duration(i) * 24 * time.Hour),
+		})
+	}
+	// 沒有進行中紀錄，只計入 lead time
+	timings = append(timings, models.CompletedTiming{CreatedAt: base, CompletedAt: base.Add(10 * 24 * time.Hour)})
+
+	mockRepo.EXPECT().FindCompletedTimings(ctx, gomock.Any(), gomock.Any()).Return(timings, nil)
+
+	report, err := svc.CycleTime(db, models.ReportFilter{})
+
+	day := int64(24 * 60 * 60)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), report.Completed)
+	assert.Equal(t, models.Percentiles{P50: 3 * day, P75: 4 * day, P90: 10 * day, P95: 10 * day}, report.LeadTime)
+	assert.Equal(t, int64(4), report.CycleTimeCount)
+	assert.Equal(t, 2*day-2*3600, report.CycleTime.P50)
+	assert.Equal(t, 4*day-4*3600, report.CycleTime.P95)
+}
+
+func TestNewReportFilter_InvalidRange(t *testing.T) {
+	_, err := services.NewReportFilter(0, 0, "2026-02-01", "2026-01-31")
+	assert.EqualError(t, err, "to 不能早於 from")
+
+	_, err = services.NewReportFilter(0, 0, "01/02/2026", "")
+	assert.EqualError(t, err, "from 格式必須為 2006-01-02")
+}
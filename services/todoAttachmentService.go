@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"todolist/config"
+	"todolist/models"
+	"todolist/pkg/storage"
+	"todolist/repositories/base"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// attachmentURLTTL 簽章下載連結的有效時間
+	attachmentURLTTL = 15 * time.Minute
+	// blobGCGrace 附件全部刪除後保留 blob 的時間，期間內仍可由資料庫手動復原
+	blobGCGrace = 24 * time.Hour
+	// sniffLen http.DetectContentType 判斷類型最多讀取的長度
+	sniffLen = 512
+)
+
+var (
+	ErrAttachmentTooLarge       = errors.New("檔案超過大小上限")
+	ErrAttachmentTypeNotAllowed = errors.New("不支援的檔案類型")
+	ErrAttachmentForbidden      = errors.New("只有上傳者或管理員可以刪除附件")
+	ErrInvalidDownloadURL       = errors.New("下載連結無效或已過期")
+)
+
+// AttachmentTarget 附件掛載的對象，DetailID 與 CommentID 只能擇一
+type AttachmentTarget struct {
+	DetailID  int
+	CommentID int
+}
+
+type TodoAttachmentService struct {
+	ctx          context.Context
+	repo         interfaces.TodoAttachmentRepository
+	users        interfaces.AuthRepository
+	store        storage.Storage
+	maxSize      int64
+	allowedTypes []string
+	urlSecret    []byte
+	now          func() time.Time
+}
+
+func NewTodoAttachmentService(ctx context.Context, repo interfaces.TodoAttachmentRepository, users interfaces.AuthRepository, store storage.Storage) *TodoAttachmentService {
+	return &TodoAttachmentService{
+		ctx:          ctx,
+		repo:         repo,
+		users:        users,
+		store:        store,
+		maxSize:      config.AttachmentMaxSize,
+		allowedTypes: config.AttachmentAllowedTypes,
+		urlSecret:    []byte(config.AttachmentURLSecret),
+		now:          time.Now,
+	}
+}
+
+// Upload 上傳附件。內容以 sha256 去重：相同內容只存一份 blob，每次上傳各自建立一筆附件紀錄。
+// 檔案類型依內容判斷，不採用用戶端提供的 Content-Type。
+func (s *TodoAttachmentService) Upload(db *gorm.DB, target AttachmentTarget, fileName string, file io.ReadSeeker) (*models.TodoAttachments, error) {
+	if _, ok := utils.CurrentUserID(s.ctx); !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	if err := s.checkTarget(db, target); err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, io.LimitReader(file, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if size == 0 {
+		return nil, errors.New("檔案不可為空")
+	}
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+
+	contentType, err := s.detectContentType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.TodoAttachments{
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+	}
+	if target.DetailID > 0 {
+		attachment.TodoListDetailID = &target.DetailID
+	} else {
+		attachment.CommentID = &target.CommentID
+	}
+
+	// 第一次嘗試時若同時有人上傳相同內容，blob 會因唯一索引衝突，重試一次即可沿用對方的 blob
+	for attempt := 0; ; attempt++ {
+		reused, err := s.attachExistingBlob(db, checksum, attachment)
+		if err != nil || reused {
+			return attachment, err
+		}
+
+		err = s.attachNewBlob(db, checksum, size, contentType, file, attachment)
+		if errors.Is(err, gorm.ErrDuplicatedKey) && attempt == 0 {
+			attachment.ID = 0
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return attachment, nil
+	}
+}
+
+// attachExistingBlob 已有相同內容的 blob 時直接引用；鎖住 blob 以免和清除排程同時進行
+func (s *TodoAttachmentService) attachExistingBlob(db *gorm.DB, checksum string, attachment *models.TodoAttachments) (bool, error) {
+	reused := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		blob, err := s.repo.LockBlobBySHA256(s.ctx, tx, checksum)
+		if err != nil || blob == nil {
+			return err
+		}
+		attachment.BlobID = blob.ID
+		reused = true
+		return s.repo.Create(s.ctx, tx, attachment)
+	})
+	return reused, err
+}
+
+// attachNewBlob 先寫入 storage，再建立 blob 與附件紀錄；交易失敗時移除剛寫入的內容。
+// 每個 blob 的 key 都帶隨機字串，清除排程刪除舊 blob 時不會誤刪新上傳的相同內容。
+func (s *TodoAttachmentService) attachNewBlob(db *gorm.DB, checksum string, size int64, contentType string, file io.ReadSeeker, attachment *models.TodoAttachments) error {
+	key, err := blobKey(checksum)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := s.store.Put(s.ctx, key, file, size, contentType); err != nil {
+		return err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		blob := &models.TodoBlobs{
+			SHA256:      checksum,
+			Size:        size,
+			ContentType: contentType,
+			StorageKey:  key,
+		}
+		if err := s.repo.CreateBlob(s.ctx, tx, blob); err != nil {
+			return err
+		}
+		attachment.BlobID = blob.ID
+		return s.repo.Create(s.ctx, tx, attachment)
+	})
+	if err != nil {
+		if delErr := s.store.Delete(s.ctx, key); delErr != nil {
+			return errors.Join(err, delErr)
+		}
+	}
+	return err
+}
+
+// Index 取出掛在指定對象上的附件
+func (s *TodoAttachmentService) Index(db *gorm.DB, target AttachmentTarget) ([]*models.TodoAttachments, error) {
+	if err := s.checkTarget(db, target); err != nil {
+		return nil, err
+	}
+	column, id := target.column()
+	return s.repo.FindByTarget(s.ctx, db, column, id)
+}
+
+// Open 取出附件與內容，呼叫端負責關閉 reader
+func (s *TodoAttachmentService) Open(db *gorm.DB, id int) (*models.TodoAttachments, io.ReadCloser, error) {
+	item, err := s.repo.FindByID(s.ctx, db, id, &base.FindOptions{PreloadFields: []string{"Blob"}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if item.Blob == nil {
+		return nil, nil, errors.New("附件內容不存在")
+	}
+
+	reader, err := s.store.Get(s.ctx, item.Blob.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.New("附件內容不存在")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return item, reader, nil
+}
+
+// Delete 軟刪除附件，只有上傳者或 Admin 可以刪除；blob 由清除排程處理
+func (s *TodoAttachmentService) Delete(db *gorm.DB, id int) (*models.TodoAttachments, error) {
+	var deleted *models.TodoAttachments
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := authorizeOwner(s.ctx, tx, s.users, item.CreatedBy, ErrAttachmentForbidden); err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// SignedURL 產生不需登入、在時限內有效的下載連結
+func (s *TodoAttachmentService) SignedURL(db *gorm.DB, id int) (string, time.Time, error) {
+	if _, err := s.repo.FindByID(s.ctx, db, id); err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := s.now().Add(attachmentURLTTL).Truncate(time.Second)
+	expires := expiresAt.Unix()
+	url := fmt.Sprintf("/api/attachments/%d/download?expires=%d&signature=%s", id, expires, s.sign(id, expires))
+	return url, expiresAt, nil
+}
+
+// VerifySignature 檢查下載連結的簽章與期限
+func (s *TodoAttachmentService) VerifySignature(id int, expires int64, signature string) error {
+	if s.now().Unix() > expires {
+		return ErrInvalidDownloadURL
+	}
+	expected := s.sign(id, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidDownloadURL
+	}
+	return nil
+}
+
+// CollectGarbage 移除已沒有附件引用的 blob，回傳移除的數量。
+// 每個 blob 都在各自的交易中鎖定並再次確認沒有引用，資料庫紀錄刪除後才移除 storage 內容。
+func (s *TodoAttachmentService) CollectGarbage(db *gorm.DB, limit int) (int, error) {
+	before := s.now().Add(-blobGCGrace)
+	ids, err := s.repo.FindOrphanBlobIDs(s.ctx, db, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, id := range ids {
+		var blob *models.TodoBlobs
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			blob, err = s.repo.LockOrphanBlob(s.ctx, tx, id, before)
+			if err != nil || blob == nil {
+				return err
+			}
+			return s.repo.DeleteBlob(s.ctx, tx, id)
+		})
+		if err != nil {
+			return removed, err
+		}
+		if blob == nil {
+			continue
+		}
+
+		if err := s.store.Delete(s.ctx, blob.StorageKey); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (s *TodoAttachmentService) sign(id int, expires int64) string {
+	mac := hmac.New(sha256.New, s.urlSecret)
+	fmt.Fprintf(mac, "attachment:%d:%d", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// detectContentType 依檔案開頭判斷類型，並檢查是否在允許清單內
+func (s *TodoAttachmentService) detectContentType(file io.ReadSeeker) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrAttachmentTypeNotAllowed
+	}
+	for _, allowed := range s.allowedTypes {
+		if strings.EqualFold(strings.TrimSpace(allowed), mediaType) {
+			return contentType, nil
+		}
+	}
+	return "", fmt.Errorf("%w：%s", ErrAttachmentTypeNotAllowed, mediaType)
+}
+
+// checkTarget 確認附件掛載的對象存在
+func (s *TodoAttachmentService) checkTarget(db *gorm.DB, target AttachmentTarget) error {
+	if (target.DetailID > 0) == (target.CommentID > 0) {
+		return errors.New("附件必須掛在 TodoListDetails 或留言其中之一")
+	}
+
+	var count int64
+	var err error
+	if target.DetailID > 0 {
+		err = db.Model(&models.TodoListDetails{}).Where("id = ?", target.DetailID).Count(&count).Error
+	} else {
+		err = db.Model(&models.TodoComments{}).Where("id = ?", target.CommentID).Count(&count).Error
+	}
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if target.DetailID > 0 {
+			return errors.New("to_do_list_detail_id 不存在")
+		}
+		return errors.New("comment_id 不存在")
+	}
+	return nil
+}
+
+func (t AttachmentTarget) column() (string, int) {
+	if t.DetailID > 0 {
+		return "to_do_list_detail_id", t.DetailID
+	}
+	return "comment_id", t.CommentID
+}
+
+// blobKey 以 sha256 前兩碼分目錄，並加上隨機字串讓每個 blob 的 key 唯一
+func blobKey(checksum string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return "blobs/" + checksum[:2] + "/" + checksum + "-" + hex.EncodeToString(suffix), nil
+}
+
+// cleanFileName 只保留檔名本身（去掉用戶端的路徑），並限制長度
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
@@ -0,0 +1,195 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+	"todolist/config"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/storage"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func setupAttachmentConfig() {
+	config.AttachmentMaxSize = 1024
+	config.AttachmentAllowedTypes = []string{"image/png", "text/plain"}
+	config.AttachmentURLSecret = "test-secret"
+}
+
+func expectDetailExists(mock sqlmock.Sqlmock, detailID int) {
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details"`).
+		WithArgs(detailID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+}
+
+func TestTodoAttachmentService_Upload_Limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setupAttachmentConfig()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoAttachmentService(ctx, mocks.NewMockTodoAttachmentRepository(ctrl), mocks.NewMockAuthRepository(ctrl), store)
+	db, mock := setupMockDB(t)
+
+	expectDetailExists(mock, 1)
+	_, err = svc.Upload(db, services.AttachmentTarget{DetailID: 1}, "big.png", bytes.NewReader(bytes.Repeat([]byte("a"), 1025)))
+	assert.ErrorIs(t, err, services.ErrAttachmentTooLarge)
+
+	// 副檔名是 png，但內容是執行檔，依內容判斷為不允許的類型
+	expectDetailExists(mock, 1)
+	_, err = svc.Upload(db, services.AttachmentTarget{DetailID: 1}, "fake.png", bytes.NewReader([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00")))
+	assert.ErrorIs(t, err, services.ErrAttachmentTypeNotAllowed)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAttachmentService_Upload_ReusesExistingBlob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setupAttachmentConfig()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mocks.NewMockTodoAttachmentRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoAttachmentService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), store)
+	db, mock := setupMockDB(t)
+
+	expectDetailExists(mock, 1)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockBlobBySHA256(ctx, gomock.Any(), gomock.Any()).Return(&models.TodoBlobs{ID: 9}, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+
+	attachment, err := svc.Upload(db, services.AttachmentTarget{DetailID: 1}, `C:\Users\me\screen.png`, bytes.NewReader(pngHeader))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, attachment.BlobID)
+	assert.Equal(t, "screen.png", attachment.FileName)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, int64(len(pngHeader)), attachment.Size)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAttachmentService_Upload_StoresNewBlob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setupAttachmentConfig()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mocks.NewMockTodoAttachmentRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoAttachmentService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), store)
+	db, mock := setupMockDB(t)
+
+	var key string
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_comments"`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockBlobBySHA256(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mockRepo.EXPECT().CreateBlob(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, blob *models.TodoBlobs) error {
+			assert.Len(t, blob.SHA256, 64)
+			assert.Equal(t, "text/plain; charset=utf-8", blob.ContentType)
+			key = blob.StorageKey
+			blob.ID = 5
+			return nil
+		})
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+
+	attachment, err := svc.Upload(db, services.AttachmentTarget{CommentID: 3}, "notes.txt", bytes.NewReader([]byte("hello")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, attachment.BlobID)
+	assert.Equal(t, 3, *attachment.CommentID)
+
+	r, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "hello", string(data))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAttachmentService_SignedURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setupAttachmentConfig()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mocks.NewMockTodoAttachmentRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoAttachmentService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), store)
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 4).Return(&models.TodoAttachments{ID: 4}, nil)
+
+	signed, _, err := svc.SignedURL(db, 4)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/attachments/4/download", u.Path)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	signature := u.Query().Get("signature")
+
+	assert.NoError(t, svc.VerifySignature(4, expires, signature))
+	assert.ErrorIs(t, svc.VerifySignature(5, expires, signature), services.ErrInvalidDownloadURL)
+	assert.ErrorIs(t, svc.VerifySignature(4, expires+60, signature), services.ErrInvalidDownloadURL)
+	assert.ErrorIs(t, svc.VerifySignature(4, 1, signature), services.ErrInvalidDownloadURL)
+}
+
+func TestTodoAttachmentService_CollectGarbage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setupAttachmentConfig()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mocks.NewMockTodoAttachmentRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoAttachmentService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), store)
+	db, mock := setupMockDB(t)
+	require.NoError(t, store.Put(ctx, "blobs/aa/orphan", bytes.NewReader([]byte("x")), 1, "text/plain"))
+
+	mockRepo.EXPECT().FindOrphanBlobIDs(ctx, gomock.Any(), gomock.Any(), 10).Return([]int{1, 2}, nil)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockOrphanBlob(ctx, gomock.Any(), 1, gomock.Any()).
+		Return(&models.TodoBlobs{ID: 1, StorageKey: "blobs/aa/orphan"}, nil)
+	mockRepo.EXPECT().DeleteBlob(ctx, gomock.Any(), 1).Return(nil)
+	mock.ExpectCommit()
+
+	// 列出後又被新的附件引用，不刪除
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockOrphanBlob(ctx, gomock.Any(), 2, gomock.Any()).Return(nil, nil)
+	mock.ExpectCommit()
+
+	removed, err := svc.CollectGarbage(db, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = store.Get(ctx, "blobs/aa/orphan")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,553 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAutomationForbidden        = errors.New("只有建立者或 Admin 可以修改此自動化規則")
+	ErrAutomationGlobalForbidden  = errors.New("只有 Admin 可以建立不限 TodoList 的自動化規則")
+	ErrInvalidAutomationAction    = errors.New("自動化規則的動作設定錯誤")
+	ErrAutomationNoActions        = errors.New("未設定執行自動化規則動作的服務")
+	ErrAutomationDepth            = errors.New("超過自動化規則的連鎖層數上限，不執行")
+	ErrAutomationWebhookForbidden = errors.New("只有 Admin 建立的規則可以呼叫其他 TodoList 的 webhook")
+)
+
+const (
+	// automationMaxDepth 動作引起的事件再觸發規則，最多連鎖這麼多層，超過的只記錄為 skipped，避免規則互相觸發形成迴圈
+	automationMaxDepth = 3
+	// automationDueWindow 逾期觸發在到期後多久內仍會補執行
+	automationDueWindow = 24 * time.Hour
+)
+
+// AutomationActions 執行動作用的服務
+type AutomationActions struct {
+	Details  *TodoListDetailsService
+	Comments *TodoCommentService
+	Webhooks *TodoWebhookService
+}
+
+// TodoAutomationService 自動化規則：觸發的資料 commit 後，符合條件的規則以建立者的身分執行動作。
+// 動作寫入的事件帶著連鎖層數，超過 automationMaxDepth 的不再執行
+type TodoAutomationService struct {
+	ctx     context.Context
+	repo    interfaces.TodoAutomationRepository
+	users   interfaces.AuthRepository
+	actions func(ctx context.Context) *AutomationActions
+}
+
+func NewTodoAutomationService(ctx context.Context, repo interfaces.TodoAutomationRepository, users interfaces.AuthRepository) *TodoAutomationService {
+	return &TodoAutomationService{
+		ctx:   ctx,
+		repo:  repo,
+		users: users,
+	}
+}
+
+// WithActions 設定建立動作服務的方式，只有處理事件與逾期觸發時需要；傳入的 ctx 帶有規則建立者與連鎖層數
+func (s *TodoAutomationService) WithActions(actions func(ctx context.Context) *AutomationActions) *TodoAutomationService {
+	s.actions = actions
+	return s
+}
+
+// Create 建立規則，listID 為 0 時處理所有 TodoList，只有 Admin 可以建立
+func (s *TodoAutomationService) Create(db *gorm.DB, listID int, name, trigger string, conditions models.AutomationConditions, actions []models.AutomationAction) (*models.TodoAutomationRules, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	rule := &models.TodoAutomationRules{Name: name, Trigger: trigger, Conditions: conditions, Actions: actions, Active: true}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if listID > 0 {
+			exists, err := automationRefExists(tx, &models.TodoList{}, listID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return errors.New("to_do_list_id 不存在")
+			}
+			rule.TodoListID = &listID
+		} else {
+			isAdmin, err := s.users.HasRole(s.ctx, tx, userID, adminRole)
+			if err != nil {
+				return err
+			}
+			if !isAdmin {
+				return ErrAutomationGlobalForbidden
+			}
+		}
+
+		if err := s.checkActions(tx, rule.TodoListID, userID, actions); err != nil {
+			return err
+		}
+		return s.repo.Create(s.ctx, tx, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Index 取出規則，listID 為 0 時取出全部
+func (s *TodoAutomationService) Index(db *gorm.DB, listID int) ([]*models.TodoAutomationRules, error) {
+	return s.repo.FindRules(s.ctx, db, listID)
+}
+
+// Show 取得規則
+func (s *TodoAutomationService) Show(db *gorm.DB, id int) (*models.TodoAutomationRules, error) {
+	return s.repo.FindByID(s.ctx, db, id)
+}
+
+// Edit 修改名稱、觸發條件、條件、動作與是否啟用，不能更換 TodoList；只有建立者或 Admin 可以修改
+func (s *TodoAutomationService) Edit(db *gorm.DB, id int, name, trigger string, conditions models.AutomationConditions, actions []models.AutomationAction, active bool) (*models.TodoAutomationRules, error) {
+	updated := &models.TodoAutomationRules{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		rule, err := s.authorize(tx, id)
+		if err != nil {
+			return err
+		}
+		// 動作以規則建立者的身分執行，webhook 的權限看建立者而不是修改者
+		if err := s.checkActions(tx, rule.TodoListID, creatorID(rule.CreatedBy), actions); err != nil {
+			return err
+		}
+
+		rule.Name = name
+		rule.Trigger = trigger
+		rule.Conditions = conditions
+		rule.Actions = actions
+		rule.Active = active
+		// active 可能改為 false，需用 Select 指定欄位強制更新
+		if err := s.repo.Update(s.ctx, tx.Select("name", "trigger", "conditions", "actions", "active", "updated_at", "updated_by"), rule); err != nil {
+			return err
+		}
+
+		*updated = *rule
+		return nil
+	})
+	return updated, err
+}
+
+// Delete 只有建立者或 Admin 可以刪除，執行紀錄一併保留到規則被實際刪除
+func (s *TodoAutomationService) Delete(db *gorm.DB, id int) (*models.TodoAutomationRules, error) {
+	rule, err := s.authorize(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SoftDelete(s.ctx, db, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Executions 分頁取出規則的執行紀錄，新的在前；只有建立者或 Admin 可以查看
+func (s *TodoAutomationService) Executions(db *gorm.DB, id int, page, pageSize int) (*utils.PaginatedResult[*models.TodoAutomationExecutions], error) {
+	if _, err := s.authorize(db, id); err != nil {
+		return nil, err
+	}
+	executions, total, err := s.repo.FindExecutions(s.ctx, db, id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewPaginatedResult(executions, total, page, pageSize), nil
+}
+
+// Test 以項目目前的資料試跑規則，回傳各條件的檢查結果與會執行的動作，不會執行任何動作也不記錄；
+// 規則停用時仍會試跑
+func (s *TodoAutomationService) Test(db *gorm.DB, id int, detailID int) (*models.AutomationTestResult, error) {
+	rule, err := s.authorize(db, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.repo.FindTarget(s.ctx, db, detailID)
+	if err != nil {
+		return nil, notFoundAs(err, "to_do_list_detail_id 不存在")
+	}
+
+	result := evaluateAutomation(rule, target)
+	result.RuleID = rule.ID
+	result.TodoListDetailID = detailID
+	result.Actions = []string{}
+	if result.Matched {
+		for _, action := range rule.Actions {
+			result.Actions = append(result.Actions, describeAutomationAction(action))
+		}
+	}
+	return result, nil
+}
+
+func (s *TodoAutomationService) authorize(db *gorm.DB, id int) (*models.TodoAutomationRules, error) {
+	rule, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOwner(s.ctx, db, s.users, rule.CreatedBy, ErrAutomationForbidden); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// automationRefExists model 中是否有 id 這筆資料
+func automationRefExists(tx *gorm.DB, model interface{}, id int) (bool, error) {
+	var count int64
+	if err := tx.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// creatorID 規則建立者的 ID，沒有建立者時為 0
+func creatorID(createdBy *uint) int {
+	if createdBy == nil {
+		return 0
+	}
+	return int(*createdBy)
+}
+
+// authorizeWebhook 規則可以呼叫不限 TodoList 或與規則同一個 TodoList 的 webhook；
+// 其他 TodoList 的 webhook 會把項目資料送到別人設定的網址，只有建立者為 Admin 的規則可以呼叫。
+// webhook 不存在時回傳 gorm.ErrRecordNotFound
+func (s *TodoAutomationService) authorizeWebhook(tx *gorm.DB, listID *int, creatorID int, webhookID int) error {
+	var hook models.TodoWebhooks
+	if err := tx.Select("id", "to_do_list_id").Where("id = ?", webhookID).Take(&hook).Error; err != nil {
+		return err
+	}
+	if hook.TodoListID == nil || (listID != nil && *listID == *hook.TodoListID) {
+		return nil
+	}
+	if creatorID == 0 {
+		return ErrAutomationWebhookForbidden
+	}
+	isAdmin, err := s.users.HasRole(s.ctx, tx, creatorID, adminRole)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrAutomationWebhookForbidden
+	}
+	return nil
+}
+
+// checkActions 檢查每個動作需要的欄位與參照的資料是否存在，以及建立者是否可以呼叫指定的 webhook
+func (s *TodoAutomationService) checkActions(tx *gorm.DB, listID *int, creatorID int, actions []models.AutomationAction) error {
+	for i, action := range actions {
+		var model interface{}
+		field, id := "", 0
+		switch action.Type {
+		case models.AutomationActionAssignUser:
+			model, field, id = &models.User{}, "user_id", action.UserID
+		case models.AutomationActionSetStatus:
+			if action.Status == "" {
+				return fmt.Errorf("%w：第 %d 個動作 %s 需要 status", ErrInvalidAutomationAction, i+1, action.Type)
+			}
+			continue
+		case models.AutomationActionAddComment:
+			if action.Body == "" {
+				return fmt.Errorf("%w：第 %d 個動作 %s 需要 body", ErrInvalidAutomationAction, i+1, action.Type)
+			}
+			continue
+		case models.AutomationActionMoveToList:
+			model, field, id = &models.TodoList{}, "to_do_list_id", action.TodoListID
+		case models.AutomationActionCallWebhook:
+			if action.WebhookID <= 0 {
+				return fmt.Errorf("%w：第 %d 個動作 %s 需要 webhook_id", ErrInvalidAutomationAction, i+1, action.Type)
+			}
+			err := s.authorizeWebhook(tx, listID, creatorID, action.WebhookID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w：第 %d 個動作的 webhook_id %d 不存在", ErrInvalidAutomationAction, i+1, action.WebhookID)
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		default:
+			return fmt.Errorf("%w：第 %d 個動作 %s 不支援", ErrInvalidAutomationAction, i+1, action.Type)
+		}
+
+		if id <= 0 {
+			return fmt.Errorf("%w：第 %d 個動作 %s 需要 %s", ErrInvalidAutomationAction, i+1, action.Type, field)
+		}
+		exists, err := automationRefExists(tx, model, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w：第 %d 個動作的 %s %d 不存在", ErrInvalidAutomationAction, i+1, field, id)
+		}
+	}
+	return nil
+}
+
+// evaluateAutomation 檢查項目是否屬於規則的 TodoList 並符合所有條件；空的條件不檢查
+func evaluateAutomation(rule *models.TodoAutomationRules, target *models.AutomationTarget) *models.AutomationTestResult {
+	detail := target.Detail
+	result := &models.AutomationTestResult{
+		InScope: rule.TodoListID == nil || *rule.TodoListID == detail.TodoListID,
+		Checks:  []models.AutomationCheck{},
+	}
+
+	labelIDs := make([]int, 0, len(detail.Labels))
+	for _, label := range detail.Labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+	userIDs := make([]int, 0, len(detail.Users))
+	for _, user := range detail.Users {
+		userIDs = append(userIDs, user.ID)
+	}
+	conditions := []struct {
+		name     string
+		expected []int
+		actual   []int
+	}{
+		{"type_ids", rule.Conditions.TypeIDs, []int{target.TypeID}},
+		{"label_ids", rule.Conditions.LabelIDs, labelIDs},
+		{"assignee_ids", rule.Conditions.AssigneeIDs, userIDs},
+	}
+
+	result.Matched = result.InScope
+	for _, c := range conditions {
+		if len(c.expected) == 0 {
+			continue
+		}
+		passed := intersects(c.expected, c.actual)
+		result.Checks = append(result.Checks, models.AutomationCheck{Condition: c.name, Expected: c.expected, Actual: c.actual, Passed: passed})
+		result.Matched = result.Matched && passed
+	}
+	return result
+}
+
+func intersects(a, b []int) bool {
+	set := make(map[int]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	for _, v := range b {
+		if set[v] {
+			return true
+		}
+	}
+	return false
+}
+
+// describeAutomationAction 動作的說明，用於試跑結果與執行紀錄
+func describeAutomationAction(action models.AutomationAction) string {
+	switch action.Type {
+	case models.AutomationActionAssignUser:
+		return fmt.Sprintf("加入負責人 %d", action.UserID)
+	case models.AutomationActionSetStatus:
+		return fmt.Sprintf("狀態改為 %s", action.Status)
+	case models.AutomationActionAddComment:
+		return fmt.Sprintf("新增留言「%s」", truncate(action.Body, 50))
+	case models.AutomationActionMoveToList:
+		return fmt.Sprintf("移到 TodoList %d", action.TodoListID)
+	case models.AutomationActionCallWebhook:
+		return fmt.Sprintf("送出 webhook %d", action.WebhookID)
+	default:
+		return action.Type
+	}
+}
+
+// HandleEvent 由 outbox relay 在事件 commit 後呼叫，執行觸發條件為該事件的規則；
+// 以事件 ID 記錄執行，relay 重送同一個事件時已經執行過的規則不會再執行
+func (s *TodoAutomationService) HandleEvent(db *gorm.DB, e events.Event) error {
+	var detailID int
+	switch e.Type {
+	case models.AutomationTriggerDetailCreated, models.AutomationTriggerStatusChanged:
+		var detail models.TodoListDetails
+		if err := e.Decode(&detail); err != nil {
+			return err
+		}
+		detailID = detail.ID
+	case models.AutomationTriggerAssigned:
+		var data models.DetailAssignment
+		if err := e.Decode(&data); err != nil || data.Detail == nil {
+			return err
+		}
+		detailID = data.Detail.ID
+	default:
+		return nil
+	}
+
+	listID := 0
+	if e.TodoListID != nil {
+		listID = *e.TodoListID
+	}
+	rules, err := s.repo.FindActive(s.ctx, db, e.Type, listID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		if err := s.execute(db, rule, e.ID, detailID, e.Depth); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunDuePassed 為到期後 automationDueWindow 內仍未完成的項目執行逾期觸發的規則，回傳執行的次數；
+// 同一個項目的同一個到期時間只會執行一次，改了到期時間後會再觸發
+func (s *TodoAutomationService) RunDuePassed(db *gorm.DB, now time.Time) (int, error) {
+	rules, err := s.repo.FindActive(s.ctx, db, models.AutomationTriggerDuePassed, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	executed := 0
+	for _, rule := range rules {
+		listID := 0
+		if rule.TodoListID != nil {
+			listID = *rule.TodoListID
+		}
+		details, err := s.repo.FindOverdue(s.ctx, db, listID, now.Add(-automationDueWindow), now.Add(time.Second))
+		if err != nil {
+			return executed, err
+		}
+		for _, detail := range details {
+			key := fmt.Sprintf("due:%d:%d", detail.ID, detail.DueAt.Unix())
+			if err := s.execute(db, rule, key, detail.ID, 0); err != nil {
+				return executed, err
+			}
+			executed++
+		}
+	}
+	return executed, nil
+}
+
+// execute 項目符合規則時執行所有動作。先寫入執行紀錄再執行，紀錄已存在時表示處理過，不再執行；
+// 動作都在同一個交易內，其中一個失敗時全部復原並記錄為 failed。只有寫入紀錄失敗時回傳錯誤
+func (s *TodoAutomationService) execute(db *gorm.DB, rule *models.TodoAutomationRules, key string, detailID int, depth int) error {
+	target, err := s.repo.FindTarget(s.ctx, db, detailID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 項目已刪除
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !evaluateAutomation(rule, target).Matched {
+		return nil
+	}
+
+	execution := &models.TodoAutomationExecutions{
+		RuleID:           rule.ID,
+		TriggerKey:       key,
+		Trigger:          rule.Trigger,
+		TodoListDetailID: &detailID,
+		Depth:            depth,
+		Results:          []string{},
+	}
+	if depth >= automationMaxDepth {
+		execution.Status = models.AutomationExecutionSkipped
+		execution.Error = ErrAutomationDepth.Error()
+		_, err := s.repo.CreateExecution(s.ctx, db, execution)
+		return err
+	}
+	if s.actions == nil {
+		return ErrAutomationNoActions
+	}
+
+	// 以規則建立者的身分執行，動作寫入的事件層數加一
+	ctx := utils.WithAutomationDepth(s.ctx, depth+1)
+	if rule.CreatedBy != nil {
+		ctx = context.WithValue(ctx, utils.UserIDKey, float64(*rule.CreatedBy))
+	}
+	actions := s.actions(ctx)
+
+	created := false
+	runErr := db.Transaction(func(tx *gorm.DB) error {
+		execution.Status = models.AutomationExecutionSucceeded
+		ok, err := s.repo.CreateExecution(s.ctx, tx, execution)
+		if err != nil || !ok {
+			return err
+		}
+		created = true
+
+		for i, action := range rule.Actions {
+			if err := s.apply(tx, actions, rule, key, target, action); err != nil {
+				return fmt.Errorf("第 %d 個動作 %s 失敗：%w", i+1, action.Type, err)
+			}
+			execution.Results = append(execution.Results, describeAutomationAction(action))
+		}
+		return s.repo.UpdateExecution(s.ctx, tx, execution)
+	})
+	if runErr == nil || !created {
+		return runErr
+	}
+
+	// 交易已復原，另外記錄失敗
+	execution.ID = 0
+	execution.Results = []string{}
+	execution.Status = models.AutomationExecutionFailed
+	execution.Error = truncate(runErr.Error(), 1000)
+	_, err = s.repo.CreateExecution(s.ctx, db, execution)
+	return err
+}
+
+// apply 執行單一動作
+func (s *TodoAutomationService) apply(tx *gorm.DB, actions *AutomationActions, rule *models.TodoAutomationRules, key string, target *models.AutomationTarget, action models.AutomationAction) error {
+	detail := target.Detail
+	switch action.Type {
+	case models.AutomationActionAssignUser:
+		ids := make([]int, 0, len(detail.Users)+1)
+		for _, user := range detail.Users {
+			if user.ID == action.UserID {
+				return nil
+			}
+			ids = append(ids, user.ID)
+		}
+		updated, err := actions.Details.SetAssignees(tx, detail.ID, append(ids, action.UserID))
+		if err != nil {
+			return err
+		}
+		detail.Users = updated.Users
+		return nil
+	case models.AutomationActionSetStatus:
+		updated, err := actions.Details.ChangeStatus(tx, detail.ID, action.Status)
+		if err != nil {
+			return err
+		}
+		detail.Status = updated.Status
+		return nil
+	case models.AutomationActionAddComment:
+		_, err := actions.Comments.Create(tx, detail.ID, 0, action.Body)
+		return err
+	case models.AutomationActionMoveToList:
+		updated, err := actions.Details.Move(tx, detail.ID, action.TodoListID, 0, 0)
+		if err != nil {
+			return err
+		}
+		detail.TodoListID = updated.TodoListID
+		return nil
+	case models.AutomationActionCallWebhook:
+		// 建立後 webhook 可能改了 TodoList、建立者也可能不再是 Admin，執行時重新檢查
+		err := s.authorizeWebhook(tx, rule.TodoListID, creatorID(rule.CreatedBy), action.WebhookID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWebhookDisabled
+		}
+		if err != nil {
+			return err
+		}
+		_, err = actions.Webhooks.Deliver(tx, action.WebhookID, models.EventAutomationTriggered, detail.TodoListID, models.AutomationWebhookData{
+			RuleID:           rule.ID,
+			RuleName:         rule.Name,
+			Trigger:          rule.Trigger,
+			TriggerKey:       key,
+			TodoListDetailID: detail.ID,
+			Detail:           detail,
+		})
+		return err
+	default:
+		return fmt.Errorf("%w：%s 不支援", ErrInvalidAutomationAction, action.Type)
+	}
+}
@@ -0,0 +1,376 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTodoAutomationService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(2))
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	mockAuthRepo := mocks.NewMockAuthRepository(ctrl)
+	svc := services.NewTodoAutomationService(ctx, mockRepo, mockAuthRepo)
+	db, mock := setupMockDB(t)
+
+	actions := []models.AutomationAction{{Type: models.AutomationActionSetStatus, Status: models.DetailStatusInProgress}}
+
+	// 不限 TodoList 的規則只有 Admin 可以建立
+	mock.ExpectBegin()
+	mockAuthRepo.EXPECT().HasRole(ctx, gomock.Any(), 2, "Admin").Return(false, nil)
+	mock.ExpectRollback()
+	_, err := svc.Create(db, 0, "開始處理", models.AutomationTriggerAssigned, models.AutomationConditions{}, actions)
+	assert.ErrorIs(t, err, services.ErrAutomationGlobalForbidden)
+
+	// 動作缺少需要的欄位
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	_, err = svc.Create(db, 3, "自動指派", models.AutomationTriggerDetailCreated, models.AutomationConditions{},
+		[]models.AutomationAction{{Type: models.AutomationActionAssignUser}})
+	assert.ErrorIs(t, err, services.ErrInvalidAutomationAction)
+	assert.EqualError(t, err, "自動化規則的動作設定錯誤：第 1 個動作 assign_user 需要 user_id")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+	rule, err := svc.Create(db, 3, "開始處理", models.AutomationTriggerAssigned, models.AutomationConditions{}, actions)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *rule.TodoListID)
+	assert.True(t, rule.Active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAutomationService_Create_WebhookScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(2))
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	mockAuthRepo := mocks.NewMockAuthRepository(ctrl)
+	svc := services.NewTodoAutomationService(ctx, mockRepo, mockAuthRepo)
+	db, mock := setupMockDB(t)
+
+	actions := []models.AutomationAction{{Type: models.AutomationActionCallWebhook, WebhookID: 4}}
+	webhookIn := func(listID interface{}) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`SELECT "id","to_do_list_id" FROM "to_do_webhooks"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "to_do_list_id"}).AddRow(4, listID))
+	}
+
+	// 其他 TodoList 的 webhook 會把項目送到別人設定的網址
+	mock.ExpectBegin()
+	webhookIn(5)
+	mockAuthRepo.EXPECT().HasRole(ctx, gomock.Any(), 2, "Admin").Return(false, nil)
+	mock.ExpectRollback()
+	_, err := svc.Create(db, 3, "通知", models.AutomationTriggerDetailCreated, models.AutomationConditions{}, actions)
+	assert.ErrorIs(t, err, services.ErrAutomationWebhookForbidden)
+
+	// 同一個 TodoList 的 webhook 不需要 Admin
+	mock.ExpectBegin()
+	webhookIn(3)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+	_, err = svc.Create(db, 3, "通知", models.AutomationTriggerDetailCreated, models.AutomationConditions{}, actions)
+	require.NoError(t, err)
+
+	// Admin 可以使用其他 TodoList 的 webhook
+	mock.ExpectBegin()
+	webhookIn(5)
+	mockAuthRepo.EXPECT().HasRole(ctx, gomock.Any(), 2, "Admin").Return(true, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+	_, err = svc.Create(db, 3, "通知", models.AutomationTriggerDetailCreated, models.AutomationConditions{}, actions)
+	require.NoError(t, err)
+
+	// webhook 不存在
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT "id","to_do_list_id" FROM "to_do_webhooks"`).WillReturnRows(sqlmock.NewRows([]string{"id", "to_do_list_id"}))
+	mock.ExpectRollback()
+	_, err = svc.Create(db, 3, "通知", models.AutomationTriggerDetailCreated, models.AutomationConditions{}, actions)
+	assert.EqualError(t, err, "自動化規則的動作設定錯誤：第 1 個動作的 webhook_id 4 不存在")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func automationTarget() *models.AutomationTarget {
+	return &models.AutomationTarget{
+		Detail: &models.TodoListDetails{
+			ID:         5,
+			TodoListID: 3,
+			Name:       "修正登入錯誤",
+			Labels:     []models.TodoLabels{{ID: 7}},
+			Users:      []models.User{{ID: 4}},
+		},
+		TypeID: 1,
+	}
+}
+
+func TestTodoAutomationService_Test(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(2))
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	svc := services.NewTodoAutomationService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	owner := uint(2)
+	listID := 3
+	rule := &models.TodoAutomationRules{
+		ID:         1,
+		TodoListID: &listID,
+		Trigger:    models.AutomationTriggerDetailCreated,
+		Conditions: models.AutomationConditions{TypeIDs: []int{1, 2}, LabelIDs: []int{8}},
+		Actions: []models.AutomationAction{
+			{Type: models.AutomationActionAssignUser, UserID: 6},
+			{Type: models.AutomationActionAddComment, Body: "已自動指派"},
+		},
+	}
+	rule.CreatedBy = &owner
+
+	// 標籤不符合
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(rule, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	result, err := svc.Test(db, 1, 5)
+	require.NoError(t, err)
+	assert.True(t, result.InScope)
+	assert.False(t, result.Matched)
+	require.Len(t, result.Checks, 2)
+	assert.Equal(t, models.AutomationCheck{Condition: "type_ids", Expected: []int{1, 2}, Actual: []int{1}, Passed: true}, result.Checks[0])
+	assert.Equal(t, models.AutomationCheck{Condition: "label_ids", Expected: []int{8}, Actual: []int{7}, Passed: false}, result.Checks[1])
+	assert.Empty(t, result.Actions)
+
+	rule.Conditions.LabelIDs = []int{7, 8}
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(rule, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	result, err = svc.Test(db, 1, 5)
+	require.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, []string{"加入負責人 6", "新增留言「已自動指派」"}, result.Actions)
+
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(rule, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 9).Return(nil, gorm.ErrRecordNotFound)
+	_, err = svc.Test(db, 1, 9)
+	assert.EqualError(t, err, "to_do_list_detail_id 不存在")
+}
+
+func TestTodoAutomationService_HandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	var actionCtx context.Context
+	svc := services.NewTodoAutomationService(ctx, mockRepo, nil).
+		WithActions(func(ctx context.Context) *services.AutomationActions {
+			actionCtx = ctx
+			return &services.AutomationActions{Webhooks: services.NewTodoWebhookService(ctx, mockWebhookRepo)}
+		})
+	db, mock := setupMockDB(t)
+
+	owner := uint(2)
+	rule := &models.TodoAutomationRules{
+		ID:      1,
+		Name:    "通知外部系統",
+		Trigger: models.AutomationTriggerStatusChanged,
+		Actions: []models.AutomationAction{{Type: models.AutomationActionCallWebhook, WebhookID: 4}},
+	}
+	rule.CreatedBy = &owner
+	listID := 3
+	data, _ := json.Marshal(automationTarget().Detail)
+	event := events.Event{ID: "evt1", Type: models.EventDetailStatusChanged, TodoListID: &listID, Data: data}
+
+	// 與自動化無關的事件
+	require.NoError(t, svc.HandleEvent(db, events.Event{Type: models.EventCommentCreated}))
+
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).Return(true, nil)
+	// 不限 TodoList 的 webhook
+	mock.ExpectQuery(`SELECT "id","to_do_list_id" FROM "to_do_webhooks"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "to_do_list_id"}).AddRow(4, nil))
+	mockWebhookRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), 4).Return(&models.TodoWebhooks{ID: 4, Active: true}, nil)
+	mockWebhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, deliveries []*models.TodoWebhookDeliveries) error {
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, models.EventAutomationTriggered, deliveries[0].Event)
+			assert.NotEqual(t, "evt1", deliveries[0].EventID)
+			var payload models.WebhookPayload
+			require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+			assert.Equal(t, 2, *payload.ActorID)
+			return nil
+		})
+	mockRepo.EXPECT().UpdateExecution(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, execution *models.TodoAutomationExecutions) error {
+			assert.Equal(t, "evt1", execution.TriggerKey)
+			assert.Equal(t, models.AutomationExecutionSucceeded, execution.Status)
+			assert.Equal(t, []string{"送出 webhook 4"}, execution.Results)
+			return nil
+		})
+	mock.ExpectCommit()
+	require.NoError(t, svc.HandleEvent(db, event))
+	assert.Equal(t, 1, utils.AutomationDepth(actionCtx))
+
+	// webhook 已停用：交易復原後記錄失敗
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).Return(true, nil)
+	mock.ExpectQuery(`SELECT "id","to_do_list_id" FROM "to_do_webhooks"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "to_do_list_id"}).AddRow(4, nil))
+	mockWebhookRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), 4).Return(&models.TodoWebhooks{ID: 4}, nil)
+	mock.ExpectRollback()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, execution *models.TodoAutomationExecutions) (bool, error) {
+			assert.Equal(t, models.AutomationExecutionFailed, execution.Status)
+			assert.Equal(t, "第 1 個動作 call_webhook 失敗：webhook 已刪除或停用", execution.Error)
+			return true, nil
+		})
+	event.Depth = 2
+	require.NoError(t, svc.HandleEvent(db, event))
+	assert.Equal(t, 3, utils.AutomationDepth(actionCtx))
+
+	// 連鎖層數到達上限時不執行
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, execution *models.TodoAutomationExecutions) (bool, error) {
+			assert.Equal(t, models.AutomationExecutionSkipped, execution.Status)
+			assert.Equal(t, 3, execution.Depth)
+			return true, nil
+		})
+	event.Depth = 3
+	require.NoError(t, svc.HandleEvent(db, event))
+
+	// 寫入紀錄失敗時交給 relay 重送
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(nil, errors.New("連線中斷"))
+	assert.EqualError(t, svc.HandleEvent(db, event), "連線中斷")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAutomationService_HandleEvent_WebhookForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	mockAuthRepo := mocks.NewMockAuthRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoAutomationService(ctx, mockRepo, mockAuthRepo).
+		WithActions(func(ctx context.Context) *services.AutomationActions {
+			return &services.AutomationActions{Webhooks: services.NewTodoWebhookService(ctx, mockWebhookRepo)}
+		})
+	db, mock := setupMockDB(t)
+
+	owner := uint(2)
+	listID := 3
+	rule := &models.TodoAutomationRules{
+		ID:         1,
+		TodoListID: &listID,
+		Name:       "通知外部系統",
+		Trigger:    models.AutomationTriggerStatusChanged,
+		Actions:    []models.AutomationAction{{Type: models.AutomationActionCallWebhook, WebhookID: 4}},
+	}
+	rule.CreatedBy = &owner
+	data, _ := json.Marshal(automationTarget().Detail)
+	event := events.Event{ID: "evt1", Type: models.EventDetailStatusChanged, TodoListID: &listID, Data: data}
+
+	// 建立規則後 webhook 被改到其他 TodoList，建立者不是 Admin：不送出，記錄為失敗
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).Return(true, nil)
+	mock.ExpectQuery(`SELECT "id","to_do_list_id" FROM "to_do_webhooks"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "to_do_list_id"}).AddRow(4, 5))
+	mockAuthRepo.EXPECT().HasRole(ctx, gomock.Any(), 2, "Admin").Return(false, nil)
+	mockWebhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mock.ExpectRollback()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, execution *models.TodoAutomationExecutions) (bool, error) {
+			assert.Equal(t, models.AutomationExecutionFailed, execution.Status)
+			assert.Equal(t, "第 1 個動作 call_webhook 失敗："+services.ErrAutomationWebhookForbidden.Error(), execution.Error)
+			return true, nil
+		})
+	require.NoError(t, svc.HandleEvent(db, event))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoAutomationService_HandleEvent_AssignWatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoAutomationRepository(ctrl)
+	mockDetailsRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockWatcherRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	svc := services.NewTodoAutomationService(ctx, mockRepo, nil).
+		WithActions(func(ctx context.Context) *services.AutomationActions {
+			return &services.AutomationActions{
+				Details: services.NewTodoListDetailsService(ctx, mockDetailsRepo).
+					WithWatchers(services.NewTodoWatcherService(ctx, mockWatcherRepo)),
+			}
+		})
+	db, mock := setupMockDB(t)
+	mock.MatchExpectationsInOrder(false)
+
+	owner := uint(2)
+	rule := &models.TodoAutomationRules{
+		ID:      1,
+		Name:    "指派審查者",
+		Trigger: models.AutomationTriggerStatusChanged,
+		Actions: []models.AutomationAction{{Type: models.AutomationActionAssignUser, UserID: 9}},
+	}
+	rule.CreatedBy = &owner
+	listID := 3
+	data, _ := json.Marshal(automationTarget().Detail)
+	event := events.Event{ID: "evt1", Type: models.EventDetailStatusChanged, TodoListID: &listID, Data: data}
+
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), models.EventDetailStatusChanged, 3).Return([]*models.TodoAutomationRules{rule}, nil)
+	mockRepo.EXPECT().FindTarget(ctx, gomock.Any(), 5).Return(automationTarget(), nil)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().CreateExecution(ctx, gomock.Any(), gomock.Any()).Return(true, nil)
+	mockDetailsRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), 5).Return(&models.TodoListDetails{ID: 5, TodoListID: 3}, nil)
+	mock.ExpectQuery(`SELECT .* FROM "users" JOIN`).WillReturnRows(sqlmock.NewRows([]string{"id", "account"}).AddRow(4, "dave"))
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE id IN`).WillReturnRows(sqlmock.NewRows([]string{"id", "account"}).AddRow(4, "dave").AddRow(9, "ivy"))
+	mock.ExpectExec(`UPDATE "to_do_list_details" SET "updated_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`INSERT INTO "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	// 規則指派的負責人在同一個交易內自動關注，原本的負責人不重複寫入
+	var watched []int
+	mockWatcherRepo.EXPECT().AddDetailWatchers(gomock.Any(), gomock.Any(), 5, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, _ int, userIDs []int) error {
+			watched = append(watched, userIDs...)
+			return nil
+		})
+	mockRepo.EXPECT().UpdateExecution(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, execution *models.TodoAutomationExecutions) error {
+			assert.Equal(t, models.AutomationExecutionSucceeded, execution.Status)
+			return nil
+		})
+	mock.ExpectCommit()
+
+	require.NoError(t, svc.HandleEvent(db, event))
+	assert.Equal(t, []int{9}, watched)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
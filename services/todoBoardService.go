@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// defaultColumnNames 尚未設定看板時，依狀態產生的預設欄位名稱
+var defaultColumnNames = map[string]string{
+	models.DetailStatusTodo:       "待辦",
+	models.DetailStatusInProgress: "進行中",
+	models.DetailStatusDone:       "完成",
+}
+
+type TodoBoardService struct {
+	ctx  context.Context
+	repo interfaces.TodoBoardRepository
+}
+
+func NewTodoBoardService(ctx context.Context, repo interfaces.TodoBoardRepository) *TodoBoardService {
+	return &TodoBoardService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// BoardColumnInput 看板欄位設定
+type BoardColumnInput struct {
+	Name     string
+	Status   string
+	WIPLimit *int
+}
+
+// BoardTransitionInput 允許的狀態轉換
+type BoardTransitionInput struct {
+	From string
+	To   string
+}
+
+// Configure 以新的欄位與轉換規則取代 TodoList 原本的看板設定
+func (s *TodoBoardService) Configure(db *gorm.DB, listID int, columns []BoardColumnInput, transitions []BoardTransitionInput) (*models.Board, error) {
+	seen := map[string]bool{}
+	rows := make([]*models.TodoBoardColumns, 0, len(columns))
+	for i, c := range columns {
+		if !isDetailStatus(c.Status) {
+			return nil, fmt.Errorf("無效的狀態：%s", c.Status)
+		}
+		if seen[c.Status] {
+			return nil, fmt.Errorf("狀態 %s 只能對應一個欄位", c.Status)
+		}
+		if c.WIPLimit != nil && *c.WIPLimit < 1 {
+			return nil, errors.New("wip_limit 必須大於 0")
+		}
+		seen[c.Status] = true
+		rows = append(rows, &models.TodoBoardColumns{
+			TodoListID: listID,
+			Name:       c.Name,
+			Status:     c.Status,
+			WIPLimit:   c.WIPLimit,
+			SortOrder:  i + 1,
+		})
+	}
+	// 沒有欄位的狀態在看板上看不到，卡片會消失
+	for _, status := range models.DetailStatuses {
+		if len(rows) > 0 && !seen[status] {
+			return nil, fmt.Errorf("缺少狀態 %s 的欄位", status)
+		}
+	}
+
+	edges := make([]*models.TodoBoardTransitions, 0, len(transitions))
+	for _, t := range transitions {
+		if !isDetailStatus(t.From) || !isDetailStatus(t.To) || t.From == t.To {
+			return nil, fmt.Errorf("無效的狀態轉換：%s -> %s", t.From, t.To)
+		}
+		edges = append(edges, &models.TodoBoardTransitions{TodoListID: listID, FromStatus: t.From, ToStatus: t.To})
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("to_do_list_id 不存在")
+		}
+
+		if err := s.repo.ReplaceColumns(s.ctx, tx, listID, rows); err != nil {
+			return err
+		}
+		return s.repo.ReplaceTransitions(s.ctx, tx, listID, edges)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Show(db, listID, 1, 0)
+}
+
+// Show 取得看板：每個欄位帶總數與依位置排序的第 page 頁卡片；pageSize 為 0 時只回傳欄位與總數
+func (s *TodoBoardService) Show(db *gorm.DB, listID int, page, pageSize int) (*models.Board, error) {
+	columns, err := s.repo.FindColumns(s.ctx, db, listID)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		columns = defaultColumns(listID)
+	}
+
+	transitions, err := s.repo.FindTransitions(s.ctx, db, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountByStatus(s.ctx, db, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{
+		TodoListID:  listID,
+		Columns:     make([]models.BoardColumn, 0, len(columns)),
+		Transitions: make([]models.TodoBoardTransitions, 0, len(transitions)),
+	}
+	for _, t := range transitions {
+		board.Transitions = append(board.Transitions, *t)
+	}
+
+	for _, c := range columns {
+		column := models.BoardColumn{
+			TodoBoardColumns: *c,
+			Count:            counts[c.Status],
+			Page:             page,
+			PageSize:         pageSize,
+			Cards:            []models.TodoListDetails{},
+		}
+		if pageSize > 0 && column.Count > 0 {
+			cards, err := s.repo.FindCards(s.ctx, db, listID, c.Status, page, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			column.Cards = cards
+		}
+		board.Columns = append(board.Columns, column)
+	}
+
+	return board, nil
+}
+
+// CheckMove 檢查卡片從 from 移到 to 是否符合轉換規則與 WIP 上限，必須在交易中呼叫。
+// 會鎖定目標欄位，同時移入同一欄的請求會依序檢查，不會一起超過上限。
+// 以 item.TodoListID 的看板檢查；from 與 to 相同（例如移到其他 TodoList）時只檢查 WIP 上限
+func (s *TodoBoardService) CheckMove(tx *gorm.DB, item *models.TodoListDetails, from, to string) error {
+	transitions, err := s.repo.FindTransitions(s.ctx, tx, item.TodoListID)
+	if err != nil {
+		return err
+	}
+	if len(transitions) > 0 && from != to {
+		allowed := false
+		for _, t := range transitions {
+			if t.FromStatus == from && t.ToStatus == to {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("不允許從 %s 移到 %s", from, to)
+		}
+	}
+
+	column, err := s.repo.LockColumnByStatus(s.ctx, tx, item.TodoListID, to)
+	if err != nil {
+		return err
+	}
+	if column == nil || column.WIPLimit == nil {
+		return nil
+	}
+
+	count, err := s.repo.CountInStatus(s.ctx, tx, item.TodoListID, to, item.ID)
+	if err != nil {
+		return err
+	}
+	if count >= int64(*column.WIPLimit) {
+		return fmt.Errorf("欄位「%s」已達 WIP 上限 %d", column.Name, *column.WIPLimit)
+	}
+	return nil
+}
+
+func defaultColumns(listID int) []*models.TodoBoardColumns {
+	columns := make([]*models.TodoBoardColumns, len(models.DetailStatuses))
+	for i, status := range models.DetailStatuses {
+		columns[i] = &models.TodoBoardColumns{
+			TodoListID: listID,
+			Name:       defaultColumnNames[status],
+			Status:     status,
+			SortOrder:  i + 1,
+		}
+	}
+	return columns
+}
+
+func isDetailStatus(status string) bool {
+	for _, s := range models.DetailStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
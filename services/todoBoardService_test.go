@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestTodoBoardService_Configure_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := services.NewTodoBoardService(context.Background(), mocks.NewMockTodoBoardRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	_, err := svc.Configure(db, 1, []services.BoardColumnInput{
+		{Name: "待辦", Status: models.DetailStatusTodo},
+		{Name: "也是待辦", Status: models.DetailStatusTodo},
+	}, nil)
+	assert.EqualError(t, err, "狀態 todo 只能對應一個欄位")
+
+	_, err = svc.Configure(db, 1, []services.BoardColumnInput{
+		{Name: "待辦", Status: models.DetailStatusTodo},
+		{Name: "完成", Status: models.DetailStatusDone},
+	}, nil)
+	assert.EqualError(t, err, "缺少狀態 in_progress 的欄位")
+
+	_, err = svc.Configure(db, 1, nil, []services.BoardTransitionInput{{From: "todo", To: "todo"}})
+	assert.EqualError(t, err, "無效的狀態轉換：todo -> todo")
+}
+
+func TestTodoBoardService_Show_DefaultColumns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoBoardRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoBoardService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindColumns(ctx, gomock.Any(), 1).Return(nil, nil)
+	mockRepo.EXPECT().FindTransitions(ctx, gomock.Any(), 1).Return(nil, nil)
+	mockRepo.EXPECT().CountByStatus(ctx, gomock.Any(), 1).Return(map[string]int64{
+		models.DetailStatusTodo: 25,
+		models.DetailStatusDone: 1,
+	}, nil)
+	mockRepo.EXPECT().FindCards(ctx, gomock.Any(), 1, models.DetailStatusTodo, 2, 20).
+		Return([]models.TodoListDetails{{ID: 21}, {ID: 22}, {ID: 23}, {ID: 24}, {ID: 25}}, nil)
+	mockRepo.EXPECT().FindCards(ctx, gomock.Any(), 1, models.DetailStatusDone, 2, 20).
+		Return(nil, nil)
+
+	board, err := svc.Show(db, 1, 2, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, board.Columns, 3)
+	assert.Equal(t, "待辦", board.Columns[0].Name)
+	assert.Equal(t, int64(25), board.Columns[0].Count)
+	assert.Len(t, board.Columns[0].Cards, 5)
+	// 沒有卡片的欄位不查詢
+	assert.Equal(t, int64(0), board.Columns[1].Count)
+	assert.Empty(t, board.Columns[1].Cards)
+}
+
+func TestTodoBoardService_CheckMove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoBoardRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoBoardService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	item := &models.TodoListDetails{ID: 7, TodoListID: 1, Status: models.DetailStatusTodo}
+	transitions := []*models.TodoBoardTransitions{
+		{TodoListID: 1, FromStatus: models.DetailStatusTodo, ToStatus: models.DetailStatusInProgress},
+		{TodoListID: 1, FromStatus: models.DetailStatusInProgress, ToStatus: models.DetailStatusDone},
+	}
+	column := &models.TodoBoardColumns{Name: "進行中", Status: models.DetailStatusInProgress, WIPLimit: intPtr(2)}
+
+	// 不在轉換規則內
+	mockRepo.EXPECT().FindTransitions(ctx, gomock.Any(), 1).Return(transitions, nil)
+	err := svc.CheckMove(db, item, models.DetailStatusTodo, models.DetailStatusDone)
+	assert.EqualError(t, err, "不允許從 todo 移到 done")
+
+	// 已達 WIP 上限
+	mockRepo.EXPECT().FindTransitions(ctx, gomock.Any(), 1).Return(transitions, nil)
+	mockRepo.EXPECT().LockColumnByStatus(ctx, gomock.Any(), 1, models.DetailStatusInProgress).Return(column, nil)
+	mockRepo.EXPECT().CountInStatus(ctx, gomock.Any(), 1, models.DetailStatusInProgress, 7).Return(int64(2), nil)
+	err = svc.CheckMove(db, item, models.DetailStatusTodo, models.DetailStatusInProgress)
+	assert.EqualError(t, err, "欄位「進行中」已達 WIP 上限 2")
+
+	// 尚有空間
+	mockRepo.EXPECT().FindTransitions(ctx, gomock.Any(), 1).Return(transitions, nil)
+	mockRepo.EXPECT().LockColumnByStatus(ctx, gomock.Any(), 1, models.DetailStatusInProgress).Return(column, nil)
+	mockRepo.EXPECT().CountInStatus(ctx, gomock.Any(), 1, models.DetailStatusInProgress, 7).Return(int64(1), nil)
+	assert.NoError(t, svc.CheckMove(db, item, models.DetailStatusTodo, models.DetailStatusInProgress))
+}
@@ -0,0 +1,577 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"todolist/models"
+	"todolist/pkg/caldav"
+	"todolist/pkg/ical"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCalDAVNotResource          = errors.New("只有 TodoList 底下的項目可以讀取、寫入與刪除")
+	ErrCalDAVPreconditionFailed   = errors.New("項目已被修改或已存在，請重新同步")
+	ErrCalDAVUnsupportedComponent = errors.New("只支援 VTODO")
+	ErrCalDAVInvalidUID           = errors.New("UID 必須與檔名相同（<UID>.ics），且不可使用 detail-<ID>@todolist")
+	ErrCalDAVReportTarget         = errors.New("REPORT 只支援 TodoList 集合")
+)
+
+// CalDAVRoot CalDAV 的根路徑，日曆程式由 /.well-known/caldav 轉址到這裡
+const CalDAVRoot = "/caldav/"
+
+const calDAVContentType = "text/calendar; charset=utf-8; component=VTODO"
+
+// davKind 路徑對應的資源
+type davKind int
+
+const (
+	davRoot       davKind = iota // /caldav/
+	davPrincipal                 // /caldav/principals/<帳號>/
+	davHome                      // /caldav/calendars/
+	davCollection                // /caldav/calendars/<TodoList ID>/
+	davResource                  // /caldav/calendars/<TodoList ID>/<UID>.ics
+)
+
+type davPath struct {
+	kind    davKind
+	account string
+	listID  int
+	uid     string
+}
+
+// parseDAVPath 解析 CalDAVRoot 之後的路徑，不認得的路徑回傳 gorm.ErrRecordNotFound
+func parseDAVPath(p string) (davPath, error) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "":
+		return davPath{kind: davRoot}, nil
+	case segments[0] == "principals" && len(segments) == 2:
+		return davPath{kind: davPrincipal, account: segments[1]}, nil
+	case segments[0] == "calendars" && len(segments) == 1:
+		return davPath{kind: davHome}, nil
+	case segments[0] == "calendars" && len(segments) <= 3:
+		listID, err := strconv.Atoi(segments[1])
+		if err != nil || listID <= 0 {
+			return davPath{}, gorm.ErrRecordNotFound
+		}
+		if len(segments) == 2 {
+			return davPath{kind: davCollection, listID: listID}, nil
+		}
+		uid, ok := strings.CutSuffix(segments[2], ".ics")
+		if !ok || uid == "" {
+			return davPath{}, gorm.ErrRecordNotFound
+		}
+		return davPath{kind: davResource, listID: listID, uid: uid}, nil
+	}
+	return davPath{}, gorm.ErrRecordNotFound
+}
+
+// detailIDFromUID 沒有 UID 的項目以 detail-<ID>@todolist 輸出（見 calendarUID）
+func detailIDFromUID(uid string) (int, bool) {
+	rest, ok := strings.CutPrefix(uid, "detail-")
+	if !ok {
+		return 0, false
+	}
+	rest, ok = strings.CutSuffix(rest, "@todolist")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(rest)
+	return id, err == nil && id > 0
+}
+
+func collectionHref(listID int) string {
+	return CalDAVRoot + "calendars/" + strconv.Itoa(listID) + "/"
+}
+
+func resourceHref(item *models.TodoListDetails) string {
+	return collectionHref(item.TodoListID) + url.PathEscape(calendarUID(item)) + ".ics"
+}
+
+// etagMatches 比對 If-Match / If-None-Match 的清單
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// TodoCalDAVService 以 CalDAV 同步 TodoList：每個 TodoList 為一個只有 VTODO 的日曆集合，每個項目為一個資源。
+// 資源以 UID 命名，沒有 UID 的項目沿用訂閱輸出的 detail-<ID>@todolist
+type TodoCalDAVService struct {
+	ctx     context.Context
+	repo    interfaces.TodoCalendarRepository
+	users   interfaces.AuthRepository
+	details *TodoListDetailsService
+}
+
+// NewTodoCalDAVService 寫入時以 details 建立與修改項目，與一般操作走相同的檢查
+func NewTodoCalDAVService(ctx context.Context, repo interfaces.TodoCalendarRepository, users interfaces.AuthRepository, details *TodoListDetailsService) *TodoCalDAVService {
+	return &TodoCalDAVService{
+		ctx:     ctx,
+		repo:    repo,
+		users:   users,
+		details: details,
+	}
+}
+
+// currentUser 目前登入（HTTP Basic 驗證）的使用者
+func (s *TodoCalDAVService) currentUser(db *gorm.DB) (*models.User, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	return s.users.FindByID(s.ctx, db, userID)
+}
+
+func principalHref(user *models.User) string {
+	return CalDAVRoot + "principals/" + url.PathEscape(user.Account) + "/"
+}
+
+// object 將項目輸出為只包含一個 VTODO 的 iCalendar；DATETIME 只精確到秒，ETag 以內容計算而不用更新時間
+func (s *TodoCalDAVService) object(item *models.TodoListDetails) (*models.CalDAVObject, error) {
+	cal := ical.NewComponent(ical.Calendar)
+	cal.Set("VERSION", "2.0")
+	cal.Set("PRODID", feedProdID)
+	cal.Add(feedComponent(item, FeedComponentTodo))
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, cal); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return &models.CalDAVObject{
+		Href:         resourceHref(item),
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: item.UpdatedAt,
+		Data:         buf.Bytes(),
+	}, nil
+}
+
+// findResource 依路徑取出項目，不存在時回傳 nil
+func (s *TodoCalDAVService) findResource(db *gorm.DB, target davPath) (*models.TodoListDetails, error) {
+	if id, ok := detailIDFromUID(target.uid); ok {
+		return s.repo.FindResourceByID(s.ctx, db, target.listID, id)
+	}
+	return s.repo.FindResourceByUID(s.ctx, db, target.listID, target.uid)
+}
+
+// resourceProps 資源的屬性；allprop 不包含 calendar-data
+func resourceProps(object *models.CalDAVObject) []caldav.Prop {
+	return []caldav.Prop{
+		caldav.ResourceTypeProp(false, false, false),
+		caldav.TextProp(caldav.PropGetETag, object.ETag),
+		caldav.TextProp(caldav.PropGetContentType, calDAVContentType),
+		caldav.TextProp(caldav.PropGetLastModified, object.LastModified.UTC().Format(http.TimeFormat)),
+	}
+}
+
+func (s *TodoCalDAVService) addResource(ms *caldav.Multistatus, item *models.TodoListDetails, req *caldav.Propfind) error {
+	object, err := s.object(item)
+	if err != nil {
+		return err
+	}
+	props := resourceProps(object)
+	if !req.AllProp {
+		props = append(props, caldav.TextProp(caldav.PropCalendarData, string(object.Data)))
+	}
+	ms.Add(caldav.Select(object.Href, props, req))
+	return nil
+}
+
+// addCollection 加入日曆集合；CTag 由名稱與所有資源的 ETag 計算，任何項目變動都會改變
+func (s *TodoCalDAVService) addCollection(ms *caldav.Multistatus, user *models.User, list *models.TodoList, items []*models.TodoListDetails, req *caldav.Propfind) error {
+	hash := sha256.New()
+	io.WriteString(hash, list.Name)
+	for _, item := range items {
+		object, err := s.object(item)
+		if err != nil {
+			return err
+		}
+		io.WriteString(hash, "|"+object.ETag)
+	}
+	ctag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	ms.Add(caldav.Select(collectionHref(list.ID), []caldav.Prop{
+		caldav.ResourceTypeProp(true, true, false),
+		caldav.TextProp(caldav.PropDisplayName, list.Name),
+		caldav.TextProp(caldav.PropGetCTag, ctag),
+		caldav.TextProp(caldav.PropGetETag, ctag),
+		caldav.ComponentSetProp(ical.Todo),
+		caldav.ReportSetProp(),
+		caldav.HrefProp(caldav.PropCurrentUserPrincipal, principalHref(user)),
+	}, req))
+	return nil
+}
+
+// Propfind 依路徑回傳屬性；depth 為 0 時只回傳路徑本身，否則一併回傳下一層
+func (s *TodoCalDAVService) Propfind(db *gorm.DB, path string, depth int, req *caldav.Propfind) (*caldav.Multistatus, error) {
+	target, err := parseDAVPath(path)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.currentUser(db)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &caldav.Multistatus{}
+	switch target.kind {
+	case davRoot:
+		ms.Add(caldav.Select(CalDAVRoot, []caldav.Prop{
+			caldav.ResourceTypeProp(true, false, false),
+			caldav.HrefProp(caldav.PropCurrentUserPrincipal, principalHref(user)),
+		}, req))
+
+	case davPrincipal:
+		// 只能查看自己的 principal
+		if !strings.EqualFold(target.account, user.Account) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		ms.Add(caldav.Select(principalHref(user), []caldav.Prop{
+			caldav.ResourceTypeProp(true, false, true),
+			caldav.TextProp(caldav.PropDisplayName, user.Account),
+			caldav.HrefProp(caldav.PropPrincipalURL, principalHref(user)),
+			caldav.HrefProp(caldav.PropCalendarHomeSet, CalDAVRoot+"calendars/"),
+			caldav.HrefProp(caldav.PropCurrentUserPrincipal, principalHref(user)),
+		}, req))
+
+	case davHome:
+		ms.Add(caldav.Select(CalDAVRoot+"calendars/", []caldav.Prop{
+			caldav.ResourceTypeProp(true, false, false),
+			caldav.HrefProp(caldav.PropCurrentUserPrincipal, principalHref(user)),
+		}, req))
+		if depth == 0 {
+			break
+		}
+
+		lists, err := s.repo.FindLists(s.ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int, len(lists))
+		for i, list := range lists {
+			ids[i] = list.ID
+		}
+		items, err := s.repo.FindResources(s.ctx, db, ids)
+		if err != nil {
+			return nil, err
+		}
+		byList := map[int][]*models.TodoListDetails{}
+		for _, item := range items {
+			byList[item.TodoListID] = append(byList[item.TodoListID], item)
+		}
+		for _, list := range lists {
+			if err := s.addCollection(ms, user, list, byList[list.ID], req); err != nil {
+				return nil, err
+			}
+		}
+
+	case davCollection:
+		list, err := s.repo.FindList(s.ctx, db, target.listID)
+		if err != nil {
+			return nil, err
+		}
+		if list == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		items, err := s.repo.FindResources(s.ctx, db, []int{list.ID})
+		if err != nil {
+			return nil, err
+		}
+		if err := s.addCollection(ms, user, list, items, req); err != nil {
+			return nil, err
+		}
+		if depth == 0 {
+			break
+		}
+		for _, item := range items {
+			if err := s.addResource(ms, item, req); err != nil {
+				return nil, err
+			}
+		}
+
+	case davResource:
+		item, err := s.findResource(db, target)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		if err := s.addResource(ms, item, req); err != nil {
+			return nil, err
+		}
+	}
+	return ms, nil
+}
+
+// Report 處理日曆集合的 calendar-query 與 calendar-multiget；time-range 以到期時間判斷，沒有到期時間的項目一律符合
+func (s *TodoCalDAVService) Report(db *gorm.DB, path string, req *caldav.Report) (*caldav.Multistatus, error) {
+	target, err := parseDAVPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if target.kind != davCollection {
+		return nil, ErrCalDAVReportTarget
+	}
+	list, err := s.repo.FindList(s.ctx, db, target.listID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	ms := &caldav.Multistatus{}
+	if req.Multiget {
+		for _, href := range req.Hrefs {
+			item, err := s.resolveHref(db, target.listID, href)
+			if err != nil {
+				return nil, err
+			}
+			if item == nil {
+				ms.Add(caldav.Response{Href: href, Status: http.StatusNotFound})
+				continue
+			}
+			if err := s.addResource(ms, item, &req.Propfind); err != nil {
+				return nil, err
+			}
+		}
+		return ms, nil
+	}
+
+	// 集合中只有 VTODO
+	if req.Filter.Component != "" && req.Filter.Component != ical.Todo {
+		return ms, nil
+	}
+	items, err := s.repo.FindResources(s.ctx, db, []int{list.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if !req.Filter.Match(item.DueAt) {
+			continue
+		}
+		if err := s.addResource(ms, item, &req.Propfind); err != nil {
+			return nil, err
+		}
+	}
+	return ms, nil
+}
+
+// resolveHref 取出 multiget 的 href 對應的項目，不在集合中或不存在時回傳 nil
+func (s *TodoCalDAVService) resolveHref(db *gorm.DB, listID int, href string) (*models.TodoListDetails, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, nil
+	}
+	rest, ok := strings.CutPrefix(u.Path, CalDAVRoot)
+	if !ok {
+		return nil, nil
+	}
+	target, err := parseDAVPath(rest)
+	if err != nil || target.kind != davResource || target.listID != listID {
+		return nil, nil
+	}
+	return s.findResource(db, target)
+}
+
+// Get 取出資源的內容
+func (s *TodoCalDAVService) Get(db *gorm.DB, path string) (*models.CalDAVObject, error) {
+	target, err := parseDAVPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if target.kind != davResource {
+		return nil, ErrCalDAVNotResource
+	}
+
+	item, err := s.findResource(db, target)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.object(item)
+}
+
+// checkPreconditions If-None-Match: * 表示只能新增，If-Match 表示只能修改用戶端手上的版本
+func checkPreconditions(current *models.CalDAVObject, ifMatch, ifNoneMatch string) error {
+	if ifNoneMatch != "" && current != nil && etagMatches(ifNoneMatch, current.ETag) {
+		return ErrCalDAVPreconditionFailed
+	}
+	if ifMatch != "" && (current == nil || !etagMatches(ifMatch, current.ETag)) {
+		return ErrCalDAVPreconditionFailed
+	}
+	return nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// Put 新增或修改資源，回傳寫入後的內容與是否為新增；只讀取第一個 VTODO，週期任務的例外不處理，
+// 已取消（CANCELLED）視為完成
+func (s *TodoCalDAVService) Put(db *gorm.DB, path string, ifMatch, ifNoneMatch string, body io.Reader) (*models.CalDAVObject, bool, error) {
+	target, err := parseDAVPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if target.kind != davResource {
+		return nil, false, ErrCalDAVNotResource
+	}
+
+	cal, err := ical.Decode(body)
+	if err != nil {
+		return nil, false, err
+	}
+	var todo *ical.Component
+	for _, c := range cal.Components {
+		if c.Name == ical.Todo {
+			todo = c
+			break
+		}
+	}
+	if todo == nil {
+		return nil, false, ErrCalDAVUnsupportedComponent
+	}
+	parsed, err := newCalendarItem(todo)
+	if err != nil {
+		return nil, false, err
+	}
+	if parsed.uid != target.uid {
+		return nil, false, ErrCalDAVInvalidUID
+	}
+	if strings.EqualFold(todo.Text("STATUS"), "CANCELLED") {
+		parsed.status = models.DetailStatusDone
+	}
+
+	var result *models.CalDAVObject
+	created := false
+	err = db.Transaction(func(tx *gorm.DB) error {
+		list, err := s.repo.FindList(s.ctx, tx, target.listID)
+		if err != nil {
+			return err
+		}
+		if list == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		existing, err := s.findResource(tx, target)
+		if err != nil {
+			return err
+		}
+		var current *models.CalDAVObject
+		if existing != nil {
+			if current, err = s.object(existing); err != nil {
+				return err
+			}
+		}
+		if err := checkPreconditions(current, ifMatch, ifNoneMatch); err != nil {
+			return err
+		}
+
+		status := models.DetailStatusTodo
+		var id int
+		if existing == nil {
+			// 沒有 UID 的項目保留 detail-<ID>@todolist 的名稱
+			if _, ok := detailIDFromUID(parsed.uid); ok {
+				return ErrCalDAVInvalidUID
+			}
+			detail, err := s.details.Create(tx, list.ID, parsed.name, parsed.detail, []int{})
+			if err != nil {
+				return err
+			}
+			if err := s.repo.SetCalendarFields(s.ctx, tx, detail.ID, &parsed.uid, parsed.dueAt); err != nil {
+				return err
+			}
+			id = detail.ID
+			created = true
+		} else {
+			id = existing.ID
+			status = existing.Status
+			if existing.Name != parsed.name || existing.Detail != parsed.detail {
+				if _, err := s.details.Edit(tx, id, parsed.name, parsed.detail); err != nil {
+					return err
+				}
+			}
+			if !sameTime(existing.DueAt, parsed.dueAt) {
+				if _, err := s.details.SetDue(tx, id, parsed.dueAt); err != nil {
+					return err
+				}
+			}
+		}
+		if status != parsed.status {
+			if _, err := s.details.ChangeStatus(tx, id, parsed.status); err != nil {
+				return err
+			}
+		}
+
+		// 重新讀取，ETag 才會與之後的 GET 相同
+		updated, err := s.findResource(tx, target)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return gorm.ErrRecordNotFound
+		}
+		result, err = s.object(updated)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result, created, nil
+}
+
+// Delete 刪除資源，有 If-Match 時只刪除用戶端手上的版本
+func (s *TodoCalDAVService) Delete(db *gorm.DB, path string, ifMatch string) error {
+	target, err := parseDAVPath(path)
+	if err != nil {
+		return err
+	}
+	if target.kind != davResource {
+		return ErrCalDAVNotResource
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.findResource(tx, target)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return gorm.ErrRecordNotFound
+		}
+		current, err := s.object(item)
+		if err != nil {
+			return err
+		}
+		if err := checkPreconditions(current, ifMatch, ""); err != nil {
+			return err
+		}
+
+		_, err = s.details.Delete(tx, item.ID)
+		return err
+	})
+}
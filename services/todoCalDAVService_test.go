@@ -0,0 +1,319 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/caldav"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func encodeMultistatus(t *testing.T, ms *caldav.Multistatus) string {
+	var buf bytes.Buffer
+	require.NoError(t, ms.Encode(&buf))
+	return buf.String()
+}
+
+func TestTodoCalDAVService_Propfind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	mockUsers.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.User{ID: 1, Account: "amy"}, nil).AnyTimes()
+	svc := services.NewTodoCalDAVService(ctx, mockRepo, mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	due := time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC)
+	uid := "abc@example.com"
+	items := []*models.TodoListDetails{
+		{ID: 3, TodoListID: 2, Name: "寫報告", Status: models.DetailStatusTodo},
+		{ID: 4, TodoListID: 2, Name: "開會", Status: models.DetailStatusTodo, DueAt: &due, ICalUID: &uid},
+	}
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2, Name: "工作"}, nil).Times(2)
+	mockRepo.EXPECT().FindResources(ctx, gomock.Any(), []int{2}).Return(items, nil).Times(2)
+
+	ms, err := svc.Propfind(db, "/calendars/2/", 1, &caldav.Propfind{AllProp: true})
+	require.NoError(t, err)
+	require.Len(t, ms.Responses, 3)
+	out := encodeMultistatus(t, ms)
+	assert.Contains(t, out, "<d:href>/caldav/calendars/2/</d:href>")
+	assert.Contains(t, out, `<c:supported-calendar-component-set><c:comp name="VTODO"/>`)
+	assert.Contains(t, out, "<d:href>/caldav/calendars/2/detail-3@todolist.ics</d:href>")
+	assert.Contains(t, out, "<d:href>/caldav/calendars/2/abc@example.com.ics</d:href>")
+	// allprop 不包含 calendar-data
+	assert.NotContains(t, out, "calendar-data")
+
+	// 內容不變時 CTag 不變
+	again, err := svc.Propfind(db, "/calendars/2/", 0, &caldav.Propfind{Props: []xml.Name{caldav.PropGetCTag}})
+	require.NoError(t, err)
+	require.Len(t, again.Responses, 1)
+	assert.Equal(t, ms.Responses[0].Props[2], again.Responses[0].Props[0])
+
+	// 只能查看自己的 principal
+	ms, err = svc.Propfind(db, "/principals/AMY/", 0, &caldav.Propfind{Props: []xml.Name{caldav.PropCalendarHomeSet}})
+	require.NoError(t, err)
+	assert.Contains(t, encodeMultistatus(t, ms), "<c:calendar-home-set><d:href>/caldav/calendars/</d:href></c:calendar-home-set>")
+	_, err = svc.Propfind(db, "/principals/bob/", 0, &caldav.Propfind{AllProp: true})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = svc.Propfind(db, "/calendars/x/", 0, &caldav.Propfind{AllProp: true})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestTodoCalDAVService_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	mockUsers.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.User{ID: 1, Account: "amy"}, nil).AnyTimes()
+	svc := services.NewTodoCalDAVService(ctx, mockRepo, mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	january := time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC)
+	march := time.Date(2026, 3, 5, 1, 0, 0, 0, time.UTC)
+	items := []*models.TodoListDetails{
+		{ID: 3, TodoListID: 2, Name: "沒有到期時間", Status: models.DetailStatusTodo},
+		{ID: 4, TodoListID: 2, Name: "一月", Status: models.DetailStatusDone, DueAt: &january},
+		{ID: 5, TodoListID: 2, Name: "三月", Status: models.DetailStatusTodo, DueAt: &march},
+	}
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2, Name: "工作"}, nil).Times(3)
+	mockRepo.EXPECT().FindResources(ctx, gomock.Any(), []int{2}).Return(items, nil)
+
+	props := caldav.Propfind{Props: []xml.Name{caldav.PropGetETag, caldav.PropCalendarData}}
+	ms, err := svc.Report(db, "/calendars/2/", &caldav.Report{
+		Propfind: props,
+		Filter: caldav.Filter{
+			Component: "VTODO",
+			Start:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:       time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, ms.Responses, 2)
+	assert.Equal(t, "/caldav/calendars/2/detail-3@todolist.ics", ms.Responses[0].Href)
+	assert.Equal(t, "/caldav/calendars/2/detail-4@todolist.ics", ms.Responses[1].Href)
+	assert.Contains(t, encodeMultistatus(t, ms), "STATUS:COMPLETED")
+
+	// 集合中只有 VTODO
+	ms, err = svc.Report(db, "/calendars/2/", &caldav.Report{Propfind: props, Filter: caldav.Filter{Component: "VEVENT"}})
+	require.NoError(t, err)
+	assert.Empty(t, ms.Responses)
+
+	mockRepo.EXPECT().FindResourceByID(ctx, gomock.Any(), 2, 5).Return(items[2], nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, "gone").Return(nil, nil)
+	ms, err = svc.Report(db, "/calendars/2/", &caldav.Report{
+		Multiget: true,
+		Propfind: props,
+		Hrefs: []string{
+			"/caldav/calendars/2/detail-5%40todolist.ics",
+			"/caldav/calendars/2/gone.ics",
+			"/caldav/calendars/9/other.ics",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, ms.Responses, 3)
+	assert.Equal(t, "/caldav/calendars/2/detail-5@todolist.ics", ms.Responses[0].Href)
+	assert.Equal(t, 404, ms.Responses[1].Status)
+	assert.Equal(t, 404, ms.Responses[2].Status)
+
+	_, err = svc.Report(db, "/calendars/", &caldav.Report{Propfind: props})
+	assert.ErrorIs(t, err, services.ErrCalDAVReportTarget)
+}
+
+func TestTodoCalDAVService_Put(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockDetails := mocks.NewMockTodoListDetailsRepository(ctrl)
+	svc := services.NewTodoCalDAVService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), services.NewTodoListDetailsService(ctx, mockDetails))
+	db, mock := setupMockDB(t)
+
+	uid := "abc@example.com"
+	existing := &models.TodoListDetails{ID: 4, TodoListID: 2, Name: "開會", Status: models.DetailStatusTodo, ICalUID: &uid}
+	body := func(uid, due string) *strings.Reader {
+		return strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:" + uid + "\r\nSUMMARY:開會\r\n" + due + "END:VTODO\r\nEND:VCALENDAR\r\n")
+	}
+
+	// 資源已存在時 If-None-Match: * 失敗
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	mock.ExpectRollback()
+	_, _, err := svc.Put(db, "/calendars/2/abc@example.com.ics", "", "*", body(uid, ""))
+	assert.ErrorIs(t, err, services.ErrCalDAVPreconditionFailed)
+
+	// 以舊的 ETag 修改
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	mock.ExpectRollback()
+	_, _, err = svc.Put(db, "/calendars/2/abc@example.com.ics", `"stale"`, "", body(uid, ""))
+	assert.ErrorIs(t, err, services.ErrCalDAVPreconditionFailed)
+
+	_, _, err = svc.Put(db, "/calendars/2/other.ics", "", "", body(uid, ""))
+	assert.ErrorIs(t, err, services.ErrCalDAVInvalidUID)
+	_, _, err = svc.Put(db, "/calendars/2/e.ics", "", "", strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:e\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.ErrorIs(t, err, services.ErrCalDAVUnsupportedComponent)
+	_, _, err = svc.Put(db, "/calendars/2/", "", "", body(uid, ""))
+	assert.ErrorIs(t, err, services.ErrCalDAVNotResource)
+
+	// 只有到期時間不同，只更新到期時間
+	due := time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC)
+	updated := *existing
+	updated.DueAt = &due
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDetails.EXPECT().FindByID(ctx, gomock.Any(), 4).Return(&models.TodoListDetails{ID: 4, TodoListID: 2}, nil)
+	mockDetails.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, item *models.TodoListDetails) error {
+			assert.True(t, due.Equal(*item.DueAt))
+			return nil
+		})
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(&updated, nil)
+	mock.ExpectCommit()
+
+	object, created, err := svc.Put(db, "/calendars/2/abc@example.com.ics", "", "", body(uid, "DUE:20260105T010000Z\r\n"))
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Contains(t, string(object.Data), "DUE:20260105T010000Z\r\n")
+
+	// 回傳的 ETag 與之後 GET 取得的相同
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(&updated, nil)
+	got, err := svc.Get(db, "/calendars/2/abc@example.com.ics")
+	require.NoError(t, err)
+	assert.Equal(t, object.ETag, got.ETag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoCalDAVService_Put_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockDetails := mocks.NewMockTodoListDetailsRepository(ctrl)
+	svc := services.NewTodoCalDAVService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), services.NewTodoListDetailsService(ctx, mockDetails))
+	db, mock := setupMockDB(t)
+
+	uid := "new@example.com"
+	due := time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC)
+	data := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:" + uid + "\r\nSUMMARY:新任務\r\nDUE:20260105T010000Z\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+	// 新的 UID 用 detail-<ID>@todolist 的格式時不建立，避免與既有項目的名稱混淆
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByID(ctx, gomock.Any(), 2, 99).Return(nil, nil)
+	mock.ExpectRollback()
+	_, _, err := svc.Put(db, "/calendars/2/detail-99@todolist.ics", "", "",
+		strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:detail-99@todolist\r\nSUMMARY:x\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"))
+	assert.ErrorIs(t, err, services.ErrCalDAVInvalidUID)
+
+	// 資源不存在時 If-Match 失敗，不會建立
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(nil, nil)
+	mock.ExpectRollback()
+	_, _, err = svc.Put(db, "/calendars/2/new@example.com.ics", `"abc"`, "", strings.NewReader(data))
+	assert.ErrorIs(t, err, services.ErrCalDAVPreconditionFailed)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindList(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2}, nil)
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(nil, nil)
+
+	// TodoListDetailsService.Create
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockDetails.EXPECT().LastPosition(ctx, gomock.Any(), 2, 0).Return("", nil)
+	mockDetails.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, detail *models.TodoListDetails) error {
+			assert.Equal(t, "新任務", detail.Name)
+			detail.ID = 30
+			return nil
+		})
+	mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`UPDATE "to_do_list_details" SET "updated_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// 保留用戶端的 UID，之後以同一個路徑存取
+	mockRepo.EXPECT().SetCalendarFields(ctx, gomock.Any(), 30, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, _ int, gotUID *string, dueAt *time.Time) error {
+			assert.Equal(t, uid, *gotUID)
+			assert.True(t, due.Equal(*dueAt))
+			return nil
+		})
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).
+		Return(&models.TodoListDetails{ID: 30, TodoListID: 2, Name: "新任務", Status: models.DetailStatusTodo, DueAt: &due, ICalUID: &uid}, nil)
+	mock.ExpectCommit()
+
+	object, created, err := svc.Put(db, "/calendars/2/new@example.com.ics", "", "*", strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "/caldav/calendars/2/new@example.com.ics", object.Href)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoCalDAVService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockDetails := mocks.NewMockTodoListDetailsRepository(ctrl)
+	svc := services.NewTodoCalDAVService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), services.NewTodoListDetailsService(ctx, mockDetails))
+	db, mock := setupMockDB(t)
+
+	uid := "abc@example.com"
+	path := "/calendars/2/abc@example.com.ics"
+	existing := &models.TodoListDetails{ID: 4, TodoListID: 2, Name: "開會", Status: models.DetailStatusTodo, ICalUID: &uid}
+
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	current, err := svc.Get(db, path)
+	require.NoError(t, err)
+
+	// If-Match 與目前的版本不同：用戶端手上的資料已過期，不刪除
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	mock.ExpectRollback()
+	assert.ErrorIs(t, svc.Delete(db, path, `"stale", W/"older"`), services.ErrCalDAVPreconditionFailed)
+
+	// 已經不存在
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, "gone").Return(nil, nil)
+	mock.ExpectRollback()
+	assert.ErrorIs(t, svc.Delete(db, "/calendars/2/gone.ics", ""), gorm.ErrRecordNotFound)
+
+	assert.ErrorIs(t, svc.Delete(db, "/calendars/2/", ""), services.ErrCalDAVNotResource)
+
+	// If-Match 符合（弱比對）時軟刪除項目
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindResourceByUID(ctx, gomock.Any(), 2, uid).Return(existing, nil)
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDetails.EXPECT().FindByID(ctx, gomock.Any(), 4).Return(existing, nil)
+	mockDetails.EXPECT().SoftDelete(ctx, gomock.Any(), existing).Return(nil)
+	mock.ExpectCommit()
+	assert.NoError(t, svc.Delete(db, path, `"stale", W/`+current.ETag))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"todolist/models"
+	"todolist/pkg/ical"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFeedTokenForbidden   = errors.New("只有建立者或管理員可以撤銷訂閱憑證")
+	ErrInvalidFeedComponent = errors.New("component 必須為 vtodo 或 vevent")
+	ErrCalendarEmpty        = errors.New("檔案中沒有 VTODO 或 VEVENT")
+	ErrCalDAVTokenList      = errors.New("CalDAV 憑證不可限定 TodoList")
+)
+
+// 訂閱輸出的元件類型
+const (
+	FeedComponentTodo  = "vtodo"
+	FeedComponentEvent = "vevent"
+)
+
+// feedTokenBytes 憑證的亂數長度
+const feedTokenBytes = 32
+
+// feedTouchInterval 日曆程式會頻繁輪詢，最後使用時間只在間隔超過這個時間時才更新
+const feedTouchInterval = time.Hour
+
+const feedProdID = "-//todolist//calendar//ZH-TW"
+
+type TodoCalendarService struct {
+	ctx     context.Context
+	repo    interfaces.TodoCalendarRepository
+	users   interfaces.AuthRepository
+	details *TodoListDetailsService
+}
+
+// NewTodoCalendarService 匯入 .ics 時以 details 建立項目，與一般新增走相同的檢查
+func NewTodoCalendarService(ctx context.Context, repo interfaces.TodoCalendarRepository, users interfaces.AuthRepository, details *TodoListDetailsService) *TodoCalendarService {
+	return &TodoCalendarService{
+		ctx:     ctx,
+		repo:    repo,
+		users:   users,
+		details: details,
+	}
+}
+
+// hashFeedToken 資料庫只保存憑證的 SHA-256
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateToken 為目前的使用者建立憑證，原始憑證只在這裡回傳一次；
+// 訂閱憑證的 listID 為 0 時訂閱指派給自己的項目，CalDAV 憑證可以存取所有 TodoList
+func (s *TodoCalendarService) CreateToken(db *gorm.DB, listID int, name string, scope string) (*models.TodoFeedTokens, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	if scope == "" {
+		scope = models.TokenScopeFeed
+	}
+	if scope == models.TokenScopeCalDAV && listID > 0 {
+		return nil, ErrCalDAVTokenList
+	}
+
+	token := &models.TodoFeedTokens{UserID: userID, Name: name, Scope: scope}
+	if listID > 0 {
+		var count int64
+		if err := db.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errors.New("to_do_list_id 不存在")
+		}
+		token.TodoListID = &listID
+	}
+
+	raw := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token.Token = base64.RawURLEncoding.EncodeToString(raw)
+	token.TokenHash = hashFeedToken(token.Token)
+
+	if err := s.repo.Create(s.ctx, db, token); err != nil {
+		return nil, err
+	}
+	if scope == models.TokenScopeFeed {
+		token.FeedURL = fmt.Sprintf("/api/feeds/%s.ics", token.Token)
+	}
+	return token, nil
+}
+
+// IndexTokens 目前使用者的憑證，不含原始憑證
+func (s *TodoCalendarService) IndexTokens(db *gorm.DB) ([]*models.TodoFeedTokens, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	return s.repo.FindByUser(s.ctx, db, userID)
+}
+
+// RevokeToken 撤銷後使用該憑證的訂閱立即失效，只有建立者或 Admin 可以撤銷
+func (s *TodoCalendarService) RevokeToken(db *gorm.DB, id int) (*models.TodoFeedTokens, error) {
+	token, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOwner(s.ctx, db, s.users, token.CreatedBy, ErrFeedTokenForbidden); err != nil {
+		return nil, err
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	now := time.Now()
+	token.RevokedAt = &now
+	if err := s.repo.Update(s.ctx, db, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Feed 以原始憑證取得訂閱的範圍與版本；憑證不存在或已撤銷時回傳 gorm.ErrRecordNotFound
+func (s *TodoCalendarService) Feed(db *gorm.DB, raw string) (*models.CalendarFeed, error) {
+	token, err := s.repo.FindActiveByHash(s.ctx, db, hashFeedToken(raw), models.TokenScopeFeed)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{Token: token}
+	if token.TodoListID != nil {
+		var list models.TodoList
+		if err := db.Select("id", "name").First(&list, *token.TodoListID).Error; err != nil {
+			return nil, err
+		}
+		feed.Name = list.Name
+	} else {
+		user, err := s.users.FindByID(s.ctx, db, token.UserID)
+		if err != nil {
+			return nil, err
+		}
+		feed.Name = user.Account + " 的任務"
+	}
+
+	count, lastModified, err := s.repo.FeedVersion(s.ctx, db, token)
+	if err != nil {
+		return nil, err
+	}
+	feed.Count = count
+	feed.LastModified = token.CreatedAt
+	if lastModified != nil && lastModified.After(feed.LastModified) {
+		feed.LastModified = *lastModified
+	}
+
+	if err := s.touch(db, token); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// AuthenticateBasic 以 HTTP Basic 的帳號與 CalDAV 憑證驗證，帳號需為憑證的建立者；
+// 驗證失敗時回傳 gorm.ErrRecordNotFound
+func (s *TodoCalendarService) AuthenticateBasic(db *gorm.DB, account string, raw string) (*models.User, error) {
+	token, err := s.repo.FindActiveByHash(s.ctx, db, hashFeedToken(raw), models.TokenScopeCalDAV)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.FindByID(s.ctx, db, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Account, account) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := s.touch(db, token); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// touch 記錄最後使用時間，間隔不到 feedTouchInterval 時不更新
+func (s *TodoCalendarService) touch(db *gorm.DB, token *models.TodoFeedTokens) error {
+	now := time.Now()
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) <= feedTouchInterval {
+		return nil
+	}
+	return s.repo.Touch(s.ctx, db, token.ID, now)
+}
+
+// ETag 訂閱內容的版本；項目數、最後修改時間、名稱與元件類型都相同時內容不變
+func (s *TodoCalendarService) ETag(feed *models.CalendarFeed, component string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%d|%d|%s",
+		feed.Token.ID, component, feed.Count, feed.LastModified.UnixNano(), feed.Name)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WriteFeed 輸出 iCalendar，有到期時間的項目以 VTODO 或 VEVENT 表示
+func (s *TodoCalendarService) WriteFeed(db *gorm.DB, feed *models.CalendarFeed, component string, w io.Writer) error {
+	if component != FeedComponentTodo && component != FeedComponentEvent {
+		return ErrInvalidFeedComponent
+	}
+
+	items, err := s.repo.FindFeedItems(s.ctx, db, feed.Token)
+	if err != nil {
+		return err
+	}
+
+	cal := ical.NewComponent(ical.Calendar)
+	cal.Set("VERSION", "2.0")
+	cal.Set("PRODID", feedProdID)
+	cal.Set("CALSCALE", "GREGORIAN")
+	cal.Set("METHOD", "PUBLISH")
+	cal.SetText("X-WR-CALNAME", feed.Name)
+	for _, item := range items {
+		cal.Add(feedComponent(item, component))
+	}
+	return ical.Encode(w, cal)
+}
+
+// calendarUID 項目的 UID；沿用匯入時的 UID，讓日曆程式辨識為同一個項目
+func calendarUID(item *models.TodoListDetails) string {
+	if item.ICalUID != nil {
+		return *item.ICalUID
+	}
+	return fmt.Sprintf("detail-%d@todolist", item.ID)
+}
+
+// feedComponent 一個項目；沒有到期時間時不輸出 DUE、DTSTART
+func feedComponent(item *models.TodoListDetails, component string) *ical.Component {
+	var c *ical.Component
+	if component == FeedComponentEvent {
+		c = ical.NewComponent(ical.Event)
+	} else {
+		c = ical.NewComponent(ical.Todo)
+	}
+	c.SetText("UID", calendarUID(item))
+	c.SetTime("DTSTAMP", item.UpdatedAt)
+	c.SetTime("CREATED", item.CreatedAt)
+	c.SetTime("LAST-MODIFIED", item.UpdatedAt)
+	c.SetText("SUMMARY", item.Name)
+	if item.Detail != "" {
+		c.SetText("DESCRIPTION", item.Detail)
+	}
+
+	if component == FeedComponentEvent {
+		// 到期時間只是一個時間點，不佔用行事曆的忙碌時段
+		if item.DueAt != nil {
+			c.SetTime("DTSTART", *item.DueAt)
+		}
+		c.Set("TRANSP", "TRANSPARENT")
+		return c
+	}
+
+	if item.DueAt != nil {
+		c.SetTime("DUE", *item.DueAt)
+	}
+	switch item.Status {
+	case models.DetailStatusDone:
+		c.Set("STATUS", "COMPLETED")
+		c.Set("PERCENT-COMPLETE", "100")
+		if item.CompletedAt != nil {
+			c.SetTime("COMPLETED", *item.CompletedAt)
+		}
+	case models.DetailStatusInProgress:
+		c.Set("STATUS", "IN-PROCESS")
+	default:
+		c.Set("STATUS", "NEEDS-ACTION")
+	}
+	return c
+}
+
+// calendarItem 從 .ics 讀出的一個項目
+type calendarItem struct {
+	uid    string
+	name   string
+	detail string
+	status string
+	dueAt  *time.Time
+}
+
+// parseCalendarItems 取出 VTODO 與 VEVENT；已取消的、同一個檔案中重複 UID 的（例如週期事件的例外）略過
+func parseCalendarItems(r io.Reader) ([]calendarItem, int, error) {
+	cal, err := ical.Decode(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var items []calendarItem
+	skipped := 0
+	seen := map[string]bool{}
+	for _, c := range cal.Components {
+		if c.Name != ical.Todo && c.Name != ical.Event {
+			continue
+		}
+
+		uid := strings.TrimSpace(c.Text("UID"))
+		if strings.EqualFold(c.Text("STATUS"), "CANCELLED") || (uid != "" && seen[uid]) {
+			skipped++
+			continue
+		}
+		seen[uid] = true
+
+		item, err := newCalendarItem(c)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, skipped, nil
+}
+
+// newCalendarItem 讀出一個 VTODO 或 VEVENT 的名稱、說明、狀態與到期時間
+func newCalendarItem(c *ical.Component) (calendarItem, error) {
+	item := calendarItem{
+		uid:    truncate(strings.TrimSpace(c.Text("UID")), 255),
+		name:   truncate(strings.TrimSpace(c.Text("SUMMARY")), 255),
+		detail: c.Text("DESCRIPTION"),
+		status: models.DetailStatusTodo,
+	}
+	if item.name == "" {
+		item.name = "（無標題）"
+	}
+	if c.Name == ical.Todo {
+		status := strings.ToUpper(c.Text("STATUS"))
+		switch {
+		case status == "COMPLETED" || c.Get("COMPLETED") != nil:
+			item.status = models.DetailStatusDone
+		case status == "IN-PROCESS":
+			item.status = models.DetailStatusInProgress
+		}
+	}
+
+	// VTODO 以 DUE 為到期時間，沒有時用 DTSTART；VEVENT 用 DTSTART
+	due := c.Get("DUE")
+	if due == nil || c.Name == ical.Event {
+		due = c.Get("DTSTART")
+	}
+	if due != nil {
+		t, allDay, err := due.Time()
+		if err != nil {
+			return item, fmt.Errorf("%w: %s 的時間無法解析：%v", ical.ErrInvalidCalendar, item.name, err)
+		}
+		// 全天的項目以伺服器時區當天 00:00 為到期時間
+		if allDay {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		}
+		item.dueAt = &t
+	}
+	return item, nil
+}
+
+// Import 將 .ics 中的 VTODO、VEVENT 匯入 TodoList；在同一個交易內完成，
+// 已匯入過相同 UID 的項目略過，因此同一個檔案可以重複匯入
+func (s *TodoCalendarService) Import(db *gorm.DB, listID int, r io.Reader) (*models.CalendarImportResult, error) {
+	items, skipped, err := parseCalendarItems(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 && skipped == 0 {
+		return nil, ErrCalendarEmpty
+	}
+	if len(items) > importMaxRows {
+		return nil, ErrImportTooManyRows
+	}
+
+	result := &models.CalendarImportResult{Created: []int{}, Skipped: skipped}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var uids []string
+		for _, item := range items {
+			if item.uid != "" {
+				uids = append(uids, item.uid)
+			}
+		}
+		imported, err := s.repo.FindImportedUIDs(s.ctx, tx, listID, uids)
+		if err != nil {
+			return err
+		}
+		exists := make(map[string]bool, len(imported))
+		for _, uid := range imported {
+			exists[uid] = true
+		}
+
+		for _, item := range items {
+			if item.uid != "" && exists[item.uid] {
+				result.Skipped++
+				continue
+			}
+
+			detail, err := s.details.Create(tx, listID, item.name, item.detail, []int{})
+			if err != nil {
+				return err
+			}
+			var uid *string
+			if item.uid != "" {
+				uid = &item.uid
+			}
+			if err := s.repo.SetCalendarFields(s.ctx, tx, detail.ID, uid, item.dueAt); err != nil {
+				return err
+			}
+			if item.status != models.DetailStatusTodo {
+				if _, err := s.details.ChangeStatus(tx, detail.ID, item.status); err != nil {
+					return err
+				}
+			}
+			result.Created = append(result.Created, detail.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,219 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTodoCalendarService_TokenAndFeedVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	var stored *models.TodoFeedTokens
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, token *models.TodoFeedTokens) error {
+			token.ID = 5
+			token.CreatedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			stored = token
+			return nil
+		})
+
+	created, err := svc.CreateToken(db, 0, "手機", "")
+	require.NoError(t, err)
+	assert.Len(t, created.Token, 43)
+	assert.Equal(t, models.TokenScopeFeed, stored.Scope)
+	assert.Equal(t, "/api/feeds/"+created.Token+".ics", created.FeedURL)
+	// 只保存雜湊
+	assert.Len(t, stored.TokenHash, 64)
+	assert.NotContains(t, stored.TokenHash, created.Token)
+
+	active := &models.TodoFeedTokens{ID: 5, UserID: 1}
+	active.CreatedAt = stored.CreatedAt
+	lastModified := time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().FindActiveByHash(ctx, gomock.Any(), stored.TokenHash, models.TokenScopeFeed).Return(active, nil).Times(2)
+	mockUsers.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.User{ID: 1, Account: "amy"}, nil).Times(2)
+	mockRepo.EXPECT().FeedVersion(ctx, gomock.Any(), active).Return(int64(2), &lastModified, nil)
+	mockRepo.EXPECT().FeedVersion(ctx, gomock.Any(), active).Return(int64(1), &lastModified, nil)
+	// 第一次取用時記錄使用時間，之後一小時內不再更新
+	mockRepo.EXPECT().Touch(ctx, gomock.Any(), 5, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, _ int, at time.Time) error {
+			active.LastUsedAt = &at
+			return nil
+		})
+
+	feed, err := svc.Feed(db, created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "amy 的任務", feed.Name)
+	assert.Equal(t, lastModified, feed.LastModified)
+	etag := svc.ETag(feed, services.FeedComponentTodo)
+	assert.NotEqual(t, etag, svc.ETag(feed, services.FeedComponentEvent))
+
+	// 項目被刪除或移出範圍時，最後修改時間不變但版本不同
+	feed, err = svc.Feed(db, created.Token)
+	require.NoError(t, err)
+	assert.NotEqual(t, etag, svc.ETag(feed, services.FeedComponentTodo))
+}
+
+func TestTodoCalendarService_WriteFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), nil)
+	db, _ := setupMockDB(t)
+
+	due := time.Date(2026, 1, 5, 9, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	completed := due.Add(-time.Hour)
+	uid := "abc@example.com"
+	items := []*models.TodoListDetails{
+		{ID: 3, Name: "寫報告", Detail: "第一段, 第二段", Status: models.DetailStatusDone, DueAt: &due, CompletedAt: &completed},
+		{ID: 4, Name: "開會", Status: models.DetailStatusTodo, DueAt: &due, ICalUID: &uid},
+	}
+	feed := &models.CalendarFeed{Token: &models.TodoFeedTokens{ID: 5, UserID: 1}, Name: "amy 的任務"}
+	mockRepo.EXPECT().FindFeedItems(ctx, gomock.Any(), feed.Token).Return(items, nil).Times(2)
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.WriteFeed(db, feed, services.FeedComponentTodo, &buf))
+	out := buf.String()
+	assert.Contains(t, out, "X-WR-CALNAME:amy 的任務\r\n")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VTODO\r\n"))
+	assert.Contains(t, out, "UID:detail-3@todolist\r\n")
+	assert.Contains(t, out, `DESCRIPTION:第一段\, 第二段`+"\r\n")
+	assert.Contains(t, out, "DUE:20260105T010000Z\r\nSTATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\nCOMPLETED:20260105T000000Z\r\n")
+	assert.Contains(t, out, "UID:abc@example.com\r\n")
+	assert.Contains(t, out, "STATUS:NEEDS-ACTION\r\n")
+
+	buf.Reset()
+	require.NoError(t, svc.WriteFeed(db, feed, services.FeedComponentEvent, &buf))
+	assert.Equal(t, 2, strings.Count(buf.String(), "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, buf.String(), "DTSTART:20260105T010000Z\r\nTRANSP:TRANSPARENT\r\n")
+
+	assert.ErrorIs(t, svc.WriteFeed(db, feed, "vjournal", &buf), services.ErrInvalidFeedComponent)
+}
+
+func TestTodoCalendarService_Import_SkipsKnownItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	details := services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl))
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), details)
+	db, mock := setupMockDB(t)
+
+	data := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Taipei\r\nEND:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\nUID:weekly@example.com\r\nSUMMARY:週會\r\nDTSTART;TZID=Asia/Taipei:20260105T090000\r\nEND:VEVENT\r\n" +
+		// 週期事件的例外與原事件同一個 UID
+		"BEGIN:VEVENT\r\nUID:weekly@example.com\r\nRECURRENCE-ID:20260112T010000Z\r\nSUMMARY:週會（改期）\r\nDTSTART:20260113T010000Z\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\nUID:cancelled@example.com\r\nSUMMARY:不做了\r\nSTATUS:CANCELLED\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().FindImportedUIDs(ctx, gomock.Any(), 2, []string{"weekly@example.com"}).Return([]string{"weekly@example.com"}, nil)
+	mock.ExpectCommit()
+
+	result, err := svc.Import(db, 2, strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Equal(t, 3, result.Skipped)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = svc.Import(db, 2, strings.NewReader("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	assert.ErrorIs(t, err, services.ErrCalendarEmpty)
+}
+
+func TestTodoCalendarService_Feed_RevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl), nil)
+	db, _ := setupMockDB(t)
+
+	// 已撤銷的憑證查不到，不會讀取項目也不會記錄使用時間
+	mockRepo.EXPECT().FindActiveByHash(ctx, gomock.Any(), gomock.Any(), models.TokenScopeFeed).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := svc.Feed(db, "revoked-token")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestTodoCalendarService_AuthenticateBasic_AccountMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	token := &models.TodoFeedTokens{ID: 5, UserID: 1, Scope: models.TokenScopeCalDAV}
+	mockRepo.EXPECT().FindActiveByHash(ctx, gomock.Any(), gomock.Any(), models.TokenScopeCalDAV).Return(token, nil).Times(2)
+	mockUsers.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.User{ID: 1, Account: "amy"}, nil).Times(2)
+
+	// 憑證屬於 amy，以其他帳號登入時視為驗證失敗
+	_, err := svc.AuthenticateBasic(db, "bob", "caldav-token")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 帳號不分大小寫
+	mockRepo.EXPECT().Touch(ctx, gomock.Any(), 5, gomock.Any()).Return(nil)
+	user, err := svc.AuthenticateBasic(db, "AMY", "caldav-token")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+}
+
+func TestTodoCalendarService_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCalendarRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCalendarService(ctx, mockRepo, mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	other := &models.TodoFeedTokens{ID: 6, UserID: 2}
+	other.CreatedBy = uintPtr(2)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 6).Return(other, nil)
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 1, "Admin").Return(false, nil)
+
+	_, err := svc.RevokeToken(db, 6)
+	assert.ErrorIs(t, err, services.ErrFeedTokenForbidden)
+
+	// 已撤銷的憑證不再更新，保留原本的撤銷時間
+	revokedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	own := &models.TodoFeedTokens{ID: 5, UserID: 1, RevokedAt: &revokedAt}
+	own.CreatedBy = uintPtr(1)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 5).Return(own, nil)
+
+	token, err := svc.RevokeToken(db, 5)
+	require.NoError(t, err)
+	assert.Equal(t, revokedAt, *token.RevokedAt)
+}
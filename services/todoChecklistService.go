@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+	"todolist/models"
+	"todolist/pkg/markdown"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+type TodoChecklistService struct {
+	ctx  context.Context
+	repo interfaces.TodoChecklistRepository
+}
+
+func NewTodoChecklistService(ctx context.Context, repo interfaces.TodoChecklistRepository) *TodoChecklistService {
+	return &TodoChecklistService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+func (s *TodoChecklistService) Create(db *gorm.DB, detailID int, name string) (*models.TodoChecklistItems, error) {
+	data := &models.TodoChecklistItems{
+		TodoListDetailID: detailID,
+		Name:             name,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 檢查 detailID 是否存在
+		var count int64
+		if err := tx.Model(&models.TodoListDetails{}).Where("id = ?", detailID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("to_do_list_detail_id 不存在")
+		}
+
+		// 新項目排在最後面
+		sortOrder, err := s.repo.NextSortOrder(s.ctx, tx, detailID)
+		if err != nil {
+			return err
+		}
+		data.SortOrder = sortOrder
+
+		return s.repo.Create(s.ctx, tx, data)
+	})
+
+	return data, err
+}
+
+func (s *TodoChecklistService) Edit(db *gorm.DB, id int, name string, isDone bool) (*models.TodoChecklistItems, error) {
+	updated := &models.TodoChecklistItems{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 取得原本資料
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		item.Name = name
+		setDone(item, isDone)
+		if err := s.updateItem(tx, item); err != nil {
+			return err
+		}
+
+		// 由說明產生的項目，一併回寫說明中對應的那一行
+		if item.MarkdownIndex != nil {
+			if err := s.writeBackTask(tx, item); err != nil {
+				return err
+			}
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+func (s *TodoChecklistService) Delete(db *gorm.DB, id int) (*models.TodoChecklistItems, error) {
+	var deleted *models.TodoChecklistItems
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 先取得資料
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// 軟刪除
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		// 由說明產生的項目，從說明中移除那一行，後面項目的順序跟著調整
+		if item.MarkdownIndex != nil {
+			src, err := s.repo.LockDetailMarkdown(s.ctx, tx, item.TodoListDetailID)
+			if err != nil {
+				return err
+			}
+			if src, ok := markdown.RemoveTask(src, *item.MarkdownIndex); ok {
+				if err := s.repo.UpdateDetailMarkdown(s.ctx, tx, item.TodoListDetailID, src); err != nil {
+					return err
+				}
+				if err := s.SyncFromMarkdown(tx, item.TodoListDetailID, src); err != nil {
+					return err
+				}
+			}
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// SyncFromMarkdown 依說明中的 - [ ] 待辦同步 checklist 項目，必須在交易中呼叫；手動新增的項目不受影響。
+// 先依名稱對應既有項目，調整順序不會遺失完成時間；剩下的依原本順序視為改名，多的刪除、少的新增。
+func (s *TodoChecklistService) SyncFromMarkdown(tx *gorm.DB, detailID int, src string) error {
+	tasks := markdown.Tasks(src)
+
+	items, err := s.repo.FindByDetailID(s.ctx, tx, detailID)
+	if err != nil {
+		return err
+	}
+	var pool []*models.TodoChecklistItems
+	for _, item := range items {
+		if item.MarkdownIndex != nil {
+			pool = append(pool, item)
+		}
+	}
+	sort.SliceStable(pool, func(i, j int) bool { return *pool[i].MarkdownIndex < *pool[j].MarkdownIndex })
+
+	assigned := make([]*models.TodoChecklistItems, len(tasks))
+	used := make(map[int]bool, len(pool))
+	for i, task := range tasks {
+		for _, item := range pool {
+			if !used[item.ID] && item.Name == taskName(task) {
+				assigned[i] = item
+				used[item.ID] = true
+				break
+			}
+		}
+	}
+	for i := range tasks {
+		if assigned[i] != nil {
+			continue
+		}
+		for _, item := range pool {
+			if !used[item.ID] {
+				assigned[i] = item
+				used[item.ID] = true
+				break
+			}
+		}
+	}
+
+	for _, item := range pool {
+		if !used[item.ID] {
+			if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, task := range tasks {
+		index := i
+		item := assigned[i]
+		if item == nil {
+			sortOrder, err := s.repo.NextSortOrder(s.ctx, tx, detailID)
+			if err != nil {
+				return err
+			}
+			item = &models.TodoChecklistItems{
+				TodoListDetailID: detailID,
+				Name:             taskName(task),
+				SortOrder:        sortOrder,
+				MarkdownIndex:    &index,
+			}
+			setDone(item, task.Done)
+			if err := s.repo.Create(s.ctx, tx, item); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if item.Name == taskName(task) && item.IsDone == task.Done && *item.MarkdownIndex == index {
+			continue
+		}
+		item.Name = taskName(task)
+		item.MarkdownIndex = &index
+		setDone(item, task.Done)
+		if err := s.updateItem(tx, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBackTask 將項目的名稱與完成狀態寫回說明中對應的待辦
+func (s *TodoChecklistService) writeBackTask(tx *gorm.DB, item *models.TodoChecklistItems) error {
+	src, err := s.repo.LockDetailMarkdown(s.ctx, tx, item.TodoListDetailID)
+	if err != nil {
+		return err
+	}
+	src, ok := markdown.SetTask(src, *item.MarkdownIndex, item.Name, item.IsDone)
+	if !ok {
+		return nil
+	}
+	return s.repo.UpdateDetailMarkdown(s.ctx, tx, item.TodoListDetailID, src)
+}
+
+// updateItem is_done / done_at / markdown_index 可能被改回零值，需用 Select 指定欄位強制更新
+func (s *TodoChecklistService) updateItem(tx *gorm.DB, item *models.TodoChecklistItems) error {
+	return s.repo.Update(s.ctx, tx.Select("name", "is_done", "done_at", "markdown_index", "updated_at", "updated_by"), item)
+}
+
+// setDone 完成狀態有變動時才更新完成時間
+func setDone(item *models.TodoChecklistItems, done bool) {
+	if done == item.IsDone {
+		return
+	}
+	item.IsDone = done
+	if done {
+		now := time.Now()
+		item.DoneAt = &now
+	} else {
+		item.DoneAt = nil
+	}
+}
+
+// taskName checklist 項目名稱最長 255 字
+func taskName(task markdown.Task) string {
+	if runes := []rune(task.Text); len(runes) > 255 {
+		return string(runes[:255])
+	}
+	return task.Text
+}
+
+// Reorder 依傳入的 ID 順序重新排列項目，ID 必須涵蓋該 TodoListDetails 的所有項目
+func (s *TodoChecklistService) Reorder(db *gorm.DB, detailID int, ids []int) ([]*models.TodoChecklistItems, error) {
+	var result []*models.TodoChecklistItems
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		items, err := s.repo.FindByDetailID(s.ctx, tx, detailID)
+		if err != nil {
+			return err
+		}
+
+		// 驗證 ids 與現有項目完全一致
+		byID := make(map[int]*models.TodoChecklistItems, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+		if len(ids) != len(items) {
+			return errors.New("排序項目數量不符")
+		}
+
+		seen := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			if byID[id] == nil || seen[id] {
+				return errors.New("排序項目不屬於此 TodoListDetails 或重複")
+			}
+			seen[id] = true
+		}
+
+		// 依新順序更新排序值
+		for i, id := range ids {
+			item := byID[id]
+			if item.SortOrder == i+1 {
+				result = append(result, item)
+				continue
+			}
+			if err := s.repo.UpdateSortOrder(s.ctx, tx, id, i+1); err != nil {
+				return err
+			}
+			item.SortOrder = i + 1
+			result = append(result, item)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
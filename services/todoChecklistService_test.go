@@ -0,0 +1,258 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoChecklistService_Create_AppendsToEnd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details" WHERE id = \$1 AND "to_do_list_details"\."deleted_at" IS NULL`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mockRepo.EXPECT().NextSortOrder(ctx, gomock.Any(), 7).Return(4, nil)
+	mockRepo.EXPECT().
+		Create(ctx, gomock.Any(), gomock.AssignableToTypeOf(&models.TodoChecklistItems{})).
+		Return(nil)
+
+	sqlmock.ExpectCommit()
+
+	result, err := service.Create(db, 7, "寫測試")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.TodoListDetailID)
+	assert.Equal(t, 4, result.SortOrder)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoChecklistService_Create_DetailNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details"`).
+		WithArgs(999).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlmock.ExpectRollback()
+
+	_, err := service.Create(db, 999, "寫測試")
+
+	assert.EqualError(t, err, "to_do_list_detail_id 不存在")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoChecklistService_Edit_TogglesDoneAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	existing := &models.TodoChecklistItems{ID: 1, Name: "舊名稱"}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(existing, nil)
+	mockRepo.EXPECT().
+		Update(ctx, gomock.Any(), existing).
+		DoAndReturn(func(ctx context.Context, tx any, item *models.TodoChecklistItems) error {
+			assert.True(t, item.IsDone)
+			assert.NotNil(t, item.DoneAt)
+			return nil
+		})
+	sqlmock.ExpectCommit()
+
+	result, err := service.Edit(db, 1, "新名稱", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "新名稱", result.Name)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoChecklistService_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	items := []*models.TodoChecklistItems{
+		{ID: 1, SortOrder: 1},
+		{ID: 2, SortOrder: 2},
+		{ID: 3, SortOrder: 3},
+	}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByDetailID(ctx, gomock.Any(), 5).Return(items, nil)
+	// 只有位置變動的項目才會更新
+	mockRepo.EXPECT().UpdateSortOrder(ctx, gomock.Any(), 3, 1).Return(nil)
+	mockRepo.EXPECT().UpdateSortOrder(ctx, gomock.Any(), 1, 2).Return(nil)
+	mockRepo.EXPECT().UpdateSortOrder(ctx, gomock.Any(), 2, 3).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := service.Reorder(db, 5, []int{3, 1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 2}, []int{result[0].ID, result[1].ID, result[2].ID})
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoChecklistService_Reorder_RejectsForeignItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	items := []*models.TodoChecklistItems{{ID: 1}, {ID: 2}}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByDetailID(ctx, gomock.Any(), 5).Return(items, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := service.Reorder(db, 5, []int{1, 99})
+
+	assert.EqualError(t, err, "排序項目不屬於此 TodoListDetails 或重複")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoList_RollUpProgress(t *testing.T) {
+	now := time.Now()
+	list := &models.TodoList{
+		Details: []models.TodoListDetails{
+			{Items: []models.TodoChecklistItems{{IsDone: true, DoneAt: &now}, {IsDone: false}}},
+			{Items: []models.TodoChecklistItems{{IsDone: true, DoneAt: &now}, {IsDone: true, DoneAt: &now}, {IsDone: false}}},
+			{},
+		},
+	}
+
+	progress := list.RollUpProgress()
+
+	assert.Equal(t, &models.Progress{Done: 3, Total: 5}, progress)
+	assert.Equal(t, &models.Progress{Done: 1, Total: 2}, list.Details[0].Progress)
+	assert.Equal(t, &models.Progress{Done: 0, Total: 0}, list.Details[2].Progress)
+}
+
+func TestTodoChecklistService_SyncFromMarkdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, _ := setupMockDB(t)
+
+	doneAt := time.Now().Add(-time.Hour)
+	manual := &models.TodoChecklistItems{ID: 1, Name: "手動項目"}
+	first := &models.TodoChecklistItems{ID: 2, Name: "設計", IsDone: true, DoneAt: &doneAt, MarkdownIndex: intPtr(0)}
+	second := &models.TodoChecklistItems{ID: 3, Name: "實作", MarkdownIndex: intPtr(1)}
+	removed := &models.TodoChecklistItems{ID: 4, Name: "上線", MarkdownIndex: intPtr(2)}
+
+	mockRepo.EXPECT().FindByDetailID(ctx, gomock.Any(), 7).
+		Return([]*models.TodoChecklistItems{manual, first, second, removed}, nil)
+
+	// 「設計」換到第二個且仍完成，保留原本的完成時間；「實作」改名為「實作 API」；「上線」被刪除
+	mockRepo.EXPECT().SoftDelete(ctx, gomock.Any(), removed).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), second).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), first).Return(nil)
+
+	err := service.SyncFromMarkdown(db, 7, "- [ ] 實作 API\n- [x] 設計\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "實作 API", second.Name)
+	assert.Equal(t, 0, *second.MarkdownIndex)
+	assert.Equal(t, 1, *first.MarkdownIndex)
+	assert.Equal(t, &doneAt, first.DoneAt)
+	assert.Nil(t, manual.MarkdownIndex)
+}
+
+func TestTodoChecklistService_SyncFromMarkdown_CreatesItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindByDetailID(ctx, gomock.Any(), 7).Return(nil, nil)
+	mockRepo.EXPECT().NextSortOrder(ctx, gomock.Any(), 7).Return(1, nil)
+	mockRepo.EXPECT().NextSortOrder(ctx, gomock.Any(), 7).Return(2, nil)
+
+	var created []*models.TodoChecklistItems
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, item *models.TodoChecklistItems) error {
+			created = append(created, item)
+			return nil
+		}).Times(2)
+
+	err := service.SyncFromMarkdown(db, 7, "說明\n\n- [x] 設計\n- [ ] 測試\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "設計", created[0].Name)
+	assert.True(t, created[0].IsDone)
+	assert.NotNil(t, created[0].DoneAt)
+	assert.Equal(t, 0, *created[0].MarkdownIndex)
+	assert.Equal(t, "測試", created[1].Name)
+	assert.Equal(t, 1, *created[1].MarkdownIndex)
+	assert.Equal(t, 2, created[1].SortOrder)
+}
+
+func TestTodoChecklistService_Edit_WritesBackToMarkdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoChecklistRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoChecklistService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	existing := &models.TodoChecklistItems{ID: 3, TodoListDetailID: 7, Name: "實作", MarkdownIndex: intPtr(1)}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 3).Return(existing, nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), existing).Return(nil)
+	mockRepo.EXPECT().LockDetailMarkdown(ctx, gomock.Any(), 7).Return("說明\n- [x] 設計\n- [ ] 實作\n", nil)
+	mockRepo.EXPECT().UpdateDetailMarkdown(ctx, gomock.Any(), 7, "說明\n- [x] 設計\n- [x] 實作\n").Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := service.Edit(db, 3, "實作", true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsDone)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
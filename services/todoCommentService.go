@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrCommentForbidden = errors.New("只有留言作者或管理員可以修改、刪除留言")
+
+// mentionPattern 比對 @account，前面必須是開頭或空白，避免把 email 當成提及
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9_.\-]+)`)
+
+type TodoCommentService struct {
+	ctx      context.Context
+	repo     interfaces.TodoCommentRepository
+	users    interfaces.AuthRepository
+	events   *TodoOutboxService
+	watchers *TodoWatcherService
+}
+
+func NewTodoCommentService(ctx context.Context, repo interfaces.TodoCommentRepository, users interfaces.AuthRepository) *TodoCommentService {
+	return &TodoCommentService{
+		ctx:   ctx,
+		repo:  repo,
+		users: users,
+	}
+}
+
+// WithEvents 設定後，新增、修改與刪除留言時會在同一個交易內寫入領域事件
+func (s *TodoCommentService) WithEvents(events *TodoOutboxService) *TodoCommentService {
+	s.events = events
+	return s
+}
+
+// WithWatchers 設定後，留言者會在同一個交易內自動關注項目
+func (s *TodoCommentService) WithWatchers(watchers *TodoWatcherService) *TodoCommentService {
+	s.watchers = watchers
+	return s
+}
+
+// publish 未設定 events 時不處理；事件以留言所屬的 TodoList 分類
+func (s *TodoCommentService) publish(tx *gorm.DB, eventType string, item *models.TodoComments) error {
+	if s.events == nil {
+		return nil
+	}
+	var listID int
+	if err := tx.Unscoped().Model(&models.TodoListDetails{}).Where("id = ?", item.TodoListDetailID).
+		Select("to_do_list_id").Scan(&listID).Error; err != nil {
+		return err
+	}
+	return s.events.Publish(tx, eventType, listID, item)
+}
+
+// Create 新增留言；parentID 不為 0 時為回覆，回覆的回覆一律掛在討論串第一則底下
+func (s *TodoCommentService) Create(db *gorm.DB, detailID int, parentID int, body string) (*models.TodoComments, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	data := &models.TodoComments{
+		TodoListDetailID: detailID,
+		Body:             body,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TodoListDetails{}).Where("id = ?", detailID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("to_do_list_detail_id 不存在")
+		}
+
+		if parentID > 0 {
+			parent, err := s.repo.FindByID(s.ctx, tx, parentID)
+			if err != nil {
+				return notFoundAs(err, "parent_id 不存在")
+			}
+			if parent.TodoListDetailID != detailID {
+				return errors.New("parent_id 不屬於此 TodoListDetails")
+			}
+			rootID := parent.ID
+			if parent.ParentID != nil {
+				rootID = *parent.ParentID
+			}
+			data.ParentID = &rootID
+		}
+
+		if err := s.repo.Create(s.ctx, tx, data); err != nil {
+			return err
+		}
+
+		if err := s.syncMentions(tx, data); err != nil {
+			return err
+		}
+		if s.watchers != nil {
+			if err := s.watchers.AutoWatchDetail(tx, detailID, []int{userID}); err != nil {
+				return err
+			}
+		}
+		return s.publish(tx, models.EventCommentCreated, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachAuthors(db, []*models.TodoComments{data})
+	return data, nil
+}
+
+// Index 分頁取出討論串（依時間先後），每串帶出所有回覆。
+// 已刪除的第一則還有回覆時以 deleted 標記保留，否則其他人的回覆會跟著看不到
+func (s *TodoCommentService) Index(db *gorm.DB, detailID int, page, pageSize int) (*utils.PaginatedResult[*models.TodoComments], error) {
+	// 查詢不排除已刪除的資料，Preload 也會沿用，回覆與使用者需自行排除
+	userColumns := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("users.deleted_at IS NULL").Select("users.id", "users.account")
+	}
+
+	query := db.Unscoped().Model(&models.TodoComments{}).
+		Where("to_do_list_detail_id = ? AND parent_id IS NULL", detailID).
+		Where("deleted_at IS NULL OR EXISTS (SELECT 1 FROM to_do_comments AS replies WHERE replies.parent_id = to_do_comments.id AND replies.deleted_at IS NULL)").
+		Preload("Mentions", userColumns).
+		Preload("Replies", func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("deleted_at IS NULL").Order("created_at asc, id asc")
+		}).
+		Preload("Replies.Mentions", userColumns)
+
+	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, "created_at asc", "id asc")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range list {
+		if item.DeletedAt.Valid {
+			item.Deleted = true
+			item.Body = ""
+			item.Mentions = []models.User{}
+		}
+	}
+
+	s.attachAuthors(db, list)
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+// Edit 修改留言內容，修改前的內容保存為修改紀錄
+func (s *TodoCommentService) Edit(db *gorm.DB, id int, body string) (*models.TodoComments, error) {
+	updated := &models.TodoComments{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorize(tx, item); err != nil {
+			return err
+		}
+
+		if item.Body != body {
+			userID, _ := utils.CurrentUserID(s.ctx)
+			editor := uint(userID)
+			if err := s.repo.CreateRevision(s.ctx, tx, &models.TodoCommentRevisions{
+				CommentID: item.ID,
+				Body:      item.Body,
+				CreatedBy: &editor,
+			}); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			item.Body = body
+			item.EditedAt = &now
+			if err := s.repo.Update(s.ctx, tx, item); err != nil {
+				return err
+			}
+
+			if err := s.syncMentions(tx, item); err != nil {
+				return err
+			}
+			if err := s.publish(tx, models.EventCommentUpdated, item); err != nil {
+				return err
+			}
+		}
+
+		*updated = *item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachAuthors(db, []*models.TodoComments{updated})
+	return updated, nil
+}
+
+// Delete 軟刪除留言；第一則還有回覆時，在 Index 中以 deleted 標記保留
+func (s *TodoCommentService) Delete(db *gorm.DB, id int) (*models.TodoComments, error) {
+	var deleted *models.TodoComments
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorize(tx, item); err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+		if err := s.publish(tx, models.EventCommentDeleted, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// Revisions 取得留言的修改紀錄
+func (s *TodoCommentService) Revisions(db *gorm.DB, id int) ([]*models.TodoCommentRevisions, error) {
+	if _, err := s.repo.FindByID(s.ctx, db, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRevisions(s.ctx, db, id)
+}
+
+// authorize 只有作者或 Admin 可以修改、刪除
+func (s *TodoCommentService) authorize(tx *gorm.DB, item *models.TodoComments) error {
+	return authorizeOwner(s.ctx, tx, s.users, item.CreatedBy, ErrCommentForbidden)
+}
+
+// syncMentions 解析內容中的 @account 並更新提及的使用者，不存在的帳號略過
+func (s *TodoCommentService) syncMentions(tx *gorm.DB, item *models.TodoComments) error {
+	users, err := s.users.FindByAccounts(s.ctx, tx, ParseMentions(item.Body))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ReplaceMentions(s.ctx, tx, item, users); err != nil {
+		return err
+	}
+	item.Mentions = users
+	return nil
+}
+
+// attachAuthors 一次查出所有留言（含回覆）的作者；查詢失敗時不影響主要結果
+func (s *TodoCommentService) attachAuthors(db *gorm.DB, comments []*models.TodoComments) {
+	var all []*models.TodoComments
+	for _, c := range comments {
+		all = append(all, c)
+		for i := range c.Replies {
+			all = append(all, &c.Replies[i])
+		}
+	}
+
+	seen := map[int]bool{}
+	var ids []int
+	for _, c := range all {
+		if c.CreatedBy != nil && !seen[int(*c.CreatedBy)] {
+			seen[int(*c.CreatedBy)] = true
+			ids = append(ids, int(*c.CreatedBy))
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	users, err := s.users.FindByIDs(s.ctx, db, ids)
+	if err != nil {
+		return
+	}
+	byID := make(map[int]*models.User, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+	for _, c := range all {
+		if c.CreatedBy != nil {
+			c.Author = byID[int(*c.CreatedBy)]
+		}
+	}
+}
+
+// ParseMentions 取出內容中不重複的 @account
+func ParseMentions(body string) []string {
+	seen := map[string]bool{}
+	var accounts []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		account := strings.TrimRight(m[1], ".-")
+		if account != "" && !seen[account] {
+			seen[account] = true
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts
+}
@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/models/base"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestParseMentions(t *testing.T) {
+	accounts := services.ParseMentions("@alice 請看一下，cc @bob. 信箱 carol@example.com 不算，@alice 重複")
+	assert.Equal(t, []string{"alice", "bob"}, accounts)
+	assert.Empty(t, services.ParseMentions("沒有提及任何人"))
+}
+
+func TestTodoCommentService_Create_ReplyAttachesToRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCommentRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCommentService(ctx, mockRepo, mockUsers)
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details"`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 5).
+		Return(&models.TodoComments{ID: 5, TodoListDetailID: 1, ParentID: intPtr(3)}, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, c *models.TodoComments) error {
+			c.ID = 6
+			c.CreatedBy = uintPtr(1)
+			return nil
+		})
+	mockUsers.EXPECT().FindByAccounts(ctx, gomock.Any(), []string{"bob"}).
+		Return([]models.User{{ID: 2, Account: "bob"}}, nil)
+	mockRepo.EXPECT().ReplaceMentions(ctx, gomock.Any(), gomock.Any(), []models.User{{ID: 2, Account: "bob"}}).Return(nil)
+	mock.ExpectCommit()
+	mockUsers.EXPECT().FindByIDs(ctx, gomock.Any(), []int{1}).
+		Return([]models.User{{ID: 1, Account: "alice"}}, nil)
+
+	comment, err := svc.Create(db, 1, 5, "@bob 收到")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *comment.ParentID)
+	assert.Len(t, comment.Mentions, 1)
+	assert.Equal(t, "alice", comment.Author.Account)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoCommentService_Edit_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCommentRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCommentService(ctx, mockRepo, mockUsers)
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).
+		Return(&models.TodoComments{ID: 7, Body: "原本的內容", OperatorModel: base.OperatorModel{CreatedBy: uintPtr(2)}}, nil)
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 1, "Admin").Return(false, nil)
+	mock.ExpectRollback()
+
+	_, err := svc.Edit(db, 7, "改掉")
+
+	assert.ErrorIs(t, err, services.ErrCommentForbidden)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoCommentService_Edit_SavesRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCommentRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoCommentService(ctx, mockRepo, mockUsers)
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).
+		Return(&models.TodoComments{ID: 7, Body: "原本的內容", OperatorModel: base.OperatorModel{CreatedBy: uintPtr(1)}}, nil)
+	mockRepo.EXPECT().CreateRevision(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, r *models.TodoCommentRevisions) error {
+			assert.Equal(t, 7, r.CommentID)
+			assert.Equal(t, "原本的內容", r.Body)
+			return nil
+		})
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockUsers.EXPECT().FindByAccounts(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().ReplaceMentions(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+	mockUsers.EXPECT().FindByIDs(ctx, gomock.Any(), []int{1}).Return(nil, nil)
+
+	comment, err := svc.Edit(db, 7, "新的內容")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "新的內容", comment.Body)
+	assert.NotNil(t, comment.EditedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoCommentService_Index_KeepsDeletedRootWithReplies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoCommentRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(2))
+	svc := services.NewTodoCommentService(ctx, mockRepo, mockUsers)
+	db, _ := setupMockDB(t)
+
+	root := &models.TodoComments{
+		ID:            3,
+		Body:          "已刪除的內容",
+		Mentions:      []models.User{{ID: 2, Account: "bob"}},
+		Replies:       []models.TodoComments{{ID: 4, ParentID: intPtr(3), Body: "其他人的回覆", OperatorModel: base.OperatorModel{CreatedBy: uintPtr(2)}}},
+		TimeModel:     base.TimeModel{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		OperatorModel: base.OperatorModel{CreatedBy: uintPtr(1)},
+	}
+	mockRepo.EXPECT().FindAllWithQuery(ctx, gomock.Any(), 1, 20, "created_at asc", "id asc").
+		DoAndReturn(func(_ context.Context, query *gorm.DB, _, _ int, _ ...string) ([]*models.TodoComments, int64, error) {
+			// 已刪除的第一則也要查出來
+			assert.True(t, query.Statement.Unscoped)
+			return []*models.TodoComments{root}, 1, nil
+		})
+	mockUsers.EXPECT().FindByIDs(ctx, gomock.Any(), []int{1, 2}).
+		Return([]models.User{{ID: 1, Account: "alice"}, {ID: 2, Account: "bob"}}, nil)
+
+	result, err := svc.Index(db, 1, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 1)
+	thread := result.Data[0]
+	assert.True(t, thread.Deleted)
+	assert.Empty(t, thread.Body)
+	assert.Empty(t, thread.Mentions)
+	assert.Len(t, thread.Replies, 1)
+	assert.False(t, thread.Replies[0].Deleted)
+	assert.Equal(t, "其他人的回覆", thread.Replies[0].Body)
+	assert.Equal(t, "bob", thread.Replies[0].Author.Account)
+}
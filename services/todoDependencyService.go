@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dependencyLockName 新增相依關係時使用的 MySQL 具名鎖，讓循環檢查與寫入一次只有一個在進行
+const dependencyLockName = "todo_dependencies"
+
+// dependencyLockTimeout 等待具名鎖的秒數
+const dependencyLockTimeout = 10
+
+var ErrDependencyBusy = errors.New("其他相依關係正在寫入，請稍後再試")
+
+type TodoDependencyService struct {
+	ctx         context.Context
+	repo        interfaces.TodoDependencyRepository
+	detailsRepo interfaces.TodoListDetailsRepository
+}
+
+func NewTodoDependencyService(ctx context.Context, repo interfaces.TodoDependencyRepository, detailsRepo interfaces.TodoListDetailsRepository) *TodoDependencyService {
+	return &TodoDependencyService{
+		ctx:         ctx,
+		repo:        repo,
+		detailsRepo: detailsRepo,
+	}
+}
+
+// AddBlocker 建立「blockerID 擋住 blockedID」的相依關係，會拒絕形成循環的關係。
+// 只鎖兩端的項目不夠：同時新增 B -> C 與 D -> A（已有 A -> B、C -> D）各自檢查都不會有循環，
+// 一起 commit 後卻形成循環，所以整個檢查與寫入先取得同一把具名鎖，commit 後才釋放
+func (s *TodoDependencyService) AddBlocker(db *gorm.DB, blockedID int, blockerID int) (*models.TodoDetailDependency, error) {
+	if blockedID == blockerID {
+		return nil, errors.New("TodoListDetails 不能相依於自己")
+	}
+
+	data := &models.TodoDetailDependency{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+
+	// 具名鎖屬於連線，取得、交易與釋放都要在同一個連線上
+	err := db.Connection(func(conn *gorm.DB) error {
+		var acquired *int
+		if err := conn.Raw("SELECT GET_LOCK(?, ?)", dependencyLockName, dependencyLockTimeout).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if acquired == nil || *acquired != 1 {
+			return ErrDependencyBusy
+		}
+		defer conn.Exec("SELECT RELEASE_LOCK(?)", dependencyLockName)
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			return s.addBlocker(tx, data)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// addBlocker 在已取得具名鎖的交易內檢查並寫入相依關係
+func (s *TodoDependencyService) addBlocker(tx *gorm.DB, data *models.TodoDetailDependency) error {
+	blockerID, blockedID := data.BlockerID, data.BlockedID
+	// 鎖住兩端的項目，確認存在且不會在檢查期間被刪除
+	var ids []int
+	if err := tx.Model(&models.TodoListDetails{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", []int{blockerID, blockedID}).
+		Order("id asc").
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) != 2 {
+		return errors.New("TodoListDetails 不存在")
+	}
+
+	exist, err := s.repo.Exists(s.ctx, tx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+
+	// 若 blockedID 已經（間接）擋住 blockerID，新增後就會形成循環
+	path, err := s.findPath(tx, blockedID, blockerID)
+	if err != nil {
+		return err
+	}
+	if path != nil {
+		return fmt.Errorf("會形成循環相依：%s", formatDependencyPath(append([]int{blockerID}, path...)))
+	}
+
+	return s.repo.Create(s.ctx, tx, data)
+}
+
+// RemoveBlocker 移除相依關係
+func (s *TodoDependencyService) RemoveBlocker(db *gorm.DB, blockedID int, blockerID int) error {
+	return s.repo.Delete(s.ctx, db, blockerID, blockedID)
+}
+
+// Graph 取得 TodoList 的相依圖，跨 TodoList 的另一端會標記為 external
+func (s *TodoDependencyService) Graph(db *gorm.DB, listID int) (*models.DependencyGraph, error) {
+	var count int64
+	if err := db.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("to_do_list_id 不存在")
+	}
+
+	details, err := s.detailsRepo.FindByListID(s.ctx, db, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := s.repo.FindByListID(s.ctx, db, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &models.DependencyGraph{
+		TodoListID: listID,
+		Nodes:      []models.DependencyGraphNode{},
+		Edges:      []models.DependencyGraphEdge{},
+	}
+
+	inList := make(map[int]bool, len(details))
+	for _, d := range details {
+		inList[d.ID] = true
+		graph.Nodes = append(graph.Nodes, models.DependencyGraphNode{
+			ID:         d.ID,
+			TodoListID: d.TodoListID,
+			Name:       d.Name,
+			Status:     d.Status,
+		})
+	}
+
+	// 找出其他 TodoList 的節點
+	var externalIDs []int
+	seen := map[int]bool{}
+	for _, e := range edges {
+		graph.Edges = append(graph.Edges, models.DependencyGraphEdge{From: e.BlockerID, To: e.BlockedID})
+		for _, id := range []int{e.BlockerID, e.BlockedID} {
+			if !inList[id] && !seen[id] {
+				seen[id] = true
+				externalIDs = append(externalIDs, id)
+			}
+		}
+	}
+
+	externals, err := s.detailsRepo.FindByIDs(s.ctx, db, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range externals {
+		graph.Nodes = append(graph.Nodes, models.DependencyGraphNode{
+			ID:         d.ID,
+			TodoListID: d.TodoListID,
+			Name:       d.Name,
+			Status:     d.Status,
+			External:   true,
+		})
+	}
+
+	return graph, nil
+}
+
+// findPath 從 from 沿著「擋住」的方向以 BFS 尋找到 to 的路徑，找不到時回傳 nil
+func (s *TodoDependencyService) findPath(tx *gorm.DB, from, to int) ([]int, error) {
+	prev := map[int]int{from: 0}
+	frontier := []int{from}
+
+	for len(frontier) > 0 {
+		edges, err := s.repo.FindEdgesFrom(s.ctx, tx, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+		for _, e := range edges {
+			if _, visited := prev[e.BlockedID]; visited {
+				continue
+			}
+			prev[e.BlockedID] = e.BlockerID
+
+			if e.BlockedID == to {
+				// 由終點回推完整路徑
+				path := []int{to}
+				for cur := to; cur != from; {
+					cur = prev[cur]
+					path = append([]int{cur}, path...)
+				}
+				return path, nil
+			}
+			next = append(next, e.BlockedID)
+		}
+		frontier = next
+	}
+
+	return nil, nil
+}
+
+func formatDependencyPath(path []int) string {
+	parts := make([]string, len(path))
+	for i, id := range path {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,161 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const lockDetailsSQL = `SELECT "id" FROM "to_do_list_details" WHERE id IN \(\$1,\$2\) AND "to_do_list_details"\."deleted_at" IS NULL ORDER BY id asc FOR UPDATE`
+
+// expectDependencyLock 新增相依關係前後取得與釋放具名鎖
+func expectDependencyLock(mock sqlmock.Sqlmock, acquired int) {
+	mock.ExpectQuery(`SELECT GET_LOCK`).
+		WithArgs("todo_dependencies", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(acquired))
+}
+
+func expectDependencyUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT RELEASE_LOCK`).WithArgs("todo_dependencies").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestTodoDependencyService_AddBlocker_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := services.NewTodoDependencyService(context.Background(),
+		mocks.NewMockTodoDependencyRepository(ctrl), mocks.NewMockTodoListDetailsRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	_, err := svc.AddBlocker(db, 1, 1)
+
+	assert.EqualError(t, err, "TodoListDetails 不能相依於自己")
+}
+
+func TestTodoDependencyService_AddBlocker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoDependencyRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoDependencyService(ctx, mockRepo, mocks.NewMockTodoListDetailsRepository(ctrl))
+	db, sqlmock := setupMockDB(t)
+
+	expectDependencyLock(sqlmock, 1)
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(lockDetailsSQL).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	mockRepo.EXPECT().Exists(ctx, gomock.Any(), 1, 2).Return(false, nil)
+	// 2 目前沒有擋住任何項目，不會形成循環
+	mockRepo.EXPECT().FindEdgesFrom(ctx, gomock.Any(), []int{2}).Return(nil, nil)
+	mockRepo.EXPECT().
+		Create(ctx, gomock.Any(), &models.TodoDetailDependency{BlockerID: 1, BlockedID: 2}).
+		Return(nil)
+
+	sqlmock.ExpectCommit()
+	expectDependencyUnlock(sqlmock)
+
+	result, err := svc.AddBlocker(db, 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.BlockerID)
+	assert.Equal(t, 2, result.BlockedID)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoDependencyService_AddBlocker_RejectsCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoDependencyRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoDependencyService(ctx, mockRepo, mocks.NewMockTodoListDetailsRepository(ctrl))
+	db, sqlmock := setupMockDB(t)
+
+	expectDependencyLock(sqlmock, 1)
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(lockDetailsSQL).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	mockRepo.EXPECT().Exists(ctx, gomock.Any(), 1, 2).Return(false, nil)
+	// 既有關係：2 -> 3 -> 1，再加上 1 -> 2 就會形成循環
+	mockRepo.EXPECT().FindEdgesFrom(ctx, gomock.Any(), []int{2}).
+		Return([]*models.TodoDetailDependency{{BlockerID: 2, BlockedID: 3}}, nil)
+	mockRepo.EXPECT().FindEdgesFrom(ctx, gomock.Any(), []int{3}).
+		Return([]*models.TodoDetailDependency{{BlockerID: 3, BlockedID: 1}}, nil)
+
+	sqlmock.ExpectRollback()
+	expectDependencyUnlock(sqlmock)
+
+	_, err := svc.AddBlocker(db, 2, 1)
+
+	assert.EqualError(t, err, "會形成循環相依：#1 -> #2 -> #3 -> #1")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoDependencyService_AddBlocker_Busy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoDependencyRepository(ctrl)
+	svc := services.NewTodoDependencyService(context.Background(), mockRepo, mocks.NewMockTodoListDetailsRepository(ctrl))
+	db, sqlmock := setupMockDB(t)
+
+	// 其他請求持有具名鎖超過等待時間：不檢查也不寫入
+	expectDependencyLock(sqlmock, 0)
+
+	_, err := svc.AddBlocker(db, 2, 1)
+
+	assert.ErrorIs(t, err, services.ErrDependencyBusy)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoDependencyService_Graph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoDependencyRepository(ctrl)
+	mockDetails := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoDependencyService(ctx, mockRepo, mockDetails)
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list" WHERE id = \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mockDetails.EXPECT().FindByListID(ctx, gomock.Any(), 10).Return([]*models.TodoListDetails{
+		{ID: 1, TodoListID: 10, Name: "設計", Status: models.DetailStatusDone},
+		{ID: 2, TodoListID: 10, Name: "實作", Status: models.DetailStatusTodo},
+	}, nil)
+	mockRepo.EXPECT().FindByListID(ctx, gomock.Any(), 10).Return([]*models.TodoDetailDependency{
+		{BlockerID: 1, BlockedID: 2},
+		{BlockerID: 2, BlockedID: 30},
+	}, nil)
+	// 30 屬於其他 TodoList
+	mockDetails.EXPECT().FindByIDs(ctx, gomock.Any(), []int{30}).Return([]*models.TodoListDetails{
+		{ID: 30, TodoListID: 11, Name: "上線", Status: models.DetailStatusTodo},
+	}, nil)
+
+	graph, err := svc.Graph(db, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, graph.Nodes, 3)
+	assert.True(t, graph.Nodes[2].External)
+	assert.Equal(t, []models.DependencyGraphEdge{{From: 1, To: 2}, {From: 2, To: 30}}, graph.Edges)
+
+	dot := graph.DOT()
+	assert.Contains(t, dot, "digraph todo_list_10 {")
+	assert.Contains(t, dot, "d1 -> d2;")
+	assert.Contains(t, dot, "d2 -> d30;")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"todolist/config"
+	"todolist/models"
+	"todolist/pkg/mail"
+	"todolist/pkg/webhook"
+	"todolist/repositories/interfaces"
+	"todolist/templates"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEmailNoSender    = errors.New("未設定 SMTP，無法寄送 email")
+	ErrEmailUnsubscribe = errors.New("收件者已取消訂閱")
+)
+
+const (
+	// emailMaxAttempts 失敗超過這個次數後不再重試
+	emailMaxAttempts = 8
+	// emailRetryBase、emailRetryMax 重試間隔從 1 分鐘開始加倍，最長 6 小時
+	emailRetryBase = time.Minute
+	emailRetryMax  = 6 * time.Hour
+	// emailSendTimeout 寄送中超過這個時間仍未結束的信件視為中斷，會被重新寄送
+	emailSendTimeout = 5 * time.Minute
+	emailTokenBytes  = 16
+)
+
+// emailData 範本使用的資料
+type emailData struct {
+	Kind           string
+	Task           *models.EmailTask
+	Tasks          []emailTaskRow
+	Digest         string
+	UnsubscribeURL string
+}
+
+type emailTaskRow struct {
+	*models.EmailTask
+	Overdue bool
+}
+
+type TodoEmailService struct {
+	ctx           context.Context
+	repo          interfaces.TodoEmailRepository
+	sender        mail.Sender
+	from          string
+	baseURL       string
+	digestHour    int
+	digestWeekday time.Weekday
+}
+
+func NewTodoEmailService(ctx context.Context, repo interfaces.TodoEmailRepository) *TodoEmailService {
+	return &TodoEmailService{
+		ctx:           ctx,
+		repo:          repo,
+		from:          config.SMTPFrom,
+		baseURL:       config.AppBaseURL,
+		digestHour:    config.EmailDigestHour,
+		digestWeekday: config.EmailDigestWeekday,
+	}
+}
+
+// WithSender 設定寄送方式，未設定時 RunNext 回傳 ErrEmailNoSender
+func (s *TodoEmailService) WithSender(sender mail.Sender) *TodoEmailService {
+	s.sender = sender
+	return s
+}
+
+// Settings 目前使用者的設定，尚未設定過時回傳預設值（不寄送）
+func (s *TodoEmailService) Settings(db *gorm.DB) (*models.TodoEmailSettings, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	settings, err := s.repo.FindSettings(s.ctx, db, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.TodoEmailSettings{
+			UserID:         userID,
+			Locale:         templates.DefaultLocale,
+			NotifyAssigned: true,
+			NotifyDue:      true,
+			Digest:         models.DigestOff,
+		}, nil
+	}
+	return settings, err
+}
+
+// SaveSettings 儲存目前使用者的設定；儲存即表示同意收信，會取消先前的取消訂閱
+func (s *TodoEmailService) SaveSettings(db *gorm.DB, email, locale string, notifyAssigned, notifyDue bool, digest string) (*models.TodoEmailSettings, error) {
+	settings, err := s.Settings(db)
+	if err != nil {
+		return nil, err
+	}
+	if settings.UnsubscribeToken == "" {
+		if settings.UnsubscribeToken, err = randomHex(emailTokenBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	settings.Email = email
+	settings.Locale = locale
+	settings.NotifyAssigned = notifyAssigned
+	settings.NotifyDue = notifyDue
+	settings.Digest = digest
+	settings.UnsubscribedAt = nil
+	if err := s.repo.SaveSettings(s.ctx, db, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// FindByToken 取消訂閱頁面顯示用，token 不存在時回傳 gorm.ErrRecordNotFound
+func (s *TodoEmailService) FindByToken(db *gorm.DB, token string) (*models.TodoEmailSettings, error) {
+	return s.repo.FindSettingsByToken(s.ctx, db, token)
+}
+
+// Unsubscribe 以信件中的 token 取消訂閱所有信件，不需要登入；重複取消不會改變時間
+func (s *TodoEmailService) Unsubscribe(db *gorm.DB, token string) (*models.TodoEmailSettings, error) {
+	settings, err := s.repo.FindSettingsByToken(s.ctx, db, token)
+	if err != nil {
+		return nil, err
+	}
+	if settings.UnsubscribedAt == nil {
+		now := time.Now()
+		settings.UnsubscribedAt = &now
+		if err := s.repo.SaveSettings(s.ctx, db, settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+func (s *TodoEmailService) unsubscribeURL(token string) string {
+	return s.baseURL + "/api/email/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// message 套用範本建立待寄出的信件
+func (s *TodoEmailService) message(settings *models.TodoEmailSettings, kind, name string, data *emailData, dedupeKey string) (*models.TodoEmailMessages, error) {
+	data.Kind = kind
+	data.UnsubscribeURL = s.unsubscribeURL(settings.UnsubscribeToken)
+	email, err := templates.RenderEmail(settings.Locale, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := settings.UserID
+	return &models.TodoEmailMessages{
+		UserID:         &userID,
+		Kind:           kind,
+		ToAddress:      settings.Email,
+		Subject:        truncate(email.Subject, 255),
+		TextBody:       email.Text,
+		HTMLBody:       email.HTML,
+		UnsubscribeURL: data.UnsubscribeURL,
+		DedupeKey:      dedupeKey,
+		Status:         models.EmailStatusPending,
+		NextAttemptAt:  time.Now(),
+	}, nil
+}
+
+// EnqueueNotifications 把指派、到期與逾期多日的通知轉為信件，只寄給有開啟對應設定且仍訂閱中的使用者；
+// 以通知的 dedupe_key 排除重複，同一則通知只寄一次
+func (s *TodoEmailService) EnqueueNotifications(db *gorm.DB, notifications []*models.TodoNotifications) error {
+	var userIDs, detailIDs []int
+	var targets []*models.TodoNotifications
+	for _, n := range notifications {
+		switch n.Type {
+		case models.NotificationAssigned, models.NotificationDueSoon, models.NotificationOverdue, models.NotificationEscalated:
+			if n.TodoListDetailID == nil {
+				continue
+			}
+			targets = append(targets, n)
+			userIDs = append(userIDs, n.UserID)
+			detailIDs = append(detailIDs, *n.TodoListDetailID)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	settingsList, err := s.repo.FindSettingsByUsers(s.ctx, db, userIDs)
+	if err != nil {
+		return err
+	}
+	settingsByUser := make(map[int]*models.TodoEmailSettings, len(settingsList))
+	for _, settings := range settingsList {
+		settingsByUser[settings.UserID] = settings
+	}
+	tasks, err := s.repo.FindTasks(s.ctx, db, detailIDs)
+	if err != nil {
+		return err
+	}
+	taskByID := make(map[int]*models.EmailTask, len(tasks))
+	for _, task := range tasks {
+		taskByID[task.ID] = task
+	}
+
+	var messages []*models.TodoEmailMessages
+	for _, n := range targets {
+		settings, task := settingsByUser[n.UserID], taskByID[*n.TodoListDetailID]
+		if settings == nil || !settings.Subscribed() || task == nil {
+			continue
+		}
+
+		name := "due"
+		enabled := settings.NotifyDue
+		if n.Type == models.NotificationAssigned {
+			name = "assigned"
+			enabled = settings.NotifyAssigned
+		}
+		// 到期通知需要到期時間，期間被清空的略過
+		if !enabled || (name == "due" && task.DueAt == nil) {
+			continue
+		}
+
+		message, err := s.message(settings, n.Type, name, &emailData{Task: task}, fmt.Sprintf("notification:%d:%s", n.UserID, n.DedupeKey))
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	return s.repo.CreateMessages(s.ctx, db, messages)
+}
+
+// digestPeriodStart 目前這一期摘要的開始時間：每日為今天的寄送時間，每週為本週寄送日的寄送時間；
+// 還沒到時回傳 false，錯過的前一期不補寄
+func (s *TodoEmailService) digestPeriodStart(digest string, now time.Time) (time.Time, bool) {
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.digestHour, 0, 0, 0, now.Location())
+	if digest == models.DigestWeekly {
+		days := (int(now.Weekday()) - int(s.digestWeekday) + 7) % 7
+		start = start.AddDate(0, 0, -days)
+	}
+	return start, !now.Before(start)
+}
+
+// RunDigest 處理一位到了寄送時間的使用者的摘要，沒有時回傳 false；沒有未完成任務時不寄送，只記錄已處理。
+// 以 FOR UPDATE SKIP LOCKED 領取，多個 instance 同時執行也不會重複寄送
+func (s *TodoEmailService) RunDigest(db *gorm.DB, now time.Time) (bool, error) {
+	for _, digest := range []string{models.DigestDaily, models.DigestWeekly} {
+		start, ok := s.digestPeriodStart(digest, now)
+		if !ok {
+			continue
+		}
+
+		processed := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			settings, err := s.repo.LockDigestDue(s.ctx, tx, digest, start)
+			if err != nil || settings == nil {
+				return err
+			}
+			processed = true
+
+			tasks, err := s.repo.FindOpenTasks(s.ctx, tx, settings.UserID)
+			if err != nil {
+				return err
+			}
+			if len(tasks) > 0 {
+				data := &emailData{Digest: digest}
+				for _, task := range tasks {
+					data.Tasks = append(data.Tasks, emailTaskRow{EmailTask: task, Overdue: task.DueAt != nil && task.DueAt.Before(now)})
+				}
+				dedupeKey := fmt.Sprintf("digest:%d:%s:%s", settings.UserID, digest, start.Format("2006-01-02"))
+				message, err := s.message(settings, models.EmailKindDigest, "digest", data, dedupeKey)
+				if err != nil {
+					return err
+				}
+				if err := s.repo.CreateMessages(s.ctx, tx, []*models.TodoEmailMessages{message}); err != nil {
+					return err
+				}
+			}
+
+			settings.LastDigestAt = &now
+			return s.repo.SaveSettings(s.ctx, tx, settings)
+		})
+		if processed || err != nil {
+			return processed, err
+		}
+	}
+	return false, nil
+}
+
+// RunNext 寄出下一封到期的信件，沒有時回傳 false；失敗時依次數延後重試，
+// 收件者在寄出前取消訂閱的不再寄送
+func (s *TodoEmailService) RunNext(db *gorm.DB) (bool, error) {
+	if s.sender == nil {
+		return false, ErrEmailNoSender
+	}
+
+	message := &models.TodoEmailMessages{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		next, err := s.repo.LockNextMessage(s.ctx, tx, now, now.Add(-emailSendTimeout))
+		if err != nil || next == nil {
+			message = nil
+			return err
+		}
+
+		next.Status = models.EmailStatusSending
+		next.Attempts++
+		next.LastAttemptAt = &now
+		if err := s.repo.UpdateMessage(s.ctx, tx, next); err != nil {
+			return err
+		}
+
+		*message = *next
+		return nil
+	})
+	if err != nil || message == nil {
+		return false, err
+	}
+
+	if message.UserID != nil {
+		settings, err := s.repo.FindSettings(s.ctx, db, *message.UserID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			s.fail(message, ErrEmailUnsubscribe, true)
+			return true, s.repo.UpdateMessage(s.ctx, db, message)
+		case err != nil:
+			// 維持寄送中，逾時後重新寄送
+			return true, err
+		case !settings.Subscribed():
+			s.fail(message, ErrEmailUnsubscribe, true)
+			return true, s.repo.UpdateMessage(s.ctx, db, message)
+		}
+	}
+
+	sendErr := s.sender.Send(s.ctx, &mail.Message{
+		From:    s.from,
+		To:      message.ToAddress,
+		Subject: message.Subject,
+		Text:    message.TextBody,
+		HTML:    message.HTMLBody,
+		Headers: map[string]string{
+			// RFC 8058 一鍵取消訂閱，郵件軟體會以 POST 呼叫這個網址
+			"List-Unsubscribe":      "<" + message.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+	if sendErr == nil {
+		now := time.Now()
+		message.Status = models.EmailStatusSent
+		message.Error = ""
+		message.SentAt = &now
+	} else {
+		s.fail(message, sendErr, false)
+	}
+
+	return true, s.repo.UpdateMessage(s.ctx, db, message)
+}
+
+// fail 記錄失敗；dead 為 true 或次數用完時不再重試
+func (s *TodoEmailService) fail(message *models.TodoEmailMessages, err error, dead bool) {
+	message.Error = truncate(err.Error(), 1000)
+	if dead || message.Attempts >= emailMaxAttempts {
+		message.Status = models.EmailStatusDead
+		return
+	}
+	message.Status = models.EmailStatusPending
+	message.NextAttemptAt = time.Now().Add(webhook.Backoff(message.Attempts, emailRetryBase, emailRetryMax))
+}
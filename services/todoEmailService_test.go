@@ -0,0 +1,295 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/config"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/mail"
+	"todolist/pkg/mail/mailtest"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupEmailConfig() {
+	config.SMTPFrom = "Todolist <no-reply@example.com>"
+	config.AppBaseURL = "https://todo.example.com"
+	config.EmailDigestHour = 8
+	config.EmailDigestWeekday = time.Monday
+}
+
+func TestTodoEmailService_EnqueueNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupEmailConfig()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoEmailRepository(ctrl)
+	svc := services.NewTodoEmailService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	detailID := 5
+	due := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	unsubscribed := time.Now()
+	notifications := []*models.TodoNotifications{
+		{UserID: 2, Type: models.NotificationAssigned, TodoListDetailID: &detailID, DedupeKey: "assigned:e1"},
+		{UserID: 3, Type: models.NotificationAssigned, TodoListDetailID: &detailID, DedupeKey: "assigned:e1"},
+		{UserID: 4, Type: models.NotificationDueSoon, TodoListDetailID: &detailID, DedupeKey: "due_soon:detail:5:1"},
+		{UserID: 5, Type: models.NotificationAssigned, TodoListDetailID: &detailID, DedupeKey: "assigned:e1"},
+		// 不寄信的通知類型
+		{UserID: 2, Type: models.NotificationComment, TodoListDetailID: &detailID, DedupeKey: "comment:comment:1"},
+	}
+
+	mockRepo.EXPECT().FindSettingsByUsers(ctx, gomock.Any(), []int{2, 3, 4, 5}).Return([]*models.TodoEmailSettings{
+		{UserID: 2, Email: "amy@example.com", Locale: "en", NotifyAssigned: true, UnsubscribeToken: "token2"},
+		// 已取消訂閱
+		{UserID: 3, Email: "bob@example.com", Locale: "zh-TW", NotifyAssigned: true, UnsubscribedAt: &unsubscribed},
+		{UserID: 4, Email: "cat@example.com", Locale: "zh-TW", NotifyDue: true, UnsubscribeToken: "token4"},
+		// 關閉了指派通知
+		{UserID: 5, Email: "dan@example.com", Locale: "zh-TW", NotifyDue: true},
+	}, nil)
+	mockRepo.EXPECT().FindTasks(ctx, gomock.Any(), []int{5, 5, 5, 5}).Return([]*models.EmailTask{
+		{ID: 5, Name: "寫報告", ListName: "工作", Status: models.DetailStatusTodo, DueAt: &due},
+	}, nil)
+	mockRepo.EXPECT().CreateMessages(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, messages []*models.TodoEmailMessages) error {
+			require.Len(t, messages, 2)
+
+			assigned := messages[0]
+			assert.Equal(t, 2, *assigned.UserID)
+			assert.Equal(t, "amy@example.com", assigned.ToAddress)
+			assert.Equal(t, `You were assigned to "寫報告"`, assigned.Subject)
+			assert.Contains(t, assigned.TextBody, `You were assigned to "寫報告" in the list "工作".`)
+			assert.Contains(t, assigned.HTMLBody, `<html lang="en">`)
+			assert.Equal(t, "https://todo.example.com/api/email/unsubscribe?token=token2", assigned.UnsubscribeURL)
+			assert.Contains(t, assigned.TextBody, assigned.UnsubscribeURL)
+			assert.Equal(t, "notification:2:assigned:e1", assigned.DedupeKey)
+			assert.Equal(t, models.EmailStatusPending, assigned.Status)
+
+			dueSoon := messages[1]
+			assert.Equal(t, models.EmailKindDueSoon, dueSoon.Kind)
+			assert.Equal(t, "「寫報告」即將到期", dueSoon.Subject)
+			assert.Contains(t, dueSoon.TextBody, "「工作」清單中的「寫報告」將於 2026-03-02 12:00 到期。")
+			return nil
+		})
+
+	require.NoError(t, svc.EnqueueNotifications(db, notifications))
+}
+
+func TestTodoEmailService_RunDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupEmailConfig()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoEmailRepository(ctrl)
+	svc := services.NewTodoEmailService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	// 星期三 07:00 還沒到每日摘要的時間，每週摘要算本週一 08:00
+	now := time.Date(2026, 3, 4, 7, 0, 0, 0, time.Local)
+	monday := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local)
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockDigestDue(ctx, gomock.Any(), models.DigestWeekly, monday).Return(nil, nil)
+	sqlmock.ExpectCommit()
+	processed, err := svc.RunDigest(db, now)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	// 08:30 每日摘要到期
+	now = time.Date(2026, 3, 4, 8, 30, 0, 0, time.Local)
+	today := time.Date(2026, 3, 4, 8, 0, 0, 0, time.Local)
+	overdue, later := now.Add(-48*time.Hour), now.Add(48*time.Hour)
+	settings := &models.TodoEmailSettings{UserID: 2, Email: "amy@example.com", Locale: "zh-TW", Digest: models.DigestDaily, UnsubscribeToken: "token2"}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockDigestDue(ctx, gomock.Any(), models.DigestDaily, today).Return(settings, nil)
+	mockRepo.EXPECT().FindOpenTasks(ctx, gomock.Any(), 2).Return([]*models.EmailTask{
+		{ID: 5, Name: "繳費", ListName: "生活", Status: models.DetailStatusTodo, DueAt: &overdue},
+		{ID: 6, Name: "寫報告", ListName: "工作", Status: models.DetailStatusInProgress, DueAt: &later},
+		{ID: 7, Name: "整理", ListName: "生活", Status: models.DetailStatusTodo},
+	}, nil)
+	mockRepo.EXPECT().CreateMessages(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, messages []*models.TodoEmailMessages) error {
+			require.Len(t, messages, 1)
+			m := messages[0]
+			assert.Equal(t, models.EmailKindDigest, m.Kind)
+			assert.Equal(t, "每日待辦摘要：3 項未完成", m.Subject)
+			assert.Equal(t, "digest:2:daily:2026-03-04", m.DedupeKey)
+			assert.Contains(t, m.TextBody, "繳費")
+			assert.Contains(t, m.TextBody, "已逾期")
+			assert.Contains(t, m.TextBody, "進行中")
+			return nil
+		})
+	mockRepo.EXPECT().SaveSettings(ctx, gomock.Any(), settings).Return(nil)
+	sqlmock.ExpectCommit()
+
+	processed, err = svc.RunDigest(db, now)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, now, *settings.LastDigestAt)
+
+	// 沒有未完成的任務時不寄送，只記錄已處理
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockDigestDue(ctx, gomock.Any(), models.DigestDaily, today).Return(settings, nil)
+	mockRepo.EXPECT().FindOpenTasks(ctx, gomock.Any(), 2).Return(nil, nil)
+	mockRepo.EXPECT().SaveSettings(ctx, gomock.Any(), settings).Return(nil)
+	sqlmock.ExpectCommit()
+	processed, err = svc.RunDigest(db, now)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoEmailService_RunNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupEmailConfig()
+
+	server, err := mailtest.NewServer()
+	require.NoError(t, err)
+	defer server.Close()
+	host, port := server.Addr()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoEmailRepository(ctrl)
+	svc := services.NewTodoEmailService(ctx, mockRepo).WithSender(mail.NewSMTP(mail.SMTPConfig{Host: host, Port: port}))
+	db, sqlmock := setupMockDB(t)
+
+	userID := 2
+	settings := &models.TodoEmailSettings{UserID: 2, Email: "amy@example.com"}
+	run := func(attempts int, check func(m *models.TodoEmailMessages)) {
+		message := &models.TodoEmailMessages{
+			ID: 9, UserID: &userID, ToAddress: "amy@example.com", Subject: "「寫報告」已逾期",
+			TextBody: "內容", HTMLBody: "<p>內容</p>", UnsubscribeURL: "https://todo.example.com/api/email/unsubscribe?token=x",
+			Attempts: attempts, Status: models.EmailStatusPending,
+		}
+		sqlmock.ExpectBegin()
+		mockRepo.EXPECT().LockNextMessage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(message, nil)
+		mockRepo.EXPECT().UpdateMessage(ctx, gomock.Any(), message).
+			DoAndReturn(func(_ context.Context, _ *gorm.DB, m *models.TodoEmailMessages) error {
+				assert.Equal(t, models.EmailStatusSending, m.Status)
+				assert.Equal(t, attempts+1, m.Attempts)
+				return nil
+			})
+		sqlmock.ExpectCommit()
+		mockRepo.EXPECT().FindSettings(ctx, gomock.Any(), 2).Return(settings, nil)
+		mockRepo.EXPECT().UpdateMessage(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *gorm.DB, m *models.TodoEmailMessages) error {
+				check(m)
+				return nil
+			})
+
+		processed, err := svc.RunNext(db)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+
+	run(0, func(m *models.TodoEmailMessages) {
+		assert.Equal(t, models.EmailStatusSent, m.Status)
+		assert.NotNil(t, m.SentAt)
+	})
+	received := server.Messages()
+	require.Len(t, received, 1)
+	assert.Equal(t, "no-reply@example.com", received[0].From)
+	assert.Equal(t, "「寫報告」已逾期", received[0].Subject())
+	assert.Equal(t, "<https://todo.example.com/api/email/unsubscribe?token=x>", received[0].Header().Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", received[0].Header().Get("List-Unsubscribe-Post"))
+
+	// 暫時失敗時延後重試，次數用完後不再寄送
+	server.FailNext(2)
+	run(0, func(m *models.TodoEmailMessages) {
+		assert.Equal(t, models.EmailStatusPending, m.Status)
+		assert.NotEmpty(t, m.Error)
+		assert.True(t, m.NextAttemptAt.After(time.Now()))
+	})
+	run(7, func(m *models.TodoEmailMessages) {
+		assert.Equal(t, models.EmailStatusDead, m.Status)
+	})
+
+	// 寄出前取消訂閱
+	now := time.Now()
+	settings.UnsubscribedAt = &now
+	run(0, func(m *models.TodoEmailMessages) {
+		assert.Equal(t, models.EmailStatusDead, m.Status)
+		assert.Equal(t, services.ErrEmailUnsubscribe.Error(), m.Error)
+	})
+	assert.Len(t, server.Messages(), 1)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+
+	// 沒有設定 SMTP
+	_, err = services.NewTodoEmailService(ctx, mockRepo).RunNext(db)
+	assert.ErrorIs(t, err, services.ErrEmailNoSender)
+}
+
+func TestTodoEmailService_Settings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupEmailConfig()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockRepo := mocks.NewMockTodoEmailRepository(ctrl)
+	svc := services.NewTodoEmailService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	// 第一次儲存時產生取消訂閱 token
+	mockRepo.EXPECT().FindSettings(ctx, gomock.Any(), 3).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().SaveSettings(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	settings, err := svc.SaveSettings(db, "bob@example.com", "en", true, false, models.DigestWeekly)
+	require.NoError(t, err)
+	assert.Equal(t, 3, settings.UserID)
+	assert.Len(t, settings.UnsubscribeToken, 32)
+	assert.True(t, settings.Subscribed())
+
+	// 取消訂閱後重新儲存會恢復寄送，token 不變
+	token := settings.UnsubscribeToken
+	mockRepo.EXPECT().FindSettingsByToken(ctx, gomock.Any(), token).Return(settings, nil).Times(2)
+	mockRepo.EXPECT().SaveSettings(ctx, gomock.Any(), settings).Return(nil)
+	_, err = svc.Unsubscribe(db, token)
+	require.NoError(t, err)
+	unsubscribedAt := settings.UnsubscribedAt
+	require.NotNil(t, unsubscribedAt)
+	_, err = svc.Unsubscribe(db, token)
+	require.NoError(t, err)
+	assert.Same(t, unsubscribedAt, settings.UnsubscribedAt)
+	assert.False(t, settings.Subscribed())
+
+	mockRepo.EXPECT().FindSettings(ctx, gomock.Any(), 3).Return(settings, nil)
+	mockRepo.EXPECT().SaveSettings(ctx, gomock.Any(), settings).Return(nil)
+	settings, err = svc.SaveSettings(db, "bob@example.com", "en", true, true, models.DigestOff)
+	require.NoError(t, err)
+	assert.Equal(t, token, settings.UnsubscribeToken)
+	assert.True(t, settings.Subscribed())
+
+	_, err = services.NewTodoEmailService(context.Background(), mockRepo).Settings(db)
+	assert.ErrorIs(t, err, services.ErrNotLoggedIn)
+}
+
+func TestTodoEmailService_Unsubscribe_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 不需要登入，錯誤的 token 不能取消任何人的訂閱
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoEmailRepository(ctrl)
+	svc := services.NewTodoEmailService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	token := "0123456789abcdef0123456789abcdef"
+	mockRepo.EXPECT().FindSettingsByToken(ctx, gomock.Any(), token).Return(nil, gorm.ErrRecordNotFound).Times(2)
+	mockRepo.EXPECT().SaveSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	settings, err := svc.Unsubscribe(db, token)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, settings)
+
+	_, err = svc.FindByToken(db, token)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"todolist/models"
+	"todolist/pkg/storage"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrExportForbidden     = errors.New("只有建立者或管理員可以查看匯出工作")
+	ErrExportNotReady      = errors.New("匯出尚未完成")
+	ErrInvalidExportFormat = errors.New("format 必須為 csv 或 json")
+)
+
+// exportBatchSize 每次從資料庫讀取的 TodoList 數，匯出時只保留一批在記憶體中
+const exportBatchSize = 100
+
+// exportJobTimeout 執行超過這個時間仍未結束的工作視為中斷，會被重新執行
+const exportJobTimeout = 30 * time.Minute
+
+// exportCSVHeader CSV 每列為一個項目，沒有項目的 TodoList 也會輸出一列
+var exportCSVHeader = []string{
+	"list_id", "list_name", "type_id", "type_name", "list_labels",
+	"detail_id", "detail_name", "detail", "status", "assignees", "detail_labels",
+	"started_at", "completed_at", "created_at",
+}
+
+type TodoExportService struct {
+	ctx   context.Context
+	repo  interfaces.TodoExportJobRepository
+	lists interfaces.TodoListRepository
+	users interfaces.AuthRepository
+	store storage.Storage
+}
+
+func NewTodoExportService(ctx context.Context, repo interfaces.TodoExportJobRepository, lists interfaces.TodoListRepository, users interfaces.AuthRepository, store storage.Storage) *TodoExportService {
+	return &TodoExportService{
+		ctx:   ctx,
+		repo:  repo,
+		lists: lists,
+		users: users,
+		store: store,
+	}
+}
+
+// ExportContentType 匯出格式對應的 Content-Type
+func ExportContentType(format string) string {
+	if format == models.ExportFormatJSON {
+		return "application/json; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func checkExportFormat(format string) error {
+	if format != models.ExportFormatCSV && format != models.ExportFormatJSON {
+		return ErrInvalidExportFormat
+	}
+	return nil
+}
+
+// exportQuery 依篩選條件限制要匯出的 TodoList
+func exportQuery(db *gorm.DB, filter models.ExportFilter) *gorm.DB {
+	query := db.Model(&models.TodoList{})
+	if filter.ListID > 0 {
+		query = query.Where("to_do_list.id = ?", filter.ListID)
+	}
+	if filter.TypeID > 0 {
+		query = query.Where("to_do_list.type_id = ?", filter.TypeID)
+	}
+	if filter.Keyword != "" {
+		query = query.Where("to_do_list.name LIKE ?", "%"+filter.Keyword+"%")
+	}
+	// 符合任一標籤即可，與列表的篩選相同
+	if len(filter.LabelIDs) > 0 {
+		query = query.Where("to_do_list.id IN (?)", db.Table("to_do_list_labels").Select("to_do_list_id").Where("label_id IN ?", filter.LabelIDs))
+	}
+	return query
+}
+
+// Write 依篩選條件分批讀取 TodoList 並寫入 w，不會一次載入全部資料；
+// 在唯讀交易中讀取，每一批都看到同一個時間點的資料。
+// 指定單一 TodoList 但不存在時，在寫入任何內容前回傳 gorm.ErrRecordNotFound
+func (s *TodoExportService) Write(db *gorm.DB, filter models.ExportFilter, format string, w io.Writer) error {
+	if err := checkExportFormat(format); err != nil {
+		return err
+	}
+
+	out := newExportWriter(format, w)
+	return db.Transaction(func(tx *gorm.DB) error {
+		afterID := 0
+		for {
+			batch, err := s.lists.FindBatch(s.ctx, exportQuery(tx, filter), afterID, exportBatchSize, listPreloads(false))
+			if err != nil {
+				return err
+			}
+			if afterID == 0 {
+				if filter.ListID > 0 && len(batch) == 0 {
+					return gorm.ErrRecordNotFound
+				}
+				if err := out.begin(); err != nil {
+					return err
+				}
+			}
+
+			for _, list := range batch {
+				list.RollUpProgress()
+				if err := out.list(list); err != nil {
+					return err
+				}
+			}
+
+			if len(batch) < exportBatchSize {
+				return out.end()
+			}
+			afterID = batch[len(batch)-1].ID
+		}
+	}, &sql.TxOptions{ReadOnly: true})
+}
+
+// CreateJob 建立背景匯出工作，由排程執行
+func (s *TodoExportService) CreateJob(db *gorm.DB, filter models.ExportFilter, format string) (*models.TodoExportJobs, error) {
+	if err := checkExportFormat(format); err != nil {
+		return nil, err
+	}
+
+	if filter.ListID > 0 {
+		var count int64
+		if err := db.Model(&models.TodoList{}).Where("id = ?", filter.ListID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errors.New("list_id 不存在")
+		}
+	}
+
+	job := &models.TodoExportJobs{Format: format, Filter: filter, Status: models.ExportStatusPending}
+	if err := s.repo.Create(s.ctx, db, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ShowJob 取得匯出工作，只有建立者或 Admin 可以查看；完成時附上下載連結
+func (s *TodoExportService) ShowJob(db *gorm.DB, id int) (*models.TodoExportJobs, error) {
+	job, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOwner(s.ctx, db, s.users, job.CreatedBy, ErrExportForbidden); err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.ExportStatusDone {
+		job.DownloadURL = fmt.Sprintf("/api/todo/export-jobs/%d/download", job.ID)
+	}
+	return job, nil
+}
+
+// OpenJob 開啟已完成的匯出檔案，呼叫端負責 Close
+func (s *TodoExportService) OpenJob(db *gorm.DB, id int) (*models.TodoExportJobs, io.ReadCloser, error) {
+	job, err := s.ShowJob(db, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != models.ExportStatusDone {
+		return nil, nil, ErrExportNotReady
+	}
+
+	reader, err := s.store.Get(s.ctx, job.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, reader, nil
+}
+
+// RunNext 領取並執行下一個待執行的工作，沒有工作時回傳 false
+func (s *TodoExportService) RunNext(db *gorm.DB) (bool, error) {
+	job := &models.TodoExportJobs{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		next, err := s.repo.LockNext(s.ctx, tx, time.Now().Add(-exportJobTimeout))
+		if err != nil || next == nil {
+			job = nil
+			return err
+		}
+
+		now := time.Now()
+		next.Status = models.ExportStatusRunning
+		next.StartedAt = &now
+		if err := s.repo.Update(s.ctx, tx, next); err != nil {
+			return err
+		}
+
+		*job = *next
+		return nil
+	})
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	size, runErr := s.run(db, job)
+
+	now := time.Now()
+	job.FinishedAt = &now
+	if runErr != nil {
+		job.Status = models.ExportStatusFailed
+		job.Error = truncate(runErr.Error(), 1000)
+	} else {
+		job.Status = models.ExportStatusDone
+		job.FileSize = size
+	}
+	if err := s.repo.Update(s.ctx, db.Select("status", "storage_key", "file_size", "error", "finished_at", "updated_at"), job); err != nil {
+		return true, err
+	}
+	return true, runErr
+}
+
+// run 先寫到暫存檔，得知大小後再放到儲存空間
+func (s *TodoExportService) run(db *gorm.DB, job *models.TodoExportJobs) (int64, error) {
+	tmp, err := os.CreateTemp("", "export-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	buffered := bufio.NewWriter(tmp)
+	if err := s.Write(db, job.Filter, job.Format, buffered); err != nil {
+		return 0, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return 0, err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	job.StorageKey = fmt.Sprintf("exports/%d.%s", job.ID, job.Format)
+	if err := s.store.Put(s.ctx, job.StorageKey, tmp, size, ExportContentType(job.Format)); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// truncate 依字元數截斷，避免切在 UTF-8 字元中間
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+// exportWriter 依格式逐筆寫出 TodoList
+type exportWriter interface {
+	begin() error
+	list(list *models.TodoList) error
+	end() error
+}
+
+func newExportWriter(format string, w io.Writer) exportWriter {
+	if format == models.ExportFormatJSON {
+		return &jsonExportWriter{w: w}
+	}
+	return &csvExportWriter{w: w, csv: csv.NewWriter(w)}
+}
+
+// jsonExportWriter 輸出 TodoList 陣列，內容與 TodoList Show 相同
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonExportWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonExportWriter) list(list *models.TodoList) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.count++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonExportWriter) end() error {
+	_, err := io.WriteString(j.w, "]")
+	return err
+}
+
+// csvExportWriter 每個項目一列，開頭加上 BOM 讓 Excel 以 UTF-8 開啟
+type csvExportWriter struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (c *csvExportWriter) begin() error {
+	if _, err := io.WriteString(c.w, "\ufeff"); err != nil {
+		return err
+	}
+	return c.csv.Write(exportCSVHeader)
+}
+
+func (c *csvExportWriter) list(list *models.TodoList) error {
+	prefix := []string{
+		strconv.Itoa(list.ID), list.Name, strconv.Itoa(list.TypeID), list.Type.Name, labelNames(list.Labels),
+	}
+	if len(list.Details) == 0 {
+		if err := c.csv.Write(append(prefix, make([]string, len(exportCSVHeader)-len(prefix))...)); err != nil {
+			return err
+		}
+	}
+	for _, detail := range list.Details {
+		assignees := make([]string, len(detail.Users))
+		for i, user := range detail.Users {
+			assignees[i] = user.Account
+		}
+		row := append(append([]string{}, prefix...),
+			strconv.Itoa(detail.ID), detail.Name, detail.Detail, detail.Status,
+			strings.Join(assignees, ", "), labelNames(detail.Labels),
+			formatExportTime(detail.StartedAt), formatExportTime(detail.CompletedAt), formatExportTime(&detail.CreatedAt),
+		)
+		if err := c.csv.Write(row); err != nil {
+			return err
+		}
+	}
+	// 每個 TodoList 寫完就送出，不在記憶體中累積
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+func (c *csvExportWriter) end() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+func labelNames(labels []models.TodoLabels) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
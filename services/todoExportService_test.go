@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/models/base"
+	"todolist/pkg/storage"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func exportList(id int) *models.TodoList {
+	return &models.TodoList{
+		ID:     id,
+		Name:   "清單",
+		TypeID: 1,
+		Type:   models.TodoTypes{ID: 1, Name: "工作"},
+		Details: []models.TodoListDetails{
+			{ID: id * 10, Name: "實作", Status: models.DetailStatusTodo, Users: []models.User{{ID: 1, Account: "amy"}, {ID: 2, Account: "bob"}}},
+		},
+	}
+}
+
+func TestTodoExportService_Write_CSVInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLists := mocks.NewMockTodoListRepository(ctrl)
+	svc := services.NewTodoExportService(context.Background(), mocks.NewMockTodoExportJobRepository(ctrl), mockLists, mocks.NewMockAuthRepository(ctrl), nil)
+	db, sqlmock := setupMockDB(t)
+
+	full := make([]*models.TodoList, 100)
+	for i := range full {
+		full[i] = exportList(i + 1)
+	}
+	empty := &models.TodoList{ID: 101, Name: "空清單", TypeID: 1}
+
+	sqlmock.ExpectBegin()
+	// 一批讀滿時以最後一筆的 ID 繼續讀下一批
+	mockLists.EXPECT().FindBatch(gomock.Any(), gomock.Any(), 0, 100, gomock.Any()).Return(full, nil)
+	mockLists.EXPECT().FindBatch(gomock.Any(), gomock.Any(), 100, 100, gomock.Any()).Return([]*models.TodoList{empty}, nil)
+	sqlmock.ExpectCommit()
+
+	var buf bytes.Buffer
+	err := svc.Write(db, models.ExportFilter{}, models.ExportFormatCSV, &buf)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\ufeff"))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 102)
+	assert.Equal(t, "list_id", records[0][0])
+	assert.Equal(t, []string{"1", "清單", "1", "工作", "", "10", "實作", "", "todo", "amy, bob", "", "", "", ""}, records[1])
+	// 沒有項目的 TodoList 也會輸出一列
+	assert.Equal(t, "101", records[101][0])
+	assert.Equal(t, "", records[101][5])
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoExportService_Write_ListNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLists := mocks.NewMockTodoListRepository(ctrl)
+	svc := services.NewTodoExportService(context.Background(), mocks.NewMockTodoExportJobRepository(ctrl), mockLists, mocks.NewMockAuthRepository(ctrl), nil)
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	mockLists.EXPECT().FindBatch(gomock.Any(), gomock.Any(), 0, 100, gomock.Any()).Return(nil, nil)
+	sqlmock.ExpectRollback()
+
+	var buf bytes.Buffer
+	err := svc.Write(db, models.ExportFilter{ListID: 9}, models.ExportFormatJSON, &buf)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	// 還沒寫出任何內容，呼叫端仍可回傳錯誤
+	assert.Zero(t, buf.Len())
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoExportService_RunNext_StoresJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockJobs := mocks.NewMockTodoExportJobRepository(ctrl)
+	mockLists := mocks.NewMockTodoListRepository(ctrl)
+	svc := services.NewTodoExportService(context.Background(), mockJobs, mockLists, mocks.NewMockAuthRepository(ctrl), store)
+	db, sqlmock := setupMockDB(t)
+
+	job := &models.TodoExportJobs{ID: 7, Format: models.ExportFormatJSON, Status: models.ExportStatusPending}
+
+	sqlmock.ExpectBegin()
+	mockJobs.EXPECT().LockNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+	mockJobs.EXPECT().Update(gomock.Any(), gomock.Any(), job).Return(nil)
+	sqlmock.ExpectCommit()
+	sqlmock.ExpectBegin()
+	mockLists.EXPECT().FindBatch(gomock.Any(), gomock.Any(), 0, 100, gomock.Any()).Return([]*models.TodoList{exportList(1), exportList(2)}, nil)
+	sqlmock.ExpectCommit()
+	mockJobs.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, finished *models.TodoExportJobs) error {
+			assert.Equal(t, models.ExportStatusDone, finished.Status)
+			assert.Equal(t, "exports/7.json", finished.StorageKey)
+			assert.NotNil(t, finished.FinishedAt)
+			assert.Positive(t, finished.FileSize)
+			return nil
+		})
+
+	processed, err := svc.RunNext(db)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	r, err := store.Get(context.Background(), "exports/7.json")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+
+	var lists []models.TodoList
+	require.NoError(t, json.Unmarshal(data, &lists))
+	require.Len(t, lists, 2)
+	assert.Equal(t, "工作", lists[0].Type.Name)
+	assert.Equal(t, "bob", lists[1].Details[0].Users[1].Account)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoExportService_RunNext_RecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockJobs := mocks.NewMockTodoExportJobRepository(ctrl)
+	mockLists := mocks.NewMockTodoListRepository(ctrl)
+	svc := services.NewTodoExportService(context.Background(), mockJobs, mockLists, mocks.NewMockAuthRepository(ctrl), store)
+	db, sqlmock := setupMockDB(t)
+
+	job := &models.TodoExportJobs{ID: 8, Format: models.ExportFormatCSV, Filter: models.ExportFilter{ListID: 3}, Status: models.ExportStatusPending}
+
+	sqlmock.ExpectBegin()
+	mockJobs.EXPECT().LockNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+	mockJobs.EXPECT().Update(gomock.Any(), gomock.Any(), job).Return(nil)
+	sqlmock.ExpectCommit()
+	// 匯出的 TodoList 在排程執行前被刪除
+	sqlmock.ExpectBegin()
+	mockLists.EXPECT().FindBatch(gomock.Any(), gomock.Any(), 0, 100, gomock.Any()).Return(nil, nil)
+	sqlmock.ExpectRollback()
+	mockJobs.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, finished *models.TodoExportJobs) error {
+			assert.Equal(t, models.ExportStatusFailed, finished.Status)
+			assert.Equal(t, gorm.ErrRecordNotFound.Error(), finished.Error)
+			assert.Empty(t, finished.StorageKey)
+			assert.NotNil(t, finished.FinishedAt)
+			return nil
+		})
+
+	processed, err := svc.RunNext(db)
+
+	// 失敗的工作也算處理過，排程會繼續領取下一個
+	assert.True(t, processed)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = store.Get(context.Background(), "exports/8.csv")
+	assert.Error(t, err)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoExportService_RunNext_NoPendingJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobs := mocks.NewMockTodoExportJobRepository(ctrl)
+	svc := services.NewTodoExportService(context.Background(), mockJobs, mocks.NewMockTodoListRepository(ctrl), mocks.NewMockAuthRepository(ctrl), nil)
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	mockJobs.EXPECT().LockNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	sqlmock.ExpectCommit()
+
+	processed, err := svc.RunNext(db)
+
+	assert.NoError(t, err)
+	assert.False(t, processed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoExportService_OpenJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobs := mocks.NewMockTodoExportJobRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoExportService(ctx, mockJobs, mocks.NewMockTodoListRepository(ctrl), mockUsers, nil)
+	db, _ := setupMockDB(t)
+
+	// 其他使用者的工作，且不是 Admin
+	mockJobs.EXPECT().FindByID(ctx, gomock.Any(), 5).
+		Return(&models.TodoExportJobs{ID: 5, Status: models.ExportStatusDone, OperatorModel: base.OperatorModel{CreatedBy: uintPtr(2)}}, nil)
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 1, "Admin").Return(false, nil)
+
+	_, _, err := svc.OpenJob(db, 5)
+	assert.ErrorIs(t, err, services.ErrExportForbidden)
+
+	// 自己的工作但還沒完成
+	mockJobs.EXPECT().FindByID(ctx, gomock.Any(), 6).
+		Return(&models.TodoExportJobs{ID: 6, Status: models.ExportStatusRunning, OperatorModel: base.OperatorModel{CreatedBy: uintPtr(1)}}, nil)
+
+	_, _, err = svc.OpenJob(db, 6)
+	assert.ErrorIs(t, err, services.ErrExportNotReady)
+}
@@ -0,0 +1,436 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"todolist/models"
+	"todolist/pkg/importer"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrImportForbidden     = errors.New("只有建立者或管理員可以查看或確認匯入")
+	ErrInvalidImportSource = errors.New("source 必須為 csv 或 trello")
+	ErrImportEmpty         = errors.New("檔案中沒有可以匯入的資料")
+	ErrImportTooManyRows   = fmt.Errorf("一次最多匯入 %d 列", importMaxRows)
+)
+
+// importMaxRows 一次匯入的列數上限
+const importMaxRows = 5000
+
+// importDefaultType 來源沒有指定類型時，新建立的 TodoList 使用的類型名稱
+const importDefaultType = "匯入"
+
+// importStatusAliases 來源中常見的狀態寫法（小寫）對應到 TodoListDetails 的狀態
+var importStatusAliases = map[string]string{
+	"":            models.DetailStatusTodo,
+	"todo":        models.DetailStatusTodo,
+	"to do":       models.DetailStatusTodo,
+	"open":        models.DetailStatusTodo,
+	"待辦":          models.DetailStatusTodo,
+	"未開始":         models.DetailStatusTodo,
+	"in_progress": models.DetailStatusInProgress,
+	"in progress": models.DetailStatusInProgress,
+	"doing":       models.DetailStatusInProgress,
+	"進行中":         models.DetailStatusInProgress,
+	"done":        models.DetailStatusDone,
+	"completed":   models.DetailStatusDone,
+	"complete":    models.DetailStatusDone,
+	"closed":      models.DetailStatusDone,
+	"完成":          models.DetailStatusDone,
+	"已完成":         models.DetailStatusDone,
+}
+
+type TodoImportService struct {
+	ctx     context.Context
+	repo    interfaces.TodoImportRepository
+	users   interfaces.AuthRepository
+	types   *TodoTypeService
+	lists   *TodoListService
+	details *TodoListDetailsService
+}
+
+// NewTodoImportService 確認匯入時以 types、lists、details 建立資料，與一般新增走相同的檢查
+func NewTodoImportService(ctx context.Context, repo interfaces.TodoImportRepository, users interfaces.AuthRepository, types *TodoTypeService, lists *TodoListService, details *TodoListDetailsService) *TodoImportService {
+	return &TodoImportService{
+		ctx:     ctx,
+		repo:    repo,
+		users:   users,
+		types:   types,
+		lists:   lists,
+		details: details,
+	}
+}
+
+// parseImport 依來源解析檔案，CSV 需要欄位對應
+func parseImport(source string, r io.Reader, mapping importer.Mapping) ([]importer.Record, error) {
+	switch source {
+	case models.ImportSourceCSV:
+		return importer.ParseCSV(r, mapping)
+	case models.ImportSourceTrello:
+		return importer.ParseTrello(r)
+	default:
+		return nil, ErrInvalidImportSource
+	}
+}
+
+// Validate 解析並逐列驗證（dry run），不建立任何 TodoList；結果保存下來供查看報告與確認
+func (s *TodoImportService) Validate(db *gorm.DB, source, fileName string, r io.Reader, mapping importer.Mapping) (*models.TodoImports, error) {
+	records, err := parseImport(source, r, mapping)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(records) > importMaxRows {
+		return nil, ErrImportTooManyRows
+	}
+
+	rows := make([]*models.TodoImportRows, len(records))
+	for i, record := range records {
+		rows[i] = validateImportRecord(record)
+	}
+
+	if err := s.checkAssignees(db, rows); err != nil {
+		return nil, err
+	}
+	resolveImportTypes(rows)
+	if err := s.checkExistingLists(db, rows); err != nil {
+		return nil, err
+	}
+
+	item := &models.TodoImports{
+		Source:    source,
+		FileName:  truncate(fileName, 255),
+		Status:    models.ImportStatusValidated,
+		TotalRows: len(rows),
+	}
+	for _, row := range rows {
+		if row.Valid {
+			item.ValidRows++
+		}
+		if len(row.Errors) > 0 {
+			item.ErrorRows++
+		}
+		if len(row.Warnings) > 0 {
+			item.WarningRows++
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Create(s.ctx, tx, item); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			row.ImportID = item.ID
+		}
+		return s.repo.CreateRows(s.ctx, tx, rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// validateImportRecord 檢查單列的必填欄位、長度與狀態，帳號與同名 TodoList 需查資料庫，另外處理
+func validateImportRecord(record importer.Record) *models.TodoImportRows {
+	row := &models.TodoImportRows{
+		RowNo:     record.Row,
+		ListName:  record.List,
+		TypeName:  record.Type,
+		Name:      record.Name,
+		Detail:    record.Detail,
+		Status:    models.DetailStatusTodo,
+		Assignees: []string{},
+		Errors:    []string{},
+		Warnings:  []string{},
+	}
+
+	if record.Skip != "" {
+		row.Warnings = append(row.Warnings, "略過："+record.Skip)
+		return row
+	}
+
+	if row.ListName == "" {
+		row.Errors = append(row.Errors, "list 不可為空")
+	} else if utf8.RuneCountInString(row.ListName) > 255 {
+		row.Errors = append(row.Errors, "list 不可超過 255 字")
+		row.ListName = truncate(row.ListName, 255)
+	}
+	if row.Name == "" {
+		row.Errors = append(row.Errors, "name 不可為空")
+	} else if utf8.RuneCountInString(row.Name) > 255 {
+		row.Errors = append(row.Errors, "name 不可超過 255 字")
+		row.Name = truncate(row.Name, 255)
+	}
+	if utf8.RuneCountInString(row.TypeName) > 255 {
+		row.Errors = append(row.Errors, "type 不可超過 255 字")
+		row.TypeName = truncate(row.TypeName, 255)
+	}
+
+	if status, ok := importStatusAliases[strings.ToLower(strings.TrimSpace(record.Status))]; ok {
+		row.Status = status
+	} else {
+		row.Errors = append(row.Errors, fmt.Sprintf("無法辨識的狀態 %q", record.Status))
+	}
+
+	row.Assignees = append(row.Assignees, record.Assignees...)
+
+	row.Valid = len(row.Errors) == 0
+	return row
+}
+
+// checkAssignees 找不到的帳號不指派，只加上警告；同一列重複的帳號只指派一次
+func (s *TodoImportService) checkAssignees(db *gorm.DB, rows []*models.TodoImportRows) error {
+	var accounts []string
+	for _, row := range rows {
+		accounts = append(accounts, row.Assignees...)
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	users, err := s.users.FindByAccounts(s.ctx, db, accounts)
+	if err != nil {
+		return err
+	}
+	// 資料庫比對帳號不分大小寫，改存實際的帳號
+	known := make(map[string]string, len(users))
+	for _, user := range users {
+		known[strings.ToLower(user.Account)] = user.Account
+	}
+
+	for _, row := range rows {
+		assignees := []string{}
+		seen := map[string]bool{}
+		for _, account := range row.Assignees {
+			if actual, ok := known[strings.ToLower(account)]; ok {
+				if !seen[actual] {
+					seen[actual] = true
+					assignees = append(assignees, actual)
+				}
+			} else {
+				row.Warnings = append(row.Warnings, fmt.Sprintf("找不到帳號 %q，不會指派", account))
+			}
+		}
+		row.Assignees = assignees
+	}
+	return nil
+}
+
+// resolveImportTypes 同一個 TodoList 的所有列統一使用第一個有指定的類型，
+// 確認時不論從哪一列繼續都會建立相同類型的 TodoList
+func resolveImportTypes(rows []*models.TodoImportRows) {
+	types := map[string]string{}
+	for _, row := range rows {
+		if row.Valid && row.TypeName != "" && types[row.ListName] == "" {
+			types[row.ListName] = row.TypeName
+		}
+	}
+
+	for _, row := range rows {
+		if !row.Valid {
+			continue
+		}
+		typeName := types[row.ListName]
+		if typeName == "" {
+			typeName = importDefaultType
+		}
+		if row.TypeName != "" && row.TypeName != typeName {
+			row.Warnings = append(row.Warnings, fmt.Sprintf("同一個 TodoList 只能有一個類型，將使用 %q", typeName))
+		}
+		row.TypeName = typeName
+	}
+}
+
+// checkExistingLists 已有同名的 TodoList 時項目會加入其中，類型維持原本的
+func (s *TodoImportService) checkExistingLists(db *gorm.DB, rows []*models.TodoImportRows) error {
+	var names []string
+	seen := map[string]bool{}
+	for _, row := range rows {
+		if row.Valid && !seen[row.ListName] {
+			seen[row.ListName] = true
+			names = append(names, row.ListName)
+		}
+	}
+
+	existing, err := s.repo.FindExistingListNames(s.ctx, db, names)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	for _, row := range rows {
+		if row.Valid && exists[row.ListName] {
+			row.Warnings = append(row.Warnings, "已有同名的 TodoList，項目會加入其中")
+		}
+	}
+	return nil
+}
+
+// Show 取得匯入，只有建立者或 Admin 可以查看
+func (s *TodoImportService) Show(db *gorm.DB, id int) (*models.TodoImports, error) {
+	item, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOwner(s.ctx, db, s.users, item.CreatedBy, ErrImportForbidden); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Rows 每一列的驗證結果，problems 為 true 時只列出有錯誤或警告的列
+func (s *TodoImportService) Rows(db *gorm.DB, id int, problems bool, page, pageSize int) (*utils.PaginatedResult[*models.TodoImportRows], error) {
+	if _, err := s.Show(db, id); err != nil {
+		return nil, err
+	}
+
+	rows, total, err := s.repo.FindRows(s.ctx, db, id, problems, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewPaginatedResult(rows, total, page, pageSize), nil
+}
+
+// Commit 依 TodoList 逐一建立驗證通過的列，每個 TodoList 一個交易；
+// 中途失敗時已完成的 TodoList 保留，匯入標記為 failed，再次確認會從還沒建立的 TodoList 繼續
+func (s *TodoImportService) Commit(db *gorm.DB, id int) (*models.TodoImports, error) {
+	item, err := s.Show(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == models.ImportStatusCommitted {
+		return item, nil
+	}
+
+	names, err := s.repo.PendingListNames(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if commitErr := s.commitList(db, id, name); commitErr != nil {
+			commitErr = fmt.Errorf("TodoList %q：%w", name, commitErr)
+			item.Status = models.ImportStatusFailed
+			item.Error = truncate(commitErr.Error(), 1000)
+			if err := s.repo.Update(s.ctx, db.Select("status", "error", "updated_at", "updated_by"), item); err != nil {
+				return nil, err
+			}
+			return nil, commitErr
+		}
+	}
+
+	now := time.Now()
+	item.Status = models.ImportStatusCommitted
+	item.Error = ""
+	item.CommittedAt = &now
+	if err := s.repo.Update(s.ctx, db.Select("status", "error", "committed_at", "updated_at", "updated_by"), item); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(s.ctx, db, id)
+}
+
+// commitList 建立一個 TodoList 還沒建立的項目；先鎖定匯入，同時確認同一個匯入時會依序執行而不會重複建立
+func (s *TodoImportService) commitList(db *gorm.DB, id int, listName string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.LockByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := s.repo.LockPendingRows(s.ctx, tx, id, listName)
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+
+		list, err := s.findOrCreateList(tx, listName, rows[0].TypeName)
+		if err != nil {
+			return err
+		}
+
+		userIDs, err := s.resolveAccounts(tx, rows)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			ids := []int{}
+			for _, account := range row.Assignees {
+				if userID, ok := userIDs[account]; ok {
+					ids = append(ids, userID)
+				}
+			}
+
+			detail, err := s.details.Create(tx, list.ID, row.Name, row.Detail, ids)
+			if err != nil {
+				return fmt.Errorf("第 %d 列：%w", row.RowNo, err)
+			}
+			if row.Status != models.DetailStatusTodo {
+				if _, err := s.details.ChangeStatus(tx, detail.ID, row.Status); err != nil {
+					return fmt.Errorf("第 %d 列：%w", row.RowNo, err)
+				}
+			}
+			if err := s.repo.MarkRowImported(s.ctx, tx, row.ID, detail.ID); err != nil {
+				return err
+			}
+		}
+
+		item.ImportedRows += len(rows)
+		return s.repo.Update(s.ctx, tx.Select("imported_rows", "updated_at", "updated_by"), item)
+	})
+}
+
+// findOrCreateList 已有同名的 TodoList 時直接使用，否則以 typeName 的類型建立（類型不存在時一併建立）
+func (s *TodoImportService) findOrCreateList(tx *gorm.DB, name, typeName string) (*models.TodoList, error) {
+	list, err := s.repo.FindListByName(s.ctx, tx, name)
+	if err != nil || list != nil {
+		return list, err
+	}
+
+	todoType, err := s.repo.FindTypeByName(s.ctx, tx, typeName)
+	if err != nil {
+		return nil, err
+	}
+	if todoType == nil {
+		if todoType, err = s.types.Create(tx, typeName); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.lists.Create(tx, name, todoType.ID)
+}
+
+// resolveAccounts 帳號對應到 User ID；驗證後才被刪除的帳號不會出現在結果中，不指派
+func (s *TodoImportService) resolveAccounts(tx *gorm.DB, rows []*models.TodoImportRows) (map[string]int, error) {
+	var accounts []string
+	for _, row := range rows {
+		accounts = append(accounts, row.Assignees...)
+	}
+	userIDs := make(map[string]int)
+	if len(accounts) == 0 {
+		return userIDs, nil
+	}
+
+	users, err := s.users.FindByAccounts(s.ctx, tx, accounts)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		userIDs[user.Account] = user.ID
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,307 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/importer"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func importedBy(status string, userID uint) *models.TodoImports {
+	item := &models.TodoImports{ID: 7, Source: models.ImportSourceCSV, Status: status}
+	item.CreatedBy = &userID
+	return item
+}
+
+func TestTodoImportService_Validate_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mockUsers,
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, mock := setupMockDB(t)
+
+	data := "Project,Kind,Task,State,Owner\n" +
+		"Website,Dev,Landing page,Done,\"Alice, ghost, alice\"\n" +
+		"Website,Ops,Footer,,\n" +
+		"Website,,,todo,\n" +
+		"Ops,,Backup,blocked,\n" +
+		"Ops,,Restore,進行中,\n"
+	mapping := importer.Mapping{
+		importer.FieldList:      "Project",
+		importer.FieldType:      "Kind",
+		importer.FieldName:      "Task",
+		importer.FieldStatus:    "State",
+		importer.FieldAssignees: "Owner",
+	}
+
+	var saved []*models.TodoImportRows
+	mockUsers.EXPECT().FindByAccounts(ctx, gomock.Any(), []string{"Alice", "ghost", "alice"}).
+		Return([]models.User{{ID: 3, Account: "alice"}}, nil)
+	mockRepo.EXPECT().FindExistingListNames(ctx, gomock.Any(), []string{"Website", "Ops"}).Return([]string{"Ops"}, nil)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, item *models.TodoImports) error {
+			item.ID = 7
+			return nil
+		})
+	mockRepo.EXPECT().CreateRows(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, rows []*models.TodoImportRows) error {
+			saved = rows
+			return nil
+		})
+	mock.ExpectCommit()
+
+	item, err := svc.Validate(db, models.ImportSourceCSV, "tasks.csv", strings.NewReader(data), mapping)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusValidated, item.Status)
+	assert.Equal(t, 5, item.TotalRows)
+	assert.Equal(t, 3, item.ValidRows)
+	assert.Equal(t, 2, item.ErrorRows)
+	assert.Equal(t, 3, item.WarningRows)
+
+	require.Len(t, saved, 5)
+	for _, row := range saved {
+		assert.Equal(t, 7, row.ImportID)
+	}
+
+	// 帳號不分大小寫，重複的只指派一次，找不到的只警告
+	assert.True(t, saved[0].Valid)
+	assert.Equal(t, models.DetailStatusDone, saved[0].Status)
+	assert.Equal(t, []string{"alice"}, saved[0].Assignees)
+	assert.Equal(t, []string{`找不到帳號 "ghost"，不會指派`}, saved[0].Warnings)
+
+	// 同一個 TodoList 統一使用第一個指定的類型
+	assert.Equal(t, "Dev", saved[1].TypeName)
+	assert.Len(t, saved[1].Warnings, 1)
+
+	assert.False(t, saved[2].Valid)
+	assert.Equal(t, []string{"name 不可為空"}, saved[2].Errors)
+	assert.False(t, saved[3].Valid)
+	assert.Equal(t, []string{`無法辨識的狀態 "blocked"`}, saved[3].Errors)
+
+	// 已存在的 TodoList 只警告；沒有指定類型時使用預設類型
+	assert.True(t, saved[4].Valid)
+	assert.Equal(t, models.DetailStatusInProgress, saved[4].Status)
+	assert.Equal(t, "匯入", saved[4].TypeName)
+	assert.Equal(t, []string{"已有同名的 TodoList，項目會加入其中"}, saved[4].Warnings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoImportService_Commit_StopsAtFailedList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl),
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, mock := setupMockDB(t)
+
+	item := importedBy(models.ImportStatusValidated, 1)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+	mockRepo.EXPECT().PendingListNames(ctx, gomock.Any(), 7).Return([]string{"Website", "Ops"}, nil)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 7).Return(item, nil)
+	mockRepo.EXPECT().LockPendingRows(ctx, gomock.Any(), 7, "Website").
+		Return([]*models.TodoImportRows{{ID: 1, ImportID: 7, ListName: "Website", TypeName: "Dev", Name: "Landing page"}}, nil)
+	mockRepo.EXPECT().FindListByName(ctx, gomock.Any(), "Website").Return(nil, errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	// 失敗後不再處理後面的 TodoList
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, updated *models.TodoImports) error {
+			assert.Equal(t, models.ImportStatusFailed, updated.Status)
+			assert.Equal(t, `TodoList "Website"：connection reset`, updated.Error)
+			return nil
+		})
+
+	_, err := svc.Commit(db, 7)
+
+	assert.EqualError(t, err, `TodoList "Website"：connection reset`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoImportService_Commit_ResumesPendingLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	mockDetails := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl),
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mockDetails))
+	db, mock := setupMockDB(t)
+
+	item := importedBy(models.ImportStatusFailed, 1)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+	// 上次已建立 Website，只剩 Ops
+	mockRepo.EXPECT().PendingListNames(ctx, gomock.Any(), 7).Return([]string{"Ops"}, nil)
+
+	locked := &models.TodoImports{ID: 7, ImportedRows: 2}
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 7).Return(locked, nil)
+	mockRepo.EXPECT().LockPendingRows(ctx, gomock.Any(), 7, "Ops").
+		Return([]*models.TodoImportRows{{ID: 3, ImportID: 7, RowNo: 5, ListName: "Ops", TypeName: "匯入", Name: "Backup", Status: models.DetailStatusTodo, Assignees: []string{}}}, nil)
+	mockRepo.EXPECT().FindListByName(ctx, gomock.Any(), "Ops").Return(&models.TodoList{ID: 4, Name: "Ops"}, nil)
+
+	// TodoListDetailsService.Create
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockDetails.EXPECT().LastPosition(ctx, gomock.Any(), 4, 0).Return("", nil)
+	mockDetails.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, detail *models.TodoListDetails) error {
+			detail.ID = 30
+			return nil
+		})
+	mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`UPDATE "to_do_list_details" SET "updated_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mockRepo.EXPECT().MarkRowImported(ctx, gomock.Any(), 3, 30).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), locked).Return(nil)
+	mock.ExpectCommit()
+
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).
+		DoAndReturn(func(_ context.Context, _ interface{}, updated *models.TodoImports) error {
+			assert.Equal(t, models.ImportStatusCommitted, updated.Status)
+			assert.NotNil(t, updated.CommittedAt)
+			return nil
+		})
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+
+	result, err := svc.Commit(db, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusCommitted, result.Status)
+	assert.Equal(t, 3, locked.ImportedRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoImportService_Validate_RejectsBeforeSaving(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// repository 沒有任何預期的呼叫：超過上限或沒有資料時不查資料庫也不建立匯入
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mocks.NewMockTodoImportRepository(ctrl), mocks.NewMockAuthRepository(ctrl),
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, mock := setupMockDB(t)
+	mapping := importer.Mapping{importer.FieldList: "List", importer.FieldName: "Name"}
+
+	_, err := svc.Validate(db, models.ImportSourceCSV, "empty.csv", strings.NewReader("List,Name\n"), mapping)
+	assert.ErrorIs(t, err, services.ErrImportEmpty)
+
+	var data strings.Builder
+	data.WriteString("List,Name\n")
+	for i := 0; i <= 5000; i++ {
+		data.WriteString("Bulk,task\n")
+	}
+	_, err = svc.Validate(db, models.ImportSourceCSV, "bulk.csv", strings.NewReader(data.String()), mapping)
+	assert.ErrorIs(t, err, services.ErrImportTooManyRows)
+
+	_, err = svc.Validate(db, "asana", "tasks.json", strings.NewReader("{}"), nil)
+	assert.ErrorIs(t, err, services.ErrInvalidImportSource)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoImportService_Commit_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mockUsers,
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(importedBy(models.ImportStatusValidated, 2), nil)
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 1, "Admin").Return(false, nil)
+
+	_, err := svc.Commit(db, 7)
+
+	assert.ErrorIs(t, err, services.ErrImportForbidden)
+}
+
+func TestTodoImportService_Commit_AlreadyCommitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl),
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, mock := setupMockDB(t)
+
+	// 重複確認直接回傳，不會再找待建立的 TodoList
+	item := importedBy(models.ImportStatusCommitted, 1)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+
+	result, err := svc.Commit(db, 7)
+
+	require.NoError(t, err)
+	assert.Same(t, item, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoImportService_Commit_SkipsListFinishedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoImportRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoImportService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl),
+		services.NewTodoTypeService(ctx, mocks.NewMockTodoTypeRepository(ctrl)),
+		services.NewTodoListService(ctx, mocks.NewMockTodoListRepository(ctrl)),
+		services.NewTodoListDetailsService(ctx, mocks.NewMockTodoListDetailsRepository(ctrl)))
+	db, mock := setupMockDB(t)
+
+	item := importedBy(models.ImportStatusValidated, 1)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+	mockRepo.EXPECT().PendingListNames(ctx, gomock.Any(), 7).Return([]string{"Website"}, nil)
+
+	// 另一個同時進行的確認已經建立完 Website：鎖定後沒有待建立的列，不建立 TodoList
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 7).Return(&models.TodoImports{ID: 7, ImportedRows: 3}, nil)
+	mockRepo.EXPECT().LockPendingRows(ctx, gomock.Any(), 7, "Website").Return(nil, nil)
+	mock.ExpectCommit()
+
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 7).Return(item, nil)
+
+	result, err := svc.Commit(db, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportStatusCommitted, result.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+type TodoLabelService struct {
+	ctx  context.Context
+	repo interfaces.TodoLabelRepository
+}
+
+func NewTodoLabelService(ctx context.Context, repo interfaces.TodoLabelRepository) *TodoLabelService {
+	return &TodoLabelService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// 預設標籤顏色
+const defaultLabelColor = "#808080"
+
+func (s *TodoLabelService) Create(db *gorm.DB, name string, color string) (*models.TodoLabels, error) {
+	if color == "" {
+		color = defaultLabelColor
+	}
+	result := &models.TodoLabels{Name: name, Color: color}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 檢查名稱是否重複
+		exist, err := s.repo.IsNameExist(s.ctx, tx, name, 0)
+		if err != nil {
+			return err
+		}
+		if exist {
+			return errors.New("名稱已存在")
+		}
+		// 建立
+		return s.repo.Create(s.ctx, tx, result)
+	})
+
+	return result, err
+}
+
+func (s *TodoLabelService) Index(db *gorm.DB, keyword string, page, pageSize int, orderBy []string) (*utils.PaginatedResult[*models.TodoLabels], error) {
+	query := db.Model(&models.TodoLabels{})
+	if keyword != "" {
+		query = query.Where("name LIKE ?", "%"+keyword+"%")
+	}
+
+	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, orderBy...)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+func (s *TodoLabelService) Show(db *gorm.DB, id int) (*models.TodoLabels, error) {
+	return s.repo.FindByID(s.ctx, db, id)
+}
+
+// Usage 回傳每個標籤的使用次數
+func (s *TodoLabelService) Usage(db *gorm.DB) ([]*models.TodoLabelUsage, error) {
+	return s.repo.Usage(s.ctx, db)
+}
+
+func (s *TodoLabelService) Edit(db *gorm.DB, id int, name string, color string) (*models.TodoLabels, error) {
+	updated := &models.TodoLabels{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 名稱是否已存在（排除自己）
+		exist, err := s.repo.IsNameExist(s.ctx, tx, name, id)
+		if err != nil {
+			return err
+		}
+		if exist {
+			return errors.New("名稱已存在")
+		}
+
+		// 取得原本資料
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// 更新內容
+		item.Name = name
+		if color != "" {
+			item.Color = color
+		}
+		if err := s.repo.Update(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Delete 軟刪除標籤，並解除它與所有任務的關聯（任務本身不受影響）
+func (s *TodoLabelService) Delete(db *gorm.DB, id int) (*models.TodoLabels, error) {
+	var deleted *models.TodoLabels
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 先取得資料
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// 解除關聯
+		if err := s.repo.DetachAll(s.ctx, tx, id); err != nil {
+			return err
+		}
+
+		// 軟刪除
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// findLabels 依 ID 取出標籤，並確認全部存在
+func findLabels(tx *gorm.DB, ids []int) ([]models.TodoLabels, error) {
+	unique := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		unique[id] = struct{}{}
+	}
+
+	var labels []models.TodoLabels
+	if err := tx.Where("id IN ?", ids).Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	if len(labels) != len(unique) {
+		return nil, errors.New("部分 Label ID 不存在")
+	}
+	return labels, nil
+}
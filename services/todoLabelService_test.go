@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoLabelService_Create_DefaultColor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoLabelRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoLabelService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectCommit()
+
+	mockRepo.EXPECT().
+		IsNameExist(ctx, gomock.Any(), "緊急", 0).
+		Return(false, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Create(ctx, gomock.Any(), gomock.AssignableToTypeOf(&models.TodoLabels{})).
+		Return(nil).
+		Times(1)
+
+	// 未指定顏色時使用預設顏色
+	result, err := service.Create(db, "緊急", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "緊急", result.Name)
+	assert.Equal(t, "#808080", result.Color)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoLabelService_Create_NameExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoLabelRepository(ctrl)
+	ctx := context.Background()
+	service := services.NewTodoLabelService(ctx, mockRepo)
+
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectRollback()
+
+	mockRepo.EXPECT().
+		IsNameExist(ctx, gomock.Any(), "緊急", 0).
+		Return(true, nil).
+		Times(1)
+
+	_, err := service.Create(db, "緊急", "#ff0000")
+
+	assert.EqualError(t, err, "名稱已存在")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoLabelService_Edit_KeepColor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoLabelRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+
+	svc := services.NewTodoLabelService(ctx, mockRepo)
+
+	id := 1
+	existing := &models.TodoLabels{ID: id, Name: "舊名稱", Color: "#00ff00"}
+
+	sqlmock.ExpectBegin()
+
+	mockRepo.EXPECT().
+		IsNameExist(ctx, gomock.Any(), "新名稱", id).
+		Return(false, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any(), id).
+		Return(existing, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Update(ctx, gomock.Any(), gomock.AssignableToTypeOf(&models.TodoLabels{})).
+		DoAndReturn(func(ctx context.Context, tx any, label *models.TodoLabels) error {
+			assert.Equal(t, "新名稱", label.Name)
+			// 沒有帶顏色時保留原本的顏色
+			assert.Equal(t, "#00ff00", label.Color)
+			return nil
+		}).
+		Times(1)
+
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Edit(db, id, "新名稱", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "新名稱", result.Name)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoLabelService_Delete_DetachesBeforeSoftDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoLabelRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+
+	svc := services.NewTodoLabelService(ctx, mockRepo)
+
+	id := 1
+	existing := &models.TodoLabels{ID: id, Name: "測試標籤"}
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectCommit()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(ctx, gomock.Any(), id).Return(existing, nil),
+		// 先解除與任務的關聯，任務本身不會被刪除
+		mockRepo.EXPECT().DetachAll(ctx, gomock.Any(), id).Return(nil),
+		mockRepo.EXPECT().SoftDelete(ctx, gomock.Any(), existing).Return(nil),
+	)
+
+	result, err := svc.Delete(db, id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoLabelService_Delete_DetachFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoLabelRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+
+	svc := services.NewTodoLabelService(ctx, mockRepo)
+
+	id := 1
+	existing := &models.TodoLabels{ID: id, Name: "測試標籤"}
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectRollback()
+
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), id).Return(existing, nil)
+	mockRepo.EXPECT().DetachAll(ctx, gomock.Any(), id).Return(errors.New("解除關聯失敗"))
+
+	result, err := svc.Delete(db, id)
+
+	assert.EqualError(t, err, "解除關聯失敗")
+	assert.Nil(t, result)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
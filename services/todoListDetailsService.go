@@ -3,15 +3,27 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 	"todolist/models"
+	"todolist/pkg/rank"
+	"todolist/repositories/base"
 	"todolist/repositories/interfaces"
+	"todolist/utils"
 
 	"gorm.io/gorm"
 )
 
 type TodoListDetailsService struct {
-	ctx  context.Context
-	repo interfaces.TodoListDetailsRepository
+	ctx        context.Context
+	repo       interfaces.TodoListDetailsRepository
+	recurrence *TodoRecurrenceService
+	board      *TodoBoardService
+	checklist  *TodoChecklistService
+	versions   *TodoVersionService
+	events     *TodoOutboxService
+	watchers   *TodoWatcherService
 }
 
 func NewTodoListDetailsService(ctx context.Context, repo interfaces.TodoListDetailsRepository) *TodoListDetailsService {
@@ -21,65 +33,351 @@ func NewTodoListDetailsService(ctx context.Context, repo interfaces.TodoListDeta
 	}
 }
 
+// WithRecurrence 設定後，週期性任務完成時會在同一個交易內產生下一次
+func (s *TodoListDetailsService) WithRecurrence(recurrence *TodoRecurrenceService) *TodoListDetailsService {
+	s.recurrence = recurrence
+	return s
+}
+
+// WithBoard 設定後，變更狀態時會檢查看板的狀態轉換規則與 WIP 上限
+func (s *TodoListDetailsService) WithBoard(board *TodoBoardService) *TodoListDetailsService {
+	s.board = board
+	return s
+}
+
+// WithChecklist 設定後，新增或修改說明時會將其中的 - [ ] 待辦同步為 checklist 項目
+func (s *TodoListDetailsService) WithChecklist(checklist *TodoChecklistService) *TodoListDetailsService {
+	s.checklist = checklist
+	return s
+}
+
+// WithVersions 設定後可以還原到先前的版本
+func (s *TodoListDetailsService) WithVersions(versions *TodoVersionService) *TodoListDetailsService {
+	s.versions = versions
+	return s
+}
+
+// WithEvents 設定後，新增、修改、變更狀態與刪除時會在同一個交易內寫入領域事件
+func (s *TodoListDetailsService) WithEvents(events *TodoOutboxService) *TodoListDetailsService {
+	s.events = events
+	return s
+}
+
+// WithWatchers 設定後，建立者與新的負責人會在同一個交易內自動關注項目
+func (s *TodoListDetailsService) WithWatchers(watchers *TodoWatcherService) *TodoListDetailsService {
+	s.watchers = watchers
+	return s
+}
+
+// autoWatch 未設定 watchers 時不處理
+func (s *TodoListDetailsService) autoWatch(tx *gorm.DB, item *models.TodoListDetails, userIDs []int) error {
+	if s.watchers == nil {
+		return nil
+	}
+	return s.watchers.AutoWatchDetail(tx, item.ID, userIDs)
+}
+
+// publish 未設定 events 時不處理
+func (s *TodoListDetailsService) publish(tx *gorm.DB, event string, item *models.TodoListDetails) error {
+	if s.events == nil {
+		return nil
+	}
+	return s.events.Publish(tx, event, item.TodoListID, item)
+}
+
+// publishAssignment userIDs 為空或未設定 events 時不處理
+func (s *TodoListDetailsService) publishAssignment(tx *gorm.DB, event string, item *models.TodoListDetails, userIDs []int) error {
+	if s.events == nil || len(userIDs) == 0 {
+		return nil
+	}
+	return s.events.Publish(tx, event, item.TodoListID, models.DetailAssignment{Detail: item, UserIDs: userIDs})
+}
+
+// syncChecklist 未設定 checklist 時不處理
+func (s *TodoListDetailsService) syncChecklist(tx *gorm.DB, item *models.TodoListDetails) error {
+	if s.checklist == nil {
+		return nil
+	}
+	return s.checklist.SyncFromMarkdown(tx, item.ID, item.Detail)
+}
+
 func (s *TodoListDetailsService) Create(db *gorm.DB, listID int, name string, detail string, ids []int) (*models.TodoListDetails, error) {
 	data := &models.TodoListDetails{
 		TodoListID: listID,
 		Name:       name,
 		Detail:     detail,
+		Status:     models.DetailStatusTodo,
+	}
+
+	err := retryOnDuplicatePosition(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			// 檢查 listID 是否存在
+			var count int64
+			if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errors.New("to_do_list_type_id 不存在")
+			}
+
+			// 新增的排在 TodoList 最後面
+			last, err := s.repo.LastPosition(s.ctx, tx, listID, 0)
+			if err != nil {
+				return err
+			}
+			position, err := rank.KeyBetween(last, "")
+			if err != nil {
+				return err
+			}
+			data.Position = &position
+
+			// 建立 TodoListDetails
+			if err := s.repo.Create(s.ctx, tx, data); err != nil {
+				return err
+			}
+
+			// 查出 User 對象並建立關聯
+			var users []models.User
+			if err := tx.Where("id IN ?", ids).Find(&users).Error; err != nil {
+				return err
+			}
+
+			// 驗證所有 ids 都存在
+			if len(users) != len(ids) {
+				return errors.New("部分 User ID 不存在")
+			}
+
+			// 加入關聯（many2many）
+			if err := tx.Model(data).Association("Users").Replace(&users); err != nil {
+				return err
+			}
+
+			if err := s.syncChecklist(tx, data); err != nil {
+				return err
+			}
+			watchers := ids
+			if userID, ok := utils.CurrentUserID(s.ctx); ok {
+				watchers = append([]int{userID}, ids...)
+			}
+			if err := s.autoWatch(tx, data, watchers); err != nil {
+				return err
+			}
+			if err := s.publish(tx, models.EventDetailCreated, data); err != nil {
+				return err
+			}
+			return s.publishAssignment(tx, models.EventDetailAssigned, data, ids)
+		})
+	})
+
+	data.RenderDetail()
+	return data, err
+}
+
+func (s *TodoListDetailsService) Show(db *gorm.DB, id int) (*models.TodoListDetails, error) {
+	opts := &base.FindOptions{
+		PreloadFields: []string{"Users", "Watchers", "Labels", "Items", "Blocks", "BlockedBy"},
+		PreloadSelects: map[string][]string{
+			"Users":     {"id", "account"},
+			"Watchers":  {"id", "account"},
+			"Blocks":    {"id", "to_do_list_id", "name", "status"},
+			"BlockedBy": {"id", "to_do_list_id", "name", "status"},
+		},
+		PreloadOrders: map[string]string{
+			"Items": "sort_order asc, id asc",
+		},
+	}
+
+	item, err := s.repo.FindByID(s.ctx, db, id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 計算 checklist 完成進度
+	item.RollUpProgress()
+	return item, nil
+}
+
+func (s *TodoListDetailsService) Edit(db *gorm.DB, id int, name string, detail string) (*models.TodoListDetails, error) {
+	updated := &models.TodoListDetails{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 取的原本的資料
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		//更新內容
+		item.Name = name
+		item.Detail = detail
+		if err := s.repo.Update(s.ctx, tx, item); err != nil {
+			return err
+		}
+		if err := s.syncChecklist(tx, item); err != nil {
+			return err
+		}
+
+		item.RenderDetail()
+		if err := s.publish(tx, models.EventDetailUpdated, item); err != nil {
+			return err
+		}
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Revert 還原名稱、說明與狀態到指定版本；狀態不同時與 ChangeStatus 做相同的檢查，
+// 會產生新的版本，不改寫原本的紀錄
+func (s *TodoListDetailsService) Revert(db *gorm.DB, id int, version int) (*models.TodoListDetails, error) {
+	if s.versions == nil {
+		return nil, errors.New("未設定版本紀錄")
 	}
 
+	var snapshot models.TodoListDetails
+	if err := s.versions.Restore(db, models.VersionEntityDetail, id, version, &snapshot); err != nil {
+		return nil, err
+	}
+
+	updated := &models.TodoListDetails{}
 	err := db.Transaction(func(tx *gorm.DB) error {
-		// 檢查 listID 是否存在
-		var count int64
-		if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
 			return err
 		}
-		if count == 0 {
-			return errors.New("to_do_list_type_id 不存在")
+
+		statusChanged := item.Status != snapshot.Status
+		if statusChanged {
+			if err := s.applyStatus(tx, item, snapshot.Status); err != nil {
+				return err
+			}
 		}
 
-		// 建立 TodoListDetails
-		if err := s.repo.Create(s.ctx, tx, data); err != nil {
+		item.Name = snapshot.Name
+		item.Detail = snapshot.Detail
+		ctx := base.WithRevertedFrom(s.ctx, version)
+		if err := s.repo.Update(ctx, tx.Select("name", "detail", "status", "started_at", "completed_at", "updated_at", "updated_by"), item); err != nil {
 			return err
 		}
+		if err := s.syncChecklist(tx, item); err != nil {
+			return err
+		}
+		if statusChanged {
+			if err := s.afterStatusChanged(tx, item); err != nil {
+				return err
+			}
+		}
 
-		// 查出 User 對象並建立關聯
+		item.RenderDetail()
+		if err := s.publish(tx, models.EventDetailUpdated, item); err != nil {
+			return err
+		}
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// SetAssignees 以 ids 取代負責人，新增與移除的負責人分別寫入 detail.assigned 與 detail.unassigned 事件
+func (s *TodoListDetailsService) SetAssignees(db *gorm.DB, id int, ids []int) (*models.TodoListDetails, error) {
+	updated := &models.TodoListDetails{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var current []models.User
+		if err := tx.Model(item).Association("Users").Find(&current); err != nil {
+			return err
+		}
 		var users []models.User
 		if err := tx.Where("id IN ?", ids).Find(&users).Error; err != nil {
 			return err
 		}
-
-		// 驗證所有 ids 都存在
 		if len(users) != len(ids) {
 			return errors.New("部分 User ID 不存在")
 		}
+		if err := tx.Model(item).Association("Users").Replace(&users); err != nil {
+			return err
+		}
 
-		// 加入關聯（many2many）
-		if err := tx.Model(data).Association("Users").Replace(&users); err != nil {
+		before := make(map[int]bool, len(current))
+		for _, user := range current {
+			before[user.ID] = true
+		}
+		var added, removed []int
+		for _, user := range users {
+			if before[user.ID] {
+				delete(before, user.ID)
+			} else {
+				added = append(added, user.ID)
+			}
+		}
+		for _, user := range current {
+			if before[user.ID] {
+				removed = append(removed, user.ID)
+			}
+		}
+
+		item.Users = users
+		if err := s.autoWatch(tx, item, added); err != nil {
+			return err
+		}
+		if err := s.publishAssignment(tx, models.EventDetailAssigned, item, added); err != nil {
+			return err
+		}
+		if err := s.publishAssignment(tx, models.EventDetailUnassigned, item, removed); err != nil {
 			return err
 		}
 
+		*updated = *item
 		return nil
 	})
 
-	return data, err
+	return updated, err
 }
 
-func (s *TodoListDetailsService) Edit(db *gorm.DB, id int, name string, detail string) (*models.TodoListDetails, error) {
+// SetEstimate 設定原始與剩餘預估工時（分鐘），nil 表示清空
+func (s *TodoListDetailsService) SetEstimate(db *gorm.DB, id int, original, remaining *int) (*models.TodoListDetails, error) {
 	updated := &models.TodoListDetails{}
 	err := db.Transaction(func(tx *gorm.DB) error {
-		// 取的原本的資料
 		item, err := s.repo.FindByID(s.ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		//更新內容
-		item.Name = name
-		item.Detail = detail
-		if err := s.repo.Update(s.ctx, tx, item); err != nil {
+		item.OriginalEstimateMinutes = original
+		item.RemainingEstimateMinutes = remaining
+		if err := s.repo.Update(s.ctx, tx.Select("original_estimate_minutes", "remaining_estimate_minutes", "updated_at", "updated_by"), item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// SetDue 設定到期時間，nil 表示清空
+func (s *TodoListDetailsService) SetDue(db *gorm.DB, id int, dueAt *time.Time) (*models.TodoListDetails, error) {
+	updated := &models.TodoListDetails{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		item.DueAt = dueAt
+		if err := s.repo.Update(s.ctx, tx.Select("due_at", "updated_at", "updated_by"), item); err != nil {
 			return err
 		}
+		if err := s.publish(tx, models.EventDetailUpdated, item); err != nil {
+			return err
+		}
+
 		*updated = *item
 		return nil
 	})
@@ -87,6 +385,104 @@ func (s *TodoListDetailsService) Edit(db *gorm.DB, id int, name string, detail s
 	return updated, err
 }
 
+// ChangeStatus 變更狀態，仍有未完成的前置任務時不能改為完成
+func (s *TodoListDetailsService) ChangeStatus(db *gorm.DB, id int, status string) (*models.TodoListDetails, error) {
+	updated := &models.TodoListDetails{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if item.Status == status {
+			*updated = *item
+			return nil
+		}
+
+		if err := s.applyStatus(tx, item, status); err != nil {
+			return err
+		}
+
+		// completed_at 可能被清空，需用 Select 指定欄位強制更新
+		if err := s.repo.Update(s.ctx, tx.Select("status", "started_at", "completed_at", "updated_at", "updated_by"), item); err != nil {
+			return err
+		}
+
+		if err := s.afterStatusChanged(tx, item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// applyStatus 檢查看板規則與前置任務後設定狀態、開始與完成時間（尚未寫入）並記錄狀態變更，
+// 寫入時需一併 Select status、started_at、completed_at
+func (s *TodoListDetailsService) applyStatus(tx *gorm.DB, item *models.TodoListDetails, status string) error {
+	now := time.Now()
+
+	if s.board != nil {
+		if err := s.board.CheckMove(tx, item, item.Status, status); err != nil {
+			return err
+		}
+	}
+
+	if status == models.DetailStatusDone {
+		blockers, err := s.repo.FindOpenBlockers(s.ctx, tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if len(blockers) > 0 {
+			names := make([]string, len(blockers))
+			for i, b := range blockers {
+				names[i] = fmt.Sprintf("#%d %s", b.ID, b.Name)
+			}
+			return fmt.Errorf("仍有未完成的前置任務：%s", strings.Join(names, ", "))
+		}
+
+		item.CompletedAt = &now
+	} else {
+		item.CompletedAt = nil
+	}
+
+	// 第一次進入進行中的時間，用來計算 cycle time；之後退回 todo 也保留
+	if status == models.DetailStatusInProgress && item.StartedAt == nil {
+		item.StartedAt = &now
+	}
+
+	// 里程碑的燃盡與累積流量圖由變更紀錄重建
+	change := &models.TodoStatusChanges{
+		TodoListDetailID: item.ID,
+		FromStatus:       item.Status,
+		ToStatus:         status,
+		ChangedAt:        now,
+	}
+	if userID, ok := utils.CurrentUserID(s.ctx); ok {
+		changedBy := uint(userID)
+		change.ChangedBy = &changedBy
+	}
+	if err := s.repo.CreateStatusChange(s.ctx, tx, change); err != nil {
+		return err
+	}
+
+	item.Status = status
+	return nil
+}
+
+// afterStatusChanged 狀態寫入後的後續處理，例如週期性任務產生下一次
+func (s *TodoListDetailsService) afterStatusChanged(tx *gorm.DB, item *models.TodoListDetails) error {
+	if item.Status == models.DetailStatusDone && s.recurrence != nil {
+		if err := s.recurrence.OnOccurrenceDone(tx, item); err != nil {
+			return err
+		}
+	}
+	return s.publish(tx, models.EventDetailStatusChanged, item)
+}
+
 func (s *TodoListDetailsService) Delete(db *gorm.DB, id int) (*models.TodoListDetails, error) {
 	var deleted *models.TodoListDetails
 
@@ -100,6 +496,9 @@ func (s *TodoListDetailsService) Delete(db *gorm.DB, id int) (*models.TodoListDe
 		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
 			return err
 		}
+		if err := s.publish(tx, models.EventDetailDeleted, item); err != nil {
+			return err
+		}
 
 		deleted = item
 		return nil
@@ -107,3 +506,227 @@ func (s *TodoListDetailsService) Delete(db *gorm.DB, id int) (*models.TodoListDe
 
 	return deleted, err
 }
+
+// AttachLabels 為 TodoListDetails 加上標籤（已存在的關聯會略過）
+func (s *TodoListDetailsService) AttachLabels(db *gorm.DB, id int, labelIDs []int) (*models.TodoListDetails, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		labels, err := findLabels(tx, labelIDs)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(item).Association("Labels").Append(&labels)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(s.ctx, db, id, &base.FindOptions{PreloadFields: []string{"Labels"}})
+}
+
+// DetachLabel 移除 TodoListDetails 上的指定標籤
+func (s *TodoListDetailsService) DetachLabel(db *gorm.DB, id int, labelID int) (*models.TodoListDetails, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(item).Association("Labels").Delete(&models.TodoLabels{ID: labelID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(s.ctx, db, id, &base.FindOptions{PreloadFields: []string{"Labels"}})
+}
+
+// Move 手動調整項目順序，可同時移到另一個 TodoList（listID 為 0 表示不換 TodoList）。
+// 排在 afterID 之後、beforeID 之前，兩者都必須屬於目標 TodoList；都不給則移到最後
+func (s *TodoListDetailsService) Move(db *gorm.DB, id int, listID int, afterID int, beforeID int) (*models.TodoListDetails, error) {
+	if id == afterID || id == beforeID {
+		return nil, errors.New("不能以自己作為移動的基準")
+	}
+
+	updated := &models.TodoListDetails{}
+	err := retryOnDuplicatePosition(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			item, err := s.repo.LockByID(s.ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			target := item.TodoListID
+			moved := listID > 0 && listID != target
+			if moved {
+				var count int64
+				if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count == 0 {
+					return errors.New("to_do_list_id 不存在")
+				}
+				target = listID
+			}
+			// 移到其他 TodoList 時狀態不變，但要符合目標看板同一欄的 WIP 上限
+			if moved && s.board != nil {
+				dest := *item
+				dest.TodoListID = target
+				if err := s.board.CheckMove(tx, &dest, item.Status, item.Status); err != nil {
+					return err
+				}
+			}
+
+			if err := s.place(tx, item, target, afterID, beforeID); err != nil {
+				return err
+			}
+			if err := s.repo.Update(s.ctx, tx.Select("to_do_list_id", "position", "updated_at", "updated_by"), item); err != nil {
+				return err
+			}
+
+			if item, err = s.rebalanceIfNeeded(tx, item); err != nil {
+				return err
+			}
+			// 只調整順序時不送出事件
+			if moved {
+				if err := s.publish(tx, models.EventDetailUpdated, item); err != nil {
+					return err
+				}
+			}
+
+			*updated = *item
+			return nil
+		})
+	})
+
+	return updated, err
+}
+
+// MoveCard 在看板上移動卡片：換到 status 對應的欄位並排在 afterID 之後、beforeID 之前，
+// 狀態轉換規則、WIP 上限、前置任務與位置在同一個交易內檢查與更新
+func (s *TodoListDetailsService) MoveCard(db *gorm.DB, id int, status string, afterID int, beforeID int) (*models.TodoListDetails, error) {
+	if id == afterID || id == beforeID {
+		return nil, errors.New("不能以自己作為移動的基準")
+	}
+
+	updated := &models.TodoListDetails{}
+	err := retryOnDuplicatePosition(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			item, err := s.repo.LockByID(s.ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			changed := item.Status != status
+			if changed {
+				if err := s.applyStatus(tx, item, status); err != nil {
+					return err
+				}
+			}
+
+			if err := s.place(tx, item, item.TodoListID, afterID, beforeID); err != nil {
+				return err
+			}
+			if err := s.repo.Update(s.ctx, tx.Select("status", "started_at", "completed_at", "position", "updated_at", "updated_by"), item); err != nil {
+				return err
+			}
+
+			if changed {
+				if err := s.afterStatusChanged(tx, item); err != nil {
+					return err
+				}
+			}
+
+			if item, err = s.rebalanceIfNeeded(tx, item); err != nil {
+				return err
+			}
+
+			*updated = *item
+			return nil
+		})
+	})
+
+	return updated, err
+}
+
+// place 計算 item 在目標 TodoList 中的新位置鍵並設定到 item（尚未寫入）
+func (s *TodoListDetailsService) place(tx *gorm.DB, item *models.TodoListDetails, target, afterID, beforeID int) error {
+	after, before, err := s.lockNeighbors(tx, target, afterID, beforeID)
+	if err != nil {
+		return err
+	}
+	if (after != nil && after.Position == nil) || (before != nil && before.Position == nil) {
+		// 舊資料還沒有位置鍵，先整體分配後再重新讀取
+		if err := s.repo.RebalancePositions(s.ctx, tx, target); err != nil {
+			return err
+		}
+		if after, before, err = s.lockNeighbors(tx, target, afterID, beforeID); err != nil {
+			return err
+		}
+	}
+
+	id := item.ID
+	scope := positionScope{
+		next: func(after string) (string, error) { return s.repo.NextPosition(s.ctx, tx, target, after, id) },
+		prev: func(before string) (string, error) { return s.repo.PrevPosition(s.ctx, tx, target, before, id) },
+		last: func() (string, error) { return s.repo.LastPosition(s.ctx, tx, target, id) },
+	}
+	key, err := resolvePosition(scope, detailPosition(after), detailPosition(before))
+	if err != nil {
+		return err
+	}
+
+	item.TodoListID = target
+	item.Position = &key
+	return nil
+}
+
+// rebalanceIfNeeded 同一個位置反覆插入會讓鍵越來越長，超過上限就重新分配並重新讀取
+func (s *TodoListDetailsService) rebalanceIfNeeded(tx *gorm.DB, item *models.TodoListDetails) (*models.TodoListDetails, error) {
+	if item.Position == nil || len(*item.Position) <= rank.MaxLength {
+		return item, nil
+	}
+	if err := s.repo.RebalancePositions(s.ctx, tx, item.TodoListID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(s.ctx, tx, item.ID)
+}
+
+// Rebalance 重新平均分配指定 TodoList 底下項目的位置鍵，順序不變
+func (s *TodoListDetailsService) Rebalance(db *gorm.DB, listID int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		return s.repo.RebalancePositions(s.ctx, tx, listID)
+	})
+}
+
+func (s *TodoListDetailsService) lockNeighbors(tx *gorm.DB, listID, afterID, beforeID int) (after, before *models.TodoListDetails, err error) {
+	if afterID > 0 {
+		if after, err = s.repo.LockByID(s.ctx, tx, afterID); err != nil {
+			return nil, nil, notFoundAs(err, "after_id 不存在")
+		}
+		if after.TodoListID != listID {
+			return nil, nil, errors.New("after_id 不屬於目標 TodoList")
+		}
+	}
+	if beforeID > 0 {
+		if before, err = s.repo.LockByID(s.ctx, tx, beforeID); err != nil {
+			return nil, nil, notFoundAs(err, "before_id 不存在")
+		}
+		if before.TodoListID != listID {
+			return nil, nil, errors.New("before_id 不屬於目標 TodoList")
+		}
+	}
+	return after, before, nil
+}
+
+func detailPosition(item *models.TodoListDetails) *string {
+	if item == nil {
+		return nil
+	}
+	return item.Position
+}
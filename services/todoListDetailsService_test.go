@@ -0,0 +1,333 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTodoListDetailsService_ChangeStatus_BlockedByOpenTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	existing := &models.TodoListDetails{ID: 2, Name: "實作", Status: models.DetailStatusInProgress}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).Return(existing, nil)
+	mockRepo.EXPECT().FindOpenBlockers(ctx, gomock.Any(), 2).Return([]*models.TodoListDetails{
+		{ID: 1, Name: "設計"},
+	}, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.ChangeStatus(db, 2, models.DetailStatusDone)
+
+	assert.EqualError(t, err, "仍有未完成的前置任務：#1 設計")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_ChangeStatus_Done(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	existing := &models.TodoListDetails{ID: 2, Name: "實作", Status: models.DetailStatusInProgress}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).Return(existing, nil)
+	mockRepo.EXPECT().FindOpenBlockers(ctx, gomock.Any(), 2).Return(nil, nil)
+	mockRepo.EXPECT().CreateStatusChange(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, change *models.TodoStatusChanges) error {
+			assert.Equal(t, 2, change.TodoListDetailID)
+			assert.Equal(t, models.DetailStatusInProgress, change.FromStatus)
+			assert.Equal(t, models.DetailStatusDone, change.ToStatus)
+			return nil
+		})
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), existing).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.ChangeStatus(db, 2, models.DetailStatusDone)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DetailStatusDone, result.Status)
+	assert.NotNil(t, result.CompletedAt)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_ChangeStatus_ReopenClearsCompletedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	done := &models.TodoListDetails{ID: 2, Status: models.DetailStatusDone}
+	now := done.CreatedAt
+	done.CompletedAt = &now
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).Return(done, nil)
+	// 重新開啟不需檢查前置任務
+	mockRepo.EXPECT().CreateStatusChange(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), done).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.ChangeStatus(db, 2, models.DetailStatusTodo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DetailStatusTodo, result.Status)
+	assert.Nil(t, result.CompletedAt)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_Move_ToAnotherList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	pos := "V"
+	item := &models.TodoListDetails{ID: 1, TodoListID: 10, Position: &pos}
+	before := &models.TodoListDetails{ID: 5, TodoListID: 20, Position: &pos}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list" WHERE id = \$1`).
+		WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 5).Return(before, nil)
+	// 排在目標 TodoList 的第一個之前
+	mockRepo.EXPECT().PrevPosition(ctx, gomock.Any(), 20, pos, 1).Return("", nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Move(db, 1, 20, 0, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20, result.TodoListID)
+	assert.Equal(t, "G", *result.Position)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_Move_RejectedByWIPLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockBoard := mocks.NewMockTodoBoardRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo).
+		WithBoard(services.NewTodoBoardService(ctx, mockBoard))
+	db, sqlmock := setupMockDB(t)
+
+	limit := 2
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).
+		Return(&models.TodoListDetails{ID: 1, TodoListID: 10, Status: models.DetailStatusInProgress}, nil)
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list" WHERE id = \$1`).
+		WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	// 狀態不變，目標看板的轉換規則不適用，只檢查目標 TodoList 同一欄的 WIP 上限
+	mockBoard.EXPECT().FindTransitions(ctx, gomock.Any(), 20).
+		Return([]*models.TodoBoardTransitions{{FromStatus: models.DetailStatusTodo, ToStatus: models.DetailStatusInProgress}}, nil)
+	mockBoard.EXPECT().LockColumnByStatus(ctx, gomock.Any(), 20, models.DetailStatusInProgress).
+		Return(&models.TodoBoardColumns{Name: "進行中", WIPLimit: &limit}, nil)
+	mockBoard.EXPECT().CountInStatus(ctx, gomock.Any(), 20, models.DetailStatusInProgress, 1).Return(int64(2), nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Move(db, 1, 20, 0, 0)
+
+	assert.EqualError(t, err, "欄位「進行中」已達 WIP 上限 2")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_Move_NeighborInOtherList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(&models.TodoListDetails{ID: 1, TodoListID: 10}, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoListDetails{ID: 2, TodoListID: 11}, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Move(db, 1, 0, 2, 0)
+
+	assert.EqualError(t, err, "after_id 不屬於目標 TodoList")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_Move_RebalancesLegacyRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	item := &models.TodoListDetails{ID: 1, TodoListID: 10}
+	pos := "F"
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	// 鄰居是尚未分配位置鍵的舊資料，先整體分配再重新讀取
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoListDetails{ID: 2, TodoListID: 10}, nil)
+	mockRepo.EXPECT().RebalancePositions(ctx, gomock.Any(), 10).Return(nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoListDetails{ID: 2, TodoListID: 10, Position: &pos}, nil)
+	mockRepo.EXPECT().NextPosition(ctx, gomock.Any(), 10, pos, 1).Return("", nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Move(db, 1, 0, 2, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "d", *result.Position)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_MoveCard_RejectedByWIPLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockBoard := mocks.NewMockTodoBoardRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo).
+		WithBoard(services.NewTodoBoardService(ctx, mockBoard))
+	db, sqlmock := setupMockDB(t)
+
+	limit := 1
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).
+		Return(&models.TodoListDetails{ID: 1, TodoListID: 10, Status: models.DetailStatusTodo}, nil)
+	mockBoard.EXPECT().FindTransitions(ctx, gomock.Any(), 10).Return(nil, nil)
+	mockBoard.EXPECT().LockColumnByStatus(ctx, gomock.Any(), 10, models.DetailStatusInProgress).
+		Return(&models.TodoBoardColumns{Name: "進行中", WIPLimit: &limit}, nil)
+	mockBoard.EXPECT().CountInStatus(ctx, gomock.Any(), 10, models.DetailStatusInProgress, 1).Return(int64(1), nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.MoveCard(db, 1, models.DetailStatusInProgress, 0, 0)
+
+	assert.EqualError(t, err, "欄位「進行中」已達 WIP 上限 1")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_MoveCard_ChangesStatusAndPosition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockBoard := mocks.NewMockTodoBoardRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo).
+		WithBoard(services.NewTodoBoardService(ctx, mockBoard))
+	db, sqlmock := setupMockDB(t)
+
+	item := &models.TodoListDetails{ID: 1, TodoListID: 10, Status: models.DetailStatusInProgress}
+	pos := "V"
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockBoard.EXPECT().FindTransitions(ctx, gomock.Any(), 10).Return(nil, nil)
+	// 完成欄位沒有 WIP 上限
+	mockBoard.EXPECT().LockColumnByStatus(ctx, gomock.Any(), 10, models.DetailStatusDone).Return(nil, nil)
+	mockRepo.EXPECT().FindOpenBlockers(ctx, gomock.Any(), 1).Return(nil, nil)
+	mockRepo.EXPECT().CreateStatusChange(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).
+		Return(&models.TodoListDetails{ID: 2, TodoListID: 10, Position: &pos}, nil)
+	mockRepo.EXPECT().PrevPosition(ctx, gomock.Any(), 10, pos, 1).Return("", nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.MoveCard(db, 1, models.DetailStatusDone, 0, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DetailStatusDone, result.Status)
+	assert.NotNil(t, result.CompletedAt)
+	assert.Equal(t, "G", *result.Position)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_ChangeStatus_RecordsFirstStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	// 曾經進行中又退回 todo，再次開始時保留第一次的時間
+	firstStart := time.Date(2026, 1, 5, 9, 0, 0, 0, time.Local)
+	existing := &models.TodoListDetails{ID: 2, Status: models.DetailStatusTodo, StartedAt: &firstStart}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).Return(existing, nil)
+	mockRepo.EXPECT().CreateStatusChange(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), existing).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.ChangeStatus(db, 2, models.DetailStatusInProgress)
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstStart, *result.StartedAt)
+	assert.Nil(t, result.CompletedAt)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_SetAssignees_WatchesInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockWatcherRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo).
+		WithWatchers(services.NewTodoWatcherService(ctx, mockWatcherRepo))
+	db, mock := setupMockDB(t)
+	mock.MatchExpectationsInOrder(false)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 5).Return(&models.TodoListDetails{ID: 5, TodoListID: 2}, nil)
+	mock.ExpectQuery(`SELECT .* FROM "users" JOIN`).WillReturnRows(sqlmock.NewRows([]string{"id", "account"}).AddRow(6, "bob"))
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE id IN`).WillReturnRows(sqlmock.NewRows([]string{"id", "account"}).AddRow(6, "bob").AddRow(7, "carol"))
+	mock.ExpectExec(`UPDATE "to_do_list_details" SET "updated_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`INSERT INTO "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM "to_do_task_assignments"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	// 只有新加入的負責人自動關注，寫入失敗時整個指派一起復原
+	mockWatcherRepo.EXPECT().AddDetailWatchers(ctx, gomock.Any(), 5, []int{7}).Return(errors.New("db down"))
+	mock.ExpectRollback()
+
+	_, err := svc.SetAssignees(db, 5, []int{6, 7})
+
+	assert.EqualError(t, err, "db down")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"todolist/models"
+	"todolist/pkg/rank"
 	"todolist/repositories/base"
 	"todolist/repositories/interfaces"
 	"todolist/utils"
@@ -12,8 +13,11 @@ import (
 )
 
 type TodoListService struct {
-	ctx  context.Context
-	repo interfaces.TodoListRepository
+	ctx      context.Context
+	repo     interfaces.TodoListRepository
+	versions *TodoVersionService
+	events   *TodoOutboxService
+	watchers *TodoWatcherService
 }
 
 func NewTodoListService(ctx context.Context, repo interfaces.TodoListRepository) *TodoListService {
@@ -23,38 +27,98 @@ func NewTodoListService(ctx context.Context, repo interfaces.TodoListRepository)
 	}
 }
 
+// WithVersions 設定後可以還原到先前的版本
+func (s *TodoListService) WithVersions(versions *TodoVersionService) *TodoListService {
+	s.versions = versions
+	return s
+}
+
+// WithEvents 設定後，新增、修改與刪除時會在同一個交易內寫入領域事件
+func (s *TodoListService) WithEvents(events *TodoOutboxService) *TodoListService {
+	s.events = events
+	return s
+}
+
+// WithWatchers 設定後，建立者會在同一個交易內自動關注新的 TodoList
+func (s *TodoListService) WithWatchers(watchers *TodoWatcherService) *TodoListService {
+	s.watchers = watchers
+	return s
+}
+
+// autoWatch 未設定 watchers 或未登入時不處理
+func (s *TodoListService) autoWatch(tx *gorm.DB, item *models.TodoList) error {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if s.watchers == nil || !ok {
+		return nil
+	}
+	return s.watchers.AutoWatchList(tx, item.ID, []int{userID})
+}
+
+// publish 未設定 events 時不處理
+func (s *TodoListService) publish(tx *gorm.DB, event string, item *models.TodoList) error {
+	if s.events == nil {
+		return nil
+	}
+	return s.events.Publish(tx, event, item.ID, item)
+}
+
 func (s *TodoListService) Create(db *gorm.DB, name string, typeID int) (*models.TodoList, error) {
 	result := &models.TodoList{
 		Name:   name,
 		TypeID: typeID,
 	}
 
-	err := db.Transaction(func(tx *gorm.DB) error {
-		// 可以先檢查 type_id 是否存在，避免外鍵錯誤
-		var count int64
-		if err := tx.Model(&models.TodoTypes{}).Where("id = ?", typeID).Count(&count).Error; err != nil {
-			return err
-		}
-		if count == 0 {
-			return errors.New("type_id 不存在")
-		}
+	err := retryOnDuplicatePosition(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			// 可以先檢查 type_id 是否存在，避免外鍵錯誤
+			var count int64
+			if err := tx.Model(&models.TodoTypes{}).Where("id = ?", typeID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errors.New("type_id 不存在")
+			}
 
-		exist, err := s.repo.IsNameExist(s.ctx, tx, name, 0)
-		if err != nil {
-			return err
-		}
-		if exist {
-			return errors.New("名稱已存在")
-		}
+			exist, err := s.repo.IsNameExist(s.ctx, tx, name, 0)
+			if err != nil {
+				return err
+			}
+			if exist {
+				return errors.New("名稱已存在")
+			}
 
-		return s.repo.Create(s.ctx, tx, result)
+			// 新增的排在最後面
+			last, err := s.repo.LastPosition(s.ctx, tx, 0)
+			if err != nil {
+				return err
+			}
+			position, err := rank.KeyBetween(last, "")
+			if err != nil {
+				return err
+			}
+			result.Position = &position
+
+			if err := s.repo.Create(s.ctx, tx, result); err != nil {
+				return err
+			}
+			if err := s.autoWatch(tx, result); err != nil {
+				return err
+			}
+			return s.publish(tx, models.EventListCreated, result)
+		})
 	})
 
 	return result, err
 }
 
-func (s *TodoListService) Index(db *gorm.DB, keyword string, page, pageSize int, orderBy []string) (*utils.PaginatedResult[*models.TodoList], error) {
+func (s *TodoListService) Index(db *gorm.DB, keyword string, labelIDs []int, page, pageSize int, orderBy []string) (*utils.PaginatedResult[*models.TodoList], error) {
 	query := db.Model(&models.TodoList{})
+	// 依標籤篩選（符合任一標籤即可）
+	if len(labelIDs) > 0 {
+		query = query.Where("id IN (?)", db.Table("to_do_list_labels").Select("to_do_list_id").Where("label_id IN ?", labelIDs))
+	}
+	query = query.Preload("Labels")
+
 	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, orderBy...)
 	if err != nil {
 		return nil, err
@@ -63,15 +127,34 @@ func (s *TodoListService) Index(db *gorm.DB, keyword string, page, pageSize int,
 	return utils.NewPaginatedResult(list, total, page, pageSize), nil
 }
 
-func (s *TodoListService) Show(db *gorm.DB, id int) (*models.TodoList, error) {
-	opts := &base.FindOptions{
-		Debug:         true,
-		PreloadFields: []string{"Details", "Type", "Details.Users"},
+// listPreloads Show 與匯出共用的關聯：類型、標籤、項目與其負責人、標籤與 checklist
+func listPreloads(debug bool) *base.FindOptions {
+	return &base.FindOptions{
+		Debug:         debug,
+		PreloadFields: []string{"Details", "Type", "Labels", "Details.Users", "Details.Labels", "Details.Items"},
 		PreloadSelects: map[string][]string{
 			"Details.Users": {"id", "account"},
 		},
+		PreloadOrders: map[string]string{
+			"Details":       "position IS NULL, position asc, id asc",
+			"Details.Items": "sort_order asc, id asc",
+		},
 	}
-	return s.repo.FindByID(s.ctx, db, id, opts)
+}
+
+func (s *TodoListService) Show(db *gorm.DB, id int) (*models.TodoList, error) {
+	// listPreloads 與匯出共用，刻意不含關注者；只有 Show 另外帶出關注者
+	opts := listPreloads(true)
+	opts.PreloadFields = append(opts.PreloadFields, "Watchers")
+	opts.PreloadSelects["Watchers"] = []string{"id", "account"}
+	item, err := s.repo.FindByID(s.ctx, db, id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 彙總 checklist 完成進度
+	item.RollUpProgress()
+	return item, nil
 }
 
 func (s *TodoListService) Edit(db *gorm.DB, id int, name string, typeID int) (*models.TodoList, error) {
@@ -108,6 +191,9 @@ func (s *TodoListService) Edit(db *gorm.DB, id int, name string, typeID int) (*m
 		if err := s.repo.Update(s.ctx, tx, item); err != nil {
 			return err
 		}
+		if err := s.publish(tx, models.EventListUpdated, item); err != nil {
+			return err
+		}
 
 		*updated = *item
 		return nil
@@ -116,6 +202,22 @@ func (s *TodoListService) Edit(db *gorm.DB, id int, name string, typeID int) (*m
 	return updated, err
 }
 
+// Revert 以指定版本的內容重新編輯，與 Edit 做相同的檢查；會產生新的版本，不改寫原本的紀錄
+func (s *TodoListService) Revert(db *gorm.DB, id int, version int) (*models.TodoList, error) {
+	if s.versions == nil {
+		return nil, errors.New("未設定版本紀錄")
+	}
+
+	var snapshot models.TodoList
+	if err := s.versions.Restore(db, models.VersionEntityList, id, version, &snapshot); err != nil {
+		return nil, err
+	}
+
+	reverting := *s
+	reverting.ctx = base.WithRevertedFrom(s.ctx, version)
+	return reverting.Edit(db, id, snapshot.Name, snapshot.TypeID)
+}
+
 func (s *TodoListService) Delete(db *gorm.DB, id int) (*models.TodoList, error) {
 	var deleted *models.TodoList
 
@@ -130,6 +232,9 @@ func (s *TodoListService) Delete(db *gorm.DB, id int) (*models.TodoList, error)
 		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
 			return err
 		}
+		if err := s.publish(tx, models.EventListDeleted, item); err != nil {
+			return err
+		}
 
 		deleted = item
 		return nil
@@ -137,3 +242,132 @@ func (s *TodoListService) Delete(db *gorm.DB, id int) (*models.TodoList, error)
 
 	return deleted, err
 }
+
+// AttachLabels 為 TodoList 加上標籤（已存在的關聯會略過）
+func (s *TodoListService) AttachLabels(db *gorm.DB, id int, labelIDs []int) (*models.TodoList, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		labels, err := findLabels(tx, labelIDs)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(item).Association("Labels").Append(&labels)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(s.ctx, db, id, &base.FindOptions{PreloadFields: []string{"Labels"}})
+}
+
+// DetachLabel 移除 TodoList 上的指定標籤
+func (s *TodoListService) DetachLabel(db *gorm.DB, id int, labelID int) (*models.TodoList, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(item).Association("Labels").Delete(&models.TodoLabels{ID: labelID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(s.ctx, db, id, &base.FindOptions{PreloadFields: []string{"Labels"}})
+}
+
+// Move 手動調整 TodoList 順序：排在 afterID 之後、beforeID 之前（可只給其中一個，都不給則移到最後），
+// 只會更新被移動的這一筆
+func (s *TodoListService) Move(db *gorm.DB, id int, afterID int, beforeID int) (*models.TodoList, error) {
+	if id == afterID || id == beforeID {
+		return nil, errors.New("不能以自己作為移動的基準")
+	}
+
+	updated := &models.TodoList{}
+	err := retryOnDuplicatePosition(func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			item, err := s.repo.LockByID(s.ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			after, before, err := s.lockNeighbors(tx, afterID, beforeID)
+			if err != nil {
+				return err
+			}
+			if (after != nil && after.Position == nil) || (before != nil && before.Position == nil) {
+				// 舊資料還沒有位置鍵，先整體分配後再重新讀取
+				if err := s.repo.RebalancePositions(s.ctx, tx); err != nil {
+					return err
+				}
+				if after, before, err = s.lockNeighbors(tx, afterID, beforeID); err != nil {
+					return err
+				}
+			}
+
+			scope := positionScope{
+				next: func(after string) (string, error) { return s.repo.NextPosition(s.ctx, tx, after, id) },
+				prev: func(before string) (string, error) { return s.repo.PrevPosition(s.ctx, tx, before, id) },
+				last: func() (string, error) { return s.repo.LastPosition(s.ctx, tx, id) },
+			}
+			key, err := resolvePosition(scope, listPosition(after), listPosition(before))
+			if err != nil {
+				return err
+			}
+
+			item.Position = &key
+			if err := s.repo.Update(s.ctx, tx.Select("position", "updated_at", "updated_by"), item); err != nil {
+				return err
+			}
+
+			// 同一個位置反覆插入會讓鍵越來越長，超過上限就重新分配
+			if len(key) > rank.MaxLength {
+				if err := s.repo.RebalancePositions(s.ctx, tx); err != nil {
+					return err
+				}
+				if item, err = s.repo.FindByID(s.ctx, tx, id); err != nil {
+					return err
+				}
+			}
+
+			*updated = *item
+			return nil
+		})
+	})
+
+	return updated, err
+}
+
+// Rebalance 重新平均分配所有 TodoList 的位置鍵，順序不變
+func (s *TodoListService) Rebalance(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		return s.repo.RebalancePositions(s.ctx, tx)
+	})
+}
+
+func (s *TodoListService) lockNeighbors(tx *gorm.DB, afterID, beforeID int) (after, before *models.TodoList, err error) {
+	if afterID > 0 {
+		if after, err = s.repo.LockByID(s.ctx, tx, afterID); err != nil {
+			return nil, nil, notFoundAs(err, "after_id 不存在")
+		}
+	}
+	if beforeID > 0 {
+		if before, err = s.repo.LockByID(s.ctx, tx, beforeID); err != nil {
+			return nil, nil, notFoundAs(err, "before_id 不存在")
+		}
+	}
+	return after, before, nil
+}
+
+func listPosition(item *models.TodoList) *string {
+	if item == nil {
+		return nil
+	}
+	return item.Position
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestTodoListService_Create_Success(t *testing.T) {
@@ -35,6 +36,12 @@ func TestTodoListService_Create_Success(t *testing.T) {
 		Return(false, nil).
 		Times(1)
 
+	// 新增的排在最後面
+	mockRepo.EXPECT().
+		LastPosition(ctx, gomock.Any(), 0).
+		Return("V", nil).
+		Times(1)
+
 	mockRepo.EXPECT().
 		Create(ctx, gomock.Any(), gomock.AssignableToTypeOf(&models.TodoList{})). // ✅ 修正點
 		Return(nil).
@@ -47,6 +54,7 @@ func TestTodoListService_Create_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Test", result.Name)
+	assert.Equal(t, "l", *result.Position)
 
 	// 驗證 SQL 預期都被滿足
 	assert.NoError(t, sqlmock.ExpectationsWereMet())
@@ -111,7 +119,7 @@ func TestTodoListService_Index(t *testing.T) {
 		Times(1)
 
 	// 執行 service
-	result, err := svc.Index(db, "", page, pageSize, orderBy)
+	result, err := svc.Index(db, "", nil, page, pageSize, orderBy)
 
 	// 驗證結果
 	assert.NoError(t, err)
@@ -346,3 +354,88 @@ func TestTodoListService_Delete_Fail(t *testing.T) {
 	// 確保 sqlmock 的所有預期呼叫都完成
 	assert.NoError(t, sqlmock.ExpectationsWereMet())
 }
+
+func TestTodoListService_Move_AfterItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+	svc := services.NewTodoListService(ctx, mockRepo)
+
+	posA, posB := "A", "B"
+	item := &models.TodoList{ID: 1}
+	after := &models.TodoList{ID: 2, Position: &posA}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(after, nil)
+	// 只給 after_id，另一邊取下一個項目（排除自己）
+	mockRepo.EXPECT().NextPosition(ctx, gomock.Any(), posA, 1).Return(posB, nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Move(db, 1, 2, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AV", *result.Position)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListService_Move_RetriesOnDuplicatePosition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+	svc := services.NewTodoListService(ctx, mockRepo)
+
+	item := &models.TodoList{ID: 1}
+
+	// 第一次與其他人同時移到最後，唯一索引衝突後重新計算
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockRepo.EXPECT().LastPosition(ctx, gomock.Any(), 1).Return("V", nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(gorm.ErrDuplicatedKey)
+	sqlmock.ExpectRollback()
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockRepo.EXPECT().LastPosition(ctx, gomock.Any(), 1).Return("l", nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), item).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Move(db, 1, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "t", *result.Position)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListService_Move_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+	ctx := context.Background()
+	svc := services.NewTodoListService(ctx, mockRepo)
+
+	posA, posB := "A", "B"
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(&models.TodoList{ID: 1}, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoList{ID: 2, Position: &posB}, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 3).Return(&models.TodoList{ID: 3, Position: &posA}, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Move(db, 1, 2, 3)
+
+	assert.EqualError(t, err, "after_id 必須排在 before_id 之前")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+
+	_, err = svc.Move(db, 1, 1, 0)
+	assert.EqualError(t, err, "不能以自己作為移動的基準")
+}
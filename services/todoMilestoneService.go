@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMilestoneClosed        = errors.New("里程碑已關閉")
+	ErrInvalidMilestoneMetric = errors.New("metric 必須為 count 或 estimate")
+)
+
+type TodoMilestoneService struct {
+	ctx  context.Context
+	repo interfaces.TodoMilestoneRepository
+}
+
+func NewTodoMilestoneService(ctx context.Context, repo interfaces.TodoMilestoneRepository) *TodoMilestoneService {
+	return &TodoMilestoneService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// parseMilestoneDates 解析開始與結束日期（含當天），結束日不能早於開始日
+func parseMilestoneDates(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(entryDateLayout, startDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date 格式必須為 %s", entryDateLayout)
+	}
+	end, err := time.ParseInLocation(entryDateLayout, endDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date 格式必須為 %s", entryDateLayout)
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("end_date 不能早於 start_date")
+	}
+	return start, end, nil
+}
+
+func (s *TodoMilestoneService) Create(db *gorm.DB, name, startDate, endDate string) (*models.TodoMilestones, error) {
+	start, end, err := parseMilestoneDates(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TodoMilestones{Name: name, StartDate: start, EndDate: end}
+	if err := s.repo.Create(s.ctx, db, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Index closed 為 nil 時不篩選，依開始日期由新到舊排序
+func (s *TodoMilestoneService) Index(db *gorm.DB, closed *bool, page, pageSize int) (*utils.PaginatedResult[*models.TodoMilestones], error) {
+	query := db.Model(&models.TodoMilestones{})
+	if closed != nil {
+		if *closed {
+			query = query.Where("closed_at IS NOT NULL")
+		} else {
+			query = query.Where("closed_at IS NULL")
+		}
+	}
+
+	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, "start_date desc", "id desc")
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+func (s *TodoMilestoneService) Show(db *gorm.DB, id int) (*models.TodoMilestones, error) {
+	return s.repo.FindByID(s.ctx, db, id)
+}
+
+func (s *TodoMilestoneService) Edit(db *gorm.DB, id int, name, startDate, endDate string) (*models.TodoMilestones, error) {
+	start, end, err := parseMilestoneDates(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &models.TodoMilestones{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.LockByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		item.Name = name
+		item.StartDate = start
+		item.EndDate = end
+		if err := s.repo.Update(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Delete 軟刪除里程碑，並移除 TodoList / TodoListDetails 與它的關聯
+func (s *TodoMilestoneService) Delete(db *gorm.DB, id int) (*models.TodoMilestones, error) {
+	var deleted *models.TodoMilestones
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.LockByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Detach(s.ctx, tx, id); err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// lockOpen 鎖定里程碑並確認尚未關閉，避免與關閉同時進行
+func (s *TodoMilestoneService) lockOpen(tx *gorm.DB, id int) (*models.TodoMilestones, error) {
+	item, err := s.repo.LockByID(s.ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.ClosedAt != nil {
+		return nil, ErrMilestoneClosed
+	}
+	return item, nil
+}
+
+// AssignList 設定 TodoList 的里程碑，milestoneID 為 nil 表示移除
+func (s *TodoMilestoneService) AssignList(db *gorm.DB, listID int, milestoneID *int) (*models.TodoList, error) {
+	updated := &models.TodoList{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("to_do_list_id 不存在")
+		}
+
+		if milestoneID != nil {
+			if _, err := s.lockOpen(tx, *milestoneID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.AssignList(s.ctx, tx, listID, milestoneID); err != nil {
+			return err
+		}
+		return tx.First(updated, listID).Error
+	})
+
+	return updated, err
+}
+
+// AssignDetail 設定 TodoListDetails 的里程碑，milestoneID 為 nil 表示沿用所屬 TodoList 的里程碑
+func (s *TodoMilestoneService) AssignDetail(db *gorm.DB, detailID int, milestoneID *int) (*models.TodoListDetails, error) {
+	updated := &models.TodoListDetails{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := detailExists(tx, detailID); err != nil {
+			return err
+		}
+
+		if milestoneID != nil {
+			if _, err := s.lockOpen(tx, *milestoneID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.AssignDetail(s.ctx, tx, detailID, milestoneID); err != nil {
+			return err
+		}
+		return tx.First(updated, detailID).Error
+	})
+
+	return updated, err
+}
+
+// Close 關閉里程碑；nextID 大於 0 時，將尚未完成的項目一次移到該里程碑
+func (s *TodoMilestoneService) Close(db *gorm.DB, id int, nextID int) (*models.MilestoneCloseResult, error) {
+	if nextID == id {
+		return nil, errors.New("不能移到同一個里程碑")
+	}
+
+	result := &models.MilestoneCloseResult{RolledOver: []int{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.lockOpen(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if nextID > 0 {
+			if _, err := s.lockOpen(tx, nextID); err != nil {
+				return fmt.Errorf("下一個里程碑：%w", err)
+			}
+
+			ids, err := s.repo.LockUnfinished(s.ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if err := s.repo.Rollover(s.ctx, tx, id, nextID, ids); err != nil {
+				return err
+			}
+			result.RolledOver = ids
+		}
+
+		now := time.Now()
+		item.ClosedAt = &now
+		if err := s.repo.Update(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		result.Milestone = item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Chart 依狀態變更紀錄重建里程碑期間每天結束時的燃盡與累積流量；
+// metric 為 estimate 時以剩餘預估分鐘數（沒有時用原始預估）計算，預估沒有歷史紀錄，一律使用目前的值
+func (s *TodoMilestoneService) Chart(db *gorm.DB, id int, metric string) (*models.MilestoneChart, error) {
+	if metric == "" {
+		metric = models.MilestoneMetricCount
+	}
+	if metric != models.MilestoneMetricCount && metric != models.MilestoneMetricEstimate {
+		return nil, ErrInvalidMilestoneMetric
+	}
+
+	milestone, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.FindMembers(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	until := chartUntil(milestone, time.Now())
+	changes, err := s.repo.FindStatusChanges(s.ctx, db, ids, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMilestoneChart(milestone, metric, items, changes, until), nil
+}
+
+// chartUntil 圖表的最後一天為結束日、今天與關閉日中最早的，回傳該天隔天的 0 點
+func chartUntil(milestone *models.TodoMilestones, now time.Time) time.Time {
+	last := milestone.EndDate
+	if today := dayStart(now); today.Before(last) {
+		last = today
+	}
+	if milestone.ClosedAt != nil {
+		if closed := dayStart(*milestone.ClosedAt); closed.Before(last) {
+			last = closed
+		}
+	}
+	return dayStart(last).AddDate(0, 0, 1)
+}
+
+func dayStart(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// milestoneWeight 項目在燃盡圖中的份量
+func milestoneWeight(item *models.TodoListDetails, metric string) int {
+	if metric == models.MilestoneMetricCount {
+		return 1
+	}
+	if item.RemainingEstimateMinutes != nil {
+		return *item.RemainingEstimateMinutes
+	}
+	if item.OriginalEstimateMinutes != nil {
+		return *item.OriginalEstimateMinutes
+	}
+	return 0
+}
+
+// buildMilestoneChart 逐日計算每個項目在當天結束時的狀態：尚未建立的不列入，
+// 沒有變更紀錄的為 todo；changes 需依項目與時間排序
+func buildMilestoneChart(milestone *models.TodoMilestones, metric string, items []*models.TodoListDetails, changes []*models.TodoStatusChanges, until time.Time) *models.MilestoneChart {
+	chart := &models.MilestoneChart{
+		MilestoneID:    milestone.ID,
+		Metric:         metric,
+		Burndown:       []models.BurndownPoint{},
+		CumulativeFlow: []models.FlowPoint{},
+	}
+
+	history := make(map[int][]*models.TodoStatusChanges, len(items))
+	for _, change := range changes {
+		history[change.TodoListDetailID] = append(history[change.TodoListDetailID], change)
+	}
+	status := make(map[int]string, len(items))
+	applied := make(map[int]int, len(items))
+
+	start := dayStart(milestone.StartDate)
+	totalDays := int(dayStart(milestone.EndDate).Sub(start).Hours()/24+0.5) + 1
+
+	for day := start; day.Before(until); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		point := models.BurndownPoint{Date: day.Format(entryDateLayout)}
+		flow := models.FlowPoint{Date: point.Date, Statuses: map[string]int{
+			models.DetailStatusTodo:       0,
+			models.DetailStatusInProgress: 0,
+			models.DetailStatusDone:       0,
+		}}
+
+		for _, item := range items {
+			if !item.CreatedAt.Before(end) {
+				continue
+			}
+			if _, ok := status[item.ID]; !ok {
+				status[item.ID] = models.DetailStatusTodo
+			}
+			for list := history[item.ID]; applied[item.ID] < len(list) && list[applied[item.ID]].ChangedAt.Before(end); applied[item.ID]++ {
+				status[item.ID] = list[applied[item.ID]].ToStatus
+			}
+
+			flow.Statuses[status[item.ID]]++
+			if status[item.ID] != models.DetailStatusDone {
+				point.Remaining += milestoneWeight(item, metric)
+			}
+		}
+
+		chart.Burndown = append(chart.Burndown, point)
+		chart.CumulativeFlow = append(chart.CumulativeFlow, flow)
+	}
+
+	// 理想線從第一天的剩餘量平均減少，到結束日為 0
+	if len(chart.Burndown) > 0 && totalDays > 1 {
+		first := float64(chart.Burndown[0].Remaining)
+		for i := range chart.Burndown {
+			chart.Burndown[i].Ideal = first * float64(totalDays-1-i) / float64(totalDays-1)
+		}
+	}
+
+	return chart
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func milestoneDay(d, hour int) time.Time {
+	return time.Date(2026, 1, d, hour, 0, 0, 0, time.Local)
+}
+
+func TestTodoMilestoneService_Chart_RebuildsFromHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoMilestoneRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoMilestoneService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	milestone := &models.TodoMilestones{ID: 1, StartDate: milestoneDay(5, 0), EndDate: milestoneDay(9, 0)}
+	remaining, original := 30, 60
+	items := []*models.TodoListDetails{
+		{ID: 1, Status: models.DetailStatusDone},
+		// 期間中才加入的項目
+		{ID: 2, Status: models.DetailStatusTodo, RemainingEstimateMinutes: &remaining},
+		{ID: 3, Status: models.DetailStatusTodo, OriginalEstimateMinutes: &original},
+	}
+	items[0].CreatedAt = milestoneDay(4, 9)
+	items[1].CreatedAt = milestoneDay(6, 12)
+	items[2].CreatedAt = milestoneDay(1, 9)
+	changes := []*models.TodoStatusChanges{
+		{TodoListDetailID: 1, FromStatus: models.DetailStatusTodo, ToStatus: models.DetailStatusInProgress, ChangedAt: milestoneDay(6, 10)},
+		{TodoListDetailID: 1, FromStatus: models.DetailStatusInProgress, ToStatus: models.DetailStatusDone, ChangedAt: milestoneDay(7, 15)},
+	}
+
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(milestone, nil).Times(2)
+	mockRepo.EXPECT().FindMembers(ctx, gomock.Any(), 1).Return(items, nil).Times(2)
+	// 結束日已過，重建到結束日當天為止
+	mockRepo.EXPECT().FindStatusChanges(ctx, gomock.Any(), []int{1, 2, 3}, milestoneDay(10, 0)).Return(changes, nil).Times(2)
+
+	chart, err := svc.Chart(db, 1, "")
+	require.NoError(t, err)
+
+	require.Len(t, chart.Burndown, 5)
+	remainingByDay := make([]int, 5)
+	idealByDay := make([]float64, 5)
+	for i, point := range chart.Burndown {
+		remainingByDay[i] = point.Remaining
+		idealByDay[i] = point.Ideal
+	}
+	assert.Equal(t, "2026-01-05", chart.Burndown[0].Date)
+	assert.Equal(t, []int{2, 3, 2, 2, 2}, remainingByDay)
+	assert.Equal(t, []float64{2, 1.5, 1, 0.5, 0}, idealByDay)
+	assert.Equal(t, map[string]int{"todo": 2, "in_progress": 0, "done": 0}, chart.CumulativeFlow[0].Statuses)
+	assert.Equal(t, map[string]int{"todo": 2, "in_progress": 1, "done": 0}, chart.CumulativeFlow[1].Statuses)
+	assert.Equal(t, map[string]int{"todo": 2, "in_progress": 0, "done": 1}, chart.CumulativeFlow[2].Statuses)
+
+	chart, err = svc.Chart(db, 1, models.MilestoneMetricEstimate)
+	require.NoError(t, err)
+	assert.Equal(t, 60, chart.Burndown[0].Remaining)
+	assert.Equal(t, 90, chart.Burndown[1].Remaining)
+	assert.Equal(t, 90, chart.Burndown[4].Remaining)
+}
+
+func TestTodoMilestoneService_Close_RollsOverUnfinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoMilestoneRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoMilestoneService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	milestone := &models.TodoMilestones{ID: 1}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(milestone, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoMilestones{ID: 2}, nil)
+	mockRepo.EXPECT().LockUnfinished(ctx, gomock.Any(), 1).Return([]int{3, 4}, nil)
+	mockRepo.EXPECT().Rollover(ctx, gomock.Any(), 1, 2, []int{3, 4}).Return(nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), milestone).Return(nil)
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Close(db, 1, 2)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Milestone.ClosedAt)
+	assert.Equal(t, []int{3, 4}, result.RolledOver)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoMilestoneService_Close_NextMilestoneClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoMilestoneRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoMilestoneService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	closedAt := milestoneDay(9, 18)
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 1).Return(&models.TodoMilestones{ID: 1}, nil)
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 2).Return(&models.TodoMilestones{ID: 2, ClosedAt: &closedAt}, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Close(db, 1, 2)
+
+	assert.ErrorIs(t, err, services.ErrMilestoneClosed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
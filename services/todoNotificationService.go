@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrUnknownNotificationType = errors.New("不支援的通知類型")
+
+type TodoNotificationService struct {
+	ctx      context.Context
+	repo     interfaces.TodoNotificationRepository
+	email    *TodoEmailService
+	watchers *TodoWatcherService
+}
+
+// NewTodoNotificationService watchers 用來決定新留言的通知對象，必須提供
+func NewTodoNotificationService(ctx context.Context, repo interfaces.TodoNotificationRepository, watchers *TodoWatcherService) *TodoNotificationService {
+	return &TodoNotificationService{
+		ctx:      ctx,
+		repo:     repo,
+		watchers: watchers,
+	}
+}
+
+// WithEmail 建立通知時一併排入 email，未設定時只有站內通知
+func (s *TodoNotificationService) WithEmail(email *TodoEmailService) *TodoNotificationService {
+	s.email = email
+	return s
+}
+
+// HandleEvent 依領域事件通知相關的使用者；事件重送時以 dedupe_key 略過已建立的通知
+func (s *TodoNotificationService) HandleEvent(db *gorm.DB, e events.Event) error {
+	// 自己做的事不通知自己
+	var exclude []int
+	if e.ActorID != nil {
+		exclude = append(exclude, *e.ActorID)
+	}
+
+	switch e.Type {
+	case models.EventDetailAssigned, models.EventDetailUnassigned:
+		var data models.DetailAssignment
+		if err := e.Decode(&data); err != nil || data.Detail == nil {
+			return err
+		}
+		n := detailNotification(data.Detail)
+		if e.Type == models.EventDetailAssigned {
+			n.Type = models.NotificationAssigned
+			n.Title = fmt.Sprintf("你被指派負責「%s」", data.Detail.Name)
+		} else {
+			n.Type = models.NotificationUnassigned
+			n.Title = fmt.Sprintf("你已不再負責「%s」", data.Detail.Name)
+		}
+		n.ActorID = e.ActorID
+		n.DedupeKey = n.Type + ":" + e.ID
+		return s.notify(db, n, data.UserIDs, exclude...)
+
+	case models.EventCommentCreated, models.EventCommentUpdated:
+		var comment models.TodoComments
+		if err := e.Decode(&comment); err != nil {
+			return err
+		}
+		return s.notifyComment(db, e, &comment, exclude)
+
+	case models.EventMemberRoleChanged:
+		var data models.MemberRoleChange
+		if err := e.Decode(&data); err != nil {
+			return err
+		}
+		n := models.TodoNotifications{
+			Type:      models.NotificationRoleChanged,
+			ActorID:   e.ActorID,
+			Title:     fmt.Sprintf("你的角色已變更為 %s", data.ToRole),
+			DedupeKey: models.NotificationRoleChanged + ":" + e.ID,
+		}
+		return s.notify(db, n, []int{data.UserID}, exclude...)
+	}
+	return nil
+}
+
+// notifyComment 被提到的使用者收到提及通知，修改留言時只通知新提到的人；
+// 新留言另外通知項目與所屬 TodoList 的關注者，已收到提及通知的與留言者自己不重複通知
+func (s *TodoNotificationService) notifyComment(db *gorm.DB, e events.Event, comment *models.TodoComments, exclude []int) error {
+	detail, err := s.repo.FindDetail(s.ctx, db, comment.TodoListDetailID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if comment.CreatedBy != nil {
+		exclude = append(exclude, int(*comment.CreatedBy))
+	}
+
+	commentID := comment.ID
+	mentioned := make([]int, 0, len(comment.Mentions))
+	for _, user := range comment.Mentions {
+		mentioned = append(mentioned, user.ID)
+	}
+	n := detailNotification(detail)
+	n.Type = models.NotificationMentioned
+	n.CommentID = &commentID
+	n.ActorID = e.ActorID
+	n.Title = fmt.Sprintf("有人在「%s」的留言中提到你", detail.Name)
+	n.DedupeKey = fmt.Sprintf("%s:comment:%d", models.NotificationMentioned, comment.ID)
+	if err := s.notify(db, n, mentioned, exclude...); err != nil {
+		return err
+	}
+	if e.Type != models.EventCommentCreated {
+		return nil
+	}
+
+	// 自行查詢關注者，不依賴事件上的 watcher_ids（只有經過 WithWatchers 的 broker 才有）
+	watchers, err := s.watchers.Recipients(db, e)
+	if err != nil {
+		return err
+	}
+	n.Type = models.NotificationComment
+	n.Title = fmt.Sprintf("「%s」有新留言", detail.Name)
+	n.DedupeKey = fmt.Sprintf("%s:comment:%d", models.NotificationComment, comment.ID)
+	return s.notify(db, n, watchers, append(exclude, mentioned...)...)
+}
+
+// detailNotification 以任務填入通知的關聯欄位
+func detailNotification(detail *models.TodoListDetails) models.TodoNotifications {
+	listID, detailID := detail.TodoListID, detail.ID
+	return models.TodoNotifications{TodoListID: &listID, TodoListDetailID: &detailID}
+}
+
+// notify 以 n 為內容通知 userIDs，略過 exclude 與關閉了該類通知的使用者
+func (s *TodoNotificationService) notify(db *gorm.DB, n models.TodoNotifications, userIDs []int, exclude ...int) error {
+	skip := make(map[int]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+	var recipients []int
+	for _, id := range userIDs {
+		if id > 0 && !skip[id] {
+			skip[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	optedOut, err := s.repo.FindOptedOut(s.ctx, db, n.Type, recipients)
+	if err != nil {
+		return err
+	}
+	disabled := make(map[int]bool, len(optedOut))
+	for _, id := range optedOut {
+		disabled[id] = true
+	}
+
+	var notifications []*models.TodoNotifications
+	for _, id := range recipients {
+		if disabled[id] {
+			continue
+		}
+		item := n
+		item.UserID = id
+		notifications = append(notifications, &item)
+	}
+	if err := s.repo.CreateNotifications(s.ctx, db, notifications); err != nil {
+		return err
+	}
+	if s.email == nil {
+		return nil
+	}
+	return s.email.EnqueueNotifications(db, notifications)
+}
+
+func (s *TodoNotificationService) currentUser() (int, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return 0, ErrNotLoggedIn
+	}
+	return userID, nil
+}
+
+// Index 目前使用者的通知，新的在前
+func (s *TodoNotificationService) Index(db *gorm.DB, unreadOnly bool, page, pageSize int) (*utils.PaginatedResult[*models.TodoNotifications], error) {
+	userID, err := s.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	list, total, err := s.repo.FindByUser(s.ctx, db, userID, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+// UnreadCount 目前使用者的未讀通知數
+func (s *TodoNotificationService) UnreadCount(db *gorm.DB) (int64, error) {
+	userID, err := s.currentUser()
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CountUnread(s.ctx, db, userID)
+}
+
+// MarkRead 將目前使用者的通知標為已讀，ids 為空時標記全部；回傳標記的筆數，其他人的通知不受影響
+func (s *TodoNotificationService) MarkRead(db *gorm.DB, ids []int) (int64, error) {
+	userID, err := s.currentUser()
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.MarkRead(s.ctx, db, userID, ids, time.Now())
+}
+
+// Preferences 目前使用者每一類通知是否接收
+func (s *TodoNotificationService) Preferences(db *gorm.DB) (map[string]bool, error) {
+	userID, err := s.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.repo.FindPreferences(s.ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		result[t] = true
+	}
+	for _, pref := range prefs {
+		if _, ok := result[pref.Type]; ok {
+			result[pref.Type] = pref.Enabled
+		}
+	}
+	return result, nil
+}
+
+// SetPreferences 更新指定類型的設定，未指定的維持原本的設定
+func (s *TodoNotificationService) SetPreferences(db *gorm.DB, enabled map[string]bool) (map[string]bool, error) {
+	userID, err := s.currentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	var prefs []*models.TodoNotificationPreferences
+	for _, t := range models.NotificationTypes {
+		if value, ok := enabled[t]; ok {
+			prefs = append(prefs, &models.TodoNotificationPreferences{UserID: userID, Type: t, Enabled: value})
+		}
+	}
+	if len(prefs) != len(enabled) {
+		return nil, ErrUnknownNotificationType
+	}
+
+	if err := s.repo.SavePreferences(s.ctx, db, prefs); err != nil {
+		return nil, err
+	}
+	return s.Preferences(db)
+}
@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func notificationEvent(t *testing.T, id, eventType string, actorID int, data interface{}) events.Event {
+	payload, err := json.Marshal(data)
+	require.NoError(t, err)
+	return events.Event{ID: id, Type: eventType, OccurredAt: time.Now(), ActorID: &actorID, Data: payload}
+}
+
+func TestTodoNotificationService_HandleEvent_Assigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	svc := services.NewTodoNotificationService(ctx, mockRepo, nil)
+	db, _ := setupMockDB(t)
+
+	detail := &models.TodoListDetails{ID: 5, TodoListID: 2, Name: "寫報告"}
+	// 指派自己不通知，關閉了指派通知的使用者也略過
+	event := notificationEvent(t, "e1", models.EventDetailAssigned, 1, models.DetailAssignment{Detail: detail, UserIDs: []int{1, 2, 3}})
+
+	mockRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), models.NotificationAssigned, []int{2, 3}).Return([]int{3}, nil)
+	mockRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, notifications []*models.TodoNotifications) error {
+			require.Len(t, notifications, 1)
+			n := notifications[0]
+			assert.Equal(t, 2, n.UserID)
+			assert.Equal(t, models.NotificationAssigned, n.Type)
+			assert.Equal(t, 5, *n.TodoListDetailID)
+			assert.Equal(t, 2, *n.TodoListID)
+			assert.Equal(t, 1, *n.ActorID)
+			assert.Equal(t, "你被指派負責「寫報告」", n.Title)
+			assert.Equal(t, "assigned:e1", n.DedupeKey)
+			return nil
+		})
+	require.NoError(t, svc.HandleEvent(db, event))
+
+	// 只有自己時不查詢也不建立
+	event = notificationEvent(t, "e2", models.EventDetailUnassigned, 1, models.DetailAssignment{Detail: detail, UserIDs: []int{1}})
+	assert.NoError(t, svc.HandleEvent(db, event))
+
+	// 與通知無關的事件略過
+	assert.NoError(t, svc.HandleEvent(db, notificationEvent(t, "e3", models.EventListCreated, 1, detail)))
+}
+
+func TestTodoNotificationService_HandleEvent_Comment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	mockWatcherRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	svc := services.NewTodoNotificationService(ctx, mockRepo, services.NewTodoWatcherService(ctx, mockWatcherRepo))
+	db, _ := setupMockDB(t)
+
+	author := uint(1)
+	comment := &models.TodoComments{ID: 9, TodoListDetailID: 5, Body: "@bob 請看一下"}
+	comment.CreatedBy = &author
+	comment.Mentions = []models.User{{ID: 2, Account: "bob"}}
+	detail := &models.TodoListDetails{ID: 5, TodoListID: 2, Name: "寫報告"}
+	listID := 2
+	created := notificationEvent(t, "e1", models.EventCommentCreated, 1, comment)
+	created.TodoListID = &listID
+
+	var notified []*models.TodoNotifications
+	collect := func(_ context.Context, _ *gorm.DB, notifications []*models.TodoNotifications) error {
+		notified = append(notified, notifications...)
+		return nil
+	}
+
+	mockRepo.EXPECT().FindDetail(ctx, gomock.Any(), 5).Return(detail, nil)
+	mockRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), models.NotificationMentioned, []int{2}).Return(nil, nil)
+	// 關注項目或 TodoList 的使用者，事件上沒有 watcher_ids 時也自行查詢
+	mockWatcherRepo.EXPECT().FindWatcherIDs(ctx, gomock.Any(), 2, 5).Return([]int{1, 2, 3, 4}, nil)
+	// 被提到的人不再收到留言通知，留言者自己也不會
+	mockRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), models.NotificationComment, []int{3, 4}).Return(nil, nil)
+	mockRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).DoAndReturn(collect).Times(2)
+	require.NoError(t, svc.HandleEvent(db, created))
+
+	require.Len(t, notified, 3)
+	assert.Equal(t, 2, notified[0].UserID)
+	assert.Equal(t, models.NotificationMentioned, notified[0].Type)
+	assert.Equal(t, 9, *notified[0].CommentID)
+	assert.Equal(t, "mentioned:comment:9", notified[0].DedupeKey)
+	assert.Equal(t, 3, notified[1].UserID)
+	assert.Equal(t, 4, notified[2].UserID)
+	assert.Equal(t, models.NotificationComment, notified[2].Type)
+	assert.Equal(t, "「寫報告」有新留言", notified[2].Title)
+
+	// 修改留言只通知提及，已通知過的由 dedupe_key 略過
+	mockRepo.EXPECT().FindDetail(ctx, gomock.Any(), 5).Return(detail, nil)
+	mockRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), models.NotificationMentioned, []int{2}).Return(nil, nil)
+	mockRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).DoAndReturn(collect)
+	require.NoError(t, svc.HandleEvent(db, notificationEvent(t, "e2", models.EventCommentUpdated, 1, comment)))
+	assert.Len(t, notified, 4)
+
+	// 任務已刪除
+	mockRepo.EXPECT().FindDetail(ctx, gomock.Any(), 5).Return(nil, gorm.ErrRecordNotFound)
+	assert.NoError(t, svc.HandleEvent(db, notificationEvent(t, "e3", models.EventCommentCreated, 1, comment)))
+}
+
+func TestTodoNotificationService_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	svc := services.NewTodoNotificationService(ctx, mockRepo, nil)
+	db, _ := setupMockDB(t)
+
+	_, err := svc.SetPreferences(db, map[string]bool{"comment": false, "unknown": true})
+	assert.ErrorIs(t, err, services.ErrUnknownNotificationType)
+
+	mockRepo.EXPECT().SavePreferences(ctx, gomock.Any(), []*models.TodoNotificationPreferences{
+		{UserID: 3, Type: models.NotificationComment, Enabled: false},
+	}).Return(nil)
+	mockRepo.EXPECT().FindPreferences(ctx, gomock.Any(), 3).Return([]*models.TodoNotificationPreferences{
+		{UserID: 3, Type: models.NotificationComment, Enabled: false},
+	}, nil)
+	prefs, err := svc.SetPreferences(db, map[string]bool{"comment": false})
+	require.NoError(t, err)
+	assert.Len(t, prefs, len(models.NotificationTypes))
+	assert.False(t, prefs[models.NotificationComment])
+	assert.True(t, prefs[models.NotificationAssigned])
+
+	_, err = services.NewTodoNotificationService(context.Background(), mockRepo, nil).UnreadCount(db)
+	assert.ErrorIs(t, err, services.ErrNotLoggedIn)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/pkg/webhook"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrOutboxNoBroker = errors.New("未設定事件的 broker")
+
+const (
+	// outboxRetryBase、outboxRetryMax 送出失敗後重試的間隔從 5 秒開始加倍，最長 10 分鐘；不會放棄
+	outboxRetryBase = 5 * time.Second
+	outboxRetryMax  = 10 * time.Minute
+	// outboxDispatchTimeout 送出中超過這個時間仍未結束的事件視為中斷，會被重新送出
+	outboxDispatchTimeout = 5 * time.Minute
+)
+
+// TodoOutboxService 領域事件的 outbox：服務在自己的交易中呼叫 Publish 寫入事件，
+// commit 後由 relay（RunNext）交給 broker，rollback 的寫入不會有事件
+type TodoOutboxService struct {
+	ctx    context.Context
+	repo   interfaces.TodoOutboxRepository
+	broker events.Broker
+}
+
+func NewTodoOutboxService(ctx context.Context, repo interfaces.TodoOutboxRepository) *TodoOutboxService {
+	return &TodoOutboxService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// WithBroker 設定 relay 送出的目的地，只有執行 RunNext 時需要
+func (s *TodoOutboxService) WithBroker(broker events.Broker) *TodoOutboxService {
+	s.broker = broker
+	return s
+}
+
+// Publish 在呼叫端的交易中寫入事件，data 會以 JSON 保存當下的內容；listID 為 0 表示與 TodoList 無關的事件
+func (s *TodoOutboxService) Publish(tx *gorm.DB, eventType string, listID int, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	eventID, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	event := &models.TodoOutboxEvents{
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Depth:         utils.AutomationDepth(s.ctx),
+		OccurredAt:    now,
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+	}
+	if userID, ok := utils.CurrentUserID(s.ctx); ok {
+		event.ActorID = &userID
+	}
+	if listID > 0 {
+		event.TodoListID = &listID
+	}
+	return s.repo.Create(s.ctx, tx, event)
+}
+
+// RunNext 送出下一個到期的事件，沒有時回傳 false。
+// 先在短交易中標記為送出中再送出，送出期間不持有鎖；broker 回傳錯誤或送出中途中斷時整個事件會再送一次（at-least-once）
+func (s *TodoOutboxService) RunNext(db *gorm.DB) (bool, error) {
+	if s.broker == nil {
+		return false, ErrOutboxNoBroker
+	}
+
+	event := &models.TodoOutboxEvents{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		next, err := s.repo.LockNext(s.ctx, tx, now, now.Add(-outboxDispatchTimeout))
+		if err != nil || next == nil {
+			event = nil
+			return err
+		}
+
+		next.Status = models.OutboxStatusDispatching
+		next.Attempts++
+		next.LastAttemptAt = &now
+		if err := s.repo.UpdateStatus(s.ctx, tx, next); err != nil {
+			return err
+		}
+
+		*event = *next
+		return nil
+	})
+	if err != nil || event == nil {
+		return false, err
+	}
+
+	publishErr := s.broker.Publish(s.ctx, event.Event())
+	if publishErr != nil {
+		event.Status = models.OutboxStatusPending
+		event.Error = truncate(publishErr.Error(), 1000)
+		event.NextAttemptAt = time.Now().Add(webhook.Backoff(event.Attempts, outboxRetryBase, outboxRetryMax))
+	} else {
+		now := time.Now()
+		event.Status = models.OutboxStatusDispatched
+		event.Error = ""
+		event.DispatchedAt = &now
+	}
+	if err := s.repo.UpdateStatus(s.ctx, db, event); err != nil {
+		return true, err
+	}
+	return true, publishErr
+}
+
+// Purge 刪除早於 before 已送出的事件
+func (s *TodoOutboxService) Purge(db *gorm.DB, before time.Time) (int64, error) {
+	return s.repo.DeleteDispatched(s.ctx, db, before)
+}
@@ -0,0 +1,246 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTodoListService_Delete_PublishesEventInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	mockOutbox := mocks.NewMockTodoOutboxRepository(ctrl)
+	svc := services.NewTodoListService(ctx, mockRepo).WithEvents(services.NewTodoOutboxService(ctx, mockOutbox))
+	db, sqlmock := setupMockDB(t)
+
+	item := &models.TodoList{ID: 1, Name: "測試項目"}
+
+	// 寫入事件失敗時整個刪除 rollback
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockRepo.EXPECT().SoftDelete(ctx, gomock.Any(), item).Return(nil)
+	mockOutbox.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(errors.New("寫入失敗"))
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Delete(db, 1)
+	assert.Error(t, err)
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(item, nil)
+	mockRepo.EXPECT().SoftDelete(ctx, gomock.Any(), item).Return(nil)
+	mockOutbox.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+			assert.Len(t, event.EventID, 32)
+			assert.Equal(t, models.EventListDeleted, event.EventType)
+			assert.Equal(t, 1, *event.TodoListID)
+			assert.Equal(t, 3, *event.ActorID)
+			assert.Equal(t, models.OutboxStatusPending, event.Status)
+			assert.Contains(t, event.Payload, `"name":"測試項目"`)
+			return nil
+		})
+	sqlmock.ExpectCommit()
+
+	_, err = svc.Delete(db, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoOutboxService_RunNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoOutboxRepository(ctrl)
+	broker := events.NewMemory()
+	svc := services.NewTodoOutboxService(ctx, mockRepo).WithBroker(broker)
+	db, sqlmock := setupMockDB(t)
+
+	listID := 2
+	claim := func(attempts int) {
+		pending := &models.TodoOutboxEvents{
+			ID: 7, EventID: "e1", EventType: models.EventListCreated, TodoListID: &listID,
+			Payload: `{"id":2}`, Status: models.OutboxStatusPending, Attempts: attempts,
+		}
+		sqlmock.ExpectBegin()
+		mockRepo.EXPECT().LockNext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(pending, nil)
+		mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), pending).
+			DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+				assert.Equal(t, models.OutboxStatusDispatching, event.Status)
+				assert.Equal(t, attempts+1, event.Attempts)
+				return nil
+			})
+		sqlmock.ExpectCommit()
+	}
+
+	// broker 失敗時延後重送
+	broker.FailWith(errors.New("broker 無法連線"))
+	claim(1)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+			assert.Equal(t, models.OutboxStatusPending, event.Status)
+			assert.Equal(t, "broker 無法連線", event.Error)
+			assert.WithinDuration(t, time.Now().Add(10*time.Second), event.NextAttemptAt, 2*time.Second)
+			return nil
+		})
+	processed, err := svc.RunNext(db)
+	assert.True(t, processed)
+	assert.Error(t, err)
+	assert.Empty(t, broker.Events())
+
+	broker.FailWith(nil)
+	claim(2)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+			assert.Equal(t, models.OutboxStatusDispatched, event.Status)
+			assert.Empty(t, event.Error)
+			assert.NotNil(t, event.DispatchedAt)
+			return nil
+		})
+	processed, err = svc.RunNext(db)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	require.Len(t, broker.Events(), 1)
+	got := broker.Events()[0]
+	assert.Equal(t, "e1", got.ID)
+	assert.Equal(t, models.EventListCreated, got.Type)
+	assert.Equal(t, 2, *got.TodoListID)
+	assert.JSONEq(t, `{"id":2}`, string(got.Data))
+
+	// 沒有到期的事件
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	sqlmock.ExpectCommit()
+	processed, err = svc.RunNext(db)
+	require.NoError(t, err)
+	assert.False(t, processed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+
+	_, err = services.NewTodoOutboxService(ctx, mockRepo).RunNext(db)
+	assert.ErrorIs(t, err, services.ErrOutboxNoBroker)
+}
+
+func TestTodoOutboxService_RunNext_RedeliversToEverySubscriber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoOutboxRepository(ctrl)
+	mockWebhooks := mocks.NewMockTodoWebhookRepository(ctrl)
+	db, sqlmock := setupMockDB(t)
+
+	// 三個訂閱者：webhook、只訂閱 detail.updated 的與第一次會失敗的
+	webhooks := services.NewTodoWebhookService(ctx, mockWebhooks)
+	var typed, flaky []string
+	bus := events.NewBus()
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		return webhooks.HandleEvent(db, e)
+	})
+	bus.Subscribe(models.EventDetailUpdated, func(_ context.Context, e events.Event) error {
+		typed = append(typed, e.ID)
+		return nil
+	})
+	bus.Subscribe(events.All, func(_ context.Context, e events.Event) error {
+		flaky = append(flaky, e.ID)
+		if len(flaky) == 1 {
+			return errors.New("暫時無法處理")
+		}
+		return nil
+	})
+	svc := services.NewTodoOutboxService(ctx, mockRepo).WithBroker(bus)
+
+	listID := 2
+	claim := func(status string, attempts int) {
+		event := &models.TodoOutboxEvents{
+			ID: 7, EventID: "e1", EventType: models.EventDetailUpdated, TodoListID: &listID,
+			Payload: `{"id":5}`, Status: status, Attempts: attempts,
+		}
+		sqlmock.ExpectBegin()
+		mockRepo.EXPECT().LockNext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(event, nil)
+		mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), event).Return(nil)
+		sqlmock.ExpectCommit()
+	}
+	hooks := []*models.TodoWebhooks{{ID: 1, Events: []string{models.EventDetailUpdated}}, {ID: 2, Events: []string{models.EventDetailUpdated}}}
+
+	// 第一次送出：webhook 建立兩筆紀錄，但有一個訂閱者失敗，整個事件延後重送
+	claim(models.OutboxStatusPending, 0)
+	mockWebhooks.EXPECT().FindActive(ctx, gomock.Any(), 2).Return(hooks, nil)
+	mockWebhooks.EXPECT().FindEventWebhookIDs(ctx, gomock.Any(), "e1").Return(nil, nil)
+	mockWebhooks.EXPECT().CreateDeliveries(ctx, gomock.Any(), gomock.Len(2)).Return(nil)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+			assert.Equal(t, models.OutboxStatusPending, event.Status)
+			assert.Equal(t, "暫時無法處理", event.Error)
+			return nil
+		})
+	_, err := svc.RunNext(db)
+	assert.Error(t, err)
+
+	// 重送時所有訂閱者都再收到同一個事件 ID；webhook 依事件 ID 略過已建立的紀錄
+	claim(models.OutboxStatusPending, 1)
+	mockWebhooks.EXPECT().FindActive(ctx, gomock.Any(), 2).Return(hooks, nil)
+	mockWebhooks.EXPECT().FindEventWebhookIDs(ctx, gomock.Any(), "e1").Return([]int{1, 2}, nil)
+	mockWebhooks.EXPECT().CreateDeliveries(ctx, gomock.Any(), gomock.Len(0)).Return(nil)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, event *models.TodoOutboxEvents) error {
+			assert.Equal(t, models.OutboxStatusDispatched, event.Status)
+			return nil
+		})
+	_, err = svc.RunNext(db)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"e1", "e1"}, typed)
+	assert.Equal(t, []string{"e1", "e1"}, flaky)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoOutboxService_RunNext_ReclaimsInterruptedDispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoOutboxRepository(ctrl)
+	broker := events.NewMemory()
+	svc := services.NewTodoOutboxService(ctx, mockRepo).WithBroker(broker)
+	db, sqlmock := setupMockDB(t)
+
+	// relay 在送出途中停止，事件停在 dispatching
+	event := &models.TodoOutboxEvents{ID: 7, EventID: "e1", EventType: models.EventListCreated, Payload: `{}`, Status: models.OutboxStatusDispatching, Attempts: 1}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, now, staleBefore time.Time) (*models.TodoOutboxEvents, error) {
+			assert.Equal(t, 5*time.Minute, now.Sub(staleBefore))
+			return event, nil
+		})
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), event).Return(nil)
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, e *models.TodoOutboxEvents) error {
+			assert.Equal(t, models.OutboxStatusDispatched, e.Status)
+			assert.Equal(t, 2, e.Attempts)
+			return nil
+		})
+
+	processed, err := svc.RunNext(db)
+
+	require.NoError(t, err)
+	assert.True(t, processed)
+	require.Len(t, broker.Events(), 1)
+	assert.Equal(t, "e1", broker.Events()[0].ID)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todolist/models"
+	"todolist/pkg/rrule"
+	"todolist/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// dtstartLayout 為規則時區內的牆上時間，不帶時區偏移
+const dtstartLayout = "2006-01-02T15:04:05"
+
+type TodoRecurrenceService struct {
+	ctx       context.Context
+	repo      interfaces.TodoRecurrenceRepository
+	checklist *TodoChecklistService
+}
+
+func NewTodoRecurrenceService(ctx context.Context, repo interfaces.TodoRecurrenceRepository) *TodoRecurrenceService {
+	return &TodoRecurrenceService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// WithChecklist 設定後，產生的任務會將說明中的 - [ ] 待辦建立為 checklist 項目
+func (s *TodoRecurrenceService) WithChecklist(checklist *TodoChecklistService) *TodoRecurrenceService {
+	s.checklist = checklist
+	return s
+}
+
+// parseSchedule 驗證 rrule / timezone，並以規則時區解析 dtstart
+func parseSchedule(rruleStr, timezone, dtstart string) (*rrule.Rule, time.Time, error) {
+	rule, err := rrule.Parse(rruleStr)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("無效的時區：%s", timezone)
+	}
+
+	start, err := time.ParseInLocation(dtstartLayout, dtstart, loc)
+	if err != nil {
+		return nil, time.Time{}, errors.New("dtstart 格式必須為 2006-01-02T15:04:05")
+	}
+
+	return rule, start, nil
+}
+
+// Create 建立週期規則，並立即產生第一次（或最近一次已到期）的任務
+func (s *TodoRecurrenceService) Create(db *gorm.DB, listID int, name, detail, rruleStr, timezone, dtstart string) (*models.TodoRecurrences, error) {
+	rule, start, err := parseSchedule(rruleStr, timezone, dtstart)
+	if err != nil {
+		return nil, err
+	}
+
+	first, ok := rule.Next(start, start.Add(-time.Second))
+	if !ok {
+		return nil, errors.New("規則沒有任何發生時間")
+	}
+
+	data := &models.TodoRecurrences{
+		TodoListID: listID,
+		Name:       name,
+		Detail:     detail,
+		RRule:      rule.String(),
+		Timezone:   timezone,
+		DtStart:    start,
+		NextAt:     &first,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("to_do_list_id 不存在")
+		}
+
+		if err := s.repo.Create(s.ctx, tx, data); err != nil {
+			return err
+		}
+
+		return s.generate(tx, data, time.Now())
+	})
+
+	return data, err
+}
+
+func (s *TodoRecurrenceService) Index(db *gorm.DB, listID int) ([]*models.TodoRecurrences, error) {
+	return s.repo.FindByListID(s.ctx, db, listID)
+}
+
+func (s *TodoRecurrenceService) Show(db *gorm.DB, id int) (*models.TodoRecurrences, error) {
+	return s.repo.FindByID(s.ctx, db, id)
+}
+
+// Edit 修改規則；已產生的任務不受影響，下一次發生時間從最後一次產生之後重新計算
+func (s *TodoRecurrenceService) Edit(db *gorm.DB, id int, name, detail, rruleStr, timezone, dtstart string) (*models.TodoRecurrences, error) {
+	rule, start, err := parseSchedule(rruleStr, timezone, dtstart)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &models.TodoRecurrences{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.LockByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		after := start.Add(-time.Second)
+		if item.LastOccurrenceAt != nil && item.LastOccurrenceAt.After(after) {
+			after = *item.LastOccurrenceAt
+		}
+
+		item.Name = name
+		item.Detail = detail
+		item.RRule = rule.String()
+		item.Timezone = timezone
+		item.DtStart = start
+		item.NextAt = nil
+		if next, ok := rule.Next(start, after); ok {
+			item.NextAt = &next
+		}
+
+		// next_at 可能被清空，需用 Select 指定欄位強制更新
+		if err := s.repo.Update(s.ctx, tx.Select("name", "detail", "rrule", "timezone", "dtstart", "next_at", "updated_at", "updated_by"), item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Delete 刪除規則後不再產生新任務，已產生的任務保留
+func (s *TodoRecurrenceService) Delete(db *gorm.DB, id int) (*models.TodoRecurrences, error) {
+	var deleted *models.TodoRecurrences
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// GenerateDue 為所有到期的規則產生任務，回傳實際處理的規則數；供排程器定期呼叫
+func (s *TodoRecurrenceService) GenerateDue(db *gorm.DB, now time.Time, limit int) (int, error) {
+	ids, err := s.repo.FindDueIDs(s.ctx, db, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range ids {
+		generated := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// 鎖定後重新檢查，其他 instance 可能已經處理過
+			item, err := s.repo.LockByID(s.ctx, tx, id)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+			if item.NextAt == nil || item.NextAt.After(now) {
+				return nil
+			}
+
+			generated = true
+			return s.generate(tx, item, now)
+		})
+		if err != nil {
+			return processed, fmt.Errorf("產生週期任務失敗 (recurrence #%d)：%w", id, err)
+		}
+		if generated {
+			processed++
+		}
+	}
+
+	return processed, nil
+}
+
+// OnOccurrenceDone 任務完成時提前產生下一次；只有最近一次產生的任務完成才會觸發，
+// 重新開啟再完成舊的任務不會多產生
+func (s *TodoRecurrenceService) OnOccurrenceDone(tx *gorm.DB, detail *models.TodoListDetails) error {
+	if detail.RecurrenceID == nil || detail.OccurrenceAt == nil {
+		return nil
+	}
+
+	item, err := s.repo.LockByID(s.ctx, tx, *detail.RecurrenceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // 規則已刪除
+		}
+		return err
+	}
+
+	if item.NextAt == nil || item.LastOccurrenceAt == nil || !item.LastOccurrenceAt.Equal(*detail.OccurrenceAt) {
+		return nil
+	}
+
+	return s.generate(tx, item, time.Now())
+}
+
+// generate 產生 NextAt 這一次的任務並推進 NextAt，必須在鎖定規則的交易中呼叫。
+// 停機期間錯過的多次只補最近一次已到期的，避免一次湧入大量任務；
+// 重複執行時由 (recurrence_id, occurrence_at) 唯一索引保證不會重複建立。
+func (s *TodoRecurrenceService) generate(tx *gorm.DB, item *models.TodoRecurrences, now time.Time) error {
+	rule, start, err := item.Schedule()
+	if err != nil {
+		return err
+	}
+
+	target := item.NextAt.In(start.Location())
+	for {
+		next, ok := rule.Next(start, target)
+		if !ok || next.After(now) {
+			break
+		}
+		target = next
+	}
+
+	recurrenceID := item.ID
+	occurrence := &models.TodoListDetails{
+		TodoListID:   item.TodoListID,
+		Name:         item.Name,
+		Detail:       item.Detail,
+		Status:       models.DetailStatusTodo,
+		RecurrenceID: &recurrenceID,
+		OccurrenceAt: &target,
+	}
+	created, err := s.repo.CreateOccurrence(s.ctx, tx, occurrence)
+	if err != nil {
+		return err
+	}
+	if created && s.checklist != nil {
+		if err := s.checklist.SyncFromMarkdown(tx, occurrence.ID, occurrence.Detail); err != nil {
+			return err
+		}
+	}
+
+	item.LastOccurrenceAt = &target
+	item.NextAt = nil
+	if next, ok := rule.Next(start, target); ok {
+		item.NextAt = &next
+	}
+
+	return s.repo.Update(s.ctx, tx.Select("next_at", "last_occurrence_at", "updated_at", "updated_by"), item)
+}
@@ -0,0 +1,173 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoRecurrenceService_Create_InvalidRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := services.NewTodoRecurrenceService(context.Background(), mocks.NewMockTodoRecurrenceRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	_, err := svc.Create(db, 1, "週會", "", "FREQ=YEARLY", "Asia/Taipei", "2030-01-07T09:00:00")
+	assert.EqualError(t, err, "不支援的 FREQ：YEARLY")
+
+	_, err = svc.Create(db, 1, "週會", "", "FREQ=WEEKLY", "Mars/Base", "2030-01-07T09:00:00")
+	assert.EqualError(t, err, "無效的時區：Mars/Base")
+}
+
+func TestTodoRecurrenceService_Create_GeneratesFirstOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoRecurrenceRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoRecurrenceService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	first := time.Date(2030, 1, 7, 9, 0, 0, 0, taipei)
+
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list" WHERE id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, r *models.TodoRecurrences) error {
+			r.ID = 5
+			return nil
+		})
+	mockRepo.EXPECT().CreateOccurrence(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, d *models.TodoListDetails) (bool, error) {
+			assert.Equal(t, 1, d.TodoListID)
+			assert.Equal(t, "週會", d.Name)
+			assert.Equal(t, models.DetailStatusTodo, d.Status)
+			assert.Equal(t, 5, *d.RecurrenceID)
+			assert.True(t, first.Equal(*d.OccurrenceAt))
+			return true, nil
+		})
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Create(db, 1, "週會", "", "freq=weekly;byday=MO", "Asia/Taipei", "2030-01-07T09:00:00")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", result.RRule)
+	assert.True(t, first.Equal(*result.LastOccurrenceAt))
+	assert.True(t, first.AddDate(0, 0, 7).Equal(*result.NextAt))
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoRecurrenceService_GenerateDue_CatchesUpToLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoRecurrenceRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoRecurrenceService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	nextAt := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	rec := &models.TodoRecurrences{ID: 3, TodoListID: 2, Name: "日報", RRule: "FREQ=DAILY", Timezone: "UTC", DtStart: start, NextAt: &nextAt}
+
+	mockRepo.EXPECT().FindDueIDs(ctx, gomock.Any(), now, 100).Return([]int{3}, nil)
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 3).Return(rec, nil)
+	// 停機期間錯過 3/5 ~ 3/7，只補最近一次已到期的 3/8
+	mockRepo.EXPECT().CreateOccurrence(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, d *models.TodoListDetails) (bool, error) {
+			assert.True(t, time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC).Equal(*d.OccurrenceAt))
+			return true, nil
+		})
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), rec).Return(nil)
+	sqlmock.ExpectCommit()
+
+	processed, err := svc.GenerateDue(db, now, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.True(t, time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC).Equal(*rec.NextAt))
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoRecurrenceService_GenerateDue_SkipsAlreadyHandled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoRecurrenceRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoRecurrenceService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	// 其他 instance 已經處理並推進 next_at
+	nextAt := now.Add(time.Hour)
+	rec := &models.TodoRecurrences{ID: 3, RRule: "FREQ=DAILY", Timezone: "UTC", NextAt: &nextAt}
+
+	mockRepo.EXPECT().FindDueIDs(ctx, gomock.Any(), now, 100).Return([]int{3}, nil)
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 3).Return(rec, nil)
+	sqlmock.ExpectCommit()
+
+	processed, err := svc.GenerateDue(db, now, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoRecurrenceService_OnOccurrenceDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoRecurrenceRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoRecurrenceService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	start := time.Date(2030, 1, 7, 9, 0, 0, 0, time.UTC)
+	last := start.AddDate(0, 0, 7)
+	nextAt := start.AddDate(0, 0, 14)
+	recurrenceID := 3
+	newRec := func() *models.TodoRecurrences {
+		l, n := last, nextAt
+		return &models.TodoRecurrences{ID: 3, RRule: "FREQ=WEEKLY", Timezone: "UTC", DtStart: start, LastOccurrenceAt: &l, NextAt: &n}
+	}
+
+	// 完成舊的一次，不產生新任務
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 3).Return(newRec(), nil)
+	old := &models.TodoListDetails{RecurrenceID: &recurrenceID, OccurrenceAt: &start}
+	assert.NoError(t, svc.OnOccurrenceDone(db, old))
+
+	// 完成最近一次，提前產生下一次
+	rec := newRec()
+	mockRepo.EXPECT().LockByID(ctx, gomock.Any(), 3).Return(rec, nil)
+	mockRepo.EXPECT().CreateOccurrence(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, d *models.TodoListDetails) (bool, error) {
+			assert.True(t, nextAt.Equal(*d.OccurrenceAt))
+			return true, nil
+		})
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), rec).Return(nil)
+
+	latest := &models.TodoListDetails{RecurrenceID: &recurrenceID, OccurrenceAt: &last}
+	assert.NoError(t, svc.OnOccurrenceDone(db, latest))
+	assert.True(t, start.AddDate(0, 0, 21).Equal(*rec.NextAt))
+
+	// 非週期性任務直接略過
+	assert.NoError(t, svc.OnOccurrenceDone(db, &models.TodoListDetails{}))
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"todolist/config"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// reminderLeaseName、reminderLeaseTTL 同一時間只有一個 instance 執行提醒排程，持有者停止後租約到期由其他 instance 接手
+	reminderLeaseName = "reminders"
+	reminderLeaseTTL  = 3 * time.Minute
+	// reminderAtDueWindow 到期提醒在到期後多久內仍會補發
+	reminderAtDueWindow = 24 * time.Hour
+	// reminderOverdueUntil 逾期超過這段時間後不再重複提醒
+	reminderOverdueUntil = 30 * 24 * time.Hour
+	// reminderEscalationWindow 通知清單建立者或 Admin 的提醒在應送出後多久內仍會補發
+	reminderEscalationWindow = 7 * 24 * time.Hour
+	// reminderRunRetention 執行紀錄保留的時間；送出的提醒另外保留，用來避免重複提醒
+	reminderRunRetention = 7 * 24 * time.Hour
+)
+
+const (
+	ReminderEscalateToOwner  = "owner"
+	ReminderEscalateToAdmins = "admins"
+)
+
+type TodoReminderService struct {
+	ctx           context.Context
+	repo          interfaces.TodoReminderRepository
+	notifications *TodoNotificationService
+	rules         []models.ReminderRule
+	escalateTo    string
+	instance      string
+}
+
+func NewTodoReminderService(ctx context.Context, repo interfaces.TodoReminderRepository, notifications *TodoNotificationService) *TodoReminderService {
+	host, _ := os.Hostname()
+	suffix, _ := randomHex(4)
+	return &TodoReminderService{
+		ctx:           ctx,
+		repo:          repo,
+		notifications: notifications,
+		rules:         reminderRules(config.ReminderBefore, config.ReminderOverdueRepeat, config.ReminderEscalateAfter),
+		escalateTo:    config.ReminderEscalateTo,
+		instance:      truncate(host, 50) + "-" + suffix,
+	}
+}
+
+// reminderRules 依設定建立規則：到期前 before 各一次、到期時一次、逾期後每 repeat 一次，
+// escalateAfter 不為 0 時逾期超過這段時間通知清單建立者或 Admin
+func reminderRules(before []time.Duration, repeat, escalateAfter time.Duration) []models.ReminderRule {
+	var rules []models.ReminderRule
+	for _, d := range before {
+		rules = append(rules, models.ReminderRule{Name: "before_" + shortDuration(d), Offset: -d, Window: d})
+	}
+	rules = append(rules, models.ReminderRule{Name: models.ReminderRuleAtDue, Window: reminderAtDueWindow})
+	if repeat > 0 {
+		rules = append(rules, models.ReminderRule{Name: models.ReminderRuleOverdue, Offset: repeat, Repeat: repeat, Until: reminderOverdueUntil, Window: repeat})
+	}
+	if escalateAfter > 0 {
+		rules = append(rules, models.ReminderRule{Name: models.ReminderRuleEscalation, Offset: escalateAfter, Window: reminderEscalationWindow, Escalate: true})
+	}
+	return rules
+}
+
+// shortDuration 24h0m0s 顯示為 24h
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// Rules 目前的提醒規則
+func (s *TodoReminderService) Rules() []models.ReminderRule {
+	return s.rules
+}
+
+// reminderNotification 依規則建立通知內容；同一個提醒的 dedupe_key 相同，重送時不會重複通知
+func reminderNotification(rule models.ReminderRule, task *models.ReminderTask, occurrence int, now time.Time) models.TodoNotifications {
+	listID, detailID := task.TodoListID, task.TodoListDetailID
+	n := models.TodoNotifications{
+		TodoListID:       &listID,
+		TodoListDetailID: &detailID,
+		DedupeKey:        fmt.Sprintf("reminder:%s:%d:%d:%d", rule.Name, task.TodoListDetailID, task.DueAt.Unix(), occurrence),
+	}
+	overdueDays := int(now.Sub(task.DueAt) / (24 * time.Hour))
+	switch {
+	case rule.Escalate:
+		n.Type = models.NotificationEscalated
+		n.Title = fmt.Sprintf("「%s」已逾期 %d 天仍未完成", task.Name, overdueDays)
+	case rule.Offset < 0:
+		n.Type = models.NotificationDueSoon
+		n.Title = fmt.Sprintf("「%s」將於 %s 到期", task.Name, task.DueAt.Format("2006-01-02 15:04"))
+	case rule.Repeat > 0:
+		n.Type = models.NotificationOverdue
+		n.Title = fmt.Sprintf("「%s」已逾期 %d 天", task.Name, overdueDays)
+	default:
+		n.Type = models.NotificationOverdue
+		n.Title = fmt.Sprintf("「%s」已到期", task.Name)
+	}
+	return n
+}
+
+// recipients 提醒的對象：一般為負責人；通知清單建立者或 Admin 的規則依設定決定，清單沒有建立者時改通知 Admin
+func (s *TodoReminderService) recipients(db *gorm.DB, rule models.ReminderRule, task *models.ReminderTask, admins *[]int) ([]int, error) {
+	if !rule.Escalate {
+		return task.AssigneeIDs, nil
+	}
+	if s.escalateTo == ReminderEscalateToOwner && task.OwnerID != nil {
+		return []int{*task.OwnerID}, nil
+	}
+	if *admins == nil {
+		ids, err := s.repo.FindUserIDsByRole(s.ctx, db, adminRole)
+		if err != nil {
+			return nil, err
+		}
+		*admins = append([]int{}, ids...)
+	}
+	return *admins, nil
+}
+
+// RunScan 送出到了提醒時間的提醒並記錄這次執行，沒有取得租約時回傳 false。
+// 同一時間只有持有租約的 instance 執行；每個提醒先寫入紀錄才通知，已有紀錄的不再送出，租約交接時也不會重複提醒
+func (s *TodoReminderService) RunScan(db *gorm.DB, now time.Time) (bool, error) {
+	acquired, err := s.repo.AcquireLease(s.ctx, db, reminderLeaseName, s.instance, now, reminderLeaseTTL)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	run := &models.TodoReminderRuns{Instance: s.instance, Status: models.ReminderRunRunning, StartedAt: now}
+	if err := s.repo.CreateRun(s.ctx, db, run); err != nil {
+		return true, err
+	}
+
+	sent, scanErr := s.scan(db, run.ID, now)
+	finished := time.Now()
+	run.Sent = sent
+	run.FinishedAt = &finished
+	run.Status = models.ReminderRunSucceeded
+	if scanErr != nil {
+		run.Status = models.ReminderRunFailed
+		run.Error = truncate(scanErr.Error(), 1000)
+	}
+	if err := s.repo.UpdateRun(s.ctx, db, run); err != nil {
+		return true, err
+	}
+	if _, err := s.repo.DeleteRuns(s.ctx, db, now.Add(-reminderRunRetention)); err != nil {
+		return true, err
+	}
+	return true, scanErr
+}
+
+// scan 依每個規則找出到了提醒時間的任務並送出，回傳送出的提醒數
+func (s *TodoReminderService) scan(db *gorm.DB, runID int, now time.Time) (int, error) {
+	sent := 0
+	var admins []int
+	for _, rule := range s.rules {
+		// 提醒時間在 (now - Window, now] 的任務；重複提醒的為逾期不超過 Until 的任務
+		from, to := now.Add(-rule.Offset-rule.Window), now.Add(-rule.Offset+time.Second)
+		if rule.Repeat > 0 {
+			from = now.Add(-rule.Until)
+		}
+		tasks, err := s.repo.FindDueTasks(s.ctx, db, from, to)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, task := range tasks {
+			occurrence, _, ok := rule.Occurrence(task.DueAt, now)
+			if !ok {
+				continue
+			}
+			userIDs, err := s.recipients(db, rule, task, &admins)
+			if err != nil {
+				return sent, err
+			}
+			if len(userIDs) == 0 {
+				continue
+			}
+
+			var fresh []int
+			err = db.Transaction(func(tx *gorm.DB) error {
+				for _, userID := range userIDs {
+					created, err := s.repo.CreateLog(s.ctx, tx, &models.TodoReminderLogs{
+						RunID:            &runID,
+						Rule:             rule.Name,
+						TodoListDetailID: task.TodoListDetailID,
+						UserID:           userID,
+						DueAt:            task.DueAt,
+						Occurrence:       occurrence,
+						SentAt:           now,
+					})
+					if err != nil {
+						return err
+					}
+					if created {
+						fresh = append(fresh, userID)
+					}
+				}
+				if len(fresh) == 0 {
+					return nil
+				}
+				return s.notifications.notify(tx, reminderNotification(rule, task, occurrence, now), fresh)
+			})
+			if err != nil {
+				return sent, err
+			}
+			// 交易 commit 後才計入，通知失敗復原時不算已送出
+			sent += len(fresh)
+		}
+	}
+	return sent, nil
+}
+
+// Upcoming 接下來 within 內會送出的提醒，依提醒時間排序；重複提醒的只列出下一次
+func (s *TodoReminderService) Upcoming(db *gorm.DB, within time.Duration) ([]*models.ReminderUpcoming, error) {
+	now := time.Now()
+	until := now.Add(within)
+	upcoming := []*models.ReminderUpcoming{}
+	var admins []int
+	for _, rule := range s.rules {
+		// 下一次提醒時間在 (now, until] 的任務
+		from, to := now.Add(-rule.Offset), until.Add(-rule.Offset+time.Second)
+		if rule.Repeat > 0 {
+			from = now.Add(-rule.Until)
+		}
+		tasks, err := s.repo.FindDueTasks(s.ctx, db, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			occurrence, fireAt, ok := rule.Next(task.DueAt, now)
+			if !ok || fireAt.After(until) {
+				continue
+			}
+			userIDs, err := s.recipients(db, rule, task, &admins)
+			if err != nil {
+				return nil, err
+			}
+			if len(userIDs) == 0 {
+				continue
+			}
+			upcoming = append(upcoming, &models.ReminderUpcoming{
+				Rule:             rule.Name,
+				TodoListDetailID: task.TodoListDetailID,
+				TodoListID:       task.TodoListID,
+				Name:             task.Name,
+				DueAt:            task.DueAt,
+				FireAt:           fireAt,
+				Occurrence:       occurrence,
+				UserIDs:          userIDs,
+			})
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].FireAt.Before(upcoming[j].FireAt)
+	})
+	return upcoming, nil
+}
+
+// Runs 最近的執行紀錄，新的在前
+func (s *TodoReminderService) Runs(db *gorm.DB, page, pageSize int) (*utils.PaginatedResult[*models.TodoReminderRuns], error) {
+	runs, total, err := s.repo.FindRuns(s.ctx, db, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewPaginatedResult(runs, total, page, pageSize), nil
+}
+
+// Run 取得一次執行與該次送出的提醒
+func (s *TodoReminderService) Run(db *gorm.DB, id int) (*models.TodoReminderRuns, error) {
+	return s.repo.FindRun(s.ctx, db, id)
+}
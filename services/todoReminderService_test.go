@@ -0,0 +1,285 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"todolist/config"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupReminderConfig(before []time.Duration, repeat, escalateAfter time.Duration, escalateTo string) {
+	config.ReminderBefore = before
+	config.ReminderOverdueRepeat = repeat
+	config.ReminderEscalateAfter = escalateAfter
+	config.ReminderEscalateTo = escalateTo
+}
+
+func TestTodoReminderService_Rules(t *testing.T) {
+	setupReminderConfig([]time.Duration{24 * time.Hour, 90 * time.Minute}, 0, 72*time.Hour, "owner")
+	svc := services.NewTodoReminderService(context.Background(), nil, nil)
+
+	var names []string
+	for _, rule := range svc.Rules() {
+		names = append(names, rule.Name)
+	}
+	assert.Equal(t, []string{"before_24h", "before_1h30m", "at_due", "escalation"}, names)
+}
+
+func TestTodoReminderService_RunScan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupReminderConfig([]time.Duration{24 * time.Hour}, 24*time.Hour, 72*time.Hour, "owner")
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoReminderRepository(ctrl)
+	mockNotificationRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	svc := services.NewTodoReminderService(ctx, mockRepo, services.NewTodoNotificationService(ctx, mockNotificationRepo, nil))
+	db, sqlmock := setupMockDB(t)
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+
+	// 其他 instance 持有租約
+	mockRepo.EXPECT().AcquireLease(ctx, gomock.Any(), "reminders", gomock.Any(), now, 3*time.Minute).Return(false, nil)
+	ran, err := svc.RunScan(db, now)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	soon := &models.ReminderTask{TodoListDetailID: 1, TodoListID: 2, Name: "寫報告", DueAt: now.Add(3 * time.Hour), AssigneeIDs: []int{1, 2}}
+	due := &models.ReminderTask{TodoListDetailID: 2, TodoListID: 2, Name: "開會", DueAt: now.Add(-time.Hour), AssigneeIDs: []int{3}}
+	overdue := &models.ReminderTask{TodoListDetailID: 3, TodoListID: 2, Name: "繳費", DueAt: now.Add(-50 * time.Hour), AssigneeIDs: []int{3}}
+	// 沒有負責人、清單也沒有建立者的改通知 Admin
+	escalated := &models.ReminderTask{TodoListDetailID: 4, TodoListID: 3, Name: "回信", DueAt: now.Add(-80 * time.Hour)}
+	// 逾期但還沒到下一次重複提醒的時間
+	notYet := &models.ReminderTask{TodoListDetailID: 5, TodoListID: 3, Name: "整理", DueAt: now.Add(-10 * time.Hour), AssigneeIDs: []int{4}}
+
+	var notifications []*models.TodoNotifications
+	var logs []*models.TodoReminderLogs
+	mockRepo.EXPECT().AcquireLease(ctx, gomock.Any(), "reminders", gomock.Any(), now, 3*time.Minute).Return(true, nil)
+	mockRepo.EXPECT().CreateRun(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, run *models.TodoReminderRuns) error {
+			assert.Equal(t, models.ReminderRunRunning, run.Status)
+			run.ID = 7
+			return nil
+		})
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), now, now.Add(24*time.Hour+time.Second)).Return([]*models.ReminderTask{soon}, nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), now.Add(-24*time.Hour), now.Add(time.Second)).Return([]*models.ReminderTask{due}, nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), now.Add(-30*24*time.Hour), now.Add(-24*time.Hour+time.Second)).Return([]*models.ReminderTask{overdue, notYet}, nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), now.Add(-72*time.Hour-7*24*time.Hour), now.Add(-72*time.Hour+time.Second)).Return([]*models.ReminderTask{escalated}, nil)
+	mockRepo.EXPECT().FindUserIDsByRole(ctx, gomock.Any(), "Admin").Return([]int{9}, nil)
+	mockRepo.EXPECT().CreateLog(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, log *models.TodoReminderLogs) (bool, error) {
+			assert.Equal(t, 7, *log.RunID)
+			// 到期提醒已經送過
+			if log.Rule == models.ReminderRuleAtDue {
+				return false, nil
+			}
+			logs = append(logs, log)
+			return true, nil
+		}).Times(5)
+	mockNotificationRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+	mockNotificationRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, created []*models.TodoNotifications) error {
+			notifications = append(notifications, created...)
+			return nil
+		}).Times(3)
+	mockRepo.EXPECT().UpdateRun(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, run *models.TodoReminderRuns) error {
+			assert.Equal(t, models.ReminderRunSucceeded, run.Status)
+			assert.Equal(t, 4, run.Sent)
+			assert.NotNil(t, run.FinishedAt)
+			return nil
+		})
+	mockRepo.EXPECT().DeleteRuns(ctx, gomock.Any(), now.Add(-7*24*time.Hour)).Return(int64(0), nil)
+	for i := 0; i < 4; i++ {
+		sqlmock.ExpectBegin()
+		sqlmock.ExpectCommit()
+	}
+
+	ran, err = svc.RunScan(db, now)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+
+	require.Len(t, logs, 4)
+	assert.Equal(t, "before_24h", logs[0].Rule)
+	assert.Equal(t, 1, logs[2].Occurrence)
+	assert.Equal(t, 9, logs[3].UserID)
+
+	require.Len(t, notifications, 4)
+	assert.Equal(t, models.NotificationDueSoon, notifications[0].Type)
+	assert.Equal(t, []int{1, 2}, []int{notifications[0].UserID, notifications[1].UserID})
+	assert.Equal(t, "「寫報告」將於 2026-03-10 12:00 到期", notifications[0].Title)
+	assert.Equal(t, models.NotificationOverdue, notifications[2].Type)
+	assert.Equal(t, "「繳費」已逾期 2 天", notifications[2].Title)
+	assert.Equal(t, fmt.Sprintf("reminder:overdue:3:%d:1", overdue.DueAt.Unix()), notifications[2].DedupeKey)
+	assert.Equal(t, models.NotificationEscalated, notifications[3].Type)
+	assert.Equal(t, 9, notifications[3].UserID)
+	assert.Equal(t, "「回信」已逾期 3 天仍未完成", notifications[3].Title)
+}
+
+func TestTodoReminderService_RunScan_NotifyFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupReminderConfig([]time.Duration{24 * time.Hour}, 0, 72*time.Hour, "owner")
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoReminderRepository(ctrl)
+	mockNotificationRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	svc := services.NewTodoReminderService(ctx, mockRepo, services.NewTodoNotificationService(ctx, mockNotificationRepo, nil))
+	db, sqlmock := setupMockDB(t)
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	soon := &models.ReminderTask{TodoListDetailID: 1, TodoListID: 2, Name: "寫報告", DueAt: now.Add(3 * time.Hour), AssigneeIDs: []int{1, 2}}
+
+	mockRepo.EXPECT().AcquireLease(ctx, gomock.Any(), "reminders", gomock.Any(), now, 3*time.Minute).Return(true, nil)
+	mockRepo.EXPECT().CreateRun(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.ReminderTask{soon}, nil)
+	mockRepo.EXPECT().CreateLog(ctx, gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+	mockNotificationRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockNotificationRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).Return(errors.New("連線中斷"))
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectRollback()
+	// 交易已復原，提醒紀錄一起消失，不能算已送出
+	mockRepo.EXPECT().UpdateRun(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, run *models.TodoReminderRuns) error {
+			assert.Equal(t, models.ReminderRunFailed, run.Status)
+			assert.Equal(t, 0, run.Sent)
+			assert.Equal(t, "連線中斷", run.Error)
+			return nil
+		})
+	mockRepo.EXPECT().DeleteRuns(ctx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	ran, err := svc.RunScan(db, now)
+	assert.True(t, ran)
+	assert.EqualError(t, err, "連線中斷")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoReminderService_RunScan_LeaseHandoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupReminderConfig(nil, 0, 0, "owner")
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoReminderRepository(ctrl)
+	mockNotificationRepo := mocks.NewMockTodoNotificationRepository(ctrl)
+	notifications := services.NewTodoNotificationService(ctx, mockNotificationRepo, nil)
+	// 兩個 instance 共用同一個資料庫
+	first := services.NewTodoReminderService(ctx, mockRepo, notifications)
+	second := services.NewTodoReminderService(ctx, mockRepo, notifications)
+	db, sqlmock := setupMockDB(t)
+
+	// 與 repository 相同的租約規則：其他 instance 持有且尚未到期時取不到
+	var owner string
+	var expiresAt time.Time
+	var owners []string
+	mockRepo.EXPECT().AcquireLease(ctx, gomock.Any(), "reminders", gomock.Any(), gomock.Any(), 3*time.Minute).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, _ string, instance string, now time.Time, ttl time.Duration) (bool, error) {
+			if owner != "" && owner != instance && expiresAt.After(now) {
+				return false, nil
+			}
+			if owner != instance {
+				owners = append(owners, instance)
+			}
+			owner, expiresAt = instance, now.Add(ttl)
+			return true, nil
+		}).AnyTimes()
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	task := &models.ReminderTask{TodoListDetailID: 2, TodoListID: 2, Name: "開會", DueAt: now.Add(-time.Hour), AssigneeIDs: []int{3}}
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.ReminderTask{task}, nil).Times(2)
+	// 與唯一索引相同，同一次提醒只會寫入一筆紀錄
+	logged := map[string]bool{}
+	mockRepo.EXPECT().CreateLog(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, log *models.TodoReminderLogs) (bool, error) {
+			key := fmt.Sprintf("%s:%d:%d:%d", log.Rule, log.TodoListDetailID, log.UserID, log.Occurrence)
+			if logged[key] {
+				return false, nil
+			}
+			logged[key] = true
+			return true, nil
+		}).Times(2)
+	var sent []int
+	mockRepo.EXPECT().CreateRun(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockRepo.EXPECT().UpdateRun(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, run *models.TodoReminderRuns) error {
+			sent = append(sent, run.Sent)
+			return nil
+		}).Times(2)
+	mockRepo.EXPECT().DeleteRuns(ctx, gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(2)
+	mockNotificationRepo.EXPECT().FindOptedOut(ctx, gomock.Any(), gomock.Any(), []int{3}).Return(nil, nil)
+	mockNotificationRepo.EXPECT().CreateNotifications(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	for i := 0; i < 2; i++ {
+		sqlmock.ExpectBegin()
+		sqlmock.ExpectCommit()
+	}
+
+	ran, err := first.RunScan(db, now)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	// 租約還沒到期，另一個 instance 不執行
+	ran, err = second.RunScan(db, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	// 持有者停止，租約到期後由另一個 instance 接手，已送過的提醒不再送出
+	ran, err = second.RunScan(db, now.Add(4*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	// 原本的持有者恢復後也不能搶回租約
+	ran, err = first.RunScan(db, now.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	require.Len(t, owners, 2)
+	assert.NotEqual(t, owners[0], owners[1])
+	assert.Equal(t, []int{1, 0}, sent)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoReminderService_Upcoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setupReminderConfig([]time.Duration{time.Hour}, 0, 48*time.Hour, "owner")
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoReminderRepository(ctrl)
+	svc := services.NewTodoReminderService(ctx, mockRepo, nil)
+	db, _ := setupMockDB(t)
+
+	now := time.Now()
+	owner := 8
+	inHalfHour := &models.ReminderTask{TodoListDetailID: 1, Name: "寫報告", DueAt: now.Add(30 * time.Minute), AssigneeIDs: []int{1}}
+	inFiveHours := &models.ReminderTask{TodoListDetailID: 2, Name: "開會", DueAt: now.Add(5 * time.Hour), AssigneeIDs: []int{2}}
+	unassigned := &models.ReminderTask{TodoListDetailID: 3, Name: "整理", DueAt: now.Add(6 * time.Hour)}
+	overdue := &models.ReminderTask{TodoListDetailID: 4, Name: "繳費", DueAt: now.Add(-40 * time.Hour), OwnerID: &owner}
+
+	// before_1h、at_due、escalation
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.ReminderTask{inHalfHour, inFiveHours}, nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.ReminderTask{inHalfHour, inFiveHours, unassigned}, nil)
+	mockRepo.EXPECT().FindDueTasks(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.ReminderTask{overdue}, nil)
+
+	upcoming, err := svc.Upcoming(db, 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, upcoming, 4)
+	// 到期前一小時的提醒已經過了
+	assert.Equal(t, "at_due", upcoming[0].Rule)
+	assert.Equal(t, 1, upcoming[0].TodoListDetailID)
+	assert.Equal(t, "before_1h", upcoming[1].Rule)
+	assert.Equal(t, inFiveHours.DueAt.Add(-time.Hour), upcoming[1].FireAt)
+	assert.Equal(t, "at_due", upcoming[2].Rule)
+	assert.Equal(t, "escalation", upcoming[3].Rule)
+	assert.Equal(t, []int{8}, upcoming[3].UserIDs)
+}
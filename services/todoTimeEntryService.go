@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTimerRunning        = errors.New("已有計時中的計時器，請先停止")
+	ErrNoRunningTimer      = errors.New("沒有計時中的計時器")
+	ErrTimeEntryForbidden  = errors.New("只有紀錄本人或管理員可以修改、刪除工時")
+	ErrInvalidTimeGrouping = errors.New("group_by 必須為 user、day、detail、list 或 type")
+)
+
+// 手動輸入的時間為伺服器時區的當地時間
+const (
+	entryTimeLayout = "2006-01-02T15:04:05"
+	entryDateLayout = "2006-01-02"
+)
+
+type TodoTimeEntryService struct {
+	ctx   context.Context
+	repo  interfaces.TodoTimeEntryRepository
+	users interfaces.AuthRepository
+}
+
+func NewTodoTimeEntryService(ctx context.Context, repo interfaces.TodoTimeEntryRepository, users interfaces.AuthRepository) *TodoTimeEntryService {
+	return &TodoTimeEntryService{
+		ctx:   ctx,
+		repo:  repo,
+		users: users,
+	}
+}
+
+func parseEntryTime(field, value string) (time.Time, error) {
+	t, err := time.ParseInLocation(entryTimeLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s 格式必須為 %s", field, entryTimeLayout)
+	}
+	return t, nil
+}
+
+// checkRange 結束時間必須晚於開始時間，且不能在未來
+func checkRange(start, end time.Time) error {
+	if !end.After(start) {
+		return errors.New("ended_at 必須晚於 started_at")
+	}
+	if end.After(time.Now()) {
+		return errors.New("ended_at 不能晚於現在")
+	}
+	return nil
+}
+
+func detailExists(tx *gorm.DB, detailID int) error {
+	var count int64
+	if err := tx.Model(&models.TodoListDetails{}).Where("id = ?", detailID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("to_do_list_detail_id 不存在")
+	}
+	return nil
+}
+
+// Start 為目前使用者開始計時；已有計時中的計時器時回傳 ErrTimerRunning
+func (s *TodoTimeEntryService) Start(db *gorm.DB, detailID int, note string) (*models.TodoTimeEntries, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	data := &models.TodoTimeEntries{
+		TodoListDetailID: detailID,
+		UserID:           userID,
+		StartedAt:        time.Now(),
+		Note:             note,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := detailExists(tx, detailID); err != nil {
+			return err
+		}
+
+		running, err := s.repo.LockRunning(s.ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if running != nil {
+			return ErrTimerRunning
+		}
+
+		return s.repo.Create(s.ctx, tx, data)
+	})
+	// 同時開始兩個計時器時由唯一索引擋下
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, ErrTimerRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Stop 停止目前使用者計時中的計時器
+func (s *TodoTimeEntryService) Stop(db *gorm.DB) (*models.TodoTimeEntries, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	updated := &models.TodoTimeEntries{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.LockRunning(s.ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return ErrNoRunningTimer
+		}
+
+		item.Finish(time.Now())
+		if err := s.repo.Update(s.ctx, tx.Select("ended_at", "duration_seconds", "updated_at", "updated_by"), item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Current 取得目前使用者計時中的計時器，沒有時回傳 nil
+func (s *TodoTimeEntryService) Current(db *gorm.DB) (*models.TodoTimeEntries, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	return s.repo.FindRunning(s.ctx, db, userID)
+}
+
+// Create 手動新增目前使用者已完成的工時
+func (s *TodoTimeEntryService) Create(db *gorm.DB, detailID int, startedAt, endedAt, note string) (*models.TodoTimeEntries, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+
+	start, err := parseEntryTime("started_at", startedAt)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseEntryTime("ended_at", endedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRange(start, end); err != nil {
+		return nil, err
+	}
+
+	data := &models.TodoTimeEntries{
+		TodoListDetailID: detailID,
+		UserID:           userID,
+		StartedAt:        start,
+		Note:             note,
+	}
+	data.Finish(end)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := detailExists(tx, detailID); err != nil {
+			return err
+		}
+		return s.repo.Create(s.ctx, tx, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// IndexByDetail 由新到舊分頁列出 TodoListDetails 的工時
+func (s *TodoTimeEntryService) IndexByDetail(db *gorm.DB, detailID int, page, pageSize int) (*utils.PaginatedResult[*models.TodoTimeEntries], error) {
+	query := db.Model(&models.TodoTimeEntries{}).Where("to_do_list_detail_id = ?", detailID)
+
+	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, "started_at desc", "id desc")
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+// Edit 修改工時；計時中的紀錄不給 endedAt 時只修改開始時間與備註，給了則一併停止
+func (s *TodoTimeEntryService) Edit(db *gorm.DB, id int, startedAt, endedAt, note string) (*models.TodoTimeEntries, error) {
+	start, err := parseEntryTime("started_at", startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &models.TodoTimeEntries{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorize(tx, item); err != nil {
+			return err
+		}
+
+		item.StartedAt = start
+		item.Note = note
+		switch {
+		case endedAt != "":
+			end, err := parseEntryTime("ended_at", endedAt)
+			if err != nil {
+				return err
+			}
+			if err := checkRange(start, end); err != nil {
+				return err
+			}
+			item.Finish(end)
+		case item.Running():
+			if start.After(time.Now()) {
+				return errors.New("started_at 不能晚於現在")
+			}
+		default:
+			return errors.New("已結束的工時必須提供 ended_at")
+		}
+
+		if err := s.repo.Update(s.ctx, tx.Select("started_at", "ended_at", "duration_seconds", "note", "updated_at", "updated_by"), item); err != nil {
+			return err
+		}
+
+		*updated = *item
+		return nil
+	})
+
+	return updated, err
+}
+
+// Delete 軟刪除工時，計時中的計時器也會一併停止
+func (s *TodoTimeEntryService) Delete(db *gorm.DB, id int) (*models.TodoTimeEntries, error) {
+	var deleted *models.TodoTimeEntries
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := s.repo.FindByID(s.ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorize(tx, item); err != nil {
+			return err
+		}
+
+		if err := s.repo.SoftDelete(s.ctx, tx, item); err != nil {
+			return err
+		}
+
+		deleted = item
+		return nil
+	})
+
+	return deleted, err
+}
+
+// Summary 依 groupBy 彙總已結束的工時；from、to 為日期（含當天），計時中的計時器不計入
+func (s *TodoTimeEntryService) Summary(db *gorm.DB, groupBy string, userID, listID, typeID int, from, to string) ([]*models.TimeSummary, error) {
+	switch groupBy {
+	case models.TimeGroupUser, models.TimeGroupDay, models.TimeGroupDetail, models.TimeGroupList, models.TimeGroupType:
+	default:
+		return nil, ErrInvalidTimeGrouping
+	}
+
+	start, end, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.TimeEntryFilter{UserID: userID, ListID: listID, TypeID: typeID, From: start, To: end}
+	return s.repo.Summarize(s.ctx, db, groupBy, filter)
+}
+
+// authorize 只有紀錄本人或 Admin 可以修改、刪除
+func (s *TodoTimeEntryService) authorize(tx *gorm.DB, item *models.TodoTimeEntries) error {
+	owner := uint(item.UserID)
+	return authorizeOwner(s.ctx, tx, s.users, &owner, ErrTimeEntryForbidden)
+}
@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTodoTimeEntryService_Start_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoTimeEntryRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoTimeEntryService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl))
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	expectDetailExists(mock, 3)
+	mockRepo.EXPECT().LockRunning(ctx, gomock.Any(), 1).Return(&models.TodoTimeEntries{ID: 8, UserID: 1}, nil)
+	mock.ExpectRollback()
+
+	_, err := svc.Start(db, 3, "")
+
+	assert.ErrorIs(t, err, services.ErrTimerRunning)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoTimeEntryService_Start_ConcurrentStartHitsUniqueIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoTimeEntryRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoTimeEntryService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl))
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	expectDetailExists(mock, 3)
+	mockRepo.EXPECT().LockRunning(ctx, gomock.Any(), 1).Return(nil, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	_, err := svc.Start(db, 3, "")
+
+	assert.ErrorIs(t, err, services.ErrTimerRunning)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoTimeEntryService_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoTimeEntryRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoTimeEntryService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl))
+	db, mock := setupMockDB(t)
+
+	started := time.Now().Add(-90 * time.Minute)
+	mock.ExpectBegin()
+	mockRepo.EXPECT().LockRunning(ctx, gomock.Any(), 1).Return(&models.TodoTimeEntries{ID: 8, UserID: 1, StartedAt: started}, nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mock.ExpectCommit()
+
+	result, err := svc.Stop(db)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.EndedAt)
+	assert.InDelta(t, 90*60, *result.DurationSeconds, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoTimeEntryService_Create_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoTimeEntryService(ctx, mocks.NewMockTodoTimeEntryRepository(ctrl), mocks.NewMockAuthRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	_, err := svc.Create(db, 3, "2026-01-05T10:00:00", "2026-01-05T09:00:00", "")
+	assert.EqualError(t, err, "ended_at 必須晚於 started_at")
+
+	_, err = svc.Create(db, 3, "2026/01/05 09:00", "2026-01-05T10:00:00", "")
+	assert.EqualError(t, err, "started_at 格式必須為 2006-01-02T15:04:05")
+}
+
+func TestTodoTimeEntryService_Edit_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoTimeEntryRepository(ctrl)
+	mockUsers := mocks.NewMockAuthRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(2))
+	svc := services.NewTodoTimeEntryService(ctx, mockRepo, mockUsers)
+	db, mock := setupMockDB(t)
+
+	mock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 8).Return(&models.TodoTimeEntries{ID: 8, UserID: 1}, nil)
+	mockUsers.EXPECT().HasRole(ctx, gomock.Any(), 2, "Admin").Return(false, nil)
+	mock.ExpectRollback()
+
+	_, err := svc.Edit(db, 8, "2026-01-05T09:00:00", "2026-01-05T10:00:00", "")
+
+	assert.ErrorIs(t, err, services.ErrTimeEntryForbidden)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoTimeEntryService_Summary_DateRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoTimeEntryRepository(ctrl)
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(1))
+	svc := services.NewTodoTimeEntryService(ctx, mockRepo, mocks.NewMockAuthRepository(ctrl))
+	db, _ := setupMockDB(t)
+
+	// to 含當天，查詢條件為隔天 0 點之前
+	mockRepo.EXPECT().Summarize(ctx, gomock.Any(), models.TimeGroupList, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, _ string, filter models.TimeEntryFilter) ([]*models.TimeSummary, error) {
+			assert.Equal(t, 2, filter.ListID)
+			assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), *filter.From)
+			assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local), *filter.To)
+			return []*models.TimeSummary{{Key: "2", Seconds: 3600}}, nil
+		})
+
+	rows, err := svc.Summary(db, models.TimeGroupList, 0, 2, 0, "2026-01-01", "2026-01-31")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+
+	_, err = svc.Summary(db, "project", 0, 0, 0, "", "")
+	assert.ErrorIs(t, err, services.ErrInvalidTimeGrouping)
+
+	_, err = svc.Summary(db, models.TimeGroupDay, 0, 0, 0, "2026-02-01", "2026-01-01")
+	assert.EqualError(t, err, "to 不能早於 from")
+}
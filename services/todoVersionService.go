@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"todolist/models"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrVersionNotFound = errors.New("版本不存在")
+
+// TodoVersionService 查詢與比較 TodoList / TodoListDetails 的版本；版本由 BaseRepository.Update 產生
+type TodoVersionService struct {
+	ctx  context.Context
+	repo interfaces.TodoVersionRepository
+}
+
+func NewTodoVersionService(ctx context.Context, repo interfaces.TodoVersionRepository) *TodoVersionService {
+	return &TodoVersionService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// Index 由新到舊列出版本
+func (s *TodoVersionService) Index(db *gorm.DB, entityType string, entityID int, page, pageSize int) (*utils.PaginatedResult[*models.TodoVersions], error) {
+	query := db.Model(&models.TodoVersions{}).Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+
+	list, total, err := s.repo.FindAllWithQuery(s.ctx, query, page, pageSize, "version desc")
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewPaginatedResult(list, total, page, pageSize), nil
+}
+
+// Find 取得指定版本
+func (s *TodoVersionService) Find(db *gorm.DB, entityType string, entityID int, version int) (*models.TodoVersions, error) {
+	item, err := s.repo.FindVersion(s.ctx, db, entityType, entityID, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Diff 逐欄比較兩個版本，from 與 to 不限先後
+func (s *TodoVersionService) Diff(db *gorm.DB, entityType string, entityID int, from, to int) ([]models.VersionChange, error) {
+	before, err := s.Find(db, entityType, entityID, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.Find(db, entityType, entityID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DiffVersions(before, after)
+}
+
+// Restore 將指定版本的快照填入 target，快照的 key 與模型的 json 標籤相同
+func (s *TodoVersionService) Restore(db *gorm.DB, entityType string, entityID int, version int, target interface{}) error {
+	item, err := s.Find(db, entityType, entityID, version)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(item.Snapshot, target)
+}
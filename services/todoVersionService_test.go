@@ -0,0 +1,142 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func snapshotVersion(n int, snapshot string) *models.TodoVersions {
+	return &models.TodoVersions{Version: n, Snapshot: json.RawMessage(snapshot)}
+}
+
+func TestTodoVersionService_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoVersionRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoVersionService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityDetail, 7, 1).
+		Return(snapshotVersion(1, `{"name":"寫文件","detail":"- [ ] 初稿","status":"todo"}`), nil)
+	mockRepo.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityDetail, 7, 3).
+		Return(snapshotVersion(3, `{"name":"寫文件","detail":"- [x] 初稿","status":"done"}`), nil)
+
+	changes, err := svc.Diff(db, models.VersionEntityDetail, 7, 1, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.VersionChange{
+		{Field: "detail", From: "- [ ] 初稿", To: "- [x] 初稿"},
+		{Field: "status", From: "todo", To: "done"},
+	}, changes)
+}
+
+func TestTodoVersionService_Diff_VersionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoVersionRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoVersionService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityList, 1, 9).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := svc.Diff(db, models.VersionEntityList, 1, 9, 1)
+
+	assert.ErrorIs(t, err, services.ErrVersionNotFound)
+}
+
+func TestTodoListService_Revert_RerunsEditValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	mockVersions := mocks.NewMockTodoVersionRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListService(ctx, mockRepo).WithVersions(services.NewTodoVersionService(ctx, mockVersions))
+	db, sqlmock := setupMockDB(t)
+
+	// 版本中的類型已經被刪除，還原應與 Edit 一樣失敗，不會更新
+	mockVersions.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityList, 1, 2).
+		Return(snapshotVersion(2, `{"name":"舊名稱","type_id":5}`), nil)
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_types"`).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Revert(db, 1, 2)
+
+	assert.EqualError(t, err, "type_id 不存在")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListService_Revert_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListRepository(ctrl)
+	mockVersions := mocks.NewMockTodoVersionRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListService(ctx, mockRepo).WithVersions(services.NewTodoVersionService(ctx, mockVersions))
+	db, sqlmock := setupMockDB(t)
+
+	mockVersions.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityList, 1, 2).
+		Return(snapshotVersion(2, `{"name":"舊名稱","type_id":1}`), nil)
+	sqlmock.ExpectBegin()
+	sqlmock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_types"`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().IsNameExist(gomock.Any(), gomock.Any(), "舊名稱", 1).Return(false, nil)
+	mockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), 1).Return(&models.TodoList{ID: 1, Name: "新名稱", TypeID: 2}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(updateCtx context.Context, _ *gorm.DB, item *models.TodoList) error {
+			// 更新時帶著還原來源，產生的新版本才會記錄 reverted_from
+			assert.NotEqual(t, ctx, updateCtx)
+			assert.Equal(t, "舊名稱", item.Name)
+			assert.Equal(t, 1, item.TypeID)
+			return nil
+		})
+	sqlmock.ExpectCommit()
+
+	result, err := svc.Revert(db, 1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "舊名稱", result.Name)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoListDetailsService_Revert_ChecksBlockers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTodoListDetailsRepository(ctrl)
+	mockVersions := mocks.NewMockTodoVersionRepository(ctrl)
+	ctx := context.Background()
+	svc := services.NewTodoListDetailsService(ctx, mockRepo).WithVersions(services.NewTodoVersionService(ctx, mockVersions))
+	db, sqlmock := setupMockDB(t)
+
+	// 還原到已完成的版本，但目前有未完成的前置任務
+	mockVersions.EXPECT().FindVersion(ctx, gomock.Any(), models.VersionEntityDetail, 2, 4).
+		Return(snapshotVersion(4, `{"name":"實作","detail":"","status":"done"}`), nil)
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).
+		Return(&models.TodoListDetails{ID: 2, Name: "實作", Status: models.DetailStatusInProgress}, nil)
+	mockRepo.EXPECT().FindOpenBlockers(ctx, gomock.Any(), 2).Return([]*models.TodoListDetails{{ID: 1, Name: "設計"}}, nil)
+	sqlmock.ExpectRollback()
+
+	_, err := svc.Revert(db, 2, 4)
+
+	assert.EqualError(t, err, "仍有未完成的前置任務：#1 設計")
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+// TodoWatcherService 關注 TodoList 與項目：關注者不一定是負責人，但會收到更新。
+// 關注項目所屬 TodoList 的使用者也視為項目的關注者
+type TodoWatcherService struct {
+	ctx  context.Context
+	repo interfaces.TodoWatcherRepository
+}
+
+func NewTodoWatcherService(ctx context.Context, repo interfaces.TodoWatcherRepository) *TodoWatcherService {
+	return &TodoWatcherService{
+		ctx:  ctx,
+		repo: repo,
+	}
+}
+
+// exists TodoList 或項目不存在時回傳 gorm.ErrRecordNotFound
+func (s *TodoWatcherService) exists(db *gorm.DB, model interface{}, id int) error {
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// WatchList 目前的使用者關注 TodoList，回傳關注者
+func (s *TodoWatcherService) WatchList(db *gorm.DB, listID int) ([]models.User, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	if err := s.exists(db, &models.TodoList{}, listID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddListWatchers(s.ctx, db, listID, []int{userID}); err != nil {
+		return nil, err
+	}
+	return s.repo.FindListWatchers(s.ctx, db, listID)
+}
+
+// UnwatchList 目前的使用者取消關注 TodoList，回傳關注者；底下個別關注的項目不受影響
+func (s *TodoWatcherService) UnwatchList(db *gorm.DB, listID int) ([]models.User, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	if err := s.exists(db, &models.TodoList{}, listID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RemoveListWatcher(s.ctx, db, listID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindListWatchers(s.ctx, db, listID)
+}
+
+// ListWatchers TodoList 的關注者
+func (s *TodoWatcherService) ListWatchers(db *gorm.DB, listID int) ([]models.User, error) {
+	if err := s.exists(db, &models.TodoList{}, listID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindListWatchers(s.ctx, db, listID)
+}
+
+// WatchDetail 目前的使用者關注項目，回傳關注者
+func (s *TodoWatcherService) WatchDetail(db *gorm.DB, detailID int) ([]models.User, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	if err := s.exists(db, &models.TodoListDetails{}, detailID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddDetailWatchers(s.ctx, db, detailID, []int{userID}); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDetailWatchers(s.ctx, db, detailID)
+}
+
+// UnwatchDetail 目前的使用者取消關注項目，回傳關注者；之後再被指派或留言時會重新關注
+func (s *TodoWatcherService) UnwatchDetail(db *gorm.DB, detailID int) ([]models.User, error) {
+	userID, ok := utils.CurrentUserID(s.ctx)
+	if !ok {
+		return nil, ErrNotLoggedIn
+	}
+	if err := s.exists(db, &models.TodoListDetails{}, detailID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RemoveDetailWatcher(s.ctx, db, detailID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDetailWatchers(s.ctx, db, detailID)
+}
+
+// DetailWatchers 項目的關注者，不含只關注所屬 TodoList 的使用者
+func (s *TodoWatcherService) DetailWatchers(db *gorm.DB, detailID int) ([]models.User, error) {
+	if err := s.exists(db, &models.TodoListDetails{}, detailID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDetailWatchers(s.ctx, db, detailID)
+}
+
+// watchedEvent 從事件內容取出判斷關注者需要的欄位：項目事件的 id 或 detail.id、留言事件的 to_do_list_detail_id。
+// 項目本身的 detail 是描述文字，只有指派事件的 detail 是項目，因此先保留原始內容
+type watchedEvent struct {
+	ID               int             `json:"id"`
+	TodoListDetailID int             `json:"to_do_list_detail_id"`
+	Detail           json.RawMessage `json:"detail"`
+}
+
+// eventDetailID 事件所屬的項目，與項目無關的事件為 0
+func eventDetailID(e events.Event, data *watchedEvent) int {
+	switch {
+	case e.Type == models.EventDetailAssigned:
+		var detail struct {
+			ID int `json:"id"`
+		}
+		if json.Unmarshal(data.Detail, &detail) != nil {
+			return 0
+		}
+		return detail.ID
+	case strings.HasPrefix(e.Type, "detail."):
+		return data.ID
+	case strings.HasPrefix(e.Type, "comment."):
+		return data.TodoListDetailID
+	default:
+		return 0
+	}
+}
+
+// Recipients 關注事件所屬項目或 TodoList 的使用者，與 TodoList 無關的事件沒有關注者
+func (s *TodoWatcherService) Recipients(db *gorm.DB, e events.Event) ([]int, error) {
+	if e.TodoListID == nil {
+		return nil, nil
+	}
+	var data watchedEvent
+	if err := e.Decode(&data); err != nil {
+		return nil, err
+	}
+	return s.repo.FindWatcherIDs(s.ctx, db, *e.TodoListID, eventDetailID(e, &data))
+}
+
+// autoWatchers 要自動關注的使用者，略過 0 與重複的；自動化規則指派的負責人與以規則建立者身分新增的留言同樣自動關注
+func (s *TodoWatcherService) autoWatchers(userIDs []int) []int {
+	seen := make(map[int]bool, len(userIDs))
+	ids := make([]int, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AutoWatchList 讓 TodoList 的建立者自動關注，在建立 TodoList 的交易內呼叫，與資料一起 commit 或復原
+func (s *TodoWatcherService) AutoWatchList(tx *gorm.DB, listID int, userIDs []int) error {
+	ids := s.autoWatchers(userIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.repo.AddListWatchers(s.ctx, tx, listID, ids)
+}
+
+// AutoWatchDetail 讓項目的建立者、負責人與留言者自動關注，在寫入的交易內呼叫；
+// 已經關注的略過，之後的通知不必等事件送出就能找到他們
+func (s *TodoWatcherService) AutoWatchDetail(tx *gorm.DB, detailID int, userIDs []int) error {
+	ids := s.autoWatchers(userIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.repo.AddDetailWatchers(s.ctx, tx, detailID, ids)
+}
@@ -0,0 +1,116 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/services"
+	"todolist/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTodoWatcherService_WatchDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), utils.UserIDKey, float64(3))
+	mockRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	svc := services.NewTodoWatcherService(ctx, mockRepo)
+	db, mock := setupMockDB(t)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	_, err := svc.WatchDetail(db, 9)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	watchers := []models.User{{ID: 1, Account: "alice"}, {ID: 3, Account: "carol"}}
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list_details"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().AddDetailWatchers(ctx, gomock.Any(), 5, []int{3}).Return(nil)
+	mockRepo.EXPECT().FindDetailWatchers(ctx, gomock.Any(), 5).Return(watchers, nil)
+	result, err := svc.WatchDetail(db, 5)
+	require.NoError(t, err)
+	assert.Equal(t, watchers, result)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "to_do_list"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockRepo.EXPECT().RemoveListWatcher(ctx, gomock.Any(), 2, 3).Return(nil)
+	mockRepo.EXPECT().FindListWatchers(ctx, gomock.Any(), 2).Return([]models.User{}, nil)
+	result, err = svc.UnwatchList(db, 2)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	// 未登入
+	_, err = services.NewTodoWatcherService(context.Background(), mockRepo).WatchList(db, 2)
+	assert.ErrorIs(t, err, services.ErrNotLoggedIn)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoWatcherService_AutoWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	svc := services.NewTodoWatcherService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	mockRepo.EXPECT().AddListWatchers(ctx, gomock.Any(), 2, []int{4}).Return(nil)
+	require.NoError(t, svc.AutoWatchList(db, 2, []int{4}))
+
+	// 建立者同時是負責人時只寫一次，未登入的 0 略過
+	mockRepo.EXPECT().AddDetailWatchers(ctx, gomock.Any(), 5, []int{4, 6, 7}).Return(nil)
+	require.NoError(t, svc.AutoWatchDetail(db, 5, []int{4, 6, 4, 0, 7}))
+
+	// 沒有人可加入時不寫入
+	require.NoError(t, svc.AutoWatchDetail(db, 5, []int{0}))
+
+	// 自動化規則引起的寫入也自動關注
+	mockRepo.EXPECT().AddDetailWatchers(gomock.Any(), gomock.Any(), 5, []int{4}).Return(nil)
+	automated := services.NewTodoWatcherService(utils.WithAutomationDepth(ctx, 1), mockRepo)
+	require.NoError(t, automated.AutoWatchDetail(db, 5, []int{4}))
+}
+
+func TestTodoWatcherService_Recipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWatcherRepository(ctrl)
+	svc := services.NewTodoWatcherService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	listID := 2
+	detail := &models.TodoListDetails{ID: 5, TodoListID: 2}
+	comment := &models.TodoComments{ID: 9, TodoListDetailID: 5}
+	withList := func(id, eventType string, data interface{}) events.Event {
+		e := notificationEvent(t, id, eventType, 1, data)
+		e.TodoListID = &listID
+		return e
+	}
+
+	mockRepo.EXPECT().FindWatcherIDs(ctx, gomock.Any(), 2, 5).Return([]int{1, 3}, nil).Times(3)
+	for _, e := range []events.Event{
+		withList("e1", models.EventDetailStatusChanged, detail),
+		withList("e2", models.EventDetailAssigned, models.DetailAssignment{Detail: detail, UserIDs: []int{3}}),
+		withList("e3", models.EventCommentCreated, comment),
+	} {
+		ids, err := svc.Recipients(db, e)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, ids)
+	}
+
+	mockRepo.EXPECT().FindWatcherIDs(ctx, gomock.Any(), 2, 0).Return([]int{1}, nil)
+	ids, err := svc.Recipients(db, withList("e4", models.EventListUpdated, &models.TodoList{ID: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, ids)
+
+	// 與 TodoList 無關的事件沒有關注者
+	ids, err = svc.Recipients(db, notificationEvent(t, "e5", models.EventMemberRoleChanged, 1, models.MemberRoleChange{UserID: 3}))
+	require.NoError(t, err)
+	assert.Nil(t, ids)
+}
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/pkg/webhook"
+	"todolist/repositories/interfaces"
+	"todolist/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidWebhookURL = errors.New("url 必須為 http 或 https 的完整網址")
+	ErrWebhookDisabled   = errors.New("webhook 已刪除或停用")
+)
+
+const (
+	// webhookMaxAttempts 失敗超過這個次數後不再自動重試
+	webhookMaxAttempts = 8
+	// webhookRetryBase、webhookRetryMax 重試間隔從 30 秒開始加倍，最長 6 小時
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+	// webhookDeliveryTimeout 送出中超過這個時間仍未結束的紀錄視為中斷，會被重新送出
+	webhookDeliveryTimeout = 5 * time.Minute
+	webhookSecretBytes     = 32
+	// webhookResponseLimit 紀錄中保留的回應內容長度
+	webhookResponseLimit = 1000
+)
+
+// webhookClient 不跟隨轉址，3xx 視為失敗
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+type TodoWebhookService struct {
+	ctx    context.Context
+	repo   interfaces.TodoWebhookRepository
+	client *http.Client
+}
+
+func NewTodoWebhookService(ctx context.Context, repo interfaces.TodoWebhookRepository) *TodoWebhookService {
+	return &TodoWebhookService{
+		ctx:    ctx,
+		repo:   repo,
+		client: webhookClient,
+	}
+}
+
+// WithClient 指定送出用的 HTTP client
+func (s *TodoWebhookService) WithClient(client *http.Client) *TodoWebhookService {
+	s.client = client
+	return s
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func checkWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// Create 建立 webhook，listID 為 0 時接收所有事件；簽章密鑰只在這裡回傳一次
+func (s *TodoWebhookService) Create(db *gorm.DB, listID int, rawURL string, events []string, description string) (*models.TodoWebhooks, error) {
+	if err := checkWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	hook := &models.TodoWebhooks{URL: rawURL, Events: events, Description: description, Active: true}
+	if listID > 0 {
+		var count int64
+		if err := db.Model(&models.TodoList{}).Where("id = ?", listID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errors.New("to_do_list_id 不存在")
+		}
+		hook.TodoListID = &listID
+	}
+
+	secret, err := randomHex(webhookSecretBytes)
+	if err != nil {
+		return nil, err
+	}
+	hook.Secret = "whsec_" + secret
+
+	if err := s.repo.Create(s.ctx, db, hook); err != nil {
+		return nil, err
+	}
+	hook.SigningSecret = hook.Secret
+	return hook, nil
+}
+
+// Index 取出 webhook，listID 為 0 時取出全部
+func (s *TodoWebhookService) Index(db *gorm.DB, listID int) ([]*models.TodoWebhooks, error) {
+	return s.repo.FindWebhooks(s.ctx, db, listID)
+}
+
+// Edit 修改網址、事件、說明與是否啟用；停用期間的事件不會補送
+func (s *TodoWebhookService) Edit(db *gorm.DB, id int, rawURL string, events []string, description string, active bool) (*models.TodoWebhooks, error) {
+	if err := checkWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	hook, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	hook.URL = rawURL
+	hook.Events = events
+	hook.Description = description
+	hook.Active = active
+	// active 可能改為 false，需用 Select 指定欄位強制更新
+	if err := s.repo.Update(s.ctx, db.Select("url", "events", "description", "active", "updated_at", "updated_by"), hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// Delete 刪除後尚未送出的紀錄不再送出
+func (s *TodoWebhookService) Delete(db *gorm.DB, id int) (*models.TodoWebhooks, error) {
+	hook, err := s.repo.FindByID(s.ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SoftDelete(s.ctx, db, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// Deliveries 分頁取出 webhook 的送出紀錄，新的在前
+func (s *TodoWebhookService) Deliveries(db *gorm.DB, webhookID int, page, pageSize int) (*utils.PaginatedResult[*models.TodoWebhookDeliveries], error) {
+	if _, err := s.repo.FindByID(s.ctx, db, webhookID); err != nil {
+		return nil, err
+	}
+	deliveries, total, err := s.repo.FindDeliveries(s.ctx, db, webhookID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewPaginatedResult(deliveries, total, page, pageSize), nil
+}
+
+// Redeliver 以相同內容與事件 ID 建立新的送出紀錄，原本的紀錄保留；接收端可以用事件 ID 排除重複
+func (s *TodoWebhookService) Redeliver(db *gorm.DB, deliveryID int) (*models.TodoWebhookDeliveries, error) {
+	original, err := s.repo.FindDelivery(s.ctx, db, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.FindByID(s.ctx, db, original.WebhookID); err != nil {
+		return nil, err
+	}
+
+	delivery := &models.TodoWebhookDeliveries{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+		RedeliveryOf:  &original.ID,
+	}
+	if err := s.repo.CreateDeliveries(s.ctx, db, []*models.TodoWebhookDeliveries{delivery}); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Deliver 不論 webhook 訂閱了哪些事件，直接為它建立一筆送出紀錄，事件 ID 每次都不同；webhook 已刪除或停用時回傳 ErrWebhookDisabled
+func (s *TodoWebhookService) Deliver(db *gorm.DB, webhookID int, event string, listID int, data interface{}) (*models.TodoWebhookDeliveries, error) {
+	hook, err := s.repo.FindByID(s.ctx, db, webhookID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookDisabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Active {
+		return nil, ErrWebhookDisabled
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	eventID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	payload := models.WebhookPayload{ID: eventID, Event: event, OccurredAt: time.Now(), Data: raw}
+	if userID, ok := utils.CurrentUserID(s.ctx); ok {
+		payload.ActorID = &userID
+	}
+	if listID > 0 {
+		payload.TodoListID = &listID
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.TodoWebhookDeliveries{
+		WebhookID:     hook.ID,
+		EventID:       eventID,
+		Event:         event,
+		Payload:       string(body),
+		Status:        models.DeliveryStatusPending,
+		NextAttemptAt: payload.OccurredAt,
+	}
+	if err := s.repo.CreateDeliveries(s.ctx, db, []*models.TodoWebhookDeliveries{delivery}); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// HandleEvent 由 outbox relay 在事件 commit 後呼叫，為訂閱事件的 webhook 建立送出紀錄；
+// relay 重送同一個事件時，已經建立過紀錄的 webhook 不會再建立
+func (s *TodoWebhookService) HandleEvent(db *gorm.DB, e events.Event) error {
+	listID := 0
+	if e.TodoListID != nil {
+		listID = *e.TodoListID
+	}
+	hooks, err := s.repo.FindActive(s.ctx, db, listID)
+	if err != nil {
+		return err
+	}
+	var targets []*models.TodoWebhooks
+	for _, hook := range hooks {
+		if hook.Subscribes(e.Type) {
+			targets = append(targets, hook)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	created, err := s.repo.FindEventWebhookIDs(s.ctx, db, e.ID)
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(created))
+	for _, id := range created {
+		done[id] = true
+	}
+
+	body, err := json.Marshal(models.WebhookPayload{
+		ID:         e.ID,
+		Event:      e.Type,
+		OccurredAt: e.OccurredAt,
+		ActorID:    e.ActorID,
+		TodoListID: e.TodoListID,
+		WatcherIDs: e.WatcherIDs,
+		Data:       e.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var deliveries []*models.TodoWebhookDeliveries
+	for _, hook := range targets {
+		if done[hook.ID] {
+			continue
+		}
+		deliveries = append(deliveries, &models.TodoWebhookDeliveries{
+			WebhookID:     hook.ID,
+			EventID:       e.ID,
+			Event:         e.Type,
+			Payload:       string(body),
+			Status:        models.DeliveryStatusPending,
+			NextAttemptAt: now,
+		})
+	}
+	return s.repo.CreateDeliveries(s.ctx, db, deliveries)
+}
+
+// RunNext 送出下一筆到期的紀錄，沒有時回傳 false。
+// 先在短交易中標記為送出中再送出，送出期間不持有鎖；失敗時依次數延後重試，次數用完後標記為 dead
+func (s *TodoWebhookService) RunNext(db *gorm.DB) (bool, error) {
+	delivery := &models.TodoWebhookDeliveries{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		next, err := s.repo.LockNextDelivery(s.ctx, tx, now, now.Add(-webhookDeliveryTimeout))
+		if err != nil || next == nil {
+			delivery = nil
+			return err
+		}
+
+		next.Status = models.DeliveryStatusDelivering
+		next.Attempts++
+		next.LastAttemptAt = &now
+		if err := s.repo.UpdateDelivery(s.ctx, tx, next); err != nil {
+			return err
+		}
+
+		*delivery = *next
+		return nil
+	})
+	if err != nil || delivery == nil {
+		return false, err
+	}
+
+	hook, err := s.repo.FindByID(s.ctx, db, delivery.WebhookID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		s.fail(delivery, 0, "", ErrWebhookDisabled, true)
+	case err != nil:
+		// 維持送出中，逾時後重新送出
+		return true, err
+	case !hook.Active:
+		s.fail(delivery, 0, "", ErrWebhookDisabled, true)
+	default:
+		status, body, sendErr := s.send(hook, delivery)
+		if sendErr == nil {
+			now := time.Now()
+			delivery.Status = models.DeliveryStatusSucceeded
+			delivery.ResponseStatus = status
+			delivery.ResponseBody = body
+			delivery.Error = ""
+			delivery.DeliveredAt = &now
+		} else {
+			s.fail(delivery, status, body, sendErr, false)
+		}
+	}
+
+	return true, s.repo.UpdateDelivery(s.ctx, db, delivery)
+}
+
+// fail 記錄失敗；dead 為 true 或次數用完時不再重試
+func (s *TodoWebhookService) fail(delivery *models.TodoWebhookDeliveries, status int, body string, err error, dead bool) {
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	delivery.Error = truncate(err.Error(), 1000)
+	if dead || delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.DeliveryStatusDead
+		return
+	}
+	delivery.Status = models.DeliveryStatusPending
+	delivery.NextAttemptAt = time.Now().Add(webhook.Backoff(delivery.Attempts, webhookRetryBase, webhookRetryMax))
+}
+
+// send 以 POST 送出，2xx 視為成功
+func (s *TodoWebhookService) send(hook *models.TodoWebhooks, delivery *models.TodoWebhookDeliveries) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todolist-webhook/1.0")
+	req.Header.Set("X-Todolist-Event", delivery.Event)
+	req.Header.Set("X-Todolist-Event-ID", delivery.EventID)
+	req.Header.Set("X-Todolist-Delivery", fmt.Sprint(delivery.ID))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, time.Now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit*4))
+	responseBody := truncate(string(data), webhookResponseLimit)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, responseBody, fmt.Errorf("接收端回應 %d", resp.StatusCode)
+	}
+	return resp.StatusCode, responseBody, nil
+}
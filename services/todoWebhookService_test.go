@@ -0,0 +1,356 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todolist/mocks"
+	"todolist/models"
+	"todolist/pkg/events"
+	"todolist/pkg/webhook"
+	"todolist/services"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTodoWebhookService_HandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	hooks := []*models.TodoWebhooks{
+		{ID: 1, Events: []string{models.EventDetailUpdated}},
+		{ID: 2, Events: []string{models.EventListCreated}},
+		{ID: 3, Events: []string{models.EventListCreated, models.EventDetailUpdated}},
+		{ID: 4, Events: []string{models.EventDetailUpdated}},
+	}
+	actorID, listID := 3, 2
+	event := events.Event{
+		ID:         "e1",
+		Type:       models.EventDetailUpdated,
+		OccurredAt: time.Now(),
+		ActorID:    &actorID,
+		TodoListID: &listID,
+		Data:       []byte(`{"id":5,"name":"寫報告"}`),
+	}
+
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), 2).Return(hooks, nil)
+	// relay 重送時，webhook 4 已經建立過紀錄
+	mockRepo.EXPECT().FindEventWebhookIDs(ctx, gomock.Any(), "e1").Return([]int{4}, nil)
+	mockRepo.EXPECT().CreateDeliveries(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, deliveries []*models.TodoWebhookDeliveries) error {
+			// 只送給訂閱的 webhook，同一個事件共用事件 ID
+			require.Len(t, deliveries, 2)
+			assert.Equal(t, 1, deliveries[0].WebhookID)
+			assert.Equal(t, 3, deliveries[1].WebhookID)
+			assert.Equal(t, "e1", deliveries[1].EventID)
+			assert.Equal(t, models.DeliveryStatusPending, deliveries[0].Status)
+
+			var payload struct {
+				ID         string                 `json:"id"`
+				Event      string                 `json:"event"`
+				ActorID    int                    `json:"actor_id"`
+				TodoListID int                    `json:"to_do_list_id"`
+				Data       models.TodoListDetails `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+			assert.Equal(t, "e1", payload.ID)
+			assert.Equal(t, models.EventDetailUpdated, payload.Event)
+			assert.Equal(t, 3, payload.ActorID)
+			assert.Equal(t, 2, payload.TodoListID)
+			assert.Equal(t, "寫報告", payload.Data.Name)
+			return nil
+		})
+
+	require.NoError(t, svc.HandleEvent(db, event))
+
+	// 沒有訂閱時不建立紀錄
+	mockRepo.EXPECT().FindActive(ctx, gomock.Any(), 0).Return(hooks, nil)
+	assert.NoError(t, svc.HandleEvent(db, events.Event{ID: "e2", Type: models.EventMemberRoleChanged}))
+}
+
+func TestTodoWebhookService_RunNext_Signed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = r.Header
+		if err := webhook.Verify("whsec_test", r.Header.Get(webhook.SignatureHeader), body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo).WithClient(server.Client())
+	db, sqlmock := setupMockDB(t)
+
+	delivery := &models.TodoWebhookDeliveries{ID: 9, WebhookID: 1, EventID: "e1", Event: models.EventListCreated, Payload: `{"id":"e1"}`, Status: models.DeliveryStatusPending}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusDelivering, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			return nil
+		})
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.TodoWebhooks{ID: 1, URL: server.URL, Secret: "whsec_test", Active: true}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusSucceeded, d.Status)
+			assert.Equal(t, http.StatusOK, d.ResponseStatus)
+			assert.Equal(t, "ok", d.ResponseBody)
+			assert.NotNil(t, d.DeliveredAt)
+			return nil
+		})
+
+	processed, err := svc.RunNext(db)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, "list.created", received.Get("X-Todolist-Event"))
+	assert.Equal(t, "e1", received.Get("X-Todolist-Event-ID"))
+	assert.Equal(t, "9", received.Get("X-Todolist-Delivery"))
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+
+	// 沒有到期的紀錄
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	sqlmock.ExpectCommit()
+	processed, err = svc.RunNext(db)
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
+
+func TestTodoWebhookService_RunNext_RetryThenDead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo).WithClient(server.Client())
+	db, sqlmock := setupMockDB(t)
+	hook := &models.TodoWebhooks{ID: 1, URL: server.URL, Secret: "whsec_test", Active: true}
+
+	run := func(attempts int, check func(d *models.TodoWebhookDeliveries)) {
+		delivery := &models.TodoWebhookDeliveries{ID: 9, WebhookID: 1, Payload: "{}", Attempts: attempts}
+		sqlmock.ExpectBegin()
+		mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+		mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).Return(nil)
+		sqlmock.ExpectCommit()
+		mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(hook, nil)
+		mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+				check(d)
+				return nil
+			})
+
+		processed, err := svc.RunNext(db)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+
+	// 第二次失敗後延後 60 秒重試
+	run(1, func(d *models.TodoWebhookDeliveries) {
+		assert.Equal(t, models.DeliveryStatusPending, d.Status)
+		assert.Equal(t, http.StatusBadGateway, d.ResponseStatus)
+		assert.Contains(t, d.Error, "502")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), d.NextAttemptAt, 5*time.Second)
+	})
+
+	// 次數用完後不再重試
+	run(7, func(d *models.TodoWebhookDeliveries) {
+		assert.Equal(t, 8, d.Attempts)
+		assert.Equal(t, models.DeliveryStatusDead, d.Status)
+	})
+
+	// webhook 已刪除時直接標記為 dead
+	delivery := &models.TodoWebhookDeliveries{ID: 10, WebhookID: 2, Payload: "{}"}
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).Return(nil)
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 2).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusDead, d.Status)
+			assert.Equal(t, services.ErrWebhookDisabled.Error(), d.Error)
+			return nil
+		})
+	_, err := svc.RunNext(db)
+	require.NoError(t, err)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoWebhookService_Redeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo)
+	db, _ := setupMockDB(t)
+
+	original := &models.TodoWebhookDeliveries{ID: 9, WebhookID: 1, EventID: "e1", Event: models.EventListCreated, Payload: `{"id":"e1"}`, Status: models.DeliveryStatusDead, Attempts: 8}
+	mockRepo.EXPECT().FindDelivery(ctx, gomock.Any(), 9).Return(original, nil)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.TodoWebhooks{ID: 1}, nil)
+	mockRepo.EXPECT().CreateDeliveries(ctx, gomock.Any(), gomock.Len(1)).Return(nil)
+
+	result, err := svc.Redeliver(db, 9)
+	require.NoError(t, err)
+	assert.Equal(t, "e1", result.EventID)
+	assert.Equal(t, original.Payload, result.Payload)
+	assert.Equal(t, models.DeliveryStatusPending, result.Status)
+	assert.Equal(t, 0, result.Attempts)
+	assert.Equal(t, 9, *result.RedeliveryOf)
+
+	// webhook 已刪除時不能重送
+	mockRepo.EXPECT().FindDelivery(ctx, gomock.Any(), 9).Return(original, nil)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(nil, gorm.ErrRecordNotFound)
+	_, err = svc.Redeliver(db, 9)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestTodoWebhookService_RunNext_ReclaimsStaleDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo).WithClient(server.Client())
+	db, sqlmock := setupMockDB(t)
+
+	// 上一個 worker 送出到一半就中斷，紀錄停在 delivering
+	delivery := &models.TodoWebhookDeliveries{ID: 9, WebhookID: 1, Payload: "{}", Status: models.DeliveryStatusDelivering, Attempts: 2}
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, now, staleBefore time.Time) (*models.TodoWebhookDeliveries, error) {
+			// 送出中超過五分鐘的紀錄可以重新領取
+			assert.Equal(t, 5*time.Minute, now.Sub(staleBefore))
+			return delivery, nil
+		})
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			// 中斷的那次也算一次嘗試
+			assert.Equal(t, 3, d.Attempts)
+			return nil
+		})
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(nil, errors.New("connection reset"))
+
+	// 查不到 webhook 但不是不存在時，維持送出中，等逾時後再領取，不計為失敗
+	processed, err := svc.RunNext(db)
+	assert.EqualError(t, err, "connection reset")
+	assert.True(t, processed)
+
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).Return(nil)
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.TodoWebhooks{ID: 1, URL: server.URL, Secret: "whsec_test", Active: true}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusSucceeded, d.Status)
+			assert.Equal(t, 4, d.Attempts)
+			assert.Empty(t, d.Error)
+			return nil
+		})
+
+	processed, err = svc.RunNext(db)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
+
+func TestTodoWebhookService_RunNext_RedirectAndDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path == "/moved" {
+			w.Write([]byte("不應該跟隨轉址"))
+			return
+		}
+		w.Header().Set("Location", "/moved")
+		w.WriteHeader(http.StatusFound)
+		w.Write([]byte(strings.Repeat("轉", 2000)))
+	}))
+	defer server.Close()
+
+	// 使用預設的 client：不跟隨轉址
+	ctx := context.Background()
+	mockRepo := mocks.NewMockTodoWebhookRepository(ctrl)
+	svc := services.NewTodoWebhookService(ctx, mockRepo)
+	db, sqlmock := setupMockDB(t)
+
+	delivery := &models.TodoWebhookDeliveries{ID: 9, WebhookID: 1, Payload: "{}"}
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).Return(nil)
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.TodoWebhooks{ID: 1, URL: server.URL, Secret: "whsec_test", Active: true}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusPending, d.Status)
+			assert.Equal(t, http.StatusFound, d.ResponseStatus)
+			// 回應內容只保留前 1000 個字
+			assert.Equal(t, 1000, len([]rune(d.ResponseBody)))
+			assert.WithinDuration(t, time.Now().Add(30*time.Second), d.NextAttemptAt, 5*time.Second)
+			return nil
+		})
+
+	_, err := svc.RunNext(db)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits)
+
+	// 停用的 webhook 不送出，紀錄直接標記為 dead
+	delivery = &models.TodoWebhookDeliveries{ID: 10, WebhookID: 1, Payload: "{}"}
+	sqlmock.ExpectBegin()
+	mockRepo.EXPECT().LockNextDelivery(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), delivery).Return(nil)
+	sqlmock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any(), 1).Return(&models.TodoWebhooks{ID: 1, URL: server.URL, Active: false}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, d *models.TodoWebhookDeliveries) error {
+			assert.Equal(t, models.DeliveryStatusDead, d.Status)
+			assert.Equal(t, services.ErrWebhookDisabled.Error(), d.Error)
+			return nil
+		})
+
+	_, err = svc.RunNext(db)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits)
+	assert.NoError(t, sqlmock.ExpectationsWereMet())
+}
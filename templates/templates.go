@@ -0,0 +1,146 @@
+// Package templates 內嵌信件範本與各語系的文字。
+// 每封信由 email/<name>.txt.tmpl 與 email/<name>.html.tmpl 定義 subject 與 content，
+// 套入 layout 後輸出；email/<name>.page.html.tmpl 為獨立的網頁。
+// 範本中以 t 取得語系文字、以 time 依語系格式化時間。
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed email
+var files embed.FS
+
+// DefaultLocale 不支援的語系改用這個
+const DefaultLocale = "zh-TW"
+
+// Locales 支援的語系
+var Locales = []string{"zh-TW", "en"}
+
+// Email 套用範本後的信件內容
+type Email struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+var (
+	loadOnce sync.Once
+	loadErr  error
+	catalogs map[string]map[string]string
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		catalogs = make(map[string]map[string]string, len(Locales))
+		for _, locale := range Locales {
+			raw, err := files.ReadFile(path.Join("email", "locales", locale+".json"))
+			if err != nil {
+				loadErr = err
+				return
+			}
+			var catalog map[string]string
+			if err := json.Unmarshal(raw, &catalog); err != nil {
+				loadErr = fmt.Errorf("%s.json: %w", locale, err)
+				return
+			}
+			catalogs[locale] = catalog
+		}
+	})
+	return loadErr
+}
+
+// funcs 依語系提供範本使用的函式；找不到的文字直接顯示 key，方便發現漏翻
+func funcs(locale string) map[string]interface{} {
+	catalog := catalogs[locale]
+	text := func(key string) string {
+		if value, ok := catalog[key]; ok {
+			return value
+		}
+		if value, ok := catalogs[DefaultLocale][key]; ok {
+			return value
+		}
+		return key
+	}
+	return map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			if len(args) == 0 {
+				return text(key)
+			}
+			return fmt.Sprintf(text(key), args...)
+		},
+		"time": func(t time.Time) string {
+			return t.Format(text("time_format"))
+		},
+	}
+}
+
+// locale 不支援時改用預設語系
+func locale(name string) string {
+	if _, ok := catalogs[name]; ok {
+		return name
+	}
+	return DefaultLocale
+}
+
+// RenderEmail 以 lang 語系的文字套用 name 範本，data 為範本使用的資料
+func RenderEmail(lang, name string, data interface{}) (*Email, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	fm := funcs(locale(lang))
+
+	text, err := texttemplate.New("layout.txt.tmpl").Funcs(fm).
+		ParseFS(files, "email/layout.txt.tmpl", "email/"+name+".txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.New("layout.html.tmpl").Funcs(fm).
+		ParseFS(files, "email/layout.html.tmpl", "email/"+name+".html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&textBody, data); err != nil {
+		return nil, err
+	}
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return nil, err
+	}
+	return &Email{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// RenderPage 以 lang 語系的文字套用 name 網頁範本
+func RenderPage(lang, name string, data interface{}) ([]byte, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	page, err := htmltemplate.New(name+".page.html.tmpl").Funcs(funcs(locale(lang))).
+		ParseFS(files, "email/"+name+".page.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
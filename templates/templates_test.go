@@ -0,0 +1,86 @@
+package templates_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todolist/templates"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type task struct {
+	Name, ListName, Status string
+	DueAt                  *time.Time
+	Overdue                bool
+}
+
+// 每個語系都要有預設語系的所有文字
+func TestLocales_Complete(t *testing.T) {
+	read := func(locale string) map[string]string {
+		raw, err := os.ReadFile(filepath.Join("email", "locales", locale+".json"))
+		require.NoError(t, err)
+		var catalog map[string]string
+		require.NoError(t, json.Unmarshal(raw, &catalog))
+		return catalog
+	}
+
+	base := read(templates.DefaultLocale)
+	for _, locale := range templates.Locales {
+		catalog := read(locale)
+		for key := range base {
+			assert.Contains(t, catalog, key, "%s 缺少 %s", locale, key)
+		}
+	}
+}
+
+func TestRenderEmail(t *testing.T) {
+	due := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	item := &task{Name: "<寫報告>", ListName: "工作", Status: "todo", DueAt: &due}
+	data := map[string]interface{}{
+		"Kind":           "overdue",
+		"Task":           item,
+		"Tasks":          []*task{item, {Name: "整理", ListName: "生活", Status: "in_progress"}},
+		"Digest":         "weekly",
+		"UnsubscribeURL": "https://todo.example.com/api/email/unsubscribe?token=x",
+	}
+
+	for _, locale := range templates.Locales {
+		for _, name := range []string{"assigned", "due", "digest"} {
+			email, err := templates.RenderEmail(locale, name, data)
+			require.NoError(t, err, "%s/%s", locale, name)
+			assert.NotEmpty(t, email.Subject)
+			assert.NotContains(t, email.Subject, ".subject", "%s/%s 缺少文字", locale, name)
+			assert.Contains(t, email.Text, "https://todo.example.com/api/email/unsubscribe?token=x")
+			assert.Contains(t, email.HTML, "https://todo.example.com/api/email/unsubscribe?token=x")
+		}
+	}
+
+	email, err := templates.RenderEmail("zh-TW", "due", data)
+	require.NoError(t, err)
+	assert.Equal(t, "「<寫報告>」已逾期", email.Subject)
+	assert.Contains(t, email.Text, "「工作」清單中的「<寫報告>」已於 2026-03-02 12:00 到期")
+	// HTML 中的任務名稱會被跳脫
+	assert.Contains(t, email.HTML, "&lt;寫報告&gt;")
+	assert.NotContains(t, email.HTML, "<寫報告>")
+
+	// 不支援的語系改用預設語系
+	email, err = templates.RenderEmail("fr", "digest", data)
+	require.NoError(t, err)
+	assert.Equal(t, "每週待辦摘要：2 項未完成", email.Subject)
+}
+
+func TestRenderPage(t *testing.T) {
+	page, err := templates.RenderPage("en", "unsubscribe", map[string]interface{}{"Email": "amy@example.com", "Action": "?token=x"})
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "Stop sending all Todolist emails to amy@example.com?")
+	assert.Contains(t, string(page), `<form method="post" action="?token=x">`)
+
+	page, err = templates.RenderPage("zh-TW", "unsubscribe", map[string]interface{}{"Email": "amy@example.com", "Done": true})
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "已取消訂閱")
+	assert.NotContains(t, string(page), "<form")
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "context"
+
+// AutomationDepthKey 自動化規則執行動作時放進 context 的連鎖層數
+const AutomationDepthKey contextKey = "automation_depth"
+
+// WithAutomationDepth 標記之後的操作由第 depth 層的自動化規則引起，寫入的事件會帶著這個層數
+func WithAutomationDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, AutomationDepthKey, depth)
+}
+
+// AutomationDepth 目前的連鎖層數，不是由自動化規則引起時為 0
+func AutomationDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(AutomationDepthKey).(int)
+	return depth
+}
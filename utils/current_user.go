@@ -0,0 +1,15 @@
+package utils
+
+import "context"
+
+// CurrentUserID 取出 JWT 驗證後放進 context 的使用者 ID（JSON 解析後為 float64）
+func CurrentUserID(ctx context.Context) (int, bool) {
+	switch v := ctx.Value(UserIDKey).(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -9,6 +9,8 @@ var AllowedOrders = map[string]bool{
 	"name desc":       true,
 	"id asc":          true,
 	"id desc":         true,
+	"position asc":    true,
+	"position desc":   true,
 	// 可擴充其他欄位
 }
 